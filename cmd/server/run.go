@@ -2,9 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
-	"os/signal"
-	"syscall"
 
 	"github.com/rei0721/go-scaffold/internal/app"
 	"github.com/rei0721/go-scaffold/types/constants"
@@ -25,67 +24,26 @@ func runApp(configPath string) {
 		os.Exit(1)
 	}
 
-	// 3. 创建信号接收通道
-	// 缓冲区大小为 1,确保至少能接收一个信号而不被阻塞
-	// 这样可以避免在处理第一个信号时丢失后续信号
-	quit := make(chan os.Signal, 1)
-
-	// 4. 注册要监听的操作系统信号
-	// SIGINT: 用户按下 Ctrl+C 时发送的中断信号
-	// SIGTERM: 系统或容器编排工具(如 Docker/K8s)发送的终止信号
-	// 监听这些信号是实现优雅关闭的关键,确保应用能够正确清理资源
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	// 5. 在新的 goroutine 中启动 HTTP 服务器
-	// 使用 goroutine 使得主线程可以继续执行,监听关闭信号
-	// 创建缓冲通道用于接收服务器运行时的错误
-	errChan := make(chan error, 1)
-	go func() {
-		// application.Run() 会阻塞直到服务器停止
-		// 如果启动失败或运行时出错,将错误发送到 errChan
-		if err := application.Run(); err != nil {
-			errChan <- err
-		}
-	}()
-
-	// 6. 等待关闭信号或服务器错误
-	// 使用 select 同时监听多个通道,哪个先有数据就处理哪个
-	// 这是 Go 语言中常用的并发模式
-	select {
-	case sig := <-quit:
-		// 收到操作系统信号(用户中断或系统终止)
-		// 记录日志以便问题追踪和审计
-		application.Logger.Info("received shutdown signal", "signal", sig.String())
-	case err := <-errChan:
-		// 服务器运行时发生错误(例如端口已被占用)
-		// 记录错误日志,便于排查问题
-		application.Logger.Error("server error", "error", err)
-	}
-
-	// 7. 创建带超时的上下文
-	// 上下文用于控制优雅关闭的最大等待时间
-	// 如果超过 shutdownTimeout(30秒)仍未完成关闭,将强制退出
-	// 这防止了因某些请求或连接无法正常关闭导致的程序挂起
-	ctx, cancel := context.WithTimeout(context.Background(), constants.AppShutdownTimeout)
-	// defer 确保在函数返回时调用 cancel,释放上下文相关资源
-	// 这是 Go 中管理资源的最佳实践
-	defer cancel()
-
-	// 8. 执行优雅关闭
-	// 优雅关闭的顺序: HTTP服务器 → 调度器 → 数据库连接 → 日志同步
-	// 这个顺序确保:
-	// - 先停止接收新请求
-	// - 等待正在执行的任务完成
-	// - 关闭数据库连接
-	// - 最后同步日志,确保所有日志都写入磁盘
-	if err := application.Shutdown(ctx); err != nil {
-		// 如果优雅关闭失败,记录错误并以非零状态退出
+	// 3. 启动应用并等待关闭信号
+	// RunUntilSignal 监听 SIGINT(Ctrl+C) 和 SIGTERM(Docker/K8s 终止),
+	// 收到信号后在 AppShutdownTimeout(30秒) 内执行优雅关闭:
+	// HTTP服务器 → 调度器 → 数据库连接 → 日志同步
+	// 超时后不再等待,防止因某些请求或连接无法正常关闭导致的程序挂起
+	if err := application.RunUntilSignal(context.Background(), constants.AppShutdownTimeout); err != nil {
+		// 按失败阶段记录错误并以非零状态退出,启动和运行期间的失败可能还带有关闭错误
 		// 退出码 1 表示程序异常退出
-		application.Logger.Error("shutdown error", "error", err)
+		switch {
+		case errors.Is(err, app.ErrStartFailed):
+			application.Logger.Error("failed to start application", "error", err)
+		case errors.Is(err, app.ErrServerStopped):
+			application.Logger.Error("http server stopped unexpectedly", "error", err)
+		default:
+			application.Logger.Error("shutdown error", "error", err)
+		}
 		os.Exit(1)
 	}
 
-	// 9. 记录成功退出的日志
+	// 4. 记录成功退出的日志
 	// 到达这里说明所有资源都已正确清理,程序正常退出
 	application.Logger.Info("application exited gracefully")
 }
@@ -5,6 +5,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
@@ -44,6 +45,10 @@ type App struct {
 	// 使用接口而非具体实现,便于切换数据库
 	DB database.Database
 
+	// DBHealth 数据库健康监控
+	// 仅 server 模式下启动,用于深度健康检查
+	DBHealth *database.HealthMonitor
+
 	// DBTx 数据库事务管理器
 	// 使用接口而非具体实现,便于切换数据库
 	DBTx dbtx.Manager
@@ -196,9 +201,9 @@ func (a *App) Start(ctx context.Context) error {
 	return nil
 }
 
-// Run 启动应用并阻塞直到接收到停止信号
+// Run 启动应用并永久阻塞
 // 这个方法是为了保持向后兼容性
-// 实际上它只是调用 Start() 然后阻塞
+// 实际上它只是调用 Start() 然后阻塞,需要处理信号和优雅关闭时使用 RunUntilSignal
 // 返回:
 //
 //	error: 启动失败时的错误
@@ -293,6 +298,13 @@ func (a *App) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	// 停止数据库健康监控
+	// 必须在关闭数据库之前,避免监控把关闭后的 Ping 失败记录为故障
+	if a.DBHealth != nil {
+		a.DBHealth.Stop()
+		a.Logger.Info("database health monitor stopped")
+	}
+
 	// 关闭数据库连接
 	// 步骤:
 	// - 关闭所有连接池中的连接
@@ -319,7 +331,8 @@ func (a *App) Shutdown(ctx context.Context) error {
 	// 检查是否有错误发生
 	if len(errs) > 0 {
 		// 有错误但已尽力关闭所有组件
-		return fmt.Errorf("shutdown completed with %d errors", len(errs))
+		// 使用 errors.Is/As 可以判断具体是哪个组件的错误
+		return fmt.Errorf("shutdown completed with %d errors: %w", len(errs), errors.Join(errs...))
 	}
 
 	a.Logger.Info("application shutdown complete")
@@ -42,6 +42,7 @@ func (app *App) initBusiness() error {
 
 	// 初始化 router
 	r := router.New(authHandler, rbacHandler, app.Logger, app.I18n, app.JWT, rbacSvc)
+	r.SetDBHealth(app.DBHealth)
 
 	// Set Gin mode based on config
 	if app.Config.Server.Mode == "release" {
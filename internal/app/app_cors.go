@@ -28,7 +28,9 @@ func (a *App) initCORS() error {
 
 	// 从环境变量覆盖
 	// 生产环境可以通过环境变量覆盖配置文件中的值
-	cfg.OverrideConfig()
+	if err := cfg.OverrideConfig(); err != nil {
+		return err
+	}
 
 	// 验证配置
 	// 确保配置有效，否则提前失败
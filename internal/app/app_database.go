@@ -7,16 +7,19 @@ import (
 )
 
 // initDatabase 初始化数据库连接
+// SQL 日志输出到应用日志,是否输出普通 SQL 由应用日志级别决定
 func (app *App) initDatabase() error {
 	db, err := database.New(&database.Config{
-		Driver:       database.Driver(app.Config.Database.Driver),
-		Host:         app.Config.Database.Host,
-		Port:         app.Config.Database.Port,
-		User:         app.Config.Database.User,
-		Password:     app.Config.Database.Password,
-		DBName:       app.Config.Database.DBName,
-		MaxOpenConns: app.Config.Database.MaxOpenConns,
-		MaxIdleConns: app.Config.Database.MaxIdleConns,
+		Driver:          database.Driver(app.Config.Database.Driver),
+		Host:            app.Config.Database.Host,
+		Port:            app.Config.Database.Port,
+		User:            app.Config.Database.User,
+		Password:        app.Config.Database.Password,
+		DBName:          app.Config.Database.DBName,
+		MaxOpenConns:    app.Config.Database.MaxOpenConns,
+		MaxIdleConns:    app.Config.Database.MaxIdleConns,
+		SlowThresholdMs: app.Config.Database.SlowThresholdMs,
+		Logger:          app.Logger,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
@@ -25,3 +28,12 @@ func (app *App) initDatabase() error {
 	app.Logger.Info("database connected successfully")
 	return nil
 }
+
+// initDBHealth 启动数据库健康监控
+// 后台定期 Ping 数据库,连续失败时自动重建连接池
+// 最近的健康状态通过 /health/deep 端点暴露
+func (app *App) initDBHealth() {
+	app.DBHealth = database.NewHealthMonitor(app.DB, nil, app.Logger)
+	app.DBHealth.Start()
+	app.Logger.Debug("database health monitor started")
+}
@@ -41,6 +41,9 @@ func (app *App) initExecutor() error {
 		return fmt.Errorf("failed to create executor manager: %w", err)
 	}
 
+	// 任务 panic 记录到应用日志
+	mgr.SetLogger(app.Logger)
+
 	app.Executor = mgr
 	app.Logger.Info("executor initialized", "pools", len(configs))
 
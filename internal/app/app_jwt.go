@@ -2,7 +2,10 @@ package app
 
 import (
 	"fmt"
+	"os"
 
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/jwt"
 )
 
@@ -18,23 +21,81 @@ import (
 func initJWT(app *App) error {
 	app.Logger.Info("Initializing JWT manager...")
 
-	// 创建 JWT 配置
-	jwtCfg := &jwt.Config{
-		Secret:    app.Config.JWT.Secret,
-		ExpiresIn: app.Config.JWT.ExpiresIn,
-		Issuer:    app.Config.JWT.Issuer,
-	}
-
 	// 创建 JWT 管理器
-	jwtManager, err := jwt.New(jwtCfg)
+	jwtManager, err := newJWTManager(&app.Config.JWT)
 	if err != nil {
 		return fmt.Errorf("failed to create JWT manager: %w", err)
 	}
 
+	// 注入缓存以启用令牌黑名单,未启用缓存时登出无法立即使令牌失效
+	if app.Cache != nil {
+		jwtManager.SetCache(app.Cache)
+	}
+
 	app.JWT = jwtManager
 	app.Logger.Info("JWT manager initialized successfully",
+		"algorithm", app.Config.JWT.Algorithm,
 		"expires_in", app.Config.JWT.ExpiresIn,
 		"issuer", app.Config.JWT.Issuer)
 
 	return nil
 }
+
+// newJWTManager 根据配置的签名算法创建 JWT 管理器
+// 非对称算法未配置私钥时创建只验证的管理器
+func newJWTManager(cfg *config.JWTConfig) (jwt.JWT, error) {
+	// 创建 JWT 配置
+	jwtCfg := &jwt.Config{
+		Secret:           cfg.Secret,
+		ExpiresIn:        cfg.ExpiresIn,
+		RefreshExpiresIn: cfg.RefreshExpiresIn,
+		Issuer:           cfg.Issuer,
+	}
+
+	switch cfg.Algorithm {
+	case config.JWTAlgorithmRS256:
+		if cfg.PrivateKeyFile == "" {
+			pub, err := readPEMFile(cfg.PublicKeyFile, gojwt.ParseRSAPublicKeyFromPEM)
+			if err != nil {
+				return nil, err
+			}
+			return jwt.VerifyOnly(jwtCfg, pub)
+		}
+		priv, err := readPEMFile(cfg.PrivateKeyFile, gojwt.ParseRSAPrivateKeyFromPEM)
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewWithRSA(jwtCfg, priv, nil)
+
+	case config.JWTAlgorithmES256:
+		if cfg.PrivateKeyFile == "" {
+			pub, err := readPEMFile(cfg.PublicKeyFile, gojwt.ParseECPublicKeyFromPEM)
+			if err != nil {
+				return nil, err
+			}
+			return jwt.VerifyOnly(jwtCfg, pub)
+		}
+		priv, err := readPEMFile(cfg.PrivateKeyFile, gojwt.ParseECPrivateKeyFromPEM)
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewWithECDSA(jwtCfg, priv, nil)
+
+	default:
+		return jwt.New(jwtCfg)
+	}
+}
+
+// readPEMFile 读取 PEM 文件并使用 parse 解析密钥
+func readPEMFile[K any](path string, parse func([]byte) (K, error)) (K, error) {
+	var zero K
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return zero, fmt.Errorf("failed to read key file %s: %w", path, err)
+	}
+	key, err := parse(data)
+	if err != nil {
+		return zero, fmt.Errorf("failed to parse key file %s: %w", path, err)
+	}
+	return key, nil
+}
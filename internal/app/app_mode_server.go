@@ -11,6 +11,7 @@ func (app *App) runModeServer() (*App, error) {
 	if err := app.initDatabase(); err != nil {
 		return nil, err
 	}
+	app.initDBHealth()
 	if err := app.initDBTx(); err != nil {
 		return nil, err
 	}
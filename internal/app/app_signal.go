@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrStartFailed 应用启动失败,RunUntilSignal 返回的错误包装此错误
+	ErrStartFailed = errors.New("application failed to start")
+
+	// ErrServerStopped HTTP 服务器运行期间异常退出
+	ErrServerStopped = errors.New("http server stopped unexpectedly")
+
+	// ErrShutdownFailed 优雅关闭失败
+	ErrShutdownFailed = errors.New("application shutdown failed")
+)
+
+// RunUntilSignal 启动应用,阻塞到收到 SIGINT/SIGTERM 或 ctx 取消,然后优雅关闭
+// 取代 main 中手写的 signal.Notify + select + Shutdown 样板代码
+// 参数:
+//
+//	ctx: 取消时与收到信号一样触发关闭,传 context.Background() 表示只由信号控制
+//	shutdownTimeout: 关闭的最长等待时间,<= 0 时不限制
+//
+// 返回:
+//
+//	error: 启动失败时包装 ErrStartFailed,HTTP 服务器异常退出时包装 ErrServerStopped,
+//	       关闭失败时包装 ErrShutdownFailed;前两种情况下关闭错误会一并返回
+//
+// 使用示例:
+//
+//	if err := application.RunUntilSignal(context.Background(), constants.AppShutdownTimeout); err != nil {
+//		os.Exit(1)
+//	}
+func (a *App) RunUntilSignal(ctx context.Context, shutdownTimeout time.Duration) error {
+	// 缓冲区大小为 1,信号在进入 select 之前到达也不会丢失
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	return a.runUntil(ctx, quit, shutdownTimeout)
+}
+
+// runUntil RunUntilSignal 的实现,信号通道由调用方提供,便于测试
+func (a *App) runUntil(ctx context.Context, quit <-chan os.Signal, shutdownTimeout time.Duration) error {
+	if err := a.Start(ctx); err != nil {
+		// 启动失败时已初始化的组件(数据库、缓存等)同样需要释放
+		return errors.Join(fmt.Errorf("%w: %w", ErrStartFailed, err), a.shutdown(shutdownTimeout))
+	}
+
+	select {
+	case sig := <-quit:
+		a.Logger.Info("received shutdown signal", "signal", sig.String())
+	case <-ctx.Done():
+		a.Logger.Info("context canceled, shutting down", "error", ctx.Err())
+	case err := <-a.HTTPServer.Errors():
+		// 服务器已停止处理请求,继续等待信号只会留下一个不可用的进程
+		return errors.Join(fmt.Errorf("%w: %w", ErrServerStopped, err), a.shutdown(shutdownTimeout))
+	}
+
+	return a.shutdown(shutdownTimeout)
+}
+
+// shutdown 执行带超时的关闭,失败时包装 ErrShutdownFailed
+func (a *App) shutdown(timeout time.Duration) error {
+	if err := a.shutdownWithTimeout(timeout); err != nil {
+		return fmt.Errorf("%w: %w", ErrShutdownFailed, err)
+	}
+	return nil
+}
+
+// shutdownWithTimeout 在超时时间内执行 Shutdown
+// 使用独立的 context,触发关闭的 ctx 已取消时仍能完成优雅关闭
+func (a *App) shutdownWithTimeout(timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return a.Shutdown(ctx)
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/executor"
+	"github.com/rei0721/go-scaffold/pkg/httpserver"
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// fakeHTTPServer 记录 Start/Shutdown 调用的 httpserver.HTTPServer 实现
+type fakeHTTPServer struct {
+	started     chan struct{}
+	errs        chan error
+	shutdown    bool
+	deadline    bool
+	startErr    error
+	shutdownErr error
+}
+
+func (s *fakeHTTPServer) Start(ctx context.Context) error {
+	close(s.started)
+	return s.startErr
+}
+
+func (s *fakeHTTPServer) Shutdown(ctx context.Context) error {
+	s.shutdown = true
+	_, s.deadline = ctx.Deadline()
+	return s.shutdownErr
+}
+
+func (s *fakeHTTPServer) Reload(ctx context.Context, cfg *httpserver.Config) error { return nil }
+func (s *fakeHTTPServer) SetExecutor(exec executor.Manager)                        {}
+func (s *fakeHTTPServer) Errors() <-chan error                                     { return s.errs }
+
+// runWithSignal 在后台运行 runUntil,服务启动后发送模拟信号,返回 runUntil 的结果
+func runWithSignal(t *testing.T, server *fakeHTTPServer) error {
+	t.Helper()
+
+	a := &App{Logger: logger.Default(), HTTPServer: server}
+	quit := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- a.runUntil(context.Background(), quit, time.Second)
+	}()
+
+	<-server.started
+	quit <- syscall.SIGTERM
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("runUntil did not return after signal")
+		return nil
+	}
+}
+
+// TestRunUntil_SignalTriggersShutdown 测试收到信号后带超时调用 Shutdown
+func TestRunUntil_SignalTriggersShutdown(t *testing.T) {
+	server := &fakeHTTPServer{started: make(chan struct{})}
+
+	if err := runWithSignal(t, server); err != nil {
+		t.Fatalf("runUntil() error = %v", err)
+	}
+	if !server.shutdown {
+		t.Fatal("Shutdown should be invoked after signal")
+	}
+	if !server.deadline {
+		t.Error("Shutdown context should carry the shutdown timeout")
+	}
+}
+
+// TestRunUntil_ShutdownError 测试返回汇总的关闭错误
+func TestRunUntil_ShutdownError(t *testing.T) {
+	errStop := errors.New("listener busy")
+	server := &fakeHTTPServer{started: make(chan struct{}), shutdownErr: errStop}
+
+	err := runWithSignal(t, server)
+	if !errors.Is(err, errStop) || !errors.Is(err, ErrShutdownFailed) {
+		t.Fatalf("runUntil() error = %v, want wrapping %v and ErrShutdownFailed", err, errStop)
+	}
+	if errors.Is(err, ErrStartFailed) {
+		t.Error("shutdown error should not be reported as a start failure")
+	}
+}
+
+// TestRunUntil_StartError 测试启动失败时返回 ErrStartFailed 并释放已初始化的组件
+func TestRunUntil_StartError(t *testing.T) {
+	errBind := errors.New("address already in use")
+	server := &fakeHTTPServer{started: make(chan struct{}), startErr: errBind}
+	a := &App{Logger: logger.Default(), HTTPServer: server}
+
+	err := a.runUntil(context.Background(), make(chan os.Signal), time.Second)
+	if !errors.Is(err, errBind) || !errors.Is(err, ErrStartFailed) {
+		t.Fatalf("runUntil() error = %v, want wrapping %v and ErrStartFailed", err, errBind)
+	}
+	if !server.shutdown {
+		t.Error("Shutdown should be invoked after a start failure")
+	}
+}
+
+// TestRunUntil_ServerError 测试 HTTP 服务器运行期间异常退出时不再等待信号
+func TestRunUntil_ServerError(t *testing.T) {
+	errServe := errors.New("listener closed")
+	server := &fakeHTTPServer{started: make(chan struct{}), errs: make(chan error, 1)}
+	a := &App{Logger: logger.Default(), HTTPServer: server}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- a.runUntil(context.Background(), make(chan os.Signal), time.Second)
+	}()
+
+	<-server.started
+	server.errs <- errServe
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errServe) || !errors.Is(err, ErrServerStopped) {
+			t.Fatalf("runUntil() error = %v, want wrapping %v and ErrServerStopped", err, errServe)
+		}
+		if !server.shutdown {
+			t.Error("Shutdown should be invoked after the server stops")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runUntil did not return after server error")
+	}
+}
@@ -24,7 +24,9 @@ func initStorage(app *App) error {
 	cfg.DefaultConfig()
 
 	// 从环境变量覆盖
-	cfg.OverrideConfig()
+	if err := cfg.OverrideConfig(); err != nil {
+		return fmt.Errorf("invalid storage env override: %w", err)
+	}
 
 	// 验证配置
 	if err := cfg.Validate(); err != nil {
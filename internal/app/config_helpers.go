@@ -124,6 +124,9 @@ func isDatabaseConfigChanged(oldCfg, newCfg *config.Config) bool {
 	if oldCfg.Database.MaxIdleConns != newCfg.Database.MaxIdleConns {
 		return true
 	}
+	if oldCfg.Database.SlowThresholdMs != newCfg.Database.SlowThresholdMs {
+		return true
+	}
 
 	return false
 }
@@ -223,6 +226,27 @@ func isLoggerConfigChanged(oldCfg, newCfg *config.Config) bool {
 	return false
 }
 
+// isLoggerLevelOnlyChanged 检查日志配置是否只有级别发生变化
+// 只有级别变化时可以通过 SetLevel 原地调整,无需重建 logger
+// 参数:
+//
+//	oldCfg: 旧配置
+//	newCfg: 新配置
+//
+// 返回:
+//
+//	bool: 级别变化且其他日志配置都未变化时返回 true
+func isLoggerLevelOnlyChanged(oldCfg, newCfg *config.Config) bool {
+	if oldCfg == newCfg || oldCfg.Logger.Level == newCfg.Logger.Level {
+		return false
+	}
+
+	// 除级别外的其他字段必须完全一致
+	oldLogger, newLogger := oldCfg.Logger, newCfg.Logger
+	oldLogger.Level = newLogger.Level
+	return oldLogger == newLogger
+}
+
 // isExecutorConfigChanged 检查执行器配置是否发生变化
 // 参数:
 //
@@ -16,6 +16,9 @@ import (
 func (a *App) reload(old, new *config.Config) {
 	// 重新加载配置
 	// a.Logger.Debug("reloading configuration...")
+	if changed := config.Diff(old, new); len(changed) > 0 {
+		a.Logger.Info("configuration changed", "fields", changed)
+	}
 
 	// cache
 	// 检查 Redis 配置是否变化
@@ -62,14 +65,16 @@ func (a *App) reload(old, new *config.Config) {
 
 		// 重新加载数据库配置
 		newDBCfg := &database.Config{
-			Driver:       database.Driver(new.Database.Driver),
-			Host:         new.Database.Host,
-			Port:         new.Database.Port,
-			User:         new.Database.User,
-			Password:     new.Database.Password,
-			DBName:       new.Database.DBName,
-			MaxOpenConns: new.Database.MaxOpenConns,
-			MaxIdleConns: new.Database.MaxIdleConns,
+			Driver:          database.Driver(new.Database.Driver),
+			Host:            new.Database.Host,
+			Port:            new.Database.Port,
+			User:            new.Database.User,
+			Password:        new.Database.Password,
+			DBName:          new.Database.DBName,
+			MaxOpenConns:    new.Database.MaxOpenConns,
+			MaxIdleConns:    new.Database.MaxIdleConns,
+			SlowThresholdMs: new.Database.SlowThresholdMs,
+			Logger:          a.Logger,
 		}
 
 		if err := a.DB.Reload(newDBCfg); err != nil {
@@ -81,7 +86,14 @@ func (a *App) reload(old, new *config.Config) {
 
 	// logger
 	// 检查日志配置是否变化
-	if isLoggerConfigChanged(old, new) {
+	if isLoggerLevelOnlyChanged(old, new) {
+		// 只有级别变化时原地调整,不重建 logger
+		if err := a.Logger.SetLevel(new.Logger.Level); err != nil {
+			a.Logger.Error("failed to set logger level", "error", err)
+		} else {
+			a.Logger.Info("logger level changed", "level", new.Logger.Level)
+		}
+	} else if isLoggerConfigChanged(old, new) {
 		a.Logger.Info("logger configuration changed, reloading logger...")
 
 		// 创建新的日志配置
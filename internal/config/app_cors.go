@@ -2,9 +2,7 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"net/url"
 )
 
 // CORSConfig 跨域资源共享(CORS)配置
@@ -14,7 +12,7 @@ type CORSConfig struct {
 	// true: 启用跨域支持
 	// false: 禁用(所有跨域请求将被浏览器阻止)
 	// 开发环境通常启用,生产环境根据需求决定
-	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled" toml:"enabled"`
+	Enabled bool `mapstructure:"enabled" json:"enabled" yaml:"enabled" toml:"enabled" env:"CORS_ENABLED"`
 
 	// AllowOrigins 允许的源列表
 	// 指定哪些域名可以跨域访问
@@ -23,14 +21,14 @@ type CORSConfig struct {
 	//   - 通配符: "*" (允许所有源,不安全,仅开发环境使用)
 	// 示例: ["http://localhost:3000", "https://example.com"]
 	// 安全建议: 生产环境必须明确列出允许的域名,禁止使用通配符
-	AllowOrigins []string `mapstructure:"allow_origins" json:"allow_origins" yaml:"allow_origins" toml:"allow_origins"`
+	AllowOrigins []string `mapstructure:"allow_origins" json:"allow_origins" yaml:"allow_origins" toml:"allow_origins" env:"CORS_ALLOW_ORIGINS"`
 
 	// AllowMethods 允许的 HTTP 方法
 	// 指定跨域请求允许使用的 HTTP 方法
 	// 常用方法: GET, POST, PUT, DELETE, PATCH, OPTIONS
 	// OPTIONS 用于预检请求,通常需要包含
 	// 示例: ["GET", "POST", "PUT", "DELETE", "OPTIONS"]
-	AllowMethods []string `mapstructure:"allow_methods" json:"allow_methods" yaml:"allow_methods" toml:"allow_methods"`
+	AllowMethods []string `mapstructure:"allow_methods" json:"allow_methods" yaml:"allow_methods" toml:"allow_methods" env:"CORS_ALLOW_METHODS"`
 
 	// AllowHeaders 允许的请求头
 	// 指定跨域请求允许携带的自定义请求头
@@ -40,7 +38,7 @@ type CORSConfig struct {
 	//   - Authorization: 用于身份认证
 	//   - X-Request-ID: 用于请求追踪
 	// 示例: ["Origin", "Content-Type", "Authorization", "X-Request-ID"]
-	AllowHeaders []string `mapstructure:"allow_headers" json:"allow_headers" yaml:"allow_headers" toml:"allow_headers"`
+	AllowHeaders []string `mapstructure:"allow_headers" json:"allow_headers" yaml:"allow_headers" toml:"allow_headers" env:"CORS_ALLOW_HEADERS"`
 
 	// ExposeHeaders 暴露给浏览器的响应头
 	// 默认情况下浏览器只能访问简单响应头(如 Content-Type)
@@ -49,7 +47,7 @@ type CORSConfig struct {
 	//   - X-Request-ID: 让前端获取请求追踪ID
 	//   - X-Total-Count: 分页总数
 	// 示例: ["X-Request-ID", "X-Total-Count"]
-	ExposeHeaders []string `mapstructure:"expose_headers" json:"expose_headers" yaml:"expose_headers" toml:"expose_headers"`
+	ExposeHeaders []string `mapstructure:"expose_headers" json:"expose_headers" yaml:"expose_headers" toml:"expose_headers" env:"CORS_EXPOSE_HEADERS"`
 
 	// AllowCredentials 是否允许携带凭证
 	// true: 允许跨域请求携带 Cookie、HTTP Auth 等凭证
@@ -58,7 +56,7 @@ type CORSConfig struct {
 	//   - 设置为 true 时,AllowOrigins 不能使用通配符 "*"
 	//   - 必须明确指定允许的域名
 	// 使用场景: 需要在跨域请求中保持用户会话
-	AllowCredentials bool `mapstructure:"allow_credentials" json:"allow_credentials" yaml:"allow_credentials" toml:"allow_credentials"`
+	AllowCredentials bool `mapstructure:"allow_credentials" json:"allow_credentials" yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
 
 	// MaxAge 预检请求缓存时间(秒)
 	// 浏览器会缓存 OPTIONS 预检请求的结果
@@ -67,7 +65,7 @@ type CORSConfig struct {
 	//   - 开发环境: 600-3600 (10分钟-1小时)
 	//   - 生产环境: 3600-86400 (1小时-24小时)
 	// 作用: 减少网络开销,提升性能
-	MaxAge int `mapstructure:"max_age" json:"max_age" yaml:"max_age" toml:"max_age"`
+	MaxAge int `mapstructure:"max_age" json:"max_age" yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE"`
 }
 
 // ValidateName 返回配置名称
@@ -87,7 +85,8 @@ func (c *CORSConfig) ValidateRequired() bool {
 // 验证规则:
 //  1. 如果未启用,跳过验证
 //  2. 如果启用了 AllowCredentials,AllowOrigins 不能包含通配符 "*"
-//  3. MaxAge 不能为负数
+//  3. 除通配符 "*" 外,每个源必须是 scheme://host[:port] 格式的 URL
+//  4. MaxAge 不能为负数
 func (c *CORSConfig) Validate() error {
 	// 如果未启用,跳过验证
 	if !c.Enabled {
@@ -104,6 +103,16 @@ func (c *CORSConfig) Validate() error {
 		}
 	}
 
+	// 验证源格式
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return err
+		}
+	}
+
 	// 验证 MaxAge
 	if c.MaxAge < 0 {
 		return fmt.Errorf("max_age must be non-negative, got %d", c.MaxAge)
@@ -112,6 +121,23 @@ func (c *CORSConfig) Validate() error {
 	return nil
 }
 
+// validateOrigin 验证源是否为 scheme://host[:port] 格式
+// 浏览器发送的 Origin 头不包含路径,因此也不允许路径、查询参数和片段
+// 常见错误如 "localhost:3000" 缺少 scheme,会被解析为 scheme "localhost"
+func validateOrigin(origin string) error {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("allow_origins: invalid origin %q: %v", origin, err)
+	}
+	if u.Scheme == "" || u.Host == "" || u.Opaque != "" {
+		return fmt.Errorf("allow_origins: invalid origin %q, must be scheme://host[:port] (e.g. http://localhost:3000)", origin)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("allow_origins: invalid origin %q, must not contain path, query, fragment or userinfo", origin)
+	}
+	return nil
+}
+
 // DefaultConfig 设置默认配置
 // 提供开发环境友好的默认值
 // 生产环境应该通过配置文件或环境变量覆盖
@@ -148,7 +174,7 @@ func (c *CORSConfig) DefaultConfig() {
 }
 
 // OverrideConfig 从环境变量覆盖配置
-// 环境变量命名规则: CORS_<字段名>,全大写,单词间用下划线
+// 环境变量由字段的 env tag 声明,规则见 BindEnv
 // 支持的环境变量:
 //   - CORS_ENABLED: 是否启用(true/false)
 //   - CORS_ALLOW_ORIGINS: 允许的源(逗号分隔)
@@ -157,62 +183,6 @@ func (c *CORSConfig) DefaultConfig() {
 //   - CORS_EXPOSE_HEADERS: 暴露的响应头(逗号分隔)
 //   - CORS_ALLOW_CREDENTIALS: 是否允许凭证(true/false)
 //   - CORS_MAX_AGE: 预检缓存时间(秒)
-func (c *CORSConfig) OverrideConfig() {
-	// Enabled
-	if val := os.Getenv(EnvCORSEnabled); val != "" {
-		if enabled, err := strconv.ParseBool(val); err == nil {
-			c.Enabled = enabled
-		}
-	}
-
-	// AllowOrigins (逗号分隔列表)
-	if val := os.Getenv(EnvCORSAllowOrigins); val != "" {
-		origins := strings.Split(val, ",")
-		// 去除空白
-		for i := range origins {
-			origins[i] = strings.TrimSpace(origins[i])
-		}
-		c.AllowOrigins = origins
-	}
-
-	// AllowMethods (逗号分隔列表)
-	if val := os.Getenv(EnvCORSAllowMethods); val != "" {
-		methods := strings.Split(val, ",")
-		for i := range methods {
-			methods[i] = strings.TrimSpace(methods[i])
-		}
-		c.AllowMethods = methods
-	}
-
-	// AllowHeaders (逗号分隔列表)
-	if val := os.Getenv(EnvCORSAllowHeaders); val != "" {
-		headers := strings.Split(val, ",")
-		for i := range headers {
-			headers[i] = strings.TrimSpace(headers[i])
-		}
-		c.AllowHeaders = headers
-	}
-
-	// ExposeHeaders (逗号分隔列表)
-	if val := os.Getenv(EnvCORSExposeHeaders); val != "" {
-		headers := strings.Split(val, ",")
-		for i := range headers {
-			headers[i] = strings.TrimSpace(headers[i])
-		}
-		c.ExposeHeaders = headers
-	}
-
-	// AllowCredentials
-	if val := os.Getenv(EnvCORSAllowCredentials); val != "" {
-		if credentials, err := strconv.ParseBool(val); err == nil {
-			c.AllowCredentials = credentials
-		}
-	}
-
-	// MaxAge
-	if val := os.Getenv(EnvCORSMaxAge); val != "" {
-		if maxAge, err := strconv.Atoi(val); err == nil {
-			c.MaxAge = maxAge
-		}
-	}
+func (c *CORSConfig) OverrideConfig() error {
+	return BindEnv(c)
 }
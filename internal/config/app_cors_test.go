@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCORSConfig_Validate_Origins(t *testing.T) {
+	tests := []struct {
+		name    string
+		origins []string
+		wantErr bool
+	}{
+		{name: "wildcard", origins: []string{"*"}},
+		{name: "valid origins", origins: []string{"http://localhost:3000", "https://example.com", "https://app.example.com/"}},
+		{name: "missing scheme", origins: []string{"localhost:3000"}, wantErr: true},
+		{name: "host only", origins: []string{"example.com"}, wantErr: true},
+		{name: "with path", origins: []string{"https://example.com/app"}, wantErr: true},
+		{name: "empty", origins: []string{""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &CORSConfig{Enabled: true, AllowOrigins: tt.origins}
+			err := cfg.Validate()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "allow_origins") {
+					t.Fatalf("Validate() error = %v, want allow_origins error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+
+	// 未启用时不校验
+	cfg := &CORSConfig{AllowOrigins: []string{"localhost:3000"}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("disabled CORS should skip validation, got %v", err)
+	}
+}
@@ -1,10 +1,6 @@
 package config
 
-import (
-	"errors"
-	"os"
-	"strings"
-)
+import "errors"
 
 // I18nConfig 国际化配置
 // 支持多语言
@@ -12,12 +8,12 @@ type I18nConfig struct {
 	// Default 默认语言
 	// 当请求的语言不支持时使用
 	// 例如: en, zh-CN, ja
-	Default string `mapstructure:"default"`
+	Default string `mapstructure:"default" env:"I18N_DEFAULT"`
 
 	// Supported 支持的语言列表
 	// 必须包含 Default 语言
 	// 例如: ["en", "zh-CN", "ja"]
-	Supported []string `mapstructure:"supported"`
+	Supported []string `mapstructure:"supported" env:"I18N_SUPPORTED"`
 
 	// MessagesDir 语言文件目录
 	// 包含所有语言的翻译文件
@@ -63,29 +59,3 @@ func (c *I18nConfig) Validate() error {
 
 	return nil
 }
-
-// overrideI18nConfig 使用环境变量覆盖国际化配置
-func overrideI18nConfig(cfg *I18nConfig) {
-	// Default
-	if val := os.Getenv(EnvI18nDefault); val != "" {
-		cfg.Default = val
-	}
-
-	// Supported
-	// 环境变量格式: "zh-CN,en-US,ja-JP"
-	// 解析为: ["zh-CN", "en-US", "ja-JP"]
-	if val := os.Getenv(EnvI18nSupported); val != "" {
-		langs := strings.Split(val, DefaultSeparator)
-		// 去除空白
-		var supported []string
-		for _, lang := range langs {
-			trimmed := strings.TrimSpace(lang)
-			if trimmed != "" {
-				supported = append(supported, trimmed)
-			}
-		}
-		if len(supported) > 0 {
-			cfg.Supported = supported
-		}
-	}
-}
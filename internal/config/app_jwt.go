@@ -5,11 +5,25 @@ import "errors"
 // JWTConfig JWT认证配置
 // 用于token的生成和验证
 type JWTConfig struct {
+	// Algorithm 签名算法
+	// 可选值: HS256(默认), RS256, ES256
+	// 多服务场景推荐使用非对称算法,其他服务只需公钥即可验证令牌
+	Algorithm string `mapstructure:"algorithm"`
+
 	// Secret 签名密钥
+	// 仅 HS256 使用
 	// 生产环境必须从环境变量设置
 	// 建议使用至少32个字符的随机字符串
 	// 注意: 此字段非常敏感,必须保密
-	Secret string `mapstructure:"secret"`
+	Secret string `mapstructure:"secret" secret:"true"`
+
+	// PrivateKeyFile PEM 格式私钥文件路径
+	// 仅 RS256/ES256 使用,为空时只验证令牌不签发
+	PrivateKeyFile string `mapstructure:"privateKeyFile"`
+
+	// PublicKeyFile PEM 格式公钥文件路径
+	// 仅 RS256/ES256 使用,为空时从私钥推导
+	PublicKeyFile string `mapstructure:"publicKeyFile"`
 
 	// ExpiresIn 令牌有效期（秒）
 	// 默认: 3600（1小时）
@@ -19,6 +33,11 @@ type JWTConfig struct {
 	// - 业务场景: 根据业务敏感度调整
 	ExpiresIn int `mapstructure:"expiresIn"`
 
+	// RefreshExpiresIn 刷新令牌有效期（秒）
+	// 默认: 604800（7天）,为 0 时使用默认值
+	// 应明显长于 ExpiresIn
+	RefreshExpiresIn int `mapstructure:"refreshExpiresIn"`
+
 	// Issuer 签发者
 	// 标识令牌由哪个系统签发
 	// 用于多系统环境下区分token来源
@@ -37,20 +56,33 @@ func (c *JWTConfig) ValidateRequired() bool {
 // Validate 验证 JWT 配置
 // 实现 Configurable 接口
 func (c *JWTConfig) Validate() error {
-	// 验证密钥
-	if c.Secret == "" {
-		return errors.New("jwt secret is required")
-	}
+	switch c.Algorithm {
+	case "", JWTAlgorithmHS256:
+		// 验证密钥
+		if c.Secret == "" {
+			return errors.New("jwt secret is required")
+		}
 
-	// 验证密钥长度（安全性要求）
-	if len(c.Secret) < 32 {
-		return errors.New("jwt secret must be at least 32 characters")
+		// 验证密钥长度（安全性要求）
+		if len(c.Secret) < 32 {
+			return errors.New("jwt secret must be at least 32 characters")
+		}
+	case JWTAlgorithmRS256, JWTAlgorithmES256:
+		// 非对称算法至少需要一个密钥文件
+		if c.PrivateKeyFile == "" && c.PublicKeyFile == "" {
+			return errors.New("jwt privateKeyFile or publicKeyFile is required for " + c.Algorithm)
+		}
+	default:
+		return errors.New("jwt algorithm must be HS256, RS256 or ES256")
 	}
 
 	// 验证过期时间
 	if c.ExpiresIn <= 0 {
 		return errors.New("jwt expiresIn must be positive")
 	}
+	if c.RefreshExpiresIn < 0 {
+		return errors.New("jwt refreshExpiresIn must not be negative")
+	}
 
 	return nil
 }
@@ -2,7 +2,9 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // Config 保存日志配置
@@ -15,7 +17,7 @@ type LoggerConfig struct {
 	// 例如:如果设置为 info,debug 日志不会输出
 	// 开发环境推荐: debug
 	// 生产环境推荐: info 或 warn
-	Level string `mapstructure:"level"`
+	Level string `mapstructure:"level" env:"LOG_LEVEL"`
 
 	// Format 默认输出格式(用于所有输出)
 	// 可选值:
@@ -24,7 +26,7 @@ type LoggerConfig struct {
 	// 如果设置了 ConsoleFormat 或 FileFormat,则此字段作为后备默认值
 	// 生产环境推荐: json(便于 ELK、Splunk 等系统分析)
 	// 开发环境推荐: console(易读)
-	Format string `mapstructure:"format"`
+	Format string `mapstructure:"format" env:"LOG_FORMAT"`
 
 	// ConsoleFormat 控制台输出专用格式(可选)
 	// 可选值: json, console
@@ -47,7 +49,7 @@ type LoggerConfig struct {
 	// - 容器/K8s 环境: stdout
 	// - 传统部署: file
 	// - 开发环境: both
-	Output string `mapstructure:"output"`
+	Output string `mapstructure:"output" env:"LOG_OUTPUT"`
 
 	// FilePath 日志文件路径
 	// 仅当 Output="file" 或 Output="both" 时有效
@@ -111,23 +113,45 @@ func (c *LoggerConfig) Validate() error {
 		return errors.New("output must be stdout, file, or both")
 	}
 
+	// 输出到文件时必须配置可创建的文件路径
+	if c.Output == "file" || c.Output == "both" {
+		if err := validateLogFilePath(c.FilePath); err != nil {
+			return fmt.Errorf("file_path: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// overrideLoggerConfig 使用环境变量覆盖日志配置
-func overrideLoggerConfig(cfg *LoggerConfig) {
-	// Level
-	if val := os.Getenv(EnvLogLevel); val != "" {
-		cfg.Level = val
+// validateLogFilePath 验证日志文件路径可以被创建
+// 路径不能为空或指向目录;向上查找最近的已存在祖先,它必须是目录,
+// 缺失的中间目录由日志库在启动时创建,这里不产生副作用
+func validateLogFilePath(path string) error {
+	if path == "" {
+		return fmt.Errorf("must not be empty when output is file or both")
 	}
 
-	// Format
-	if val := os.Getenv(EnvLogFormat); val != "" {
-		cfg.Format = val
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return fmt.Errorf("%q is a directory", path)
 	}
 
-	// Output
-	if val := os.Getenv(EnvLogOutput); val != "" {
-		cfg.Output = val
+	dir := filepath.Dir(filepath.Clean(path))
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("cannot create %q: %q is not a directory", path, dir)
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("cannot create %q: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
 	}
 }
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// validLoggerConfig 返回输出到文件的有效日志配置
+func validLoggerConfig(path string) *LoggerConfig {
+	return &LoggerConfig{Level: "info", Format: "json", Output: "file", FilePath: path}
+}
+
+func TestLoggerConfig_Validate_FilePath(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		path    string
+		wantErr string
+	}{
+		{name: "stdout without path", output: "stdout", path: ""},
+		{name: "missing path", output: "file", path: "", wantErr: "must not be empty"},
+		{name: "both without path", output: "both", path: "", wantErr: "must not be empty"},
+		{name: "nested directories created later", output: "file", path: filepath.Join(dir, "a", "b", "app.log")},
+		{name: "path is directory", output: "file", path: dir, wantErr: "is a directory"},
+		{name: "parent is file", output: "file", path: filepath.Join(blocker, "app.log"), wantErr: "is not a directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validLoggerConfig(tt.path)
+			cfg.Output = tt.output
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "file_path") {
+				t.Fatalf("Validate() error = %v, want file_path error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// 校验不应创建任何目录
+	if _, err := os.Stat(filepath.Join(dir, "a")); !os.IsNotExist(err) {
+		t.Errorf("Validate() should not create directories, stat err = %v", err)
+	}
+}
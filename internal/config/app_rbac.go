@@ -22,6 +22,10 @@ type RBACConfig struct {
 	// 仅在EnableCache=true时生效
 	CacheTTL time.Duration `mapstructure:"cache_ttl"`
 
+	// 缓存条目上限（0 表示不限制）
+	// 仅在EnableCache=true时生效
+	CacheMaxEntries int `mapstructure:"cache_max_entries"`
+
 	// 是否自动保存策略（默认true）
 	// 设置为true时，每次策略变更都会立即持久化到数据库
 	// 设置为false时，需要手动调用SavePolicy()
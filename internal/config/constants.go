@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // 环境变量名称常量
 // 定义所有支持的环境变量名称,避免魔法字符串
@@ -164,6 +167,18 @@ const (
 	EnvJWTIssuer = "JWT_ISSUER"
 )
 
+// JWT 签名算法
+const (
+	// JWTAlgorithmHS256 HMAC-SHA256,使用共享密钥,默认算法
+	JWTAlgorithmHS256 = "HS256"
+
+	// JWTAlgorithmRS256 RSA-SHA256,私钥签名、公钥验证
+	JWTAlgorithmRS256 = "RS256"
+
+	// JWTAlgorithmES256 ECDSA P-256,私钥签名、公钥验证
+	JWTAlgorithmES256 = "ES256"
+)
+
 // CORS 相关环境变量
 const (
 	// EnvCORSEnabled CORS 是否启用
@@ -215,6 +230,37 @@ const (
 	DefaultSeparator = ","
 )
 
+// 配置保存相关常量
+const (
+	// SecretTagName 标记敏感字段的 struct tag 名称
+	// 示例: Password string `mapstructure:"password" secret:"true"`
+	SecretTagName = "secret"
+
+	// CommentTagName WriteDefault 输出字段注释的 struct tag 名称
+	// 设置后替代字段的文档注释,示例: Port int `mapstructure:"port" comment:"监听端口"`
+	CommentTagName = "comment"
+
+	// RedactedValue 敏感字段脱敏后的占位值
+	RedactedValue = "******"
+
+	// DefaultSaveFileMode 新建配置文件的权限
+	DefaultSaveFileMode = 0o644
+)
+
+// 配置监听相关常量
+const (
+	// DefaultWatchDebounce 配置文件变化后等待的防抖窗口
+	// 编辑器保存时常先截断再写入,窗口内的连续变化只触发一次重新加载
+	DefaultWatchDebounce = 200 * time.Millisecond
+)
+
+// 密钥引用相关常量
+const (
+	// SecretRefScheme 配置值中密钥引用的前缀
+	// 示例: password: ${secret:db/password}
+	SecretRefScheme = "secret"
+)
+
 // 应用配置名称常量
 const (
 	AppServerName   = "server"
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configSources 配置结构体的源码,用于提取字段文档注释
+// 结构体定义是默认配置文件中注释的唯一来源,修改字段注释后重新生成即可同步
+//
+//go:embed config.go app_*.go
+var configSources embed.FS
+
+var (
+	fieldDocsOnce sync.Once
+	fieldDocs     map[string]map[string]string
+)
+
+// Default 返回默认配置
+// 所有字段都有可用的值,可以直接通过 Validate 校验;
+// JWT 密钥为占位值,生产环境必须替换
+func Default() *Config {
+	cfg := &Config{
+		Server: ServerConfig{
+			Host:         "0.0.0.0",
+			Port:         8080,
+			Mode:         "debug",
+			ReadTimeout:  10,
+			WriteTimeout: 10,
+			IdleTimeout:  60,
+		},
+		Database: DatabaseConfig{
+			Driver:          "sqlite",
+			DBName:          "./data/app.db",
+			MaxOpenConns:    100,
+			MaxIdleConns:    10,
+			SlowThresholdMs: 200,
+		},
+		Redis: RedisConfig{
+			Enabled:      false,
+			Host:         "localhost",
+			Port:         6379,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			MaxRetries:   3,
+			DialTimeout:  5,
+			ReadTimeout:  3,
+			WriteTimeout: 3,
+		},
+		Logger: LoggerConfig{
+			Level:         "info",
+			Format:        "console",
+			ConsoleFormat: "console",
+			FileFormat:    "json",
+			Output:        "stdout",
+			FilePath:      "logs/app.log",
+			MaxSize:       10,
+			MaxBackups:    10,
+			MaxAge:        30,
+		},
+		I18n: I18nConfig{
+			Default:     "zh-CN",
+			Supported:   []string{"zh-CN", "en-US"},
+			MessagesDir: "./configs/locales",
+		},
+		InitDB: InitDBConfig{
+			ScriptDir:        "./scripts/initdb",
+			LockFile:         ".initialized",
+			ScriptFilePrefix: "initdb",
+		},
+		Executor: ExecutorConfig{
+			Enabled: true,
+			Pools: []ExecutorPoolConfig{
+				{Name: "http", Size: 200, Expiry: 10, NonBlocking: true},
+				{Name: "background", Size: 30, Expiry: 60, NonBlocking: true},
+			},
+		},
+		JWT: JWTConfig{
+			Algorithm:        JWTAlgorithmHS256,
+			Secret:           "change-me-to-a-random-string-of-at-least-32-characters",
+			ExpiresIn:        3600,
+			RefreshExpiresIn: 604800,
+			Issuer:           "go-scaffold",
+		},
+		RBAC: RBACConfig{
+			Enabled:         true,
+			EnableCache:     true,
+			CacheTTL:        time.Hour,
+			CacheMaxEntries: 10000,
+			AutoSave:        true,
+		},
+	}
+	cfg.Storage.DefaultConfig()
+	cfg.CORS.DefaultConfig()
+	return cfg
+}
+
+// WriteDefault 将带注释的默认配置以 YAML 格式写入文件
+// 每个字段前的注释取自 comment tag,没有时取字段的文档注释,
+// 生成的文件可以直接用 Load 加载,适合作为新环境的配置模板
+// 参数:
+//
+//	path: 目标文件路径,已存在时覆盖
+//
+// 返回:
+//
+//	error: 编码或写入失败时的错误
+func WriteDefault(path string) error {
+	cfg := Default()
+
+	// 默认值中没有真实密钥,按原值写出,保证生成的文件可以直接加载
+	node, err := encodeYAMLNode(reflect.ValueOf(*cfg), SaveOptions{IncludeSecrets: true})
+	if err != nil {
+		return fmt.Errorf("failed to encode default config: %w", err)
+	}
+	annotateYAMLNode(node, reflect.TypeOf(*cfg), loadFieldDocs())
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return fmt.Errorf("failed to marshal default config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to marshal default config: %w", err)
+	}
+
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write default config file: %w", err)
+	}
+	return nil
+}
+
+// annotateYAMLNode 为 encodeYAMLNode 生成的节点添加字段注释
+// 节点与类型按 encodeYAMLNode 的规则一一对应,列表中的每个元素都会添加注释
+func annotateYAMLNode(node *yaml.Node, t reflect.Type, docs map[string]map[string]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case node.Kind == yaml.SequenceNode && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		for _, item := range node.Content {
+			annotateYAMLNode(item, t.Elem(), docs)
+		}
+
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Struct:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			field, ok := structFieldByKey(t, keyNode.Value)
+			if !ok {
+				continue
+			}
+
+			comment := field.Tag.Get(CommentTagName)
+			if comment == "" {
+				comment = docs[t.Name()][field.Name]
+			}
+			keyNode.HeadComment = comment
+
+			annotateYAMLNode(valueNode, field.Type, docs)
+		}
+	}
+}
+
+// structFieldByKey 按 mapstructure 键名查找结构体字段
+func structFieldByKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && fieldKey(field) == key {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// loadFieldDocs 解析嵌入的配置源码,返回 结构体名 -> 字段名 -> 文档注释
+// 只解析一次;源码解析失败时对应文件的字段没有注释
+func loadFieldDocs() map[string]map[string]string {
+	fieldDocsOnce.Do(func() {
+		fieldDocs = make(map[string]map[string]string)
+
+		entries, err := configSources.ReadDir(".")
+		if err != nil {
+			return
+		}
+
+		fset := token.NewFileSet()
+		for _, entry := range entries {
+			src, err := configSources.ReadFile(entry.Name())
+			if err != nil {
+				continue
+			}
+			file, err := parser.ParseFile(fset, entry.Name(), src, parser.ParseComments)
+			if err != nil {
+				continue
+			}
+			collectFieldDocs(file, fieldDocs)
+		}
+	})
+	return fieldDocs
+}
+
+// collectFieldDocs 收集文件中所有结构体字段的文档注释
+func collectFieldDocs(file *ast.File, docs map[string]map[string]string) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+
+		fields := make(map[string]string)
+		for _, field := range st.Fields.List {
+			doc := strings.TrimSpace(field.Doc.Text())
+			if doc == "" {
+				continue
+			}
+			for _, name := range field.Names {
+				fields[name.Name] = doc
+			}
+		}
+		docs[spec.Name.Name] = fields
+		return false
+	})
+}
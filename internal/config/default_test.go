@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefault_Valid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("Default() should be valid: %v", err)
+	}
+}
+
+func TestWriteDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := WriteDefault(path); err != nil {
+		t.Fatalf("WriteDefault() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+
+	// 生成的文件包含 server.port
+	var raw map[string]map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("written file is not valid YAML: %v", err)
+	}
+	if _, ok := raw["server"]["port"]; !ok {
+		t.Errorf("written file should contain server.port, got:\n%s", data)
+	}
+
+	// 字段注释取自结构体的文档注释
+	for _, want := range []string{"# Server HTTP 服务器配置", "# Port 监听端口"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("written file should contain comment %q, got:\n%s", want, data)
+		}
+	}
+
+	// 可以重新加载为有效配置
+	cfg := loadSaveTestConfig(t, path).Get()
+	if cfg.Server.Port != Default().Server.Port {
+		t.Errorf("reloaded server.port = %d, want %d", cfg.Server.Port, Default().Server.Port)
+	}
+}
+
+func TestWriteDefault_CommentTag(t *testing.T) {
+	type tagged struct {
+		// Port 文档注释
+		Port int `mapstructure:"port" comment:"来自 comment tag"`
+	}
+
+	node, err := encodeYAMLNode(reflect.ValueOf(tagged{Port: 1}), SaveOptions{})
+	if err != nil {
+		t.Fatalf("encodeYAMLNode() failed: %v", err)
+	}
+	annotateYAMLNode(node, reflect.TypeOf(tagged{}), map[string]map[string]string{
+		"tagged": {"Port": "Port 文档注释"},
+	})
+
+	if got := node.Content[0].HeadComment; got != "来自 comment tag" {
+		t.Errorf("HeadComment = %q, want comment tag value", got)
+	}
+}
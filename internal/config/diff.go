@@ -0,0 +1,103 @@
+package config
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Diff 比较两份配置,返回发生变化的字段路径
+// 路径由 mapstructure tag 组成,用点号分隔,如 "logger.level"、"i18n.default"
+// 参数:
+//
+//	old: 旧配置
+//	new: 新配置
+//
+// 返回:
+//
+//	[]string: 变化字段的路径,按结构体字段声明顺序排列;没有变化时为空
+//
+// 比较规则:
+//   - 嵌套结构体递归比较,只返回叶子字段
+//   - 长度相同的结构体切片按元素比较,路径带下标,如 "executor.pools[0].size"
+//   - 其他切片和 map 整体比较,返回切片字段本身的路径
+//   - old 或 new 为 nil 时返回 nil
+//
+// 使用示例:
+//
+//	mgr.RegisterHook(func(old, new *config.Config) {
+//	    for _, path := range config.Diff(old, new) {
+//	        if strings.HasPrefix(path, "logger.") {
+//	            // 重新配置日志
+//	        }
+//	    }
+//	})
+func Diff(old, new *Config) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+
+	var changed []string
+	diffValue("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changed)
+	return changed
+}
+
+// diffValue 递归比较两个值,将变化的路径追加到 changed
+func diffValue(path string, oldVal, newVal reflect.Value, changed *[]string) {
+	switch oldVal.Kind() {
+	case reflect.Struct:
+		t := oldVal.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			diffValue(joinPath(path, fieldKey(field)), oldVal.Field(i), newVal.Field(i), changed)
+		}
+
+	case reflect.Ptr:
+		if oldVal.IsNil() || newVal.IsNil() {
+			if oldVal.IsNil() != newVal.IsNil() {
+				*changed = append(*changed, path)
+			}
+			return
+		}
+		diffValue(path, oldVal.Elem(), newVal.Elem(), changed)
+
+	case reflect.Slice:
+		// 结构体切片长度不变时逐个比较,便于定位到具体元素
+		if oldVal.Type().Elem().Kind() == reflect.Struct && oldVal.Len() == newVal.Len() {
+			for i := 0; i < oldVal.Len(); i++ {
+				diffValue(path+"["+strconv.Itoa(i)+"]", oldVal.Index(i), newVal.Index(i), changed)
+			}
+			return
+		}
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			*changed = append(*changed, path)
+		}
+
+	default:
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			*changed = append(*changed, path)
+		}
+	}
+}
+
+// fieldKey 返回字段在配置文件中的键名
+// 优先使用 mapstructure tag,没有时使用小写字段名
+func fieldKey(field reflect.StructField) string {
+	if tag := field.Tag.Get("mapstructure"); tag != "" {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name)
+}
+
+// joinPath 拼接字段路径
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// newDiffTestConfig 创建用于比较的基础配置
+func newDiffTestConfig() *Config {
+	return &Config{
+		Logger: LoggerConfig{Level: "info", Format: "json"},
+		I18n:   I18nConfig{Default: "zh-CN", Supported: []string{"zh-CN", "en-US"}},
+		Executor: ExecutorConfig{
+			Enabled: true,
+			Pools:   []ExecutorPoolConfig{{Name: "http", Size: 10}, {Name: "cache", Size: 5}},
+		},
+	}
+}
+
+// TestDiff 测试返回变化字段的点分路径
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+		want   []string
+	}{
+		{"no change", func(*Config) {}, nil},
+		{"scalar fields", func(c *Config) {
+			c.Logger.Level = "debug"
+			c.I18n.Default = "en-US"
+		}, []string{"logger.level", "i18n.default"}},
+		{"string slice", func(c *Config) {
+			c.I18n.Supported = append(c.I18n.Supported, "ja-JP")
+		}, []string{"i18n.supported"}},
+		{"struct slice element", func(c *Config) {
+			c.Executor.Pools[1].Size = 20
+		}, []string{"executor.pools[1].size"}},
+		{"struct slice length", func(c *Config) {
+			c.Executor.Pools = c.Executor.Pools[:1]
+		}, []string{"executor.pools"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newDiffTestConfig()
+			updated := newDiffTestConfig()
+			tt.mutate(updated)
+
+			if got := Diff(old, updated); !slices.Equal(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"fmt"
 	"os"
 	"strconv"
 
@@ -32,27 +31,10 @@ func LoadEnv() {
 	// 2. 解析 KEY=VALUE 格式
 	// 3. 将变量设置到进程环境变量中
 	// 4. 不会覆盖已存在的环境变量
-	err := godotenv.Load(EnvFilePath)
-	if err != nil {
-		// .env 文件不存在或读取失败
-		// 这是正常情况,不需要报错
-		// 生产环境通常不使用 .env 文件
-
-		// 调试: 打印到 stderr 以便诊断
-		fmt.Fprintf(os.Stderr, "[DEBUG] .env file not loaded: %v\n", err)
-		return
-	}
-
-	// .env 文件加载成功
-	// 调试: 打印成功信息
-	fmt.Fprintf(os.Stderr, "[DEBUG] .env file loaded successfully\n")
-
-	// 调试: 打印一些关键环境变量
-	fmt.Fprintf(os.Stderr, "[DEBUG] DB_DRIVER=%s\n", os.Getenv("DB_DRIVER"))
-	fmt.Fprintf(os.Stderr, "[DEBUG] REDIS_HOST=%s\n", os.Getenv("REDIS_HOST"))
-	fmt.Fprintf(os.Stderr, "[DEBUG] DB_HOST=%s\n", os.Getenv("DB_HOST"))
-	fmt.Fprintf(os.Stderr, "[DEBUG] REDIS_ENABLED=%s\n", os.Getenv("REDIS_ENABLED"))
-	fmt.Fprintf(os.Stderr, "[DEBUG] REI_APP_TEST=%s\n", os.Getenv("REI_APP_TEST"))
+	//
+	// .env 文件不存在或读取失败是正常情况,不需要报错
+	// 生产环境通常不使用 .env 文件
+	_ = godotenv.Load(EnvFilePath)
 }
 
 // OverrideWithEnv 使用环境变量覆盖配置
@@ -62,38 +44,24 @@ func LoadEnv() {
 //
 //	cfg: 从 config.yaml 加载的配置
 //
+// 返回:
+//
+//	error: 环境变量值无法解析时的错误
+//
 // 工作流程:
-//  1. 检查每个支持的环境变量
+//  1. 通过 BindEnv 遍历配置结构体,读取字段 env tag 声明的环境变量
 //  2. 如果环境变量存在,使用其值覆盖配置
 //  3. 如果环境变量不存在,保持 config.yaml 的值
 //
 // 使用示例:
 //
 //	config := loadFromYaml()
-//	OverrideWithEnv(config)
+//	if err := OverrideWithEnv(config); err != nil {
+//	    return err
+//	}
 //	// 此时 config 中的值可能已被环境变量覆盖
-func OverrideWithEnv(cfg *Config) {
-	// 调试: 显示开始覆盖配置
-	fmt.Fprintf(os.Stderr, "[DEBUG] OverrideWithEnv: starting environment variable override\n")
-
-	// 数据库配置
-	overrideDatabaseConfig(&cfg.Database)
-
-	// Redis 配置
-	overrideRedisConfig(&cfg.Redis)
-
-	// 服务器配置
-	overrideServerConfig(&cfg.Server)
-
-	// 日志配置
-	overrideLoggerConfig(&cfg.Logger)
-
-	// 国际化配置
-	overrideI18nConfig(&cfg.I18n)
-
-	// 调试: 显示覆盖后的值
-	fmt.Fprintf(os.Stderr, "[DEBUG] After override - DB_DRIVER=%s, DB_HOST=%s, REDIS_ENABLED=%v\n",
-		cfg.Database.Driver, cfg.Database.Host, cfg.Redis.Enabled)
+func OverrideWithEnv(cfg *Config) error {
+	return BindEnv(cfg)
 }
 
 // getEnvOrDefault 获取环境变量,如果不存在则返回默认值
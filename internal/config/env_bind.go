@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// EnvTagName 环境变量绑定使用的 struct tag 名称
+	EnvTagName = "env"
+
+	// EnvTagOptionPrefix env tag 选项,变量名需要拼接 EnvPrefix
+	EnvTagOptionPrefix = "prefix"
+)
+
+// BindEnv 根据 struct tag 使用环境变量覆盖配置
+// 优先级: 环境变量 > config.yaml
+// 参数:
+//
+//	cfg: 配置结构体指针,如 *Config、*CORSConfig
+//
+// 返回:
+//
+//	error: cfg 不是非 nil 结构体指针、字段类型不支持或环境变量值无法解析时的错误
+//
+// 绑定规则:
+//   - 字段通过 `env:"NAME"` 声明对应的环境变量
+//   - `env:"NAME,prefix"` 表示变量名带应用前缀,即 EnvPrefixJoin("NAME")
+//   - 环境变量未设置或为空时保持原值
+//   - 嵌套结构体(及非 nil 结构体指针)递归处理
+//   - 支持的字段类型: string、int 系列、bool、[]string(逗号分隔,去除空白和空项)
+//
+// 使用示例:
+//
+//	type ServerConfig struct {
+//	    Port int `mapstructure:"port" env:"SERVER_PORT"`
+//	}
+//
+//	if err := config.BindEnv(cfg); err != nil {
+//	    return err
+//	}
+func BindEnv(cfg any) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind env: expected non-nil pointer to struct, got %T", cfg)
+	}
+	return bindEnvStruct(v.Elem())
+}
+
+// bindEnvStruct 遍历结构体字段,应用带 env tag 的环境变量
+func bindEnvStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+
+		name := envName(field.Tag.Get(EnvTagName))
+		if name == "" || name == "-" {
+			// 没有 env tag 的嵌套结构体继续递归
+			switch {
+			case fv.Kind() == reflect.Struct:
+				if err := bindEnvStruct(fv); err != nil {
+					return err
+				}
+			case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+				if err := bindEnvStruct(fv.Elem()); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		val := os.Getenv(name)
+		if val == "" {
+			continue
+		}
+		if err := setEnvValue(fv, val); err != nil {
+			return fmt.Errorf("bind env %s to field %s: %w", name, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// envName 解析 env tag,返回环境变量名
+// 带 prefix 选项时拼接 EnvPrefix,例如 "DB_HOST,prefix" -> "REI_APP_DB_HOST"
+func envName(tag string) string {
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if strings.TrimSpace(opt) == EnvTagOptionPrefix && name != "" && name != "-" {
+			return EnvPrefixJoin(name)
+		}
+	}
+	return name
+}
+
+// setEnvValue 将环境变量字符串解析后写入字段
+func setEnvValue(fv reflect.Value, val string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(val)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid int value %q", val)
+		}
+		fv.SetInt(n)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("invalid bool value %q", val)
+		}
+		fv.SetBool(b)
+
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", fv.Type())
+		}
+		// 全部为空项时保持原值
+		if items := splitEnvList(val); len(items) > 0 {
+			fv.Set(reflect.ValueOf(items).Convert(fv.Type()))
+		}
+
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// splitEnvList 按 DefaultSeparator 拆分列表类型的环境变量
+// 例如: "zh-CN, en-US,,ja-JP" -> ["zh-CN", "en-US", "ja-JP"]
+func splitEnvList(val string) []string {
+	var items []string
+	for _, item := range strings.Split(val, DefaultSeparator) {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBindEnv_Types 测试各类型字段的绑定
+func TestBindEnv_Types(t *testing.T) {
+	t.Setenv("REDIS_HOST", "redis.internal")
+	t.Setenv("REDIS_PORT", "6380")
+	t.Setenv("REDIS_ENABLED", "true")
+	t.Setenv("I18N_SUPPORTED", " zh-CN, en-US,,ja-JP ")
+
+	cfg := &Config{
+		Redis: RedisConfig{Host: "localhost", Port: 6379, DB: 2},
+		I18n:  I18nConfig{Default: "en-US", Supported: []string{"en-US"}},
+	}
+	if err := BindEnv(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Redis.Host != "redis.internal" {
+		t.Errorf("string: got %q", cfg.Redis.Host)
+	}
+	if cfg.Redis.Port != 6380 {
+		t.Errorf("int: got %d", cfg.Redis.Port)
+	}
+	if !cfg.Redis.Enabled {
+		t.Errorf("bool: got false")
+	}
+	if want := []string{"zh-CN", "en-US", "ja-JP"}; !reflect.DeepEqual(cfg.I18n.Supported, want) {
+		t.Errorf("[]string: got %v, want %v", cfg.I18n.Supported, want)
+	}
+
+	// 未设置的环境变量保持原值
+	if cfg.Redis.DB != 2 || cfg.I18n.Default != "en-US" {
+		t.Errorf("unset env should keep values, got db=%d default=%q", cfg.Redis.DB, cfg.I18n.Default)
+	}
+}
+
+// TestBindEnv_Nested 测试嵌套结构体和结构体指针
+func TestBindEnv_Nested(t *testing.T) {
+	type inner struct {
+		Name string `env:"BIND_TEST_INNER_NAME"`
+	}
+	type outer struct {
+		Inner    inner
+		InnerPtr *inner
+		NilPtr   *inner
+		Skipped  string `env:"-"`
+	}
+
+	t.Setenv("BIND_TEST_INNER_NAME", "nested")
+
+	cfg := &outer{InnerPtr: &inner{}}
+	if err := BindEnv(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Inner.Name != "nested" || cfg.InnerPtr.Name != "nested" {
+		t.Errorf("nested fields not bound: %+v, %+v", cfg.Inner, cfg.InnerPtr)
+	}
+	if cfg.NilPtr != nil {
+		t.Errorf("nil pointer should be left untouched")
+	}
+}
+
+// TestBindEnv_Prefix 测试 prefix 选项拼接应用前缀
+func TestBindEnv_Prefix(t *testing.T) {
+	t.Setenv(EnvPrefixJoin("DB_HOST"), "db.internal")
+	t.Setenv("DB_HOST", "unprefixed")
+
+	cfg := &Config{Database: DatabaseConfig{Host: "localhost"}}
+	if err := BindEnv(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Database.Host != "db.internal" {
+		t.Errorf("expected prefixed env to be bound, got %q", cfg.Database.Host)
+	}
+}
+
+// TestBindEnv_Errors 测试无效值和无效参数
+func TestBindEnv_Errors(t *testing.T) {
+	t.Run("invalid int", func(t *testing.T) {
+		t.Setenv("SERVER_PORT", "eighty")
+		cfg := &Config{Server: ServerConfig{Port: 8080}}
+		if err := BindEnv(cfg); err == nil {
+			t.Fatal("expected error for invalid int")
+		}
+	})
+
+	t.Run("invalid bool", func(t *testing.T) {
+		t.Setenv("CORS_ENABLED", "maybe")
+		if err := BindEnv(&CORSConfig{}); err == nil {
+			t.Fatal("expected error for invalid bool")
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		t.Setenv("BIND_TEST_RATIO", "0.5")
+		cfg := &struct {
+			Ratio float64 `env:"BIND_TEST_RATIO"`
+		}{}
+		if err := BindEnv(cfg); err == nil {
+			t.Fatal("expected error for unsupported type")
+		}
+	})
+
+	t.Run("not a pointer", func(t *testing.T) {
+		if err := BindEnv(Config{}); err == nil {
+			t.Fatal("expected error for non-pointer")
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -84,7 +85,40 @@ type Manager interface {
 	//   error: 启动监听失败时的错误
 	// 功能:
 	//   自动检测配置文件变化并重新加载
+	//   debounce 窗口内的连续变化只触发一次重新加载
 	Watch() error
+
+	// SetWatchDebounce 设置 Watch 的防抖窗口
+	// 参数:
+	//   d: 最后一次文件变化后等待的时长,默认 DefaultWatchDebounce,0 表示不等待
+	// 注意:
+	//   编辑器保存时常分多次写入,窗口过短会读到写了一半的文件
+	SetWatchDebounce(d time.Duration)
+
+	// Save 将当前配置以 YAML 格式写入文件
+	// 参数:
+	//   path: 目标文件路径
+	// 返回:
+	//   error: 配置未加载或写入失败时的错误
+	// 注意:
+	//   原子写入,不会留下写了一半的文件
+	//   敏感字段默认脱敏,${secret:ref} 引用原样写回
+	Save(path string) error
+
+	// SaveWithOptions 按选项将当前配置写入文件
+	// 参数:
+	//   path: 目标文件路径
+	//   opts: 保存选项,默认脱敏密码等敏感字段,IncludeSecrets 为 true 时写出明文
+	// 使用示例:
+	//   manager.SaveWithOptions("config.local.yaml", config.SaveOptions{IncludeSecrets: true})
+	SaveWithOptions(path string, opts SaveOptions) error
+
+	// SetSecretProvider 设置解析 ${secret:ref} 引用的密钥提供者
+	// 参数:
+	//   p: 密钥提供者,默认为 EnvSecretProvider
+	// 注意:
+	//   应在 Load 之前调用,热重载时使用同一个提供者
+	SetSecretProvider(p SecretProvider)
 }
 
 // manager 实现 Manager 接口
@@ -102,6 +136,10 @@ type manager struct {
 	// 写入时原子替换整个配置对象
 	config atomic.Pointer[Config]
 
+	// secretRefs 当前配置中 ${secret:ref} 引用的原始值
+	// 随配置一起替换,Save 时用于写回引用而不是密钥明文
+	secretRefs atomic.Pointer[secretRefs]
+
 	// configPath 配置文件路径
 	// 用于监听文件变化
 	configPath string
@@ -122,6 +160,20 @@ type manager struct {
 	// log 日志记录器实例
 	// 用于记录配置加载、更新等事件
 	log logger.Logger
+
+	// secretProvider 解析 ${secret:ref} 引用的密钥提供者
+	secretProvider SecretProvider
+
+	// watchDebounce 文件变化后等待的防抖窗口
+	watchDebounce time.Duration
+
+	// reloadTimer 防抖计时器,窗口内的新事件会重置它
+	// timerMu 保护 reloadTimer 和 watchDebounce
+	reloadTimer *time.Timer
+	timerMu     sync.Mutex
+
+	// reloadMu 保证同一时刻只有一次重新加载
+	reloadMu sync.Mutex
 }
 
 // NewManager 创建一个新的配置管理器
@@ -135,19 +187,46 @@ type manager struct {
 //	mgr.Load("config.yaml")
 func NewManager() Manager {
 	return &manager{
-		v:     viper.New(),            // 创建新的 viper 实例
-		hooks: make([]HookHandler, 0), // 初始化空的钩子列表
+		v:              viper.New(),            // 创建新的 viper 实例
+		hooks:          make([]HookHandler, 0), // 初始化空的钩子列表
+		secretProvider: EnvSecretProvider{},    // 默认从环境变量读取密钥
+		watchDebounce:  DefaultWatchDebounce,   // 合并编辑器的多次写入
+	}
+}
+
+// SetSecretProvider 设置密钥提供者
+// 参数:
+//
+//	p: 密钥提供者,为 nil 时恢复默认的 EnvSecretProvider
+func (m *manager) SetSecretProvider(p SecretProvider) {
+	if p == nil {
+		p = EnvSecretProvider{}
 	}
+	m.secretProvider = p
+}
+
+// SetWatchDebounce 设置 Watch 的防抖窗口
+// 参数:
+//
+//	d: 防抖窗口,负数按 0 处理
+func (m *manager) SetWatchDebounce(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	m.timerMu.Lock()
+	m.watchDebounce = d
+	m.timerMu.Unlock()
 }
 
 // Load 从指定路径加载配置
 // 加载流程:
 //  1. 设置配置文件路径
 //  2. 读取配置文件
-//  3. 处理环境变量替换(${VAR:default})
-//  4. 反序列化到 Config 结构体
-//  5. 验证配置
-//  6. 原子存储配置
+//  3. 解析密钥引用(${secret:ref})
+//  4. 处理环境变量替换(${VAR:default})
+//  5. 反序列化到 Config 结构体
+//  6. 验证配置
+//  7. 原子存储配置
 //
 // 参数:
 //
@@ -176,34 +255,46 @@ func (m *manager) Load(configPath string) error {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// 4. 处理环境变量替换
+	// 4. 解析密钥引用
+	// 将配置中的 ${secret:ref} 替换为密钥提供者返回的值
+	// 必须在环境变量替换之前,否则 ${secret:ref} 会被当作名为 secret 的环境变量
+	refs, err := m.resolveSecretsForViper(m.v)
+	if err != nil {
+		return fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	// 5. 处理环境变量替换
 	// 将配置中的 ${VAR_NAME:default} 替换为环境变量值
 	// 例如: port: ${PORT:8080} -> port: 8080(如果 PORT 未设置)
 	if err := m.processEnvSubstitution(); err != nil {
 		return fmt.Errorf("failed to process env substitution: %w", err)
 	}
 
-	// 5. 反序列化为 Config 结构体
+	// 6. 反序列化为 Config 结构体
 	// viper 会根据 mapstructure tag 映射字段
 	cfg := &Config{}
 	if err := m.v.Unmarshal(cfg); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	// 6. 使用环境变量覆盖配置
+	// 7. 使用环境变量覆盖配置
 	// 优先级: 环境变量 > config.yaml
 	// 这允许通过环境变量覆盖配置文件中的任何值
 	// 特别适合容器环境和CI/CD流程
-	OverrideWithEnv(cfg)
+	if err := OverrideWithEnv(cfg); err != nil {
+		return fmt.Errorf("failed to override config with env: %w", err)
+	}
 
-	// 7. 验证配置
+	// 8. 验证配置
 	// 确保所有必需的字段都有有效值
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("config validation failed: %w", err)
 	}
 
-	// 8. 原子存储配置
+	// 9. 原子存储配置
 	// 使用 atomic.Pointer.Store 确保并发安全
+	// 密钥引用先于配置存储,Save 读到新配置时一定能看到对应的引用
+	m.secretRefs.Store(&refs)
 	m.config.Store(cfg)
 
 	return nil
@@ -535,7 +626,7 @@ func (m *manager) Watch() error {
 	// 注册配置变更回调
 	// 当文件变化时,viper 会调用这个函数
 	m.v.OnConfigChange(func(e fsnotify.Event) {
-		m.handleConfigChange(e)
+		m.scheduleReload(e)
 	})
 
 	// 开始监听配置文件
@@ -544,6 +635,24 @@ func (m *manager) Watch() error {
 	return nil
 }
 
+// scheduleReload 防抖处理配置文件变化事件
+// 每个事件都会重置计时器,只有 watchDebounce 内没有新事件时才重新加载,
+// 避免编辑器分多次写入时读到写了一半的文件
+// 参数:
+//
+//	e: 文件系统事件,重新加载时使用窗口内的最后一个事件
+func (m *manager) scheduleReload(e fsnotify.Event) {
+	m.timerMu.Lock()
+	defer m.timerMu.Unlock()
+
+	if m.reloadTimer != nil {
+		m.reloadTimer.Stop()
+	}
+	m.reloadTimer = time.AfterFunc(m.watchDebounce, func() {
+		m.handleConfigChange(e)
+	})
+}
+
 // handleConfigChange 处理配置文件变化事件
 // Shadow Loading 模式:
 //  1. 使用临时 viper 实例加载新配置
@@ -559,6 +668,10 @@ func (m *manager) Watch() error {
 //
 //	e: 文件系统事件
 func (m *manager) handleConfigChange(e fsnotify.Event) {
+	// 串行执行,防止两次重新加载交错替换配置
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
 	if m.log != nil {
 		m.log.Info("config file changed", "file", e.Name, "op", e.Op.String())
 	}
@@ -576,6 +689,15 @@ func (m *manager) handleConfigChange(e fsnotify.Event) {
 		return
 	}
 
+	// 解析密钥引用,失败时保持当前配置不变
+	refs, err := m.resolveSecretsForViper(tempViper)
+	if err != nil {
+		if m.log != nil {
+			m.log.Error("failed to resolve secrets in changed config, keeping current config", "error", err)
+		}
+		return
+	}
+
 	// 处理环境变量替换
 	m.processEnvSubstitutionForViper(tempViper)
 
@@ -588,6 +710,15 @@ func (m *manager) handleConfigChange(e fsnotify.Event) {
 		return
 	}
 
+	// 与 Load 相同,环境变量优先于配置文件
+	// 否则文件变更后通过环境变量设置的值会被文件中的值覆盖
+	if err := OverrideWithEnv(newCfg); err != nil {
+		if m.log != nil {
+			m.log.Error("failed to override changed config with env, keeping current config", "error", err)
+		}
+		return
+	}
+
 	// 验证新配置
 	// 如果验证失败,保持当前配置不变
 	if err := newCfg.Validate(); err != nil {
@@ -602,6 +733,7 @@ func (m *manager) handleConfigChange(e fsnotify.Event) {
 
 	// 原子切换配置
 	// 从这一刻起,Get() 会返回新配置
+	m.secretRefs.Store(&refs)
 	m.config.Store(newCfg)
 
 	// 更新主 viper 实例
@@ -628,6 +760,27 @@ func (m *manager) processEnvSubstitutionForViper(v *viper.Viper) {
 	}
 }
 
+// resolveSecretsForViper 解析指定 viper 实例中所有的密钥引用
+// 参数:
+//
+//	v: viper 实例
+//
+// 返回:
+//
+//	secretRefs: 含引用的配置项及其原始值,用于保存时写回引用
+//	error: 任一引用解析失败时的错误,包含配置项路径
+func (m *manager) resolveSecretsForViper(v *viper.Viper) (secretRefs, error) {
+	refs := make(secretRefs)
+	for key, value := range v.AllSettings() {
+		resolved, err := resolveSecretValue(key, value, m.secretProvider, refs)
+		if err != nil {
+			return nil, err
+		}
+		v.Set(key, resolved)
+	}
+	return refs, nil
+}
+
 // GetConfigDir 返回配置文件所在的目录
 // 用途:
 //   - 加载相对于配置文件的其他文件
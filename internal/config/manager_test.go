@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// writeWatchTestConfig 写入端口为 port 的测试配置
+func writeWatchTestConfig(t *testing.T, path, port string) {
+	t.Helper()
+	content := strings.Replace(saveTestYAML, "port: 8080", "port: "+port, 1)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+}
+
+// TestWatch_DebounceRapidWrites 测试防抖窗口内的连续写入只触发一次重新加载
+func TestWatch_DebounceRapidWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeWatchTestConfig(t, path, "8080")
+
+	mgr := loadSaveTestConfig(t, path)
+	mgr.SetWatchDebounce(50 * time.Millisecond)
+	m := mgr.(*manager)
+
+	var mu sync.Mutex
+	var ports [][2]int
+	mgr.RegisterHook(func(old, new *Config) {
+		mu.Lock()
+		defer mu.Unlock()
+		ports = append(ports, [2]int{old.Server.Port, new.Server.Port})
+	})
+
+	event := fsnotify.Event{Name: path, Op: fsnotify.Write}
+	writeWatchTestConfig(t, path, "8081")
+	m.scheduleReload(event)
+	writeWatchTestConfig(t, path, "9090")
+	m.scheduleReload(event)
+
+	time.Sleep(300 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ports) != 1 || ports[0] != [2]int{8080, 9090} {
+		t.Fatalf("expected a single reload 8080 -> 9090, got %v", ports)
+	}
+	if got := mgr.Get().Server.Port; got != 9090 {
+		t.Fatalf("expected port 9090 after reload, got %d", got)
+	}
+}
+
+// TestWatch_ReloadKeepsEnvOverrides 测试重新加载后环境变量仍然优先于配置文件
+func TestWatch_ReloadKeepsEnvOverrides(t *testing.T) {
+	t.Setenv(EnvPrefixJoin(EnvDBMaxOpenConns), "42")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeWatchTestConfig(t, path, "8080")
+
+	mgr := loadSaveTestConfig(t, path)
+	if got := mgr.Get().Database.MaxOpenConns; got != 42 {
+		t.Fatalf("expected env override 42 after load, got %d", got)
+	}
+
+	writeWatchTestConfig(t, path, "9090")
+	mgr.(*manager).handleConfigChange(fsnotify.Event{Name: path, Op: fsnotify.Write})
+
+	cfg := mgr.Get()
+	if cfg.Server.Port != 9090 {
+		t.Fatalf("expected port 9090 after reload, got %d", cfg.Server.Port)
+	}
+	if cfg.Database.MaxOpenConns != 42 {
+		t.Fatalf("expected env override 42 after reload, got %d", cfg.Database.MaxOpenConns)
+	}
+}
+
+// TestWatch_InvalidChangeKeepsCurrent 测试变更后的配置无效或不完整时保持当前配置
+func TestWatch_InvalidChangeKeepsCurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeWatchTestConfig(t, path, "8080")
+
+	mgr := loadSaveTestConfig(t, path)
+	m := mgr.(*manager)
+
+	called := false
+	mgr.RegisterHook(func(old, new *Config) { called = true })
+
+	event := fsnotify.Event{Name: path, Op: fsnotify.Write}
+	for _, content := range []string{
+		saveTestYAML[:len(saveTestYAML)/3], // 写了一半的文件
+		strings.Replace(saveTestYAML, "port: 8080", "port: 0", 1),
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		m.handleConfigChange(event)
+
+		if called {
+			t.Fatal("hooks should not be called for an invalid config")
+		}
+		if got := mgr.Get().Server.Port; got != 8080 {
+			t.Fatalf("expected current port 8080 to be kept, got %d", got)
+		}
+	}
+}
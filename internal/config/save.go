@@ -0,0 +1,243 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SaveOptions 保存配置的选项
+type SaveOptions struct {
+	// IncludeSecrets 是否写出敏感字段的明文
+	// 默认为 false,带 secret:"true" tag 的非空字段写为 RedactedValue
+	// 值来自 ${secret:ref} 引用的字段始终写回原引用,不受此选项影响
+	IncludeSecrets bool
+}
+
+// Save 将当前配置以 YAML 格式写入文件,敏感字段脱敏
+// 等价于 SaveWithOptions(path, SaveOptions{})
+func (m *manager) Save(path string) error {
+	return m.SaveWithOptions(path, SaveOptions{})
+}
+
+// SaveWithOptions 将当前配置以 YAML 格式写入文件
+// 键名使用 mapstructure tag,与 Load 读取时一致,字段按结构体声明顺序输出
+// 参数:
+//
+//	path: 目标文件路径
+//	opts: 保存选项
+//
+// 返回:
+//
+//	error: 配置未加载、编码或写入失败时的错误
+//
+// 注意:
+//   - 先写入同目录下的临时文件再重命名,避免写入中途失败留下不完整的文件
+//   - 目标文件已存在时保留其权限,否则使用 DefaultSaveFileMode
+//   - 保存的是最终生效的配置(已应用环境变量覆盖),原文件中的注释和 ${VAR} 占位符不会保留
+//   - ${secret:ref} 引用会保留:字段值未被修改时写回原引用,而不是解析出的密钥
+func (m *manager) SaveWithOptions(path string, opts SaveOptions) error {
+	cfg := m.Get()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	var refs secretRefs
+	if p := m.secretRefs.Load(); p != nil {
+		refs = *p
+	}
+
+	data, err := marshalYAML(cfg, opts, refs)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
+	return nil
+}
+
+// MarshalYAML 将配置编码为 YAML
+// 参数:
+//
+//	cfg: 要编码的配置
+//	opts: 保存选项,默认脱敏敏感字段
+//
+// 返回:
+//
+//	[]byte: YAML 内容
+//	error: 编码失败时的错误
+func MarshalYAML(cfg *Config, opts SaveOptions) ([]byte, error) {
+	return marshalYAML(cfg, opts, nil)
+}
+
+// marshalYAML 将配置编码为 YAML,refs 中记录的字段写回 ${secret:ref} 引用
+func marshalYAML(cfg *Config, opts SaveOptions, refs secretRefs) ([]byte, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+
+	e := &yamlEncoder{opts: opts, refs: refs}
+	node, err := e.encode(reflect.ValueOf(*cfg), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	// 使用 2 空格缩进,与 configs/ 下的配置文件保持一致
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeYAMLNode 按选项将值编码为 yaml.Node,不处理密钥引用
+func encodeYAMLNode(v reflect.Value, opts SaveOptions) (*yaml.Node, error) {
+	e := &yamlEncoder{opts: opts}
+	return e.encode(v, "")
+}
+
+// yamlEncoder 将配置编码为 yaml.Node
+type yamlEncoder struct {
+	opts SaveOptions
+	refs secretRefs
+}
+
+// secretRef 返回 key 处应写回的 ${secret:ref} 引用
+// 仅当字段仍是加载时解析出的值才写回,被 Update 或环境变量修改过的字段按当前值处理
+func (e *yamlEncoder) secretRef(key string, v reflect.Value) (string, bool) {
+	if v.Kind() != reflect.String {
+		return "", false
+	}
+	ref, ok := e.refs[key]
+	if !ok || v.String() != ref.resolved {
+		return "", false
+	}
+	return ref.raw, true
+}
+
+// encode 将值编码为 yaml.Node
+// key 为值对应的配置项路径,与 viper 的小写键一致,用于查找密钥引用
+// 结构体编码为 mapping,保持字段声明顺序;time.Duration 编码为 "5m0s" 形式的字符串
+// nil 切片字段会被省略
+func (e *yamlEncoder) encode(v reflect.Value, key string) (*yaml.Node, error) {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: d.String()}, nil
+	}
+	if raw, ok := e.secretRef(key, v); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: raw}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("mapstructure") == "-" {
+				continue
+			}
+
+			fv := v.Field(i)
+			// nil 切片不输出,重新加载后仍为 nil,保证往返一致
+			if fv.Kind() == reflect.Slice && fv.IsNil() {
+				continue
+			}
+
+			name := fieldKey(field)
+			fieldPath := strings.ToLower(name)
+			if key != "" {
+				fieldPath = key + "." + fieldPath
+			}
+
+			var valueNode *yaml.Node
+			_, isRef := e.secretRef(fieldPath, fv)
+			if !isRef && !e.opts.IncludeSecrets && field.Tag.Get(SecretTagName) == "true" && !fv.IsZero() {
+				valueNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: RedactedValue}
+			} else {
+				var err error
+				if valueNode, err = e.encode(fv, fieldPath); err != nil {
+					return nil, err
+				}
+			}
+
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: name}
+			node.Content = append(node.Content, keyNode, valueNode)
+		}
+		return node, nil
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+		}
+		return e.encode(v.Elem(), key)
+
+	case reflect.Slice, reflect.Array:
+		// 空列表输出为 []
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		if v.Len() == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		for i := 0; i < v.Len(); i++ {
+			item, err := e.encode(v.Index(i), fmt.Sprintf("%s[%d]", key, i))
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		return node, nil
+
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(v.Interface()); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+}
+
+// writeFileAtomic 原子写入文件
+// 先写同目录临时文件并 Sync,再 Rename 覆盖目标文件
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(DefaultSaveFileMode)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	// 失败时清理临时文件;重命名成功后 Remove 返回的错误可以忽略
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
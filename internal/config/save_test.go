@@ -0,0 +1,194 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const saveTestYAML = `
+server:
+  host: 127.0.0.1
+  port: 8080
+  mode: release
+  read_timeout: 10
+  write_timeout: 10
+database:
+  driver: sqlite
+  dbname: ./data/app.db
+  password: db-s3cret
+  max_open_conns: 10
+  max_idle_conns: 5
+redis:
+  enabled: false
+logger:
+  level: info
+  format: json
+  output: stdout
+i18n:
+  default: zh-CN
+  supported: [zh-CN, en-US]
+  messages_dir: ./configs/locales
+executor:
+  enabled: true
+  pools:
+    - name: http
+      size: 100
+      expiry: 10
+      non_blocking: true
+jwt:
+  secret: jwt-secret-at-least-32-characters-long
+  expiresIn: 3600
+  issuer: go-scaffold
+rbac:
+  enabled: true
+  cache_ttl: 5m
+cors:
+  enabled: false
+`
+
+// loadSaveTestConfig 写入测试配置并加载
+func loadSaveTestConfig(t *testing.T, path string) Manager {
+	t.Helper()
+	mgr := NewManager()
+	if err := mgr.Load(path); err != nil {
+		t.Fatalf("failed to load config %s: %v", path, err)
+	}
+	return mgr
+}
+
+// TestSave_RoundTrip 测试 load→save→load 结果一致
+func TestSave_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(src, []byte(saveTestYAML), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := loadSaveTestConfig(t, src)
+	dst := filepath.Join(dir, "saved.yaml")
+	if err := mgr.SaveWithOptions(dst, SaveOptions{IncludeSecrets: true}); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	reloaded := loadSaveTestConfig(t, dst)
+	if !reflect.DeepEqual(mgr.Get(), reloaded.Get()) {
+		t.Fatalf("config changed after round trip:\nbefore: %+v\nafter:  %+v", mgr.Get(), reloaded.Get())
+	}
+
+	// 不应残留临时文件
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected only source and saved files, got %d entries", len(entries))
+	}
+}
+
+// TestSave_Redact 测试默认脱敏敏感字段
+func TestSave_Redact(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(src, []byte(saveTestYAML), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := loadSaveTestConfig(t, src)
+	dst := filepath.Join(dir, "redacted.yaml")
+	if err := mgr.Save(dst); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("failed to read saved config: %v", err)
+	}
+	out := string(data)
+
+	for _, secret := range []string{"db-s3cret", "jwt-secret-at-least-32-characters-long"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("secret %q should be redacted", secret)
+		}
+	}
+	if strings.Count(out, RedactedValue) != 2 {
+		t.Errorf("expected 2 redacted values (empty redis password kept empty), got:\n%s", out)
+	}
+}
+
+// TestSave_KeepsSecretRefs 测试保存时写回 ${secret:ref} 引用而不是解析出的密钥
+func TestSave_KeepsSecretRefs(t *testing.T) {
+	t.Setenv("TEST_SECRET_DB_PASSWORD", "resolved-password")
+	t.Setenv("TEST_SECRET_POOL_NAME", "http")
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config.yaml")
+	yaml := strings.Replace(saveTestYAML, "password: db-s3cret", "password: ${secret:db/password}", 1)
+	yaml = strings.Replace(yaml, "- name: http", "- name: ${secret:pool/name}", 1)
+	if err := os.WriteFile(src, []byte(yaml), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewManager()
+	mgr.SetSecretProvider(EnvSecretProvider{Prefix: "TEST_SECRET_"})
+	if err := mgr.Load(src); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	// 引用不受 IncludeSecrets 影响
+	for _, opts := range []SaveOptions{{}, {IncludeSecrets: true}} {
+		dst := filepath.Join(dir, "saved.yaml")
+		if err := mgr.SaveWithOptions(dst, opts); err != nil {
+			t.Fatalf("failed to save config: %v", err)
+		}
+		data, err := os.ReadFile(dst)
+		if err != nil {
+			t.Fatalf("failed to read saved config: %v", err)
+		}
+		out := string(data)
+
+		if strings.Contains(out, "resolved-password") {
+			t.Errorf("IncludeSecrets=%v: resolved secret written to file:\n%s", opts.IncludeSecrets, out)
+		}
+		for _, ref := range []string{"${secret:db/password}", "${secret:pool/name}"} {
+			if !strings.Contains(out, ref) {
+				t.Errorf("IncludeSecrets=%v: expected reference %s to be kept, got:\n%s", opts.IncludeSecrets, ref, out)
+			}
+		}
+
+		// 写回引用的文件重新加载后得到相同的配置
+		// 默认选项下 JWT 密钥被脱敏,无法通过校验,只检查包含明文的情况
+		if !opts.IncludeSecrets {
+			continue
+		}
+		reloaded := NewManager()
+		reloaded.SetSecretProvider(EnvSecretProvider{Prefix: "TEST_SECRET_"})
+		if err := reloaded.Load(dst); err != nil {
+			t.Fatalf("failed to reload saved config: %v", err)
+		}
+		if !reflect.DeepEqual(mgr.Get(), reloaded.Get()) {
+			t.Fatalf("config changed after round trip:\nbefore: %+v\nafter:  %+v", mgr.Get(), reloaded.Get())
+		}
+	}
+
+	// 修改过的字段不再对应原引用,按当前值处理
+	cfg := *mgr.Get()
+	cfg.Database.Password = "changed-password"
+	data, err := marshalYAML(&cfg, SaveOptions{}, *mgr.(*manager).secretRefs.Load())
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	out := string(data)
+	if strings.Contains(out, "${secret:db/password}") || strings.Contains(out, "changed-password") {
+		t.Errorf("expected changed password to be redacted, got:\n%s", out)
+	}
+	if !strings.Contains(out, "${secret:pool/name}") {
+		t.Errorf("expected unchanged reference to be kept, got:\n%s", out)
+	}
+}
+
+// TestSave_NotLoaded 测试未加载配置时保存失败
+func TestSave_NotLoaded(t *testing.T) {
+	if err := NewManager().Save(filepath.Join(t.TempDir(), "config.yaml")); err == nil {
+		t.Fatal("expected error when configuration is not loaded")
+	}
+}
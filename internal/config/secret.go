@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrSecretNotFound 密钥引用无法解析时返回的错误
+var ErrSecretNotFound = errors.New("secret not found")
+
+// secretRefPattern 匹配 ${secret:ref} 格式的密钥引用
+// 捕获组 1 为引用名,如 db/password
+var secretRefPattern = regexp.MustCompile(`\$\{` + SecretRefScheme + `:([^}]+)\}`)
+
+// SecretProvider 密钥提供者
+// 配置值中的 ${secret:ref} 在加载时交给提供者解析,
+// 可实现此接口对接 Vault、云厂商 KMS 等密钥服务
+//
+// 使用示例:
+//
+//	mgr := config.NewManager()
+//	mgr.SetSecretProvider(config.FileSecretProvider{Dir: "/run/secrets"})
+//	mgr.Load("config.yaml") // password: ${secret:db/password}
+type SecretProvider interface {
+	// Resolve 返回引用对应的密钥值
+	// 参数:
+	//   ref: 引用名,即 ${secret:ref} 中的 ref
+	// 返回:
+	//   string: 密钥值
+	//   error: 引用不存在时应返回包装了 ErrSecretNotFound 的错误
+	Resolve(ref string) (string, error)
+}
+
+// EnvSecretProvider 从环境变量读取密钥,默认的密钥提供者
+// 引用名转为大写,非字母数字字符替换为下划线,再加上 Prefix:
+//
+//	${secret:db/password} -> DB_PASSWORD
+//	Prefix 为 "SECRET_" 时 -> SECRET_DB_PASSWORD
+type EnvSecretProvider struct {
+	// Prefix 环境变量名前缀
+	Prefix string
+}
+
+// Resolve 读取引用对应的环境变量,未设置或为空时返回 ErrSecretNotFound
+func (p EnvSecretProvider) Resolve(ref string) (string, error) {
+	name := p.Prefix + secretEnvName(ref)
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("%w: %s (env %s)", ErrSecretNotFound, ref, name)
+	}
+	return value, nil
+}
+
+// secretEnvName 将引用名转换为环境变量名
+func secretEnvName(ref string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, ref)
+}
+
+// FileSecretProvider 从目录下的文件读取密钥
+// 引用名即相对 Dir 的文件路径,适用于 Docker/Kubernetes 挂载的 secret 文件,
+// 文件末尾的换行会被去除
+type FileSecretProvider struct {
+	// Dir 密钥文件所在目录
+	Dir string
+}
+
+// Resolve 读取引用对应的文件,文件不存在时返回 ErrSecretNotFound
+// 引用名不能跳出 Dir (如 ../etc/passwd)
+func (p FileSecretProvider) Resolve(ref string) (string, error) {
+	if !filepath.IsLocal(ref) {
+		return "", fmt.Errorf("invalid secret ref %q: must be a relative path inside %s", ref, p.Dir)
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.Dir, ref))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, ref)
+		}
+		return "", fmt.Errorf("failed to read secret %s: %w", ref, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// ResolveSecretRefs 解析字符串中的所有 ${secret:ref} 引用
+// 参数:
+//
+//	value: 要解析的字符串
+//	provider: 密钥提供者
+//
+// 返回:
+//
+//	string: 替换后的字符串
+//	error: 任一引用解析失败时的错误
+func ResolveSecretRefs(value string, provider SecretProvider) (string, error) {
+	var resolveErr error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		ref := secretRefPattern.FindStringSubmatch(match)[1]
+		secret, err := provider.Resolve(ref)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return secret
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// secretRef 记录含 ${secret:ref} 引用的配置值
+// 保存配置时,字段值仍等于 resolved 则写回 raw,避免把密钥明文写入文件
+type secretRef struct {
+	raw      string // 配置文件中的原始值,如 "${secret:db/password}"
+	resolved string // 解析后的值
+}
+
+// secretRefs 按配置项路径(小写,如 "database.password"、"executor.pools[0].name")索引的密钥引用
+type secretRefs map[string]secretRef
+
+// resolveSecretValue 递归解析配置值中的密钥引用
+// 与 processValue 的遍历方式一致,key 用于在错误中指明配置项
+// refs 不为 nil 时记录每个含引用的字符串,供保存配置时还原
+func resolveSecretValue(key string, value any, provider SecretProvider, refs secretRefs) (any, error) {
+	switch v := value.(type) {
+	case string:
+		resolved, err := ResolveSecretRefs(v, provider)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if refs != nil && secretRefPattern.MatchString(v) {
+			refs[key] = secretRef{raw: v, resolved: resolved}
+		}
+		return resolved, nil
+
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			resolved, err := resolveSecretValue(key+"."+k, item, provider, refs)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = resolved
+		}
+		return result, nil
+
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveSecretValue(fmt.Sprintf("%s[%d]", key, i), item, provider, refs)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestResolveSecretRefs_Env 测试环境变量提供者解析引用
+func TestResolveSecretRefs_Env(t *testing.T) {
+	t.Setenv("TEST_SECRET_DB_PASSWORD", "s3cret")
+
+	provider := EnvSecretProvider{Prefix: "TEST_SECRET_"}
+	got, err := ResolveSecretRefs("user:${secret:db/password}@host", provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "user:s3cret@host" {
+		t.Errorf("got %q", got)
+	}
+
+	// 不含引用的值原样返回
+	if got, err := ResolveSecretRefs("${PORT:8080}", provider); err != nil || got != "${PORT:8080}" {
+		t.Errorf("plain value changed: %q, %v", got, err)
+	}
+}
+
+// TestResolveSecretRefs_Unknown 测试未知引用返回 ErrSecretNotFound
+func TestResolveSecretRefs_Unknown(t *testing.T) {
+	_, err := ResolveSecretRefs("${secret:missing/key}", EnvSecretProvider{Prefix: "TEST_SECRET_"})
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got %v", err)
+	}
+
+	_, err = ResolveSecretRefs("${secret:missing}", FileSecretProvider{Dir: t.TempDir()})
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound from file provider, got %v", err)
+	}
+}
+
+// TestFileSecretProvider 测试文件提供者去除换行并拒绝跳出目录
+func TestFileSecretProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "password"), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := FileSecretProvider{Dir: dir}
+	if got, err := provider.Resolve("db/password"); err != nil || got != "from-file" {
+		t.Errorf("Resolve() = %q, %v", got, err)
+	}
+	if _, err := provider.Resolve("../outside"); err == nil {
+		t.Error("expected error for ref outside dir")
+	}
+}
+
+// TestLoad_SecretRefs 测试 Load 解析配置中的密钥引用,未知引用导致加载失败
+func TestLoad_SecretRefs(t *testing.T) {
+	t.Setenv("TEST_SECRET_DB_PASSWORD", "resolved-password")
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "config.yaml")
+	yaml := strings.Replace(saveTestYAML, "password: db-s3cret", "password: ${secret:db/password}", 1)
+	if err := os.WriteFile(src, []byte(yaml), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewManager()
+	mgr.SetSecretProvider(EnvSecretProvider{Prefix: "TEST_SECRET_"})
+	if err := mgr.Load(src); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := mgr.Get().Database.Password; got != "resolved-password" {
+		t.Errorf("password = %q, want resolved secret", got)
+	}
+
+	unknown := strings.Replace(saveTestYAML, "password: db-s3cret", "password: ${secret:db/unknown}", 1)
+	if err := os.WriteFile(src, []byte(unknown), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	mgr = NewManager()
+	mgr.SetSecretProvider(EnvSecretProvider{Prefix: "TEST_SECRET_"})
+	if err := mgr.Load(src); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected ErrSecretNotFound, got %v", err)
+	}
+}
@@ -52,7 +52,7 @@ func NewAuthHandler(authService auth.AuthService, logger logger.Logger) *AuthHan
 //
 //	200 OK - 注册成功，返回用户信息
 //	400 Bad Request - 请求参数错误
-//	409 Conflict - 用户名或邮箱已存在
+//	422 Unprocessable Entity - 用户名或邮箱已存在
 //	500 Internal Server Error - 服务器内部错误
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req types.RegisterRequest
@@ -61,7 +61,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	// ShouldBindJSON 会自动验证 binding tag
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid register request", "error", err)
-		result.BadRequest(c, "无效的请求参数: "+err.Error())
+		handleBindError(c, err)
 		return
 	}
 
@@ -69,9 +69,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	user, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to register user", "username", req.Username, "error", err)
-		// 根据错误类型返回不同的状态码
-		// 这里简化处理，实际应该根据具体错误类型判断
-		result.InternalError(c, "注册失败: "+err.Error())
+		handleServiceError(c, err, "注册失败")
 		return
 	}
 
@@ -99,15 +97,20 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// 绑定并验证请求数据
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid login request", "error", err)
-		result.BadRequest(c, "无效的请求参数: "+err.Error())
+		handleBindError(c, err)
 		return
 	}
 
 	// 调用服务层处理登录逻辑
 	loginResp, err := h.authService.Login(c.Request.Context(), &req)
 	if err != nil {
+		if isServerError(err) {
+			h.logger.Error("login failed", "username", req.Username, "error", err)
+			handleServiceError(c, err, "登录失败")
+			return
+		}
 		h.logger.Warn("login failed", "username", req.Username, "error", err)
-		// 登录失败返回 401
+		// 用户不存在和密码错误统一返回 401,避免暴露用户名是否存在
 		result.Unauthorized(c, "用户名或密码错误")
 		return
 	}
@@ -137,10 +140,13 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
+	// 当前访问令牌,由 AuthMiddleware 设置,用于吊销
+	token, _ := middleware.GetToken(c)
+
 	// 调用服务层处理登出逻辑
-	if err := h.authService.Logout(c.Request.Context(), userID); err != nil {
+	if err := h.authService.Logout(c.Request.Context(), userID, token); err != nil {
 		h.logger.Error("failed to logout", "userId", userID, "error", err)
-		result.InternalError(c, "登出失败: "+err.Error())
+		handleServiceError(c, err, "登出失败")
 		return
 	}
 
@@ -164,6 +170,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 //	200 OK - 密码修改成功
 //	400 Bad Request - 请求参数错误
 //	401 Unauthorized - 未认证或旧密码错误
+//	404 Not Found - 用户不存在
 //	500 Internal Server Error - 服务器内部错误
 //
 // 注意: 需要认证中间件保护
@@ -173,7 +180,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	// 绑定并验证请求数据
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid change password request", "error", err)
-		result.BadRequest(c, "无效的请求参数: "+err.Error())
+		handleBindError(c, err)
 		return
 	}
 
@@ -188,9 +195,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	// 调用服务层处理密码修改逻辑
 	if err := h.authService.ChangePassword(c.Request.Context(), userID, &req); err != nil {
 		h.logger.Error("failed to change password", "userId", userID, "error", err)
-		// 根据错误类型返回不同的响应
-		// 这里简化处理，实际应该根据具体错误类型判断
-		result.InternalError(c, "修改密码失败: "+err.Error())
+		handleServiceError(c, err, "修改密码失败")
 		return
 	}
 
@@ -219,13 +224,18 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	// 绑定并验证请求数据
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Warn("invalid refresh token request", "error", err)
-		result.BadRequest(c, "无效的请求参数: "+err.Error())
+		handleBindError(c, err)
 		return
 	}
 
 	// 调用服务层处理 token 刷新逻辑
 	tokenResp, err := h.authService.RefreshToken(c.Request.Context(), &req)
 	if err != nil {
+		if isServerError(err) {
+			h.logger.Error("refresh token failed", "error", err)
+			handleServiceError(c, err, "刷新 token 失败")
+			return
+		}
 		h.logger.Warn("refresh token failed", "error", err)
 		// Token 验证失败返回 401
 		result.Unauthorized(c, "refresh token 无效或过期")
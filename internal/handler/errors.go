@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/internal/middleware"
+	"github.com/rei0721/go-scaffold/types/errors"
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// handleServiceError 将服务层返回的错误写为 HTTP 响应
+// 使用 errors.AsBizError 沿错误链查找 BizError,被多次包装时同样生效
+// 参数:
+//
+//	c: Gin上下文
+//	err: 服务层返回的错误
+//	fallback: 非业务错误或服务器错误时返回给客户端的消息
+//
+// 响应规则:
+//   - BizError 且为客户端错误(4xx): 使用其错误码、消息和对应的 HTTP 状态码
+//   - BizError 且为服务器错误(5xx): 保留错误码,消息使用 fallback,避免泄露内部细节
+//   - 其他错误: 500 Internal Server Error,消息使用 fallback
+func handleServiceError(c *gin.Context, err error, fallback string) {
+	bizErr, ok := errors.AsBizError(err)
+	if !ok {
+		result.InternalError(c, fallback)
+		return
+	}
+
+	status := errors.HTTPStatus(bizErr.Code)
+	message := bizErr.Message
+	if status >= http.StatusInternalServerError {
+		message = fallback
+	}
+	c.JSON(status, result.ErrorWithTrace(bizErr.Code, message, result.GetTraceID(c)))
+}
+
+// isServerError 判断错误是否为服务器内部错误
+// 非 BizError 视为服务器错误
+func isServerError(err error) bool {
+	bizErr, ok := errors.AsBizError(err)
+	if !ok {
+		return true
+	}
+	return errors.HTTPStatus(bizErr.Code) >= http.StatusInternalServerError
+}
+
+// handleBindError 将请求绑定错误写为 HTTP 响应
+// 校验失败时返回字段级错误,便于客户端定位;请求体超过 BodyLimit 限制时返回 413;
+// 其他错误(如 JSON 格式错误)返回通用的参数错误
+func handleBindError(c *gin.Context, err error) {
+	if middleware.IsBodyTooLarge(err) {
+		c.JSON(http.StatusRequestEntityTooLarge, result.ErrorLocalized(c, errors.ErrRequestTooLarge, result.GetTraceID(c)))
+		return
+	}
+	if fields := errors.ParseValidationErrors(err); fields != nil {
+		result.ValidationFailed(c, result.GetTraceID(c), fields)
+		return
+	}
+	result.BadRequest(c, "无效的请求参数")
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
@@ -50,9 +51,9 @@ func (h *RBACHandler) AssignRole(c *gin.Context) {
 
 	// 分配角色
 	if req.Domain != "" {
-		err = h.rbacService.AssignRoleInDomain(c.Request.Context(), userID, req.Role, req.Domain)
+		err = h.rbacService.AssignRoleInDomain(h.requestContext(c), userID, req.Role, req.Domain)
 	} else {
-		err = h.rbacService.AssignRole(c.Request.Context(), userID, req.Role)
+		err = h.rbacService.AssignRole(h.requestContext(c), userID, req.Role)
 	}
 
 	if err != nil {
@@ -90,9 +91,9 @@ func (h *RBACHandler) RevokeRole(c *gin.Context) {
 
 	// 撤销角色
 	if domain != "" {
-		err = h.rbacService.RevokeRoleInDomain(c.Request.Context(), userID, role, domain)
+		err = h.rbacService.RevokeRoleInDomain(h.requestContext(c), userID, role, domain)
 	} else {
-		err = h.rbacService.RevokeRole(c.Request.Context(), userID, role)
+		err = h.rbacService.RevokeRole(h.requestContext(c), userID, role)
 	}
 
 	if err != nil {
@@ -124,9 +125,9 @@ func (h *RBACHandler) GetUserRoles(c *gin.Context) {
 	// 获取角色
 	var roles []string
 	if domain != "" {
-		roles, err = h.rbacService.GetUserRolesInDomain(c.Request.Context(), userID, domain)
+		roles, err = h.rbacService.GetUserRolesInDomain(h.requestContext(c), userID, domain)
 	} else {
-		roles, err = h.rbacService.GetUserRoles(c.Request.Context(), userID)
+		roles, err = h.rbacService.GetUserRoles(h.requestContext(c), userID)
 	}
 
 	if err != nil {
@@ -152,7 +153,7 @@ func (h *RBACHandler) GetRoleUsers(c *gin.Context) {
 	}
 
 	// 获取用户列表
-	userIDs, err := h.rbacService.GetRoleUsers(c.Request.Context(), role)
+	userIDs, err := h.rbacService.GetRoleUsers(h.requestContext(c), role)
 	if err != nil {
 		h.logger.Error("failed to get role users", "role", role, "error", err)
 		result.InternalError(c, "Failed to get role users")
@@ -165,6 +166,183 @@ func (h *RBACHandler) GetRoleUsers(c *gin.Context) {
 	}))
 }
 
+// GetUserPermissions 获取用户的所有有效权限
+// GET /users/:id/permissions
+// 用户只能查询自己的权限，admin 可以查询任意用户
+func (h *RBACHandler) GetUserPermissions(c *gin.Context) {
+	// 获取用户ID参数
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		result.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	// 非本人查询需要 admin 角色
+	if !h.authorizeSelfOrAdmin(c, userID, "") {
+		return
+	}
+
+	// 获取权限
+	permissions, err := h.rbacService.GetUserPermissions(h.requestContext(c), userID)
+	if err != nil {
+		h.logger.Error("failed to get user permissions", "user_id", userID, "error", err)
+		result.InternalError(c, "Failed to get user permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Success(types.UserPermissionsResponse{
+		UserID:      userID,
+		Permissions: permissions,
+	}))
+}
+
+// GetEffectivePermissions 获取用户在指定域中的所有有效权限
+// GET /rbac/users/:id/effective
+// Query: ?domain=tenant1
+// 合并用户所有角色（含继承角色）的权限并去重，用户只能查询自己的权限，admin 可以查询任意用户
+func (h *RBACHandler) GetEffectivePermissions(c *gin.Context) {
+	// 获取用户ID参数
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		result.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	// 获取域参数（可选）
+	domain := c.Query("domain")
+
+	if !h.authorizeSelfOrAdmin(c, userID, domain) {
+		return
+	}
+
+	permissions, err := h.rbacService.GetUserPermissionsInDomain(h.requestContext(c), userID, domain)
+	if err != nil {
+		h.logger.Error("failed to get user permissions", "user_id", userID, "domain", domain, "error", err)
+		result.InternalError(c, "Failed to get user permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Success(types.UserPermissionsResponse{
+		UserID:      userID,
+		Domain:      domain,
+		Permissions: permissions,
+	}))
+}
+
+// CanUser 检查用户是否拥有指定权限
+// GET /rbac/users/:id/can
+// Query: ?resource=users&action=write&domain=tenant1
+// 用于前端按权限控制功能开关，用户只能检查自己的权限，admin 可以检查任意用户
+func (h *RBACHandler) CanUser(c *gin.Context) {
+	// 获取用户ID参数
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		result.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	resource, action := c.Query("resource"), c.Query("action")
+	if resource == "" || action == "" {
+		result.BadRequest(c, "Resource and action are required")
+		return
+	}
+
+	domain := c.Query("domain")
+	if !h.authorizeSelfOrAdmin(c, userID, domain) {
+		return
+	}
+
+	// 检查权限
+	var allowed bool
+	if domain != "" {
+		allowed, err = h.rbacService.CheckPermissionWithDomain(h.requestContext(c), userID, domain, resource, action)
+	} else {
+		allowed, err = h.rbacService.CheckPermission(h.requestContext(c), userID, resource, action)
+	}
+
+	if err != nil {
+		h.logger.Error("failed to check permission", "user_id", userID, "resource", resource, "action", action, "error", err)
+		result.InternalError(c, "Failed to check permission")
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Success(types.CheckPermissionResponse{
+		Allowed: allowed,
+	}))
+}
+
+// CanUserBatch 批量检查用户是否拥有指定权限
+// POST /rbac/users/:id/can-batch
+// Body: {"domain": "tenant1", "checks": [{"resource": "posts", "action": "read"}]}
+// 与 CanUser 相同，用户只能检查自己的权限，admin 可以检查任意用户
+func (h *RBACHandler) CanUserBatch(c *gin.Context) {
+	// 获取用户ID参数
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		result.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	// 解析请求体
+	var req types.CanUserBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		result.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	if !h.authorizeSelfOrAdmin(c, userID, req.Domain) {
+		return
+	}
+
+	results, err := h.rbacService.CheckPermissions(h.requestContext(c), userID, req.Domain, req.Checks)
+	if err != nil {
+		h.logger.Error("failed to check permissions", "user_id", userID, "domain", req.Domain, "count", len(req.Checks), "error", err)
+		result.InternalError(c, "Failed to check permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Success(types.CheckPermissionsResponse{
+		Results: results,
+	}))
+}
+
+// authorizeSelfOrAdmin 检查当前用户是否为 userID 本人或 admin
+// admin 包含通过角色继承获得的 admin；domain 非空时在该域中持有 admin 也视为通过
+// 未通过时已写入 401/403 响应，调用方直接返回
+func (h *RBACHandler) authorizeSelfOrAdmin(c *gin.Context, userID int64, domain string) bool {
+	currentID, ok := h.GetCurrentUserID(c)
+	if !ok {
+		result.Unauthorized(c, "Authentication required")
+		return false
+	}
+	if currentID == userID {
+		return true
+	}
+
+	domains := []string{""}
+	if domain != "" {
+		domains = append(domains, domain)
+	}
+	for _, dom := range domains {
+		isAdmin, err := h.rbacService.HasRole(h.requestContext(c), currentID, "admin", dom)
+		if err != nil {
+			h.logger.Error("failed to check user role", "user_id", currentID, "domain", dom, "error", err)
+			result.InternalError(c, "Failed to check user role")
+			return false
+		}
+		if isAdmin {
+			return true
+		}
+	}
+
+	result.Forbidden(c, "Insufficient permissions")
+	return false
+}
+
 // ========== 策略管理接口 ==========
 
 // AddPolicy 添加策略
@@ -181,9 +359,9 @@ func (h *RBACHandler) AddPolicy(c *gin.Context) {
 	// 添加策略
 	var err error
 	if req.Domain != "" {
-		err = h.rbacService.AddPolicyWithDomain(c.Request.Context(), req.Role, req.Domain, req.Resource, req.Action)
+		err = h.rbacService.AddPolicyWithDomain(h.requestContext(c), req.Role, req.Domain, req.Resource, req.Action)
 	} else {
-		err = h.rbacService.AddPolicy(c.Request.Context(), req.Role, req.Resource, req.Action)
+		err = h.rbacService.AddPolicy(h.requestContext(c), req.Role, req.Resource, req.Action)
 	}
 
 	if err != nil {
@@ -211,9 +389,9 @@ func (h *RBACHandler) RemovePolicy(c *gin.Context) {
 	// 删除策略
 	var err error
 	if req.Domain != "" {
-		err = h.rbacService.RemovePolicyWithDomain(c.Request.Context(), req.Role, req.Domain, req.Resource, req.Action)
+		err = h.rbacService.RemovePolicyWithDomain(h.requestContext(c), req.Role, req.Domain, req.Resource, req.Action)
 	} else {
-		err = h.rbacService.RemovePolicy(c.Request.Context(), req.Role, req.Resource, req.Action)
+		err = h.rbacService.RemovePolicy(h.requestContext(c), req.Role, req.Resource, req.Action)
 	}
 
 	if err != nil {
@@ -231,7 +409,7 @@ func (h *RBACHandler) RemovePolicy(c *gin.Context) {
 // GET /rbac/policies
 func (h *RBACHandler) GetPolicies(c *gin.Context) {
 	// 获取策略列表
-	policies, err := h.rbacService.GetPolicies(c.Request.Context())
+	policies, err := h.rbacService.GetPolicies(h.requestContext(c))
 	if err != nil {
 		h.logger.Error("failed to get policies", "error", err)
 		result.InternalError(c, "Failed to get policies")
@@ -255,7 +433,7 @@ func (h *RBACHandler) GetPoliciesByRole(c *gin.Context) {
 	}
 
 	// 获取策略列表
-	policies, err := h.rbacService.GetPoliciesByRole(c.Request.Context(), role)
+	policies, err := h.rbacService.GetPoliciesByRole(h.requestContext(c), role)
 	if err != nil {
 		h.logger.Error("failed to get policies by role", "role", role, "error", err)
 		result.InternalError(c, "Failed to get policies by role")
@@ -268,6 +446,32 @@ func (h *RBACHandler) GetPoliciesByRole(c *gin.Context) {
 	}))
 }
 
+// ListPoliciesByResource 分页获取指定资源的策略
+// GET /rbac/resources/:resource/policies
+// Query: ?page=1&page_size=20
+func (h *RBACHandler) ListPoliciesByResource(c *gin.Context) {
+	// 获取资源参数
+	resource := c.Param("resource")
+	if resource == "" {
+		result.BadRequest(c, "Resource is required")
+		return
+	}
+
+	// 获取分页参数（非法值使用默认值）
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+	page, pageSize = rbac.NormalizePage(page, pageSize)
+
+	policies, total, err := h.rbacService.ListPoliciesByResource(h.requestContext(c), resource, page, pageSize)
+	if err != nil {
+		h.logger.Error("failed to list policies by resource", "resource", resource, "error", err)
+		result.InternalError(c, "Failed to list policies")
+		return
+	}
+
+	result.Page(c, policies, total, page, pageSize)
+}
+
 // ========== 权限检查接口 ==========
 
 // CheckPermission 检查权限
@@ -285,9 +489,9 @@ func (h *RBACHandler) CheckPermission(c *gin.Context) {
 	var allowed bool
 	var err error
 	if req.Domain != "" {
-		allowed, err = h.rbacService.CheckPermissionWithDomain(c.Request.Context(), req.UserID, req.Domain, req.Resource, req.Action)
+		allowed, err = h.rbacService.CheckPermissionWithDomain(h.requestContext(c), req.UserID, req.Domain, req.Resource, req.Action)
 	} else {
-		allowed, err = h.rbacService.CheckPermission(c.Request.Context(), req.UserID, req.Resource, req.Action)
+		allowed, err = h.rbacService.CheckPermission(h.requestContext(c), req.UserID, req.Resource, req.Action)
 	}
 
 	if err != nil {
@@ -301,6 +505,29 @@ func (h *RBACHandler) CheckPermission(c *gin.Context) {
 	}))
 }
 
+// CheckPermissions 批量检查权限
+// POST /rbac/check-batch
+// Body: {"user_id": 123, "domain": "tenant1", "checks": [{"resource": "posts", "action": "read"}]}
+func (h *RBACHandler) CheckPermissions(c *gin.Context) {
+	// 解析请求体
+	var req types.CheckPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		result.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	results, err := h.rbacService.CheckPermissions(h.requestContext(c), req.UserID, req.Domain, req.Checks)
+	if err != nil {
+		h.logger.Error("failed to check permissions", "user_id", req.UserID, "domain", req.Domain, "count", len(req.Checks), "error", err)
+		result.InternalError(c, "Failed to check permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Success(types.CheckPermissionsResponse{
+		Results: results,
+	}))
+}
+
 // AssignRoles 批量为用户分配角色
 // POST /rbac/users/:id/roles/batch
 // Body: {"roles": ["admin", "editor"], "domain": "tenant1"}
@@ -321,7 +548,7 @@ func (h *RBACHandler) AssignRoles(c *gin.Context) {
 	}
 
 	// 批量分配角色
-	err = h.rbacService.AssignRoles(c.Request.Context(), userID, req.Roles)
+	err = h.rbacService.AssignRoles(h.requestContext(c), userID, req.Roles)
 	if err != nil {
 		h.logger.Error("failed to assign roles", "user_id", userID, "roles", req.Roles, "error", err)
 		result.InternalError(c, "Failed to assign roles")
@@ -345,7 +572,7 @@ func (h *RBACHandler) AddPolicies(c *gin.Context) {
 	}
 
 	// 批量添加策略
-	err := h.rbacService.AddPolicies(c.Request.Context(), req.Policies)
+	err := h.rbacService.AddPolicies(h.requestContext(c), req.Policies)
 	if err != nil {
 		h.logger.Error("failed to add policies", "count", len(req.Policies), "error", err)
 		result.InternalError(c, "Failed to add policies")
@@ -357,6 +584,47 @@ func (h *RBACHandler) AddPolicies(c *gin.Context) {
 	}))
 }
 
+// AssignPermissions 批量为角色分配权限
+// POST /rbac/roles/:role/permissions
+// Body: {"permissions": [{"resource": "users", "action": "read"}], "domain": "tenant1"}
+func (h *RBACHandler) AssignPermissions(c *gin.Context) {
+	// 获取角色参数
+	role := c.Param("role")
+	if role == "" {
+		result.BadRequest(c, "Role is required")
+		return
+	}
+
+	// 解析请求体
+	var req types.AssignPermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		result.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	// 批量分配权限（全部成功或全部失败）
+	err := h.rbacService.AssignPermissions(h.requestContext(c), role, req.Domain, req.Permissions)
+	if err != nil {
+		h.logger.Error("failed to assign permissions", "role", role, "count", len(req.Permissions), "error", err)
+		result.InternalError(c, "Failed to assign permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, result.Success(gin.H{
+		"message": "Permissions assigned successfully",
+	}))
+}
+
+// requestContext 返回携带当前操作者的请求上下文
+// 服务层据此记录审计事件的 Actor
+func (h *RBACHandler) requestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if userID, ok := h.GetCurrentUserID(c); ok {
+		ctx = rbac.WithActor(ctx, userID)
+	}
+	return ctx
+}
+
 // GetCurrentUserID 从上下文获取当前用户ID
 // 用于需要操作当前用户权限的场景
 func (h *RBACHandler) GetCurrentUserID(c *gin.Context) (int64, bool) {
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/internal/middleware"
+	"github.com/rei0721/go-scaffold/internal/service/rbac"
+	"github.com/rei0721/go-scaffold/types"
+)
+
+// fakeRBACService 只实现权限查询相关方法的 RBAC 服务
+// 嵌入接口满足其余方法,测试中调用其他方法会 panic
+type fakeRBACService struct {
+	rbac.RBACService
+
+	// roles 用户ID -> 域 -> 直接持有的角色
+	roles map[int64]map[string][]string
+	// parents 角色 -> 继承的父角色
+	parents map[string][]string
+	// permissions 用户ID -> 域 -> 权限列表
+	permissions map[int64]map[string][]types.Permission
+}
+
+// HasRole 沿 parents 展开继承角色
+func (f *fakeRBACService) HasRole(ctx context.Context, userID int64, role, domain string) (bool, error) {
+	queue := append([]string(nil), f.roles[userID][domain]...)
+	for i := 0; i < len(queue); i++ {
+		if queue[i] == role {
+			return true, nil
+		}
+		queue = append(queue, f.parents[queue[i]]...)
+	}
+	return false, nil
+}
+
+func (f *fakeRBACService) GetUserPermissionsInDomain(ctx context.Context, userID int64, domain string) ([]types.Permission, error) {
+	return f.permissions[userID][domain], nil
+}
+
+func (f *fakeRBACService) CheckPermission(ctx context.Context, userID int64, resource, action string) (bool, error) {
+	return f.CheckPermissionWithDomain(ctx, userID, "", resource, action)
+}
+
+func (f *fakeRBACService) CheckPermissionWithDomain(ctx context.Context, userID int64, domain, resource, action string) (bool, error) {
+	for _, p := range f.permissions[userID][domain] {
+		if p.Resource == resource && p.Action == action {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeRBACService) CheckPermissions(ctx context.Context, userID int64, domain string, checks []types.PermCheck) (map[string]bool, error) {
+	results := make(map[string]bool, len(checks))
+	for _, c := range checks {
+		results[c.Resource+":"+c.Action], _ = f.CheckPermissionWithDomain(ctx, userID, domain, c.Resource, c.Action)
+	}
+	return results, nil
+}
+
+// newRBACQueryEngine 创建以 currentID 身份访问权限查询接口的引擎
+func newRBACQueryEngine(currentID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	svc := &fakeRBACService{
+		roles: map[int64]map[string][]string{
+			1: {"": {"admin"}},
+			2: {"": {"editor"}},
+			3: {"": {"superadmin"}},
+			4: {"tenant1": {"admin"}},
+		},
+		parents: map[string][]string{"superadmin": {"admin"}},
+		permissions: map[int64]map[string][]types.Permission{
+			2: {
+				"":        {{Resource: "posts", Action: "read"}},
+				"tenant1": {{Resource: "posts", Action: "write"}},
+			},
+		},
+	}
+	h := NewRBACHandler(svc, nil)
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextKeyUserID, currentID)
+		c.Next()
+	})
+	engine.GET("/rbac/users/:id/effective", h.GetEffectivePermissions)
+	engine.GET("/rbac/users/:id/can", h.CanUser)
+	engine.POST("/rbac/users/:id/can-batch", h.CanUserBatch)
+	return engine
+}
+
+// serveRBACQuery 发起 GET 请求并解析响应中的 data
+func serveRBACQuery(t *testing.T, currentID int64, url string, data any) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	newRBACQueryEngine(currentID).ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+	if w.Code == http.StatusOK {
+		body := struct {
+			Data any `json:"data"`
+		}{Data: data}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode response %s: %v", w.Body.String(), err)
+		}
+	}
+	return w.Code
+}
+
+// TestGetEffectivePermissions 测试本人、admin 和其他用户查询有效权限
+func TestGetEffectivePermissions(t *testing.T) {
+	var resp types.UserPermissionsResponse
+	if code := serveRBACQuery(t, 2, "/rbac/users/2/effective", &resp); code != http.StatusOK {
+		t.Fatalf("self query: expected 200, got %d", code)
+	}
+	if resp.UserID != 2 || len(resp.Permissions) != 1 || resp.Permissions[0] != (types.Permission{Resource: "posts", Action: "read"}) {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	resp = types.UserPermissionsResponse{}
+	if code := serveRBACQuery(t, 1, "/rbac/users/2/effective?domain=tenant1", &resp); code != http.StatusOK {
+		t.Fatalf("admin query: expected 200, got %d", code)
+	}
+	if resp.Domain != "tenant1" || len(resp.Permissions) != 1 || resp.Permissions[0].Action != "write" {
+		t.Fatalf("unexpected domain response: %+v", resp)
+	}
+
+	if code := serveRBACQuery(t, 2, "/rbac/users/1/effective", nil); code != http.StatusForbidden {
+		t.Fatalf("non-admin querying another user: expected 403, got %d", code)
+	}
+}
+
+// TestAuthorizeSelfOrAdmin 测试查询他人权限时的 admin 判断包含继承角色和域
+func TestAuthorizeSelfOrAdmin(t *testing.T) {
+	tests := []struct {
+		name      string
+		currentID int64
+		url       string
+		code      int
+	}{
+		{"global admin", 1, "/rbac/users/2/effective", http.StatusOK},
+		{"inherited admin", 3, "/rbac/users/2/effective", http.StatusOK},
+		{"domain admin", 4, "/rbac/users/2/effective?domain=tenant1", http.StatusOK},
+		{"domain admin without domain", 4, "/rbac/users/2/effective", http.StatusForbidden},
+		{"domain admin in other domain", 4, "/rbac/users/2/effective?domain=tenant2", http.StatusForbidden},
+		{"domain admin can", 4, "/rbac/users/2/can?resource=posts&action=write&domain=tenant1", http.StatusOK},
+		{"non-admin", 2, "/rbac/users/1/effective", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := serveRBACQuery(t, tt.currentID, tt.url, nil); code != tt.code {
+				t.Fatalf("expected %d, got %d", tt.code, code)
+			}
+		})
+	}
+}
+
+// TestCanUser 测试单项权限检查
+func TestCanUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		currentID int64
+		url       string
+		code      int
+		allowed   bool
+	}{
+		{"allowed", 2, "/rbac/users/2/can?resource=posts&action=read", http.StatusOK, true},
+		{"denied", 2, "/rbac/users/2/can?resource=posts&action=write", http.StatusOK, false},
+		{"domain", 2, "/rbac/users/2/can?resource=posts&action=write&domain=tenant1", http.StatusOK, true},
+		{"admin", 1, "/rbac/users/2/can?resource=posts&action=read", http.StatusOK, true},
+		{"other user", 2, "/rbac/users/1/can?resource=posts&action=read", http.StatusForbidden, false},
+		{"missing action", 2, "/rbac/users/2/can?resource=posts", http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp types.CheckPermissionResponse
+			if code := serveRBACQuery(t, tt.currentID, tt.url, &resp); code != tt.code {
+				t.Fatalf("expected %d, got %d", tt.code, code)
+			}
+			if resp.Allowed != tt.allowed {
+				t.Fatalf("expected allowed=%v, got %v", tt.allowed, resp.Allowed)
+			}
+		})
+	}
+}
+
+// TestCanUserBatch 测试批量权限检查的域参数和本人/admin 限制
+func TestCanUserBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		currentID int64
+		url       string
+		body      string
+		code      int
+		results   map[string]bool
+	}{
+		{"self", 2, "/rbac/users/2/can-batch",
+			`{"checks":[{"resource":"posts","action":"read"},{"resource":"posts","action":"write"}]}`,
+			http.StatusOK, map[string]bool{"posts:read": true, "posts:write": false}},
+		{"domain", 2, "/rbac/users/2/can-batch",
+			`{"domain":"tenant1","checks":[{"resource":"posts","action":"read"},{"resource":"posts","action":"write"}]}`,
+			http.StatusOK, map[string]bool{"posts:read": false, "posts:write": true}},
+		{"admin", 1, "/rbac/users/2/can-batch",
+			`{"checks":[{"resource":"posts","action":"read"}]}`,
+			http.StatusOK, map[string]bool{"posts:read": true}},
+		{"other user", 2, "/rbac/users/1/can-batch",
+			`{"checks":[{"resource":"posts","action":"read"}]}`,
+			http.StatusForbidden, nil},
+		{"empty checks", 2, "/rbac/users/2/can-batch", `{"checks":[]}`, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			newRBACQueryEngine(tt.currentID).ServeHTTP(w, req)
+
+			if w.Code != tt.code {
+				t.Fatalf("expected %d, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data types.CheckPermissionsResponse `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response %s: %v", w.Body.String(), err)
+			}
+			if len(body.Data.Results) != len(tt.results) {
+				t.Fatalf("expected %v, got %v", tt.results, body.Data.Results)
+			}
+			for k, v := range tt.results {
+				if body.Data.Results[k] != v {
+					t.Fatalf("results[%s] = %v, want %v", k, body.Data.Results[k], v)
+				}
+			}
+		})
+	}
+}
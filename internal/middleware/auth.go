@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rei0721/go-scaffold/pkg/jwt"
+	"github.com/rei0721/go-scaffold/pkg/logger"
 	"github.com/rei0721/go-scaffold/types/result"
 )
 
@@ -33,7 +35,33 @@ import (
 //  3. 验证 token 有效性
 //  4. 将用户信息存入上下文
 //  5. 调用下一个处理器
+//
+// 需要滑动过期时使用 AuthMiddlewareWithOptions
 func AuthMiddleware(jwtManager jwt.JWT) gin.HandlerFunc {
+	return AuthMiddlewareWithOptions(jwtManager, AuthOptions{})
+}
+
+// AuthOptions 认证中间件的选项
+type AuthOptions struct {
+	// RefreshWindow 滑动过期窗口
+	// 访问令牌剩余有效期不超过该值时签发新令牌,通过 X-New-Token 响应头返回,
+	// 活跃用户无需单独调用刷新接口即可保持登录;<= 0 表示不刷新
+	// 新令牌由 jwt.RefreshToken 签发,保留旧令牌的自定义声明和 Family
+	RefreshWindow time.Duration
+}
+
+// AuthMiddlewareWithOptions 带选项的JWT认证中间件
+// 认证流程与 AuthMiddleware 相同,另外按 opts.RefreshWindow 实现滑动过期
+// 使用方式:
+//
+//	protected.Use(middleware.AuthMiddlewareWithOptions(jwtManager, middleware.AuthOptions{
+//	    RefreshWindow: 10 * time.Minute,
+//	}))
+//
+// 注意:
+//   - 浏览器跨域访问时需要将 X-New-Token 加入 CORS 的 ExposeHeaders
+//   - 签发新令牌失败时记录警告日志,不影响本次请求,客户端继续使用旧令牌
+func AuthMiddlewareWithOptions(jwtManager jwt.JWT, opts AuthOptions) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 1. 从请求头获取 token
 		// 标准HTTP认证头格式: Authorization: Bearer <token>
@@ -73,8 +101,22 @@ func AuthMiddleware(jwtManager jwt.JWT) gin.HandlerFunc {
 		// 使用常量键避免拼写错误
 		c.Set(ContextKeyUserID, claims.UserID)
 		c.Set(ContextKeyUsername, claims.Username)
+		c.Set(ContextKeyToken, tokenString)
+
+		// 5. 临近过期时签发新令牌
+		// 响应头必须在处理器写入响应之前设置
+		if opts.RefreshWindow > 0 && claims.ExpiresAt != nil &&
+			time.Until(claims.ExpiresAt.Time) <= opts.RefreshWindow {
+			newToken, err := jwtManager.RefreshToken(tokenString)
+			if err != nil {
+				logger.FromContext(c.Request.Context()).Warn("failed to refresh access token",
+					"user_id", claims.UserID, "error", err)
+			} else {
+				c.Header(HeaderNewToken, newToken)
+			}
+		}
 
-		// 5. 调用下一个处理器
+		// 6. 调用下一个处理器
 		c.Next()
 	}
 }
@@ -87,6 +129,10 @@ const (
 
 	// ContextKeyUsername 用户名在上下文中的键
 	ContextKeyUsername = "username"
+
+	// ContextKeyToken 原始访问令牌在上下文中的键
+	// 登出时用于吊销当前令牌
+	ContextKeyToken = "access_token"
 )
 
 // GetUserID 从上下文获取用户ID
@@ -148,3 +194,21 @@ func GetUsername(c *gin.Context) (string, bool) {
 	name, ok := username.(string)
 	return name, ok
 }
+
+// GetToken 从上下文获取当前请求的访问令牌
+// 参数:
+//
+//	c: Gin上下文
+//
+// 返回:
+//
+//	string: 访问令牌字符串（不含 "Bearer " 前缀）
+//	bool: 是否成功获取
+func GetToken(c *gin.Context) (string, bool) {
+	token, exists := c.Get(ContextKeyToken)
+	if !exists {
+		return "", false
+	}
+	s, ok := token.(string)
+	return s, ok
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/jwt"
+)
+
+// newAuthEngine 创建挂载带滑动过期的认证中间件的引擎
+// 令牌有效期 60 秒
+func newAuthEngine(t *testing.T, window time.Duration) (*gin.Engine, jwt.JWT) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	jwtManager, err := jwt.New(&jwt.Config{
+		Secret:    "test-secret-key-at-least-32-characters",
+		ExpiresIn: 60,
+	})
+	if err != nil {
+		t.Fatalf("jwt.New() failed: %v", err)
+	}
+
+	engine := gin.New()
+	engine.Use(AuthMiddlewareWithOptions(jwtManager, AuthOptions{RefreshWindow: window}))
+	engine.GET("/me", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine, jwtManager
+}
+
+// doAuthRequest 携带令牌请求 /me
+func doAuthRequest(engine *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_RefreshNearExpiry(t *testing.T) {
+	// 窗口大于令牌有效期,令牌视为临近过期
+	engine, jwtManager := newAuthEngine(t, 2*time.Minute)
+
+	token, err := jwtManager.GenerateToken(42, "alice")
+	if err != nil {
+		t.Fatalf("GenerateToken() failed: %v", err)
+	}
+
+	w := doAuthRequest(engine, token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	newToken := w.Header().Get(HeaderNewToken)
+	if newToken == "" {
+		t.Fatal("expected X-New-Token header for token near expiry")
+	}
+	claims, err := jwtManager.ValidateToken(newToken)
+	if err != nil {
+		t.Fatalf("new token should be valid: %v", err)
+	}
+	if claims.UserID != 42 || claims.Username != "alice" {
+		t.Errorf("new token claims = (%d, %q), want (42, alice)", claims.UserID, claims.Username)
+	}
+}
+
+func TestAuthMiddleware_RefreshKeepsCustomClaims(t *testing.T) {
+	engine, jwtManager := newAuthEngine(t, 2*time.Minute)
+
+	token, err := jwtManager.GenerateTokenWithClaims(42, "alice", map[string]any{
+		"roles": []string{"admin"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateTokenWithClaims() failed: %v", err)
+	}
+
+	w := doAuthRequest(engine, token)
+	newToken := w.Header().Get(HeaderNewToken)
+	if newToken == "" {
+		t.Fatal("expected X-New-Token header for token near expiry")
+	}
+
+	claims, err := jwtManager.ParseClaims(newToken)
+	if err != nil {
+		t.Fatalf("new token should be valid: %v", err)
+	}
+	roles, _ := claims["roles"].([]any)
+	if len(roles) != 1 || roles[0] != "admin" {
+		t.Errorf("new token roles = %v, want [admin]", claims["roles"])
+	}
+}
+
+func TestAuthMiddleware_RefreshKeepsFamily(t *testing.T) {
+	engine, jwtManager := newAuthEngine(t, 2*time.Minute)
+
+	access, refresh, err := jwtManager.GenerateTokenPair(42, "alice")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() failed: %v", err)
+	}
+	refreshClaims, err := jwtManager.ValidateRefreshToken(refresh)
+	if err != nil {
+		t.Fatalf("ValidateRefreshToken() failed: %v", err)
+	}
+
+	w := doAuthRequest(engine, access)
+	claims, err := jwtManager.ValidateToken(w.Header().Get(HeaderNewToken))
+	if err != nil {
+		t.Fatalf("new token should be valid: %v", err)
+	}
+	if claims.Family != refreshClaims.Family {
+		t.Errorf("new token family = %q, want %q", claims.Family, refreshClaims.Family)
+	}
+}
+
+func TestAuthMiddleware_NoRefreshForFreshToken(t *testing.T) {
+	engine, jwtManager := newAuthEngine(t, 10*time.Second)
+
+	token, err := jwtManager.GenerateToken(42, "alice")
+	if err != nil {
+		t.Fatalf("GenerateToken() failed: %v", err)
+	}
+
+	w := doAuthRequest(engine, token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if got := w.Header().Get(HeaderNewToken); got != "" {
+		t.Errorf("fresh token should not be refreshed, got X-New-Token %q", got)
+	}
+}
+
+func TestAuthMiddleware_NoRefreshForInvalidToken(t *testing.T) {
+	engine, _ := newAuthEngine(t, 2*time.Minute)
+
+	w := doAuthRequest(engine, "not-a-token")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+	if got := w.Header().Get(HeaderNewToken); got != "" {
+		t.Errorf("invalid token should not be refreshed, got X-New-Token %q", got)
+	}
+}
@@ -2,6 +2,8 @@
 // 中间件在请求处理链中起到过滤、增强和监控的作用
 package middleware
 
+import "time"
+
 // MiddlewareConfig 包含所有中间件组件的配置
 // 这是一个聚合配置,统一管理所有中间件
 // 好处:
@@ -35,6 +37,11 @@ type RecoveryConfig struct {
 	// 生产环境必须设置为 true
 	// 测试环境可以设置为 false 以便 panic 直接暴露
 	Enabled bool `mapstructure:"enabled"`
+
+	// PanicHandler 可选的 panic 回调
+	// 在记录日志之后、写入响应之前调用,用于上报 Sentry 等错误追踪系统
+	// 为 nil 时不调用
+	PanicHandler PanicHandler `mapstructure:"-"`
 }
 
 // LoggerConfig 日志记录中间件的配置
@@ -53,6 +60,21 @@ type LoggerConfig struct {
 	// - 提高性能
 	// 例如: []string{"/health", "/metrics", "/favicon.ico"}
 	SkipPaths []string `mapstructure:"skipPaths"`
+
+	// Sampling 按状态码类别采样,键为 "2xx"、"3xx"、"4xx",值为 0~1 的采样率
+	// 5xx 始终全部记录,配置的采样率不生效;未配置的类别全部记录,
+	// 高流量服务可以只采样成功请求而保留全部错误,
+	// 例如: map[string]float64{"2xx": 0.01}
+	Sampling map[string]float64 `mapstructure:"sampling"`
+
+	// SlowThreshold 慢请求阈值
+	// 耗时达到阈值的请求不受采样影响,始终以 Warn 级别记录,0 表示不区分慢请求
+	SlowThreshold time.Duration `mapstructure:"slowThreshold"`
+
+	// LatencyBuckets 延迟分桶的上界,按升序排列
+	// 日志中的 latencyBucket 字段为请求所在的桶,如 "<=100ms"、">1s",
+	// 便于日志系统直接按桶聚合出延迟分布;为空时使用 DefaultLatencyBuckets
+	LatencyBuckets []time.Duration `mapstructure:"latencyBuckets"`
 }
 
 // TraceIDConfig 请求追踪 ID 中间件的配置
@@ -1 +1,33 @@
 package middleware
+
+import "time"
+
+const (
+	// DefaultRateLimitWindow 限流窗口的默认时长
+	DefaultRateLimitWindow = time.Minute
+
+	// DefaultRateLimitKeyPrefix 限流计数器缓存键的默认前缀
+	DefaultRateLimitKeyPrefix = "ratelimit:"
+
+	// HeaderRetryAfter 触发限流时建议的重试等待秒数
+	HeaderRetryAfter = "Retry-After"
+
+	// HeaderRateLimitLimit 窗口内允许的最大请求数
+	HeaderRateLimitLimit = "X-RateLimit-Limit"
+
+	// HeaderRateLimitRemaining 窗口内剩余可用请求数
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+
+	// HeaderNewToken 滑动过期时返回新访问令牌的响应头
+	HeaderNewToken = "X-New-Token"
+)
+
+// DefaultLatencyBuckets 访问日志延迟分桶的默认上界
+var DefaultLatencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
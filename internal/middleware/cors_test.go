@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newCORSEngine 创建只挂载 CORS 中间件和一个 GET 路由的引擎
+func newCORSEngine(cfg CORSConfig) (*gin.Engine, *bool) {
+	gin.SetMode(gin.TestMode)
+	handled := false
+
+	engine := gin.New()
+	engine.Use(CORSMiddleware(cfg))
+	engine.GET("/api/v1/ping", func(c *gin.Context) {
+		handled = true
+		c.String(http.StatusOK, "pong")
+	})
+
+	return engine, &handled
+}
+
+func testCORSConfig() CORSConfig {
+	return CORSConfig{
+		Enabled:          true,
+		AllowOrigins:     []string{"http://localhost:3000"},
+		AllowMethods:     []string{"GET", "POST"},
+		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		ExposeHeaders:    []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+}
+
+// TestCORSMiddleware_Preflight 测试预检请求直接返回且带有配置的响应头
+// 没有注册 OPTIONS 路由,gin 对未匹配的请求同样执行全局中间件
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	engine, handled := newCORSEngine(testCORSConfig())
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/ping", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "Authorization")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if *handled {
+		t.Fatal("expected preflight to be short-circuited before the handler")
+	}
+
+	headers := w.Header()
+	if got := headers.Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Allow-Origin = %q", got)
+	}
+	if got := headers.Get("Access-Control-Allow-Methods"); !strings.Contains(got, "POST") {
+		t.Errorf("Allow-Methods = %q, want to contain POST", got)
+	}
+	if got := headers.Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Allow-Credentials = %q, want true", got)
+	}
+	if got := headers.Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Max-Age = %q, want 600", got)
+	}
+}
+
+// TestCORSMiddleware_DisallowedOrigin 测试不在白名单中的源被拒绝
+func TestCORSMiddleware_DisallowedOrigin(t *testing.T) {
+	engine, handled := newCORSEngine(testCORSConfig())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	req.Header.Set("Origin", "http://evil.example.com")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if *handled {
+		t.Fatal("expected request from disallowed origin not to reach the handler")
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Allow-Origin = %q, want empty", got)
+	}
+}
+
+// TestCORSMiddleware_Disabled 测试禁用时不添加任何 CORS 响应头
+func TestCORSMiddleware_Disabled(t *testing.T) {
+	cfg := testCORSConfig()
+	cfg.Enabled = false
+	engine, handled := newCORSEngine(cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	req.Header.Set("Origin", "http://evil.example.com")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || !*handled {
+		t.Fatalf("expected request to pass through, status = %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Allow-Origin = %q, want empty", got)
+	}
+}
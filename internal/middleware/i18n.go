@@ -3,23 +3,78 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/rei0721/go-scaffold/pkg/i18n"
+	"github.com/rei0721/go-scaffold/types/result"
 )
 
-// I18n 中间件提取并存储用户的语言偏好
+// 语言相关的上下文键和请求参数
+const (
+	// ContextKeyLanguage 请求语言在上下文中的键
+	// 与 result 包共用,result.ErrorLocalized 据此本地化错误消息
+	ContextKeyLanguage = result.ContextKeyLanguage
+
+	// ContextKeyI18n I18n 实例在上下文中的键
+	ContextKeyI18n = result.ContextKeyI18n
+
+	// LanguageQueryParam 覆盖语言的查询参数名,如 ?lang=en-US
+	LanguageQueryParam = "lang"
+
+	// LanguageCookieName 保存语言偏好的 cookie 名
+	LanguageCookieName = "lang"
+)
+
+// I18n 中间件解析并存储请求的语言
+// 语言来源(优先级从高到低):
+//  1. 查询参数 ?lang=,用于临时覆盖
+//  2. cookie lang,用于保存用户选择的语言
+//  3. Accept-Language 头部,按 q 权重依次尝试
+//  4. 默认语言
+//
+// 每个来源的值都经过 I18n.Match 校验,不支持的语言会被跳过而不是直接回退默认语言
+// 处理器通过 GetLanguage 获取结果
 func I18n(i18nApp i18n.I18n) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 从 Accept-Language 头部获取语言
-		lang := c.GetHeader(i18n.LanguageHeader)
-
-		// 如果语言不支持,使用默认语言
-		if lang == "" || !i18nApp.IsSupported(lang) {
-			lang = i18nApp.GetDefaultLanguage()
-		}
+		lang := resolveLanguage(c, i18nApp)
 
 		// 存储到上下文
-		c.Set("lang", lang)
-		c.Set("i18n", i18nApp)
+		c.Set(ContextKeyLanguage, lang)
+		c.Set(ContextKeyI18n, i18nApp)
 
 		c.Next()
 	}
 }
+
+// resolveLanguage 按优先级解析请求语言
+func resolveLanguage(c *gin.Context, i18nApp i18n.I18n) string {
+	if lang, ok := i18nApp.Match(c.Query(LanguageQueryParam)); ok {
+		return lang
+	}
+
+	if cookie, err := c.Cookie(LanguageCookieName); err == nil {
+		if lang, ok := i18nApp.Match(cookie); ok {
+			return lang
+		}
+	}
+
+	if lang, ok := i18nApp.Match(i18n.ParseAcceptLanguage(c.GetHeader(i18n.LanguageHeader))...); ok {
+		return lang
+	}
+
+	return i18nApp.GetDefaultLanguage()
+}
+
+// GetLanguage 从上下文获取请求语言
+// 参数:
+//
+//	c: Gin上下文
+//
+// 返回:
+//
+//	string: 语言代码;未经过 I18n 中间件时返回空字符串
+func GetLanguage(c *gin.Context) string {
+	if lang, exists := c.Get(ContextKeyLanguage); exists {
+		if s, ok := lang.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rei0721/go-scaffold/pkg/i18n"
+)
+
+// newI18nEngine 创建只挂载 I18n 中间件、返回解析结果的引擎
+func newI18nEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(I18n(i18n.Default()))
+	engine.GET("/lang", func(c *gin.Context) {
+		c.String(http.StatusOK, GetLanguage(c))
+	})
+	return engine
+}
+
+// TestI18n_ResolveLanguage 测试各来源的优先级和回退
+func TestI18n_ResolveLanguage(t *testing.T) {
+	engine := newI18nEngine()
+
+	tests := []struct {
+		name   string
+		query  string
+		cookie string
+		header string
+		want   string
+	}{
+		{"default", "", "", "", i18n.LanguageChinese},
+		{"accept-language", "", "", "en-US,en;q=0.9", i18n.LanguageEnglish},
+		{"accept-language weights", "", "", "fr;q=0.9,en-GB;q=0.5,zh-CN;q=0.1", i18n.LanguageEnglish},
+		{"cookie over header", "", "en-US", "zh-CN", i18n.LanguageEnglish},
+		{"query over cookie", "?lang=zh-CN", "en-US", "en-US", i18n.LanguageChinese},
+		{"unsupported query falls through", "?lang=fr", "", "en-US", i18n.LanguageEnglish},
+		{"all unsupported", "?lang=fr", "de", "ko-KR", i18n.LanguageChinese},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/lang"+tt.query, nil)
+		if tt.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: LanguageCookieName, Value: tt.cookie})
+		}
+		if tt.header != "" {
+			req.Header.Set(i18n.LanguageHeader, tt.header)
+		}
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		if got := w.Body.String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/types/errors"
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// BodyLimit 返回请求体大小限制中间件
+// 使用 http.MaxBytesReader 包装请求体,读取超过 maxBytes 时返回 *http.MaxBytesError
+// 参数:
+//
+//	maxBytes: 允许的最大请求体字节数,<= 0 时返回空中间件
+//
+// 返回:
+//
+//	gin.HandlerFunc: 请求体大小限制中间件
+//
+// 行为:
+//   - Content-Length 已知且超过限制时直接返回 413,不调用后续处理器
+//   - 分块传输等长度未知的请求在读取时触发限制,处理器绑定请求体会得到 *http.MaxBytesError,
+//     应返回 413(handler 包的 handleBindError 已处理)
+//
+// 使用示例:
+//
+//	r.Use(middleware.BodyLimit(4 << 20)) // 4MB
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	if maxBytes <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, result.ErrorLocalized(
+				c,
+				errors.ErrRequestTooLarge,
+				GetTraceID(c),
+			))
+			return
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+		c.Next()
+	}
+}
+
+// IsBodyTooLarge 判断错误是否由请求体超过 BodyLimit 限制引起
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return stderrors.As(err, &maxBytesErr)
+}
+
+// Timeout 返回请求超时中间件
+// 为请求上下文设置截止时间,超时后上下文被取消,处理器未写入响应时返回 503
+// 参数:
+//
+//	d: 超时时间,<= 0 时返回空中间件
+//
+// 返回:
+//
+//	gin.HandlerFunc: 请求超时中间件
+//
+// 注意:
+//   - 处理器在同一协程中执行,中间件不会强行中断处理器;
+//     处理器应使用 c.Request.Context() 调用数据库、缓存等操作,以便超时后尽快返回
+//   - 处理器超时后已经写入的响应保持不变
+//   - 与 server 的 ReadTimeout/WriteTimeout 互补:后者限制连接读写,此中间件限制业务处理时长
+//
+// 使用示例:
+//
+//	r.Use(middleware.Timeout(10 * time.Second))
+func Timeout(d time.Duration) gin.HandlerFunc {
+	if d <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if stderrors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, result.ErrorLocalized(
+				c,
+				errors.ErrServiceUnavailable,
+				GetTraceID(c),
+			))
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestBodyLimit_ContentLength 测试 Content-Length 超过限制时直接返回 413
+func TestBodyLimit_ContentLength(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handled := false
+
+	engine := gin.New()
+	engine.Use(BodyLimit(16))
+	engine.POST("/upload", func(c *gin.Context) {
+		handled = true
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("a", 32)))
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	if handled {
+		t.Fatal("handler should not be called")
+	}
+}
+
+// TestBodyLimit_Streaming 测试长度未知的请求体在读取时触发限制
+func TestBodyLimit_Streaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(BodyLimit(16))
+	engine.POST("/upload", func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			if !IsBodyTooLarge(err) {
+				t.Errorf("expected MaxBytesError, got %v", err)
+			}
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	// 隐藏 Content-Length,模拟分块传输
+	req := httptest.NewRequest(http.MethodPost, "/upload", io.NopCloser(strings.NewReader(strings.Repeat("a", 32))))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	// 未超过限制的请求正常处理
+	req = httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("small"))
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+// TestTimeout 测试处理器超时返回 503 且上下文被取消
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var ctxErr error
+
+	engine := gin.New()
+	engine.Use(Timeout(20 * time.Millisecond))
+	engine.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			ctxErr = c.Request.Context().Err()
+		case <-time.After(time.Second):
+			c.Status(http.StatusOK)
+		}
+	})
+	engine.GET("/fast", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if ctxErr != context.DeadlineExceeded {
+		t.Fatalf("context error = %v, want %v", ctxErr, context.DeadlineExceeded)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,9 +23,19 @@ func Logger(cfg LoggerConfig, log logger.Logger) gin.HandlerFunc {
 		skipPaths[path] = true
 	}
 
+	// 延迟分桶,未配置时使用默认分桶
+	buckets := cfg.LatencyBuckets
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+
 	// 返回 Gin 中间件处理函数
 	// 这个函数会在每个请求处理前后被调用
 	return func(c *gin.Context) {
+		// 将应用 Logger 存入请求 context,供 logger.FromContext 使用
+		// 无论是否记录访问日志都需要存入
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), log))
+
 		// 检查是否启用了日志中间件
 		// 如果未启用,直接调用 c.Next() 跳过日志记录
 		// 这个设计允许在配置中动态开关日志功能
@@ -58,6 +70,15 @@ func Logger(cfg LoggerConfig, log logger.Logger) gin.HandlerFunc {
 		// 这个指标对于性能监控和优化非常重要
 		duration := time.Since(start)
 
+		status := c.Writer.Status()
+
+		// 慢请求始终记录,其余请求按状态码类别采样
+		// 采样在取 TraceID 等字段之前进行,被丢弃的请求几乎没有额外开销
+		slow := cfg.SlowThreshold > 0 && duration >= cfg.SlowThreshold
+		if !slow && !sampled(cfg.Sampling, status) {
+			return
+		}
+
 		// 从上下文中获取 TraceID (追踪ID)
 		// TraceID 用于关联同一个请求在不同服务/组件中的日志
 		// 这在微服务架构和问题排查时特别有用
@@ -65,14 +86,53 @@ func Logger(cfg LoggerConfig, log logger.Logger) gin.HandlerFunc {
 
 		// 记录请求详细信息
 		// 使用结构化日志,便于日志分析和监控系统解析
-		log.Info("request completed",
+		fields := []interface{}{
 			"method", c.Request.Method, // HTTP 方法(GET/POST/PUT等)
 			"path", path, // 请求路径
-			"status", c.Writer.Status(), // HTTP 响应状态码
+			"status", status, // HTTP 响应状态码
 			"duration", duration.String(), // 耗时的字符串表示(如 "123ms")
 			"durationMs", duration.Milliseconds(), // 耗时的毫秒数,便于监控系统计算
+			"latencyBucket", latencyBucket(duration, buckets), // 延迟分桶,便于按桶聚合
+			"slow", slow, // 是否超过慢请求阈值
 			"clientIP", c.ClientIP(), // 客户端 IP 地址
 			"traceId", traceID, // 追踪 ID,用于请求链路追踪
-		)
+		}
+		if slow {
+			log.Warn("slow request completed", fields...)
+			return
+		}
+		log.Info("request completed", fields...)
+	}
+}
+
+// sampled 按状态码类别的采样率决定是否记录
+// 5xx 服务端错误始终记录,不受采样率影响
+// 其他未配置的类别始终记录,采样率 <= 0 时从不记录,>= 1 时始终记录
+func sampled(sampling map[string]float64, status int) bool {
+	if status >= 500 {
+		return true
+	}
+	rate, ok := sampling[statusClass(status)]
+	if !ok || rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return rand.Float64() < rate
+}
+
+// statusClass 返回状态码类别,如 200 -> "2xx"
+func statusClass(status int) string {
+	return fmt.Sprintf("%dxx", status/100)
+}
+
+// latencyBucket 返回耗时所在的分桶,如 "<=100ms",超过最大上界时为 ">5s"
+func latencyBucket(d time.Duration, buckets []time.Duration) string {
+	for _, upper := range buckets {
+		if d <= upper {
+			return "<=" + upper.String()
+		}
 	}
+	return ">" + buckets[len(buckets)-1].String()
 }
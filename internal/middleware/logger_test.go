@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/executor"
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// logEntry 记录的一条日志
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordLogger 记录日志调用的 logger.Logger 实现
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordLogger) record(level, msg string, kv []interface{}) {
+	fields := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		if key, ok := kv[i].(string); ok {
+			fields[key] = kv[i+1]
+		}
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: fields})
+	l.mu.Unlock()
+}
+
+func (l *recordLogger) Debug(msg string, kv ...interface{})  { l.record("debug", msg, kv) }
+func (l *recordLogger) Info(msg string, kv ...interface{})   { l.record("info", msg, kv) }
+func (l *recordLogger) Warn(msg string, kv ...interface{})   { l.record("warn", msg, kv) }
+func (l *recordLogger) Error(msg string, kv ...interface{})  { l.record("error", msg, kv) }
+func (l *recordLogger) Fatal(msg string, kv ...interface{})  { l.record("fatal", msg, kv) }
+func (l *recordLogger) With(kv ...interface{}) logger.Logger { return l }
+func (l *recordLogger) Sync() error                          { return nil }
+func (l *recordLogger) SetExecutor(exec executor.Manager)    {}
+func (l *recordLogger) Reload(cfg *logger.Config) error      { return nil }
+func (l *recordLogger) SetLevel(level string) error          { return nil }
+
+// take 返回并清空已记录的日志
+func (l *recordLogger) take() []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := l.entries
+	l.entries = nil
+	return entries
+}
+
+// newLoggerEngine 创建挂载 Logger 中间件的测试引擎
+// /ok 立即返回 200,/slow 等待 20ms 后返回 200,/fail 返回 500
+func newLoggerEngine(cfg LoggerConfig, log logger.Logger) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(Logger(cfg, log))
+	engine.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/slow", func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+	return engine
+}
+
+// serveLogged 发送一个 GET 请求
+func serveLogged(engine *gin.Engine, path string) {
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// TestLogger_Sampling 测试 0% 采样时快速 200 被跳过,500 和慢请求始终记录
+func TestLogger_Sampling(t *testing.T) {
+	log := &recordLogger{}
+	engine := newLoggerEngine(LoggerConfig{
+		Enabled:       true,
+		Sampling:      map[string]float64{"2xx": 0},
+		SlowThreshold: 10 * time.Millisecond,
+	}, log)
+
+	serveLogged(engine, "/ok")
+	if entries := log.take(); len(entries) != 0 {
+		t.Fatalf("fast 200 should be sampled out, got %+v", entries)
+	}
+
+	for i := 0; i < 3; i++ {
+		serveLogged(engine, "/fail")
+	}
+	if entries := log.take(); len(entries) != 3 {
+		t.Fatalf("every 500 should be logged, got %d entries", len(entries))
+	}
+
+	serveLogged(engine, "/slow")
+	entries := log.take()
+	if len(entries) != 1 {
+		t.Fatalf("slow 200 should be logged, got %d entries", len(entries))
+	}
+	if e := entries[0]; e.level != "warn" || e.fields["slow"] != true || e.fields["status"] != http.StatusOK {
+		t.Errorf("unexpected slow request entry: %+v", e)
+	}
+}
+
+// TestLogger_SamplingNeverDrops5xx 测试 5xx 配置了低采样率时仍然全部记录
+func TestLogger_SamplingNeverDrops5xx(t *testing.T) {
+	log := &recordLogger{}
+	engine := newLoggerEngine(LoggerConfig{
+		Enabled:  true,
+		Sampling: map[string]float64{"5xx": 0.01},
+	}, log)
+
+	for i := 0; i < 20; i++ {
+		serveLogged(engine, "/fail")
+	}
+	if entries := log.take(); len(entries) != 20 {
+		t.Fatalf("every 500 should be logged regardless of sampling, got %d entries", len(entries))
+	}
+}
+
+// TestLogger_LatencyFields 测试日志包含延迟分桶等结构化字段
+func TestLogger_LatencyFields(t *testing.T) {
+	log := &recordLogger{}
+	engine := newLoggerEngine(LoggerConfig{
+		Enabled:        true,
+		LatencyBuckets: []time.Duration{5 * time.Millisecond, 10 * time.Millisecond},
+	}, log)
+
+	serveLogged(engine, "/ok")
+	serveLogged(engine, "/slow")
+	entries := log.take()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries without sampling, got %d", len(entries))
+	}
+	if got := entries[0].fields["latencyBucket"]; got != "<=5ms" {
+		t.Errorf("fast request bucket = %v, want <=5ms", got)
+	}
+	if got := entries[1].fields["latencyBucket"]; got != ">10ms" {
+		t.Errorf("slow request bucket = %v, want >10ms", got)
+	}
+	if _, ok := entries[1].fields["durationMs"]; !ok || entries[1].level != "info" {
+		t.Errorf("expected info entry with durationMs, got %+v", entries[1])
+	}
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/cache"
+	"github.com/rei0721/go-scaffold/types/errors"
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// KeyFunc 从请求中提取限流键
+// 返回的键标识一个限流主体(如客户端 IP、用户 ID),相同键共享同一个计数
+type KeyFunc func(c *gin.Context) string
+
+// RateLimitOptions 限流中间件的选项
+type RateLimitOptions struct {
+	// Limit 每个窗口内允许的最大请求数
+	// <= 0 表示不限流
+	Limit int64
+
+	// Window 窗口时长
+	// <= 0 时使用 DefaultRateLimitWindow
+	Window time.Duration
+
+	// KeyFunc 限流键提取函数
+	// 为 nil 时使用 KeyByClientIP
+	KeyFunc KeyFunc
+
+	// KeyPrefix 缓存键前缀
+	// 为空时使用 DefaultRateLimitKeyPrefix
+	KeyPrefix string
+}
+
+// rateLimitNow 当前时间,测试中可替换以控制窗口边界
+var rateLimitNow = time.Now
+
+// KeyByClientIP 按客户端 IP 限流
+// 使用 gin 的 ClientIP,是否信任 X-Forwarded-For 取决于引擎的 TrustedProxies 设置
+func KeyByClientIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// KeyByUserID 按用户 ID 限流
+// 需要在 AuthMiddleware 之后注册;未认证的请求退回按客户端 IP 限流
+func KeyByUserID(c *gin.Context) string {
+	if userID, ok := GetUserID(c); ok {
+		return "user:" + strconv.FormatInt(userID, 10)
+	}
+	return KeyByClientIP(c)
+}
+
+// RateLimit 返回基于缓存的限流中间件
+// 使用滑动窗口计数算法:每个固定窗口一个计数器(Incr + Expire),
+// 请求数按 当前窗口计数 + 上一窗口计数 × 上一窗口在滑动窗口中的剩余占比 估算,
+// 避免固定窗口在边界处允许两倍突发
+// 参数:
+//
+//	c: 缓存实例,多实例部署时应使用 Redis 以共享计数
+//	opts: 限流选项
+//
+// 返回:
+//
+//	gin.HandlerFunc: 限流中间件
+//
+// 响应:
+//
+//	超过限制时返回 429 Too Many Requests,设置 Retry-After 响应头(秒),
+//	响应体错误码为 errors.ErrTooManyRequests,消息按请求语言本地化
+//	所有经过限流的请求都会设置 X-RateLimit-Limit 和 X-RateLimit-Remaining 响应头
+//
+// 注意:
+//   - c 为 nil 或 Limit <= 0 时返回空中间件
+//   - 缓存操作失败时放行请求,避免缓存故障导致服务整体不可用
+//   - 键提取函数返回空字符串时放行请求
+//   - 被拒绝的请求同样计数,持续超限的客户端需要降低频率才能恢复
+//
+// 使用示例:
+//
+//	api.Use(middleware.RateLimit(app.Cache, middleware.RateLimitOptions{
+//	    Limit:   100,
+//	    Window:  time.Minute,
+//	    KeyFunc: middleware.KeyByUserID,
+//	}))
+func RateLimit(c cache.Cache, opts RateLimitOptions) gin.HandlerFunc {
+	if c == nil || opts.Limit <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	if opts.Window <= 0 {
+		opts.Window = DefaultRateLimitWindow
+	}
+	if opts.KeyFunc == nil {
+		opts.KeyFunc = KeyByClientIP
+	}
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = DefaultRateLimitKeyPrefix
+	}
+
+	limiter := &slidingWindowLimiter{cache: c, opts: opts}
+
+	return func(c *gin.Context) {
+		key := opts.KeyFunc(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		allowed, remaining, retryAfter, err := limiter.allow(c.Request.Context(), key)
+		if err != nil {
+			// 缓存不可用时放行
+			c.Next()
+			return
+		}
+
+		c.Header(HeaderRateLimitLimit, strconv.FormatInt(opts.Limit, 10))
+		c.Header(HeaderRateLimitRemaining, strconv.FormatInt(remaining, 10))
+
+		if !allowed {
+			c.Header(HeaderRetryAfter, strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, result.ErrorLocalized(
+				c,
+				errors.ErrTooManyRequests,
+				GetTraceID(c),
+			))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// slidingWindowLimiter 滑动窗口计数限流器
+type slidingWindowLimiter struct {
+	cache cache.Cache
+	opts  RateLimitOptions
+}
+
+// allow 记录一次请求并判断是否允许
+// 返回:
+//
+//	bool: 是否允许
+//	int64: 剩余可用请求数
+//	time.Duration: 被拒绝时建议的重试等待时间
+//	error: 缓存操作失败时的错误
+func (l *slidingWindowLimiter) allow(ctx context.Context, key string) (bool, int64, time.Duration, error) {
+	now := rateLimitNow()
+	window := l.opts.Window
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - index*int64(window))
+
+	currentKey := l.windowKey(key, index)
+	current, err := l.cache.Incr(ctx, currentKey)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	// 首次计数时设置过期时间,保留两个窗口以便下一窗口读取
+	if current == 1 {
+		if err := l.cache.Expire(ctx, currentKey, 2*window); err != nil {
+			return false, 0, 0, err
+		}
+	}
+
+	previous, err := l.count(ctx, l.windowKey(key, index-1))
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	weight := 1 - float64(elapsed)/float64(window)
+	estimated := int64(math.Floor(float64(previous)*weight)) + current
+
+	remaining := l.opts.Limit - estimated
+	if remaining < 0 {
+		remaining = 0
+	}
+	if estimated > l.opts.Limit {
+		// 保守估计:等到当前窗口结束,上一窗口的计数不再参与计算
+		return false, remaining, window - elapsed, nil
+	}
+	return true, remaining, 0, nil
+}
+
+// count 读取窗口计数,键不存在时为 0
+func (l *slidingWindowLimiter) count(ctx context.Context, key string) (int64, error) {
+	value, err := l.cache.Get(ctx, key)
+	if err != nil {
+		if stderrors.Is(err, cache.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// windowKey 返回窗口计数器的缓存键
+func (l *slidingWindowLimiter) windowKey(key string, index int64) string {
+	return l.opts.KeyPrefix + key + ":" + strconv.FormatInt(index, 10)
+}
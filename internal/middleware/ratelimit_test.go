@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/cache"
+	"github.com/rei0721/go-scaffold/types/errors"
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// newRateLimitEngine 创建只挂载限流中间件和一个 GET 路由的引擎
+// 时间固定在窗口起点之后 1 秒,避免测试期间跨越窗口边界
+func newRateLimitEngine(t *testing.T, opts RateLimitOptions) (*gin.Engine, *time.Time) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	c := cache.NewMemory(nil)
+	t.Cleanup(func() { c.Close() })
+
+	now := time.Unix(1_700_000_000, 0).Truncate(time.Minute).Add(time.Second)
+	rateLimitNow = func() time.Time { return now }
+	t.Cleanup(func() { rateLimitNow = time.Now })
+
+	engine := gin.New()
+	engine.Use(RateLimit(c, opts))
+	engine.GET("/api/v1/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, "pong")
+	})
+	return engine, &now
+}
+
+// doRateLimitRequest 以指定客户端地址发送请求
+func doRateLimitRequest(engine *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+// TestRateLimit_UnderLimit 测试未超过限制的突发请求全部放行
+func TestRateLimit_UnderLimit(t *testing.T) {
+	engine, _ := newRateLimitEngine(t, RateLimitOptions{Limit: 5, Window: time.Minute})
+
+	for i := 1; i <= 5; i++ {
+		w := doRateLimitRequest(engine, "10.0.0.1:1234")
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i, w.Code, http.StatusOK)
+		}
+		if got, want := w.Header().Get(HeaderRateLimitRemaining), strconv.Itoa(5-i); got != want {
+			t.Errorf("request %d: remaining = %s, want %s", i, got, want)
+		}
+	}
+}
+
+// TestRateLimit_Exceeded 测试超过限制返回 429 和 Retry-After
+func TestRateLimit_Exceeded(t *testing.T) {
+	engine, _ := newRateLimitEngine(t, RateLimitOptions{Limit: 3, Window: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		doRateLimitRequest(engine, "10.0.0.1:1234")
+	}
+
+	w := doRateLimitRequest(engine, "10.0.0.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	// 时间在窗口起点后 1 秒,距窗口结束 59 秒
+	if got := w.Header().Get(HeaderRetryAfter); got != "59" {
+		t.Errorf("Retry-After = %q, want %q", got, "59")
+	}
+
+	var resp result.Result[any]
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Code != errors.ErrTooManyRequests {
+		t.Errorf("code = %d, want %d", resp.Code, errors.ErrTooManyRequests)
+	}
+
+	// 其他客户端不受影响
+	if w := doRateLimitRequest(engine, "10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("other client: status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+// TestRateLimit_SlidingWindow 测试上一窗口的计数按剩余占比参与计算
+func TestRateLimit_SlidingWindow(t *testing.T) {
+	engine, now := newRateLimitEngine(t, RateLimitOptions{Limit: 4, Window: time.Minute})
+
+	for i := 0; i < 4; i++ {
+		doRateLimitRequest(engine, "10.0.0.1:1234")
+	}
+
+	// 进入下一窗口的一半:上一窗口计为 4×0.5=2,还能放行 2 个请求
+	*now = now.Truncate(time.Minute).Add(time.Minute + 30*time.Second)
+	for i := 0; i < 2; i++ {
+		if w := doRateLimitRequest(engine, "10.0.0.1:1234"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i, w.Code, http.StatusOK)
+		}
+	}
+	if w := doRateLimitRequest(engine, "10.0.0.1:1234"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
+
+// TestRateLimit_CustomKey 测试自定义键提取函数
+func TestRateLimit_CustomKey(t *testing.T) {
+	engine, _ := newRateLimitEngine(t, RateLimitOptions{
+		Limit:   1,
+		Window:  time.Minute,
+		KeyFunc: func(c *gin.Context) string { return "global" },
+	})
+
+	if w := doRateLimitRequest(engine, "10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	// 所有客户端共享同一个键
+	if w := doRateLimitRequest(engine, "10.0.0.2:1234"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
@@ -22,9 +22,16 @@ import (
 //
 //	gin.HandlerFunc: Gin中间件处理函数
 //
+// 响应:
+//
+//	401 Unauthorized - 上下文中没有用户ID
+//	403 Forbidden - 用户没有该资源的操作权限
+//	500 Internal Server Error - 权限检查失败
+//
 // 注意:
 //
 //	此中间件依赖于AuthMiddleware,必须在认证中间件之后使用
+//	与 RequireRole 相比粒度更细,适合按资源/操作授权的路由
 func RequirePermission(rbacSvc rbac.RBACService, resource, action string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从上下文获取用户ID
@@ -99,7 +106,7 @@ func RequirePermissionWithDomain(rbacSvc rbac.RBACService, domain, resource, act
 }
 
 // RequireRole 角色检查中间件
-// 检查当前用户是否拥有指定角色
+// 检查当前用户是否拥有指定角色，通过角色继承间接持有的角色同样视为拥有
 // 用法:
 //
 //	router.Use(middleware.RequireRole(rbacSvc, "admin"))
@@ -122,23 +129,14 @@ func RequireRole(rbacSvc rbac.RBACService, role string) gin.HandlerFunc {
 			return
 		}
 
-		// 获取用户的所有角色
-		roles, err := rbacSvc.GetUserRoles(c.Request.Context(), userID)
+		// 检查是否拥有指定角色（含通过角色继承获得的角色）
+		hasRole, err := rbacSvc.HasRole(c.Request.Context(), userID, role, "")
 		if err != nil {
 			result.InternalError(c, "Failed to get user roles")
 			c.Abort()
 			return
 		}
 
-		// 检查是否拥有指定角色
-		hasRole := false
-		for _, r := range roles {
-			if r == role {
-				hasRole = true
-				break
-			}
-		}
-
 		if !hasRole {
 			result.Forbidden(c, "Required role not found")
 			c.Abort()
@@ -150,7 +148,7 @@ func RequireRole(rbacSvc rbac.RBACService, role string) gin.HandlerFunc {
 }
 
 // RequireRoleInDomain 带域的角色检查中间件
-// 检查当前用户在指定域中是否拥有指定角色
+// 检查当前用户在指定域中是否拥有指定角色，包含通过角色继承间接持有的角色
 // 用于多租户场景
 // 用法:
 //
@@ -171,23 +169,14 @@ func RequireRoleInDomain(rbacSvc rbac.RBACService, role, domain string) gin.Hand
 			return
 		}
 
-		// 获取用户在指定域中的角色
-		roles, err := rbacSvc.GetUserRolesInDomain(c.Request.Context(), userID, domain)
+		// 检查在指定域中是否拥有指定角色（含通过角色继承获得的角色）
+		hasRole, err := rbacSvc.HasRole(c.Request.Context(), userID, role, domain)
 		if err != nil {
 			result.InternalError(c, "Failed to get user roles in domain")
 			c.Abort()
 			return
 		}
 
-		// 检查是否拥有指定角色
-		hasRole := false
-		for _, r := range roles {
-			if r == role {
-				hasRole = true
-				break
-			}
-		}
-
 		if !hasRole {
 			result.Forbidden(c, "Required role not found in domain")
 			c.Abort()
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/internal/service/rbac"
+)
+
+// fakeRBACService 只实现 CheckPermission 和 HasRole 的 RBAC 服务
+// 嵌入接口满足其余方法,测试中调用其他方法会 panic
+type fakeRBACService struct {
+	rbac.RBACService
+
+	// permissions 用户ID -> "resource:action" -> 是否允许
+	permissions map[int64]map[string]bool
+	// roles 用户ID -> 域 -> 持有的角色（含继承获得的角色）
+	roles map[int64]map[string][]string
+	err   error
+}
+
+func (f *fakeRBACService) HasRole(ctx context.Context, userID int64, role, domain string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return slices.Contains(f.roles[userID][domain], role), nil
+}
+
+func (f *fakeRBACService) CheckPermission(ctx context.Context, userID int64, resource, action string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.permissions[userID][resource+":"+action], nil
+}
+
+// newPermissionEngine 创建挂载 RequirePermission 的引擎
+// userID 为 0 时不设置用户ID,模拟未认证请求
+func newPermissionEngine(svc rbac.RBACService, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(ContextKeyUserID, userID)
+		}
+		c.Next()
+	})
+	engine.Use(RequirePermission(svc, "users", "write"))
+	engine.POST("/users", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+// TestRequirePermission 测试有权限、无权限、未认证和检查失败
+func TestRequirePermission(t *testing.T) {
+	svc := &fakeRBACService{
+		permissions: map[int64]map[string]bool{
+			1: {"users:write": true},
+			2: {"users:read": true},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		svc    rbac.RBACService
+		userID int64
+		want   int
+	}{
+		{"allowed", svc, 1, http.StatusOK},
+		{"denied", svc, 2, http.StatusForbidden},
+		{"unauthenticated", svc, 0, http.StatusUnauthorized},
+		{"check error", &fakeRBACService{err: errors.New("enforcer unavailable")}, 1, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newPermissionEngine(tt.svc, tt.userID).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", nil))
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// newRoleEngine 创建挂载角色检查中间件的引擎
+// userID 为 0 时不设置用户ID,模拟未认证请求
+func newRoleEngine(userID int64, mw gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(ContextKeyUserID, userID)
+		}
+		c.Next()
+	})
+	engine.Use(mw)
+	engine.GET("/admin", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+// TestRequireRole 测试全局角色和域内角色检查都通过 HasRole 判定
+func TestRequireRole(t *testing.T) {
+	// 用户 1 通过继承持有 admin,用户 2 只在 tenant1 中持有 admin
+	svc := &fakeRBACService{
+		roles: map[int64]map[string][]string{
+			1: {"": {"superadmin", "admin"}},
+			2: {"tenant1": {"admin"}},
+		},
+	}
+
+	errSvc := &fakeRBACService{err: errors.New("enforcer unavailable")}
+
+	tests := []struct {
+		name   string
+		mw     gin.HandlerFunc
+		userID int64
+		want   int
+	}{
+		{"inherited role", RequireRole(svc, "admin"), 1, http.StatusOK},
+		{"missing role", RequireRole(svc, "admin"), 2, http.StatusForbidden},
+		{"unauthenticated", RequireRole(svc, "admin"), 0, http.StatusUnauthorized},
+		{"check error", RequireRole(errSvc, "admin"), 1, http.StatusInternalServerError},
+		{"domain role", RequireRoleInDomain(svc, "admin", "tenant1"), 2, http.StatusOK},
+		{"other domain", RequireRoleInDomain(svc, "admin", "tenant2"), 2, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newRoleEngine(tt.userID, tt.mw).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
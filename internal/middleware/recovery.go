@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"runtime/debug"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -10,13 +13,37 @@ import (
 	"github.com/rei0721/go-scaffold/types/result"
 )
 
+// PanicHandler panic 回调函数
+// 参数:
+//
+//	c: 发生 panic 的请求上下文
+//	recovered: recover() 返回的原始值
+//	stack: panic 发生时的调用栈
+//
+// 示例(上报 Sentry):
+//
+//	cfg.Recovery.PanicHandler = func(c *gin.Context, recovered interface{}, stack []byte) {
+//		sentry.CurrentHub().Recover(recovered)
+//	}
+type PanicHandler func(c *gin.Context, recovered interface{}, stack []byte)
+
+// panicDetail 调试模式下返回给客户端的 panic 详情
+type panicDetail struct {
+	// Panic panic 值的字符串形式
+	Panic string `json:"panic"`
+
+	// Stack 调用栈,按行拆分便于阅读
+	Stack []string `json:"stack"`
+}
+
 // Recovery 返回一个从 panic 中恢复的中间件
-// 当处理器发生 panic 时,捕获错误并返回 500 状态码
+// 当处理器发生 panic 时,捕获错误并返回统一格式的错误响应
 // 这是防止服务崩溃的最后一道防线
 // 设计考虑:
 // - 捕获所有未处理的 panic,保证服务持续运行
-// - 记录详细的错误日志,包含 TraceID 便于问题追踪
-// - 返回统一的错误格式,避免暴露内部实现细节
+// - 以 error 级别记录完整调用栈,包含 TraceID 便于问题追踪
+// - panic 值为 *errors.BizError 时使用其错误码和消息,否则返回 ErrInternalServer
+// - 调试模式(gin.DebugMode)在响应中附带调用栈,其他模式不暴露内部实现细节
 // 使用场景:
 // - 处理意外的运行时错误(nil 指针、数组越界等)
 // - 防止第三方库的 panic 导致整个服务崩溃
@@ -34,36 +61,55 @@ func Recovery(cfg RecoveryConfig, log logger.Logger) gin.HandlerFunc {
 		// defer 确保即使发生 panic 也会执行这段代码
 		// recover() 只在 defer 函数中有效,用于捕获 panic
 		defer func() {
-			if err := recover(); err != nil {
-				// 从上下文中获取 TraceID
-				// TraceID 对于追踪分布式系统中的错误至关重要
-				// 可以将同一个请求在不同组件中的日志关联起来
-				traceID := GetTraceID(c)
-
-				// 记录 panic 详情到日志
-				// 这是排查问题的关键信息:
-				// - error: panic 的原因(可能是字符串或 error 类型)
-				// - path: 发生错误的请求路径
-				// - method: HTTP 方法
-				// - traceId: 请求追踪 ID
-				log.Error("panic recovered",
-					"error", err,
-					"path", c.Request.URL.Path,
-					"method", c.Request.Method,
-					"traceId", traceID,
-				)
-
-				// 返回 500 错误给客户端
-				// AbortWithStatusJSON 会立即返回响应并停止后续中间件执行
-				// 使用统一的错误格式,包含:
-				// - HTTP 状态码: 500 (Internal Server Error)
-				// - 错误码: errors.ErrInternalServer
-				// - 错误消息: "internal server error"(不暴露内部细节)
-				// - TraceID: 便于客户端报告问题时提供追踪信息
-				c.AbortWithStatusJSON(http.StatusInternalServerError,
-					result.ErrorWithTrace(errors.ErrInternalServer, "internal server error", traceID),
-				)
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			// 调用栈必须在 defer 中立即获取,此时仍包含 panic 发生的位置
+			stack := debug.Stack()
+
+			// 从上下文中获取 TraceID
+			// TraceID 对于追踪分布式系统中的错误至关重要
+			// 可以将同一个请求在不同组件中的日志关联起来
+			traceID := GetTraceID(c)
+
+			// 记录 panic 详情到日志
+			// 这是排查问题的关键信息:
+			// - error: panic 的原因(可能是字符串或 error 类型)
+			// - path: 发生错误的请求路径
+			// - method: HTTP 方法
+			// - traceId: 请求追踪 ID
+			// - stack: 完整调用栈
+			log.Error("panic recovered",
+				"error", recovered,
+				"path", c.Request.URL.Path,
+				"method", c.Request.Method,
+				"traceId", traceID,
+				"stack", string(stack),
+			)
+
+			// 交给外部错误追踪系统
+			if cfg.PanicHandler != nil {
+				cfg.PanicHandler(c, recovered, stack)
 			}
+
+			// 响应已经开始写出时无法再修改状态码和响应体,只能中止
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			// 返回错误给客户端
+			// AbortWithStatusJSON 会立即返回响应并停止后续中间件执行
+			status, resp := panicResponse(c, recovered, traceID)
+			if gin.IsDebugging() {
+				resp.Data = panicDetail{
+					Panic: fmt.Sprint(recovered),
+					Stack: strings.Split(strings.TrimSpace(string(stack)), "\n"),
+				}
+			}
+			c.AbortWithStatusJSON(status, resp)
 		}()
 
 		// 继续执行后续的中间件和处理器
@@ -71,3 +117,16 @@ func Recovery(cfg RecoveryConfig, log logger.Logger) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// panicResponse 根据 panic 值构造响应
+// - panic(*errors.BizError) 或包装了 BizError 的 error: 使用其错误码、消息和对应的 HTTP 状态码
+// - 其他值: 500 和按请求语言本地化的通用错误消息(不暴露 panic 内容)
+func panicResponse(c *gin.Context, recovered interface{}, traceID string) (int, *result.Result[any]) {
+	if err, ok := recovered.(error); ok {
+		if bizErr, ok := errors.AsBizError(err); ok {
+			return errors.HTTPStatus(bizErr.Code), result.ErrorWithTrace(bizErr.Code, bizErr.Message, traceID)
+		}
+	}
+
+	return http.StatusInternalServerError, result.ErrorLocalized(c, errors.ErrInternalServer, traceID)
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/types/errors"
+)
+
+// recoveryResponse panic 响应体
+type recoveryResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	TraceID string       `json:"traceId"`
+	Data    *panicDetail `json:"data"`
+}
+
+// serveRecovered 在指定 gin 模式下请求一个 panic 的处理器
+func serveRecovered(t *testing.T, mode string, cfg RecoveryConfig, log *recordLogger, value interface{}) (*httptest.ResponseRecorder, recoveryResponse) {
+	t.Helper()
+	prev := gin.Mode()
+	gin.SetMode(mode)
+	t.Cleanup(func() { gin.SetMode(prev) })
+
+	engine := gin.New()
+	engine.Use(TraceID(TraceIDConfig{Enabled: true}))
+	engine.Use(Recovery(cfg, log))
+	engine.GET("/panic", func(c *gin.Context) { panic(value) })
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(DefaultHeaderName, "trace-123")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	var resp recoveryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response body %q: %v", w.Body.String(), err)
+	}
+	return w, resp
+}
+
+// TestRecovery_DebugAndRelease 测试调试模式返回调用栈,发布模式不暴露,两者都包含 TraceID
+func TestRecovery_DebugAndRelease(t *testing.T) {
+	log := &recordLogger{}
+
+	w, resp := serveRecovered(t, gin.DebugMode, RecoveryConfig{Enabled: true}, log, "boom")
+	if w.Code != http.StatusInternalServerError || resp.Code != errors.ErrInternalServer || resp.TraceID != "trace-123" {
+		t.Fatalf("debug response = %d %+v", w.Code, resp)
+	}
+	if resp.Data == nil || resp.Data.Panic != "boom" || len(resp.Data.Stack) == 0 {
+		t.Errorf("debug response should include panic detail, got %s", w.Body.String())
+	}
+
+	w, resp = serveRecovered(t, gin.ReleaseMode, RecoveryConfig{Enabled: true}, log, "boom")
+	if w.Code != http.StatusInternalServerError || resp.Code != errors.ErrInternalServer || resp.TraceID != "trace-123" {
+		t.Fatalf("release response = %d %+v", w.Code, resp)
+	}
+	if resp.Data != nil || strings.Contains(w.Body.String(), "boom") || strings.Contains(w.Body.String(), "goroutine") {
+		t.Errorf("release response should not leak panic detail, got %s", w.Body.String())
+	}
+
+	// 两次 panic 都以 error 级别记录了调用栈
+	entries := log.take()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		stack, _ := e.fields["stack"].(string)
+		if e.level != "error" || e.fields["traceId"] != "trace-123" || !strings.Contains(stack, "recovery_test.go") {
+			t.Errorf("unexpected log entry %+v", e)
+		}
+	}
+}
+
+// TestRecovery_BizErrorAndHandler 测试 panic BizError 时使用其错误码,并调用 PanicHandler
+func TestRecovery_BizErrorAndHandler(t *testing.T) {
+	var handled interface{}
+	cfg := RecoveryConfig{
+		Enabled: true,
+		PanicHandler: func(c *gin.Context, recovered interface{}, stack []byte) {
+			handled = recovered
+		},
+	}
+
+	bizErr := errors.NewBizError(errors.ErrPermissionDenied, "permission denied")
+	w, resp := serveRecovered(t, gin.ReleaseMode, cfg, &recordLogger{}, bizErr)
+
+	if w.Code != http.StatusForbidden || resp.Code != errors.ErrPermissionDenied || resp.Message != "permission denied" {
+		t.Errorf("BizError response = %d %+v", w.Code, resp)
+	}
+	if handled != bizErr {
+		t.Errorf("PanicHandler received %v, want %v", handled, bizErr)
+	}
+}
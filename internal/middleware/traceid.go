@@ -3,6 +3,7 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/rei0721/go-scaffold/pkg/logger"
 	"github.com/rei0721/go-scaffold/pkg/utils"
 )
 
@@ -83,6 +84,10 @@ func TraceID(cfg TraceIDConfig) gin.HandlerFunc {
 		// 这样就不需要在每个函数中传递 TraceID 参数
 		c.Set(TraceIDKey, traceID)
 
+		// 同时存入请求的 context.Context
+		// 服务层等拿不到 gin.Context 的代码可以通过 logger.FromContext(ctx) 记录带 TraceID 的日志
+		c.Request = c.Request.WithContext(logger.WithTraceID(c.Request.Context(), traceID))
+
 		// 4. 将 TraceID 添加到响应 header 中
 		// 好处:
 		// - 客户端可以获取 TraceID,用于问题报告
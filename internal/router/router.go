@@ -2,16 +2,19 @@ package router
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/rei0721/go-scaffold/internal/handler"
 	"github.com/rei0721/go-scaffold/internal/middleware"
 	rbacService "github.com/rei0721/go-scaffold/internal/service/rbac"
+	"github.com/rei0721/go-scaffold/pkg/database"
 	"github.com/rei0721/go-scaffold/pkg/i18n"
 	"github.com/rei0721/go-scaffold/pkg/jwt"
 	"github.com/rei0721/go-scaffold/pkg/logger"
 	"github.com/rei0721/go-scaffold/types/constants"
+	"github.com/rei0721/go-scaffold/types/errors"
 	"github.com/rei0721/go-scaffold/types/result"
 )
 
@@ -51,6 +54,10 @@ type Router struct {
 	// rbacService RBAC服务
 	// 用于中间件权限检查
 	rbacService rbacService.RBACService
+
+	// dbHealth 数据库健康监控
+	// 用于深度健康检查端点,为 nil 时 /health/deep 不检查数据库
+	dbHealth *database.HealthMonitor
 }
 
 // New 创建一个新的 Router 实例
@@ -81,6 +88,12 @@ func New(authHandler *handler.AuthHandler, rbacHandler *handler.RBACHandler, log
 	}
 }
 
+// SetDBHealth 设置数据库健康监控
+// 必须在 Setup 之前调用
+func (r *Router) SetDBHealth(m *database.HealthMonitor) {
+	r.dbHealth = m
+}
+
 // Setup 初始化 Gin 引擎并配置中间件和路由
 // 这个方法完成路由器的完整设置
 // 参数:
@@ -104,6 +117,9 @@ func (r *Router) Setup(cfg middleware.MiddlewareConfig) *gin.Engine {
 	// 我们使用 gin.New() 从零开始,完全控制中间件
 	r.engine = gin.New()
 
+	// 参数校验错误使用 JSON 字段名,便于客户端定位字段
+	registerJSONFieldNames()
+
 	// 应用 I18n 中间件
 	// 处理国际化
 	// 必须在其他中间件之前,以便预检请求(OPTIONS)能被正确处理
@@ -161,6 +177,12 @@ func (r *Router) registerRoutes() {
 	// - 不需要认证
 	r.engine.GET("/health", r.healthCheck)
 
+	// 深度健康检查端点
+	// GET /health/deep
+	// 返回数据库等依赖的最近健康状态,依赖不健康时返回 503
+	// 用于 K8s readiness 探针,与 /health(liveness)区分
+	r.engine.GET("/health/deep", r.deepHealthCheck)
+
 	// API v1 路由组
 	// 所有 v1 API 都在 /api/v1 路径下
 	// 好处:
@@ -208,6 +230,30 @@ func (r *Router) registerRoutes() {
 		}
 
 		// ==================== 受保护路由 ====================
+		// 用户相关路由组(需要认证)
+		if r.rbacHandler != nil && r.jwt != nil {
+			usersGroup := v1.Group("/users")
+			usersGroup.Use(middleware.AuthMiddleware(r.jwt))
+			{
+				// GET /api/v1/users/:id/permissions - 获取用户有效权限
+				// 本人或admin可访问
+				usersGroup.GET("/:id/permissions", r.rbacHandler.GetUserPermissions)
+			}
+
+			// 权限查询路由组(需要认证,本人或admin可访问)
+			// 不挂载 RequireRole,前端可据此按当前用户的权限控制功能开关
+			rbacUsersGroup := v1.Group("/rbac/users")
+			rbacUsersGroup.Use(middleware.AuthMiddleware(r.jwt))
+			{
+				// GET /api/v1/rbac/users/:id/effective - 获取用户在域中的有效权限
+				rbacUsersGroup.GET("/:id/effective", r.rbacHandler.GetEffectivePermissions)
+				// GET /api/v1/rbac/users/:id/can - 检查用户是否拥有某项权限
+				rbacUsersGroup.GET("/:id/can", r.rbacHandler.CanUser)
+				// POST /api/v1/rbac/users/:id/can-batch - 批量检查用户权限
+				rbacUsersGroup.POST("/:id/can-batch", r.rbacHandler.CanUserBatch)
+			}
+		}
+
 		// RBAC管理路由组(需要认证+admin权限)
 		if r.rbacHandler != nil && r.jwt != nil && r.rbacService != nil {
 			rbacGroup := v1.Group("/rbac")
@@ -229,9 +275,12 @@ func (r *Router) registerRoutes() {
 				rbacGroup.DELETE("/policies", r.rbacHandler.RemovePolicy)
 				rbacGroup.GET("/policies", r.rbacHandler.GetPolicies)
 				rbacGroup.GET("/roles/:role/policies", r.rbacHandler.GetPoliciesByRole)
+				rbacGroup.GET("/resources/:resource/policies", r.rbacHandler.ListPoliciesByResource)
+				rbacGroup.POST("/roles/:role/permissions", r.rbacHandler.AssignPermissions)
 
 				// 权限检查
 				rbacGroup.POST("/check", r.rbacHandler.CheckPermission)
+				rbacGroup.POST("/check-batch", r.rbacHandler.CheckPermissions)
 			}
 		}
 
@@ -263,6 +312,41 @@ func (r *Router) healthCheck(c *gin.Context) {
 	}))
 }
 
+// deepHealthCheck 处理深度健康检查请求
+// GET /health/deep
+// 响应:
+//
+//	200 OK - 所有依赖健康
+//	503 Service Unavailable - 数据库不健康
+//
+// 设计考虑:
+//   - 读取健康监控最近一次的检查结果,不在请求中访问数据库,保证快速响应
+//   - 未配置健康监控时只返回服务状态
+func (r *Router) deepHealthCheck(c *gin.Context) {
+	data := gin.H{
+		"status":  "ok",
+		"version": constants.AppVersion,
+	}
+
+	if r.dbHealth != nil {
+		dbStatus := r.dbHealth.Status()
+		data["database"] = dbStatus
+		if !dbStatus.Healthy {
+			data["status"] = "unhealthy"
+			c.JSON(http.StatusServiceUnavailable, &result.Result[gin.H]{
+				Code:       errors.ErrServiceUnavailable,
+				Message:    "database unhealthy",
+				Data:       data,
+				TraceID:    middleware.GetTraceID(c),
+				ServerTime: time.Now().Unix(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, result.Success(data))
+}
+
 // Engine 返回底层的 Gin 引擎
 // 这是一个访问器方法,用于特殊场景
 // 使用场景:
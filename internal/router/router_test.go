@@ -0,0 +1,57 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rei0721/go-scaffold/internal/middleware"
+	"github.com/rei0721/go-scaffold/pkg/i18n"
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// newTestEngine 使用 Setup 创建引擎,不注入 JWT 和 RBAC,只注册公开路由
+func newTestEngine(t *testing.T, cfg middleware.MiddlewareConfig) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	i, err := i18n.New(&i18n.Config{})
+	if err != nil {
+		t.Fatalf("failed to create i18n: %v", err)
+	}
+
+	r := New(nil, nil, logger.Default(), i, nil, nil)
+	return r.Setup(cfg)
+}
+
+// TestSetup_CORSPreflight 测试 Setup 装配的路由无需注册 OPTIONS 路由即可响应预检请求
+func TestSetup_CORSPreflight(t *testing.T) {
+	engine := newTestEngine(t, middleware.MiddlewareConfig{
+		CORS: middleware.CORSConfig{
+			Enabled:      true,
+			AllowOrigins: []string{"http://localhost:3000"},
+			AllowMethods: []string{"GET", "POST"},
+			AllowHeaders: []string{"Authorization", "Content-Type"},
+			MaxAge:       600,
+		},
+	})
+
+	// /api/v1/auth/login 只注册了 POST
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/auth/login", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Allow-Origin = %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Max-Age = %q, want 600", got)
+	}
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// registerJSONFieldNames 让参数校验错误使用 JSON 字段名
+// 默认 validator 报告结构体字段名(如 Username),
+// 注册后 errors.ParseValidationErrors 返回的 Field 与请求体中的键一致(如 username)
+func registerJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		default:
+			return name
+		}
+	})
+}
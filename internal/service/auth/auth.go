@@ -4,7 +4,7 @@
 // - 用户登录（验证凭证 + 生成 Token）
 // - 用户登出（清除缓存/会话）
 // - 密码修改（验证旧密码 + 更新新密码）
-// - Token 刷新（验证 refresh token + 生成新 access token）
+// - Token 刷新（验证 refresh token + 轮换令牌对 + 复用检测）
 //
 // 设计原则：
 // - 与 UserService 职责分离：Auth 负责认证，User 负责用户资料管理
@@ -36,12 +36,15 @@ type AuthService interface {
 	Login(ctx context.Context, req *types.LoginRequest) (*types.LoginResponse, error)
 
 	// Logout 用户登出
-	Logout(ctx context.Context, userID int64) error
+	// token 为当前访问令牌,启用缓存时吊销它所属的整个令牌家族,
+	// 同一次登录签发的刷新令牌也立即失效;为空时只清理缓存
+	Logout(ctx context.Context, userID int64, token string) error
 
 	// ChangePassword 修改密码
 	ChangePassword(ctx context.Context, userID int64, req *types.ChangePasswordRequest) error
 
-	// RefreshToken 刷新访问令牌
+	// RefreshToken 使用刷新令牌换取新的令牌对
+	// 已使用的刷新令牌会被吊销,再次使用时吊销整个令牌家族(同一次登录签发的所有令牌)
 	RefreshToken(ctx context.Context, req *types.RefreshTokenRequest) (*types.TokenResponse, error)
 
 	// SetDB 设置DB依赖（延迟注入）
@@ -2,13 +2,15 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"time"
 
 	"github.com/rei0721/go-scaffold/internal/models"
 	"github.com/rei0721/go-scaffold/internal/repository"
 	"github.com/rei0721/go-scaffold/internal/service"
+	"github.com/rei0721/go-scaffold/pkg/cache"
+	"github.com/rei0721/go-scaffold/pkg/jwt"
 	"github.com/rei0721/go-scaffold/types"
 	"github.com/rei0721/go-scaffold/types/constants"
 	"github.com/rei0721/go-scaffold/types/errors"
@@ -120,9 +122,7 @@ func (s *authService) Register(ctx context.Context, req *types.RegisterRequest)
 			userCopy := *user
 			_ = exec.Execute(constants.AppPoolCache, func() {
 				key := fmt.Sprintf("user:%d", userCopy.ID)
-				if data, err := json.Marshal(userCopy); err == nil {
-					_ = c.Set(context.Background(), key, string(data), 1*time.Hour)
-				}
+				_ = cache.SetJSON(context.Background(), c, key, userCopy, 1*time.Hour)
 			})
 		}
 	}
@@ -222,20 +222,18 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 			userCopy := *user
 			_ = exec.Execute(constants.AppPoolCache, func() {
 				key := fmt.Sprintf("user:%d", userCopy.ID)
-				if data, err := json.Marshal(userCopy); err == nil {
-					_ = c.Set(context.Background(), key, string(data), 1*time.Hour)
-				}
+				_ = cache.SetJSON(context.Background(), c, key, userCopy, 1*time.Hour)
 			})
 		}
 	}
 
-	// 7. 生成访问令牌
-	var token string
+	// 7. 生成访问令牌和刷新令牌
+	var token, refreshToken string
 	var expiresIn int
 
 	if jwtManager := s.GetJWT(); jwtManager != nil {
 		var err error
-		token, err = jwtManager.GenerateToken(user.ID, user.Username)
+		token, refreshToken, err = jwtManager.GenerateTokenPair(user.ID, user.Username)
 		if err != nil {
 			if log := s.GetLogger(); log != nil {
 				log.Error("failed to generate JWT token", "error", err, "userId", user.ID)
@@ -266,15 +264,36 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 
 	// 9. 返回登录响应
 	return &types.LoginResponse{
-		Token:     token,
-		ExpiresIn: expiresIn,
-		User:      *toUserResponse(user),
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    expiresIn,
+		User:         *toUserResponse(user),
 	}, nil
 }
 
 // Logout 用户登出
-func (s *authService) Logout(ctx context.Context, userID int64) error {
-	// 1. 清除缓存的用户信息
+func (s *authService) Logout(ctx context.Context, userID int64, token string) error {
+	// 1. 吊销当前令牌
+	// 令牌属于某个登录家族时吊销整个家族,刷新令牌随之失效,无法再换取新的访问令牌
+	// 未注入缓存时 JWT 无法吊销,退化为仅清理缓存(令牌在过期前仍然有效)
+	if jwtManager := s.GetJWT(); jwtManager != nil && token != "" {
+		var err error
+		if claims, vErr := jwtManager.ValidateToken(token); vErr == nil && claims.Family != "" {
+			err = jwtManager.RevokeFamily(ctx, claims.Family)
+		} else {
+			err = jwtManager.Revoke(ctx, token)
+		}
+		if err != nil {
+			if !stderrors.Is(err, jwt.ErrCacheNotSet) {
+				return errors.NewBizError(errors.ErrInternalServer, "failed to revoke token").WithCause(err)
+			}
+			if log := s.GetLogger(); log != nil {
+				log.Warn("token revocation unavailable, token remains valid until expiry", "userId", userID)
+			}
+		}
+	}
+
+	// 2. 清除缓存的用户信息
 	if c := s.GetCache(); c != nil {
 		userKey := fmt.Sprintf("user:%d", userID)
 		tokenKey := fmt.Sprintf("%s%d", CacheKeyPrefixAuthToken, userID)
@@ -289,7 +308,7 @@ func (s *authService) Logout(ctx context.Context, userID int64) error {
 		}
 	}
 
-	// 2. 记录登出日志
+	// 3. 记录登出日志
 	if log := s.GetLogger(); log != nil {
 		log.Info("user logged out", "userId", userID)
 	}
@@ -350,44 +369,70 @@ func (s *authService) ChangePassword(ctx context.Context, userID int64, req *typ
 }
 
 // RefreshToken 刷新访问令牌
+// 轮换刷新令牌: 签发新的令牌对并吊销已使用的刷新令牌
+// 已吊销的刷新令牌再次出现说明令牌被复用(可能已泄露),吊销整个令牌家族
 func (s *authService) RefreshToken(ctx context.Context, req *types.RefreshTokenRequest) (*types.TokenResponse, error) {
-	// 1. 验证 refresh token
 	jwtManager := s.GetJWT()
 	if jwtManager == nil {
 		return nil, errors.NewBizError(errors.ErrInternalServer, "JWT manager not available")
 	}
 
-	// 2. 验证并提取 token 信息
-	claims, err := jwtManager.ValidateToken(req.RefreshToken)
+	// 1. 验证 refresh token
+	claims, err := jwtManager.ValidateRefreshToken(req.RefreshToken)
 	if err != nil {
-		if log := s.GetLogger(); log != nil {
+		if stderrors.Is(err, jwt.ErrTokenRevoked) && claims != nil {
+			s.revokeReusedFamily(ctx, claims)
+		} else if log := s.GetLogger(); log != nil {
 			log.Warn("refresh token validation failed", "error", err)
 		}
 		return nil, errors.NewBizError(errors.ErrUnauthorized, "invalid refresh token").WithCause(err)
 	}
 
-	// 3. 生成新的 access token
-	accessToken, err := jwtManager.GenerateToken(claims.UserID, claims.Username)
+	// 2. 吊销已使用的 refresh token,之后再次使用即视为复用
+	// 未注入缓存时无法吊销,退化为不检测复用
+	if err := jwtManager.Revoke(ctx, req.RefreshToken); err != nil {
+		if !stderrors.Is(err, jwt.ErrCacheNotSet) {
+			return nil, errors.NewBizError(errors.ErrInternalServer, "failed to revoke refresh token").WithCause(err)
+		}
+		if log := s.GetLogger(); log != nil {
+			log.Warn("token revocation unavailable, refresh token reuse cannot be detected", "userId", claims.UserID)
+		}
+	}
+
+	// 3. 轮换出同一家族的新令牌对
+	accessToken, refreshToken, err := jwtManager.RotateTokenPair(claims)
 	if err != nil {
 		if log := s.GetLogger(); log != nil {
-			log.Error("failed to generate new access token", "error", err, "userId", claims.UserID)
+			log.Error("failed to rotate token pair", "error", err, "userId", claims.UserID)
 		}
 		return nil, errors.NewBizError(errors.ErrInternalServer, "failed to generate token").WithCause(err)
 	}
 
-	// 4. 可选：生成新的 refresh token（refresh token rotation）
-	// 这里暂时不实现，使用原 refresh token
-	// newRefreshToken, err := jwtManager.GenerateRefreshToken(claims.UserID, claims.Username)
-
-	// 5. 返回新的 token 响应
+	// 4. 返回新的 token 响应
 	return &types.TokenResponse{
 		AccessToken:  accessToken,
-		RefreshToken: req.RefreshToken, // 保持原 refresh token
-		ExpiresIn:    3600,             // 应该从配置读取
+		RefreshToken: refreshToken,
+		ExpiresIn:    3600, // 应该从配置读取
 		TokenType:    "Bearer",
 	}, nil
 }
 
+// revokeReusedFamily 吊销被复用的刷新令牌所属的令牌家族
+// 吊销失败只记录日志,请求本身已被拒绝
+func (s *authService) revokeReusedFamily(ctx context.Context, claims *jwt.Claims) {
+	err := s.GetJWT().RevokeFamily(ctx, claims.Family)
+
+	log := s.GetLogger()
+	if log == nil {
+		return
+	}
+	if err != nil {
+		log.Error("failed to revoke token family after refresh token reuse", "userId", claims.UserID, "error", err)
+		return
+	}
+	log.Warn("revoked refresh token reused, token family revoked", "userId", claims.UserID)
+}
+
 // toUserResponse 将 User 模型转换为 UserResponse
 func toUserResponse(user *models.DBUser) *types.UserResponse {
 	return &types.UserResponse{
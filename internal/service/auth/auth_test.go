@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/rei0721/go-scaffold/internal/models"
+	"github.com/rei0721/go-scaffold/internal/repository"
+	"github.com/rei0721/go-scaffold/pkg/cache"
+	"github.com/rei0721/go-scaffold/pkg/crypto"
+	"github.com/rei0721/go-scaffold/pkg/jwt"
+	"github.com/rei0721/go-scaffold/types"
+	"github.com/rei0721/go-scaffold/types/errors"
+)
+
+// fakeAuthRepository 只实现登录用到的查询
+// 嵌入接口满足其余方法,测试中调用其他方法会 panic
+type fakeAuthRepository struct {
+	repository.AuthRepository
+
+	users map[string]*models.DBUser
+}
+
+func (f *fakeAuthRepository) FindUserByUsername(ctx context.Context, username string) (*models.DBUser, error) {
+	return f.users[username], nil
+}
+
+// newTestAuthService 创建注入了 JWT(带黑名单缓存) 和用户 alice 的认证服务
+func newTestAuthService(t *testing.T) (AuthService, jwt.JWT) {
+	t.Helper()
+
+	c := cache.NewMemory(nil)
+	t.Cleanup(func() { c.Close() })
+
+	j, err := jwt.New(&jwt.Config{Secret: strings.Repeat("s", 32)})
+	if err != nil {
+		t.Fatalf("failed to create jwt manager: %v", err)
+	}
+	j.SetCache(c)
+
+	cr, err := crypto.NewBcrypt(crypto.WithBcryptCost(crypto.MinBcryptCost))
+	if err != nil {
+		t.Fatalf("failed to create crypto: %v", err)
+	}
+	hash, err := cr.HashPassword("password123")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	repo := &fakeAuthRepository{users: map[string]*models.DBUser{
+		"alice": {Username: "alice", Password: hash, Status: 1},
+	}}
+	repo.users["alice"].ID = 1
+
+	svc := NewAuthService(repo)
+	svc.SetJWT(j)
+	svc.SetCrypto(cr)
+	return svc, j
+}
+
+// login 以 alice 登录并返回令牌对
+func login(t *testing.T, svc AuthService) *types.LoginResponse {
+	t.Helper()
+	resp, err := svc.Login(context.Background(), &types.LoginRequest{Username: "alice", Password: "password123"})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	return resp
+}
+
+// assertUnauthorized 断言错误为 ErrUnauthorized 业务错误
+func assertUnauthorized(t *testing.T, err error) {
+	t.Helper()
+	bizErr, ok := errors.AsBizError(err)
+	if !ok || bizErr.Code != errors.ErrUnauthorized {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+}
+
+// TestLogin_IssuesRefreshToken 测试登录签发同一家族的访问令牌和刷新令牌
+func TestLogin_IssuesRefreshToken(t *testing.T) {
+	svc, j := newTestAuthService(t)
+	resp := login(t, svc)
+
+	access, err := j.ValidateToken(resp.Token)
+	if err != nil {
+		t.Fatalf("access token invalid: %v", err)
+	}
+	refresh, err := j.ValidateRefreshToken(resp.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh token invalid: %v", err)
+	}
+	if access.Family == "" || access.Family != refresh.Family {
+		t.Fatalf("expected tokens to share a family, got %q and %q", access.Family, refresh.Family)
+	}
+}
+
+// TestRefreshToken_Rotation 测试刷新时轮换令牌对并吊销已使用的刷新令牌
+func TestRefreshToken_Rotation(t *testing.T) {
+	svc, j := newTestAuthService(t)
+	ctx := context.Background()
+	resp := login(t, svc)
+
+	// 访问令牌不能用来刷新
+	_, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: resp.Token})
+	assertUnauthorized(t, err)
+
+	rotated, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	if err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+	if rotated.RefreshToken == "" || rotated.RefreshToken == resp.RefreshToken {
+		t.Fatal("expected a new refresh token")
+	}
+	if _, err := j.ValidateToken(rotated.AccessToken); err != nil {
+		t.Fatalf("rotated access token invalid: %v", err)
+	}
+
+	// 轮换出的刷新令牌可以继续使用
+	if _, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: rotated.RefreshToken}); err != nil {
+		t.Fatalf("second refresh failed: %v", err)
+	}
+}
+
+// TestRefreshToken_ReuseRevokesFamily 测试复用已吊销的刷新令牌时吊销整个家族
+func TestRefreshToken_ReuseRevokesFamily(t *testing.T) {
+	svc, j := newTestAuthService(t)
+	ctx := context.Background()
+	resp := login(t, svc)
+	other := login(t, svc)
+
+	rotated, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	if err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+
+	// 复用旧刷新令牌被拒绝
+	_, err = svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	assertUnauthorized(t, err)
+
+	// 家族内轮换出的令牌全部失效
+	if _, err := j.ValidateToken(rotated.AccessToken); !stderrors.Is(err, jwt.ErrTokenRevoked) {
+		t.Fatalf("expected rotated access token to be revoked, got %v", err)
+	}
+	_, err = svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: rotated.RefreshToken})
+	assertUnauthorized(t, err)
+
+	// 其他登录会话不受影响
+	if _, err := j.ValidateToken(other.Token); err != nil {
+		t.Fatalf("other session should stay valid: %v", err)
+	}
+	if _, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: other.RefreshToken}); err != nil {
+		t.Fatalf("other session refresh failed: %v", err)
+	}
+}
+
+// TestLogout_RevokesRefreshToken 测试登出后刷新令牌同样失效
+func TestLogout_RevokesRefreshToken(t *testing.T) {
+	svc, j := newTestAuthService(t)
+	ctx := context.Background()
+	resp := login(t, svc)
+	other := login(t, svc)
+
+	if err := svc.Logout(ctx, 1, resp.Token); err != nil {
+		t.Fatalf("logout failed: %v", err)
+	}
+
+	if _, err := j.ValidateToken(resp.Token); !stderrors.Is(err, jwt.ErrTokenRevoked) {
+		t.Fatalf("expected access token to be revoked, got %v", err)
+	}
+	_, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: resp.RefreshToken})
+	assertUnauthorized(t, err)
+
+	// 其他登录会话不受影响
+	if _, err := svc.RefreshToken(ctx, &types.RefreshTokenRequest{RefreshToken: other.RefreshToken}); err != nil {
+		t.Fatalf("other session refresh failed: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package rbac
+
+import (
+	"context"
+	"time"
+
+	"github.com/rei0721/go-scaffold/types"
+)
+
+// AuditSink 审计事件接收器
+// RBACService 在每次变更操作成功后调用 Record
+// 实现方可以写入数据库、日志或消息队列
+// 注意: Record 在请求路径上同步调用，耗时操作应在实现内部异步处理
+type AuditSink interface {
+	// Record 记录一条审计事件
+	Record(ctx context.Context, event AuditEvent)
+}
+
+// AuditEvent RBAC变更审计事件
+type AuditEvent struct {
+	// Actor 操作者用户ID，0 表示系统操作（如初始化、种子数据）
+	Actor int64 `json:"actor"`
+
+	// Operation 操作类型，见 AuditOp* 常量
+	Operation string `json:"operation"`
+
+	// UserID 目标用户ID（角色分配类操作），其他操作为0
+	UserID int64 `json:"user_id,omitempty"`
+
+	// Role 目标角色
+	Role string `json:"role,omitempty"`
+
+	// ParentRole 父角色（角色继承操作）
+	ParentRole string `json:"parent_role,omitempty"`
+
+	// Domain 域名（租户ID）
+	Domain string `json:"domain,omitempty"`
+
+	// Policies 受影响的策略（策略类操作）
+	// 批量添加时只包含实际写入的策略，已存在而被跳过的不计入
+	Policies []types.RBACPolicy `json:"policies,omitempty"`
+
+	// Force 是否强制删除（删除角色操作），强制删除会级联移除角色的分配、继承和策略
+	Force bool `json:"force,omitempty"`
+
+	// Timestamp 事件时间
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// noopAuditSink 默认的空实现
+type noopAuditSink struct{}
+
+// Record 丢弃事件
+func (noopAuditSink) Record(context.Context, AuditEvent) {}
+
+// actorKey 操作者在 context 中的键
+type actorKey struct{}
+
+// WithActor 将操作者用户ID写入 context
+// 处理器在调用变更类方法前设置，审计事件从中读取 Actor
+func WithActor(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext 从 context 读取操作者用户ID
+func ActorFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(actorKey{}).(int64)
+	return userID, ok
+}
@@ -1,3 +1,37 @@
 package rbac
 
 // 常量定义（如需要可在此添加）
+
+const (
+	// DefaultPageSize 默认分页大小
+	DefaultPageSize = 20
+
+	// MaxPageSize 最大分页大小
+	MaxPageSize = 100
+
+	// policyFieldResource 策略中资源字段的索引
+	// 内置模型的策略格式为 [sub, dom, obj, act]
+	policyFieldResource = 2
+)
+
+// 策略导出/导入格式
+const (
+	// PolicyFormatJSON JSON格式，内容为 types.PolicySnapshot
+	PolicyFormatJSON = "json"
+
+	// PolicyFormatCSV CSV格式，与 Casbin 策略文件一致
+	// 每行为 "p, role, domain, resource, action" 或 "g, subject, role, domain"
+	PolicyFormatCSV = "csv"
+)
+
+// 审计操作类型
+const (
+	AuditOpAssignRole        = "assign_role"
+	AuditOpRevokeRole        = "revoke_role"
+	AuditOpAssignParentRole  = "assign_parent_role"
+	AuditOpDeleteRole        = "delete_role"
+	AuditOpAddPolicy         = "add_policy"
+	AuditOpRemovePolicy      = "remove_policy"
+	AuditOpAssignPermissions = "assign_permissions"
+	AuditOpImportPolicies    = "import_policies"
+)
@@ -43,6 +43,17 @@ type RBACService interface {
 	//   action: 操作名称
 	CheckPermissionWithDomain(ctx context.Context, userID int64, domain, resource, action string) (bool, error)
 
+	// CheckPermissions 批量检查用户权限
+	// 每一项都通过 Enforcer 判定，与单项检查共用匹配规则和决策缓存
+	// 参数:
+	//   ctx: 上下文
+	//   userID: 用户ID
+	//   domain: 域名（租户ID），为空表示无域
+	//   checks: 待检查的权限列表
+	// 返回:
+	//   map[string]bool: 键为 "resource:action"，值为是否有权限
+	CheckPermissions(ctx context.Context, userID int64, domain string, checks []types.PermCheck) (map[string]bool, error)
+
 	// ========== 角色管理 ==========
 
 	// AssignRole 为用户分配角色
@@ -81,6 +92,15 @@ type RBACService interface {
 	// GetUserRolesInDomain 获取用户在指定域中的角色
 	GetUserRolesInDomain(ctx context.Context, userID int64, domain string) ([]string, error)
 
+	// HasRole 检查用户在指定域中是否持有角色
+	// 包含通过角色继承间接获得的角色
+	// 参数:
+	//   ctx: 上下文
+	//   userID: 用户ID
+	//   role: 角色名称
+	//   domain: 域名，为空表示全局
+	HasRole(ctx context.Context, userID int64, role, domain string) (bool, error)
+
 	// GetRoleUsers 获取拥有指定角色的所有用户
 	// 参数:
 	//   ctx: 上下文
@@ -89,6 +109,45 @@ type RBACService interface {
 	//   []int64: 用户ID列表
 	GetRoleUsers(ctx context.Context, role string) ([]int64, error)
 
+	// DeleteRole 删除角色
+	// 角色仍分配给用户时默认拒绝删除，避免静默撤销访问权限；仅被子角色继承不算分配
+	// 检查与删除是原子的，不会在检查之后漏掉新的分配
+	// 参数:
+	//   ctx: 上下文
+	//   role: 角色名称
+	//   force: 为 true 时在单个事务中级联删除角色的所有分配和策略
+	// 返回:
+	//   error: 未强制删除且角色仍在使用时返回包装了 ErrRoleInUse 的错误，包含受影响的数量
+	DeleteRole(ctx context.Context, role string, force bool) error
+
+	// AssignParentRole 设置角色继承
+	// childRole 继承 parentRole 的全部权限，支持多级传递
+	// 参数:
+	//   ctx: 上下文
+	//   childRole: 子角色（如 admin）
+	//   parentRole: 父角色（如 editor）
+	// 返回:
+	//   error: 形成继承环时返回包装了 rbac.ErrRoleCycle 的错误
+	AssignParentRole(ctx context.Context, childRole, parentRole string) error
+
+	// GetUserPermissions 获取用户的所有有效权限
+	// 合并用户所有角色（含继承角色）的权限并去重，用于前端按权限渲染界面
+	// 参数:
+	//   ctx: 上下文
+	//   userID: 用户ID
+	// 返回:
+	//   []types.Permission: 去重后的权限列表
+	GetUserPermissions(ctx context.Context, userID int64) ([]types.Permission, error)
+
+	// GetUserPermissionsInDomain 获取用户在指定域中的所有有效权限
+	// 参数:
+	//   ctx: 上下文
+	//   userID: 用户ID
+	//   domain: 域名（租户ID），为空时等同于 GetUserPermissions
+	// 返回:
+	//   []types.Permission: 去重后的权限列表
+	GetUserPermissionsInDomain(ctx context.Context, userID int64, domain string) ([]types.Permission, error)
+
 	// ========== 策略管理 ==========
 
 	// AddPolicy 添加策略
@@ -124,6 +183,36 @@ type RBACService interface {
 	//   role: 角色名称
 	GetPoliciesByRole(ctx context.Context, role string) ([]types.RBACPolicy, error)
 
+	// ListPoliciesByResource 分页获取指定资源的策略
+	// 参数:
+	//   ctx: 上下文
+	//   resource: 资源名称
+	//   page: 页码，从 1 开始
+	//   pageSize: 每页大小
+	// 返回:
+	//   []types.RBACPolicy: 当前页的策略列表
+	//   int64: 该资源的策略总数（用于计算总页数）
+	ListPoliciesByResource(ctx context.Context, resource string, page, pageSize int) ([]types.RBACPolicy, int64, error)
+
+	// ========== 备份与导入 ==========
+
+	// ExportPolicies 导出全部策略和角色分配
+	// 参数:
+	//   ctx: 上下文
+	//   format: 导出格式，PolicyFormatJSON 或 PolicyFormatCSV
+	// 返回:
+	//   []byte: 导出内容
+	//   error: 格式不支持时返回 ErrUnsupportedPolicyFormat
+	ExportPolicies(ctx context.Context, format string) ([]byte, error)
+
+	// ImportPolicies 导入策略和角色分配
+	// 在单个事务中写入，已存在的条目跳过（可重复执行），任一失败则全部回滚
+	// 参数:
+	//   ctx: 上下文
+	//   format: 数据格式，PolicyFormatJSON 或 PolicyFormatCSV
+	//   data: ExportPolicies 导出的内容
+	ImportPolicies(ctx context.Context, format string, data []byte) error
+
 	// ========== 批量操作 ==========
 
 	// AssignRoles 批量为用户分配角色
@@ -139,6 +228,15 @@ type RBACService interface {
 	//   policies: 策略列表
 	AddPolicies(ctx context.Context, policies []types.RBACPolicy) error
 
+	// AssignPermissions 批量为角色分配权限
+	// 角色已有的权限会被跳过，新增权限在同一事务中写入，任一失败则全部回滚
+	// 参数:
+	//   ctx: 上下文
+	//   role: 角色名称
+	//   domain: 域名，为空表示全局
+	//   permissions: 权限列表
+	AssignPermissions(ctx context.Context, role, domain string, permissions []types.Permission) error
+
 	// ========== 延迟注入方法 ==========
 
 	// SetRBAC 设置RBAC管理器（延迟注入）
@@ -146,4 +244,8 @@ type RBACService interface {
 
 	// SetLogger 设置日志记录器（延迟注入）
 	SetLogger(l logger.Logger)
+
+	// SetAuditSink 设置审计事件接收器（延迟注入）
+	// 未设置时审计事件被丢弃
+	SetAuditSink(sink AuditSink)
 }
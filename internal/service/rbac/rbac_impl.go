@@ -2,20 +2,27 @@ package rbac
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync/atomic"
+	"time"
 
 	"github.com/rei0721/go-scaffold/pkg/logger"
 	"github.com/rei0721/go-scaffold/pkg/rbac"
 	"github.com/rei0721/go-scaffold/types"
 )
 
+// ErrRoleInUse 角色仍分配给用户，未强制删除时拒绝
+// 与 rbac.ErrRoleInUse 是同一个错误
+var ErrRoleInUse = rbac.ErrRoleInUse
+
 // rbacServiceImpl 是 RBACService 的具体实现
 type rbacServiceImpl struct {
 	// 延迟注入的依赖（使用 atomic.Value）
 	rbac   atomic.Value // rbac.RBAC
 	logger atomic.Value // logger.Logger
+	audit  atomic.Value // AuditSink
 }
 
 // NewRBACService 创建新的RBAC服务实例
@@ -35,6 +42,11 @@ func (s *rbacServiceImpl) SetLogger(l logger.Logger) {
 	s.logger.Store(l)
 }
 
+// SetAuditSink 设置审计事件接收器（延迟注入）
+func (s *rbacServiceImpl) SetAuditSink(sink AuditSink) {
+	s.audit.Store(sink)
+}
+
 // ========== 辅助方法 ==========
 
 // getRBAC 获取RBAC实例
@@ -53,6 +65,21 @@ func (s *rbacServiceImpl) getLogger() logger.Logger {
 	return nil
 }
 
+// getAuditSink 获取审计接收器，未注入时返回空实现
+func (s *rbacServiceImpl) getAuditSink() AuditSink {
+	if a := s.audit.Load(); a != nil {
+		return a.(AuditSink)
+	}
+	return noopAuditSink{}
+}
+
+// recordAudit 补全操作者和时间后记录审计事件
+func (s *rbacServiceImpl) recordAudit(ctx context.Context, event AuditEvent) {
+	event.Actor, _ = ActorFromContext(ctx)
+	event.Timestamp = time.Now()
+	s.getAuditSink().Record(ctx, event)
+}
+
 // userIDToString 将用户ID转换为字符串
 // Casbin使用string作为subject
 func userIDToString(userID int64) string {
@@ -116,6 +143,35 @@ func (s *rbacServiceImpl) CheckPermissionWithDomain(ctx context.Context, userID
 	return allowed, nil
 }
 
+// CheckPermissions 批量检查用户权限
+// 逐项调用 EnforceWithDomain，命中决策缓存时不再执行匹配；批内重复的检查只判定一次
+func (s *rbacServiceImpl) CheckPermissions(ctx context.Context, userID int64, domain string, checks []types.PermCheck) (map[string]bool, error) {
+	r := s.getRBAC()
+	if r == nil {
+		return nil, fmt.Errorf("RBAC not initialized")
+	}
+
+	user := userIDToString(userID)
+	results := make(map[string]bool, len(checks))
+	for _, c := range checks {
+		key := c.Resource + ":" + c.Action
+		if _, ok := results[key]; ok {
+			continue
+		}
+
+		allowed, err := r.EnforceWithDomain(user, domain, c.Resource, c.Action)
+		if err != nil {
+			if log := s.getLogger(); log != nil {
+				log.Error("failed to check permissions", "user_id", userID, "domain", domain, "count", len(checks), "error", err)
+			}
+			return nil, fmt.Errorf("failed to check permissions: %w", err)
+		}
+		results[key] = allowed
+	}
+
+	return results, nil
+}
+
 // ========== 角色管理 ==========
 
 // AssignRole 为用户分配角色
@@ -139,6 +195,8 @@ func (s *rbacServiceImpl) AssignRole(ctx context.Context, userID int64, role str
 		log.Info("role assigned", "user_id", userID, "role", role)
 	}
 
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpAssignRole, UserID: userID, Role: role})
+
 	return nil
 }
 
@@ -163,6 +221,8 @@ func (s *rbacServiceImpl) AssignRoleInDomain(ctx context.Context, userID int64,
 		log.Info("role assigned in domain", "user_id", userID, "role", role, "domain", domain)
 	}
 
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpAssignRole, UserID: userID, Role: role, Domain: domain})
+
 	return nil
 }
 
@@ -187,6 +247,8 @@ func (s *rbacServiceImpl) RevokeRole(ctx context.Context, userID int64, role str
 		log.Info("role revoked", "user_id", userID, "role", role)
 	}
 
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpRevokeRole, UserID: userID, Role: role})
+
 	return nil
 }
 
@@ -211,6 +273,8 @@ func (s *rbacServiceImpl) RevokeRoleInDomain(ctx context.Context, userID int64,
 		log.Info("role revoked in domain", "user_id", userID, "role", role, "domain", domain)
 	}
 
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpRevokeRole, UserID: userID, Role: role, Domain: domain})
+
 	return nil
 }
 
@@ -254,6 +318,25 @@ func (s *rbacServiceImpl) GetUserRolesInDomain(ctx context.Context, userID int64
 	return roles, nil
 }
 
+// HasRole 检查用户在指定域中是否持有角色（含继承）
+func (s *rbacServiceImpl) HasRole(ctx context.Context, userID int64, role, domain string) (bool, error) {
+	r := s.getRBAC()
+	if r == nil {
+		return false, fmt.Errorf("RBAC not initialized")
+	}
+
+	ok, err := r.HasRoleForUser(userIDToString(userID), role, domain)
+	if err != nil {
+		log := s.getLogger()
+		if log != nil {
+			log.Error("failed to check user role", "user_id", userID, "role", role, "domain", domain, "error", err)
+		}
+		return false, fmt.Errorf("failed to check user role: %w", err)
+	}
+
+	return ok, nil
+}
+
 // GetRoleUsers 获取拥有指定角色的所有用户
 func (s *rbacServiceImpl) GetRoleUsers(ctx context.Context, role string) ([]int64, error) {
 	r := s.getRBAC()
@@ -288,6 +371,95 @@ func (s *rbacServiceImpl) GetRoleUsers(ctx context.Context, role string) ([]int6
 	return userIDs, nil
 }
 
+// DeleteRole 删除角色
+// 非强制删除时由 DeleteUnassignedRole 原子地检查分配数量并删除，仍有用户持有角色就拒绝
+func (s *rbacServiceImpl) DeleteRole(ctx context.Context, role string, force bool) error {
+	r := s.getRBAC()
+	if r == nil {
+		return fmt.Errorf("RBAC not initialized")
+	}
+
+	log := s.getLogger()
+
+	var err error
+	if force {
+		err = r.DeleteRole(role)
+	} else {
+		err = r.DeleteUnassignedRole(role)
+	}
+	if errors.Is(err, ErrRoleInUse) {
+		if log != nil {
+			log.Warn("role still assigned, delete rejected", "role", role, "error", err)
+		}
+		return err
+	}
+	if err != nil {
+		if log != nil {
+			log.Error("failed to delete role", "role", role, "force", force, "error", err)
+		}
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	if log != nil {
+		log.Info("role deleted", "role", role, "force", force)
+	}
+
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpDeleteRole, Role: role, Force: force})
+
+	return nil
+}
+
+// AssignParentRole 设置角色继承
+func (s *rbacServiceImpl) AssignParentRole(ctx context.Context, childRole, parentRole string) error {
+	r := s.getRBAC()
+	if r == nil {
+		return fmt.Errorf("RBAC not initialized")
+	}
+
+	log := s.getLogger()
+
+	if err := r.AddRoleInheritance(childRole, parentRole, ""); err != nil {
+		if log != nil {
+			log.Error("failed to assign parent role", "child", childRole, "parent", parentRole, "error", err)
+		}
+		return fmt.Errorf("failed to assign parent role: %w", err)
+	}
+
+	if log != nil {
+		log.Info("parent role assigned", "child", childRole, "parent", parentRole)
+	}
+
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpAssignParentRole, Role: childRole, ParentRole: parentRole})
+
+	return nil
+}
+
+// GetUserPermissions 获取用户的所有有效权限
+// 权限从 Casbin 内存模型中解析，不额外访问数据库
+func (s *rbacServiceImpl) GetUserPermissions(ctx context.Context, userID int64) ([]types.Permission, error) {
+	return s.GetUserPermissionsInDomain(ctx, userID, "")
+}
+
+// GetUserPermissionsInDomain 获取用户在指定域中的所有有效权限
+func (s *rbacServiceImpl) GetUserPermissionsInDomain(ctx context.Context, userID int64, domain string) ([]types.Permission, error) {
+	r := s.getRBAC()
+	if r == nil {
+		return nil, fmt.Errorf("RBAC not initialized")
+	}
+
+	user := userIDToString(userID)
+	policies, err := r.GetImplicitPermissionsForUserInDomain(user, domain)
+	if err != nil {
+		log := s.getLogger()
+		if log != nil {
+			log.Error("failed to get user permissions", "user_id", userID, "domain", domain, "error", err)
+		}
+		return nil, fmt.Errorf("failed to get user permissions: %w", err)
+	}
+
+	return dedupePermissions(policies), nil
+}
+
 // ========== 策略管理 ==========
 
 // AddPolicy 添加策略
@@ -310,6 +482,12 @@ func (s *rbacServiceImpl) AddPolicy(ctx context.Context, role, resource, action
 		log.Info("policy added", "role", role, "resource", resource, "action", action)
 	}
 
+	s.recordAudit(ctx, AuditEvent{
+		Operation: AuditOpAddPolicy,
+		Role:      role,
+		Policies:  []types.RBACPolicy{{Role: role, Resource: resource, Action: action}},
+	})
+
 	return nil
 }
 
@@ -333,6 +511,13 @@ func (s *rbacServiceImpl) AddPolicyWithDomain(ctx context.Context, role, domain,
 		log.Info("policy added with domain", "role", role, "domain", domain, "resource", resource, "action", action)
 	}
 
+	s.recordAudit(ctx, AuditEvent{
+		Operation: AuditOpAddPolicy,
+		Role:      role,
+		Domain:    domain,
+		Policies:  []types.RBACPolicy{{Role: role, Domain: domain, Resource: resource, Action: action}},
+	})
+
 	return nil
 }
 
@@ -356,6 +541,12 @@ func (s *rbacServiceImpl) RemovePolicy(ctx context.Context, role, resource, acti
 		log.Info("policy removed", "role", role, "resource", resource, "action", action)
 	}
 
+	s.recordAudit(ctx, AuditEvent{
+		Operation: AuditOpRemovePolicy,
+		Role:      role,
+		Policies:  []types.RBACPolicy{{Role: role, Resource: resource, Action: action}},
+	})
+
 	return nil
 }
 
@@ -379,6 +570,13 @@ func (s *rbacServiceImpl) RemovePolicyWithDomain(ctx context.Context, role, doma
 		log.Info("policy removed with domain", "role", role, "domain", domain, "resource", resource, "action", action)
 	}
 
+	s.recordAudit(ctx, AuditEvent{
+		Operation: AuditOpRemovePolicy,
+		Role:      role,
+		Domain:    domain,
+		Policies:  []types.RBACPolicy{{Role: role, Domain: domain, Resource: resource, Action: action}},
+	})
+
 	return nil
 }
 
@@ -406,6 +604,28 @@ func (s *rbacServiceImpl) GetPoliciesByRole(ctx context.Context, role string) ([
 	return convertCasbinPoliciesToTypes(policies), nil
 }
 
+// ListPoliciesByResource 分页获取指定资源的策略
+func (s *rbacServiceImpl) ListPoliciesByResource(ctx context.Context, resource string, page, pageSize int) ([]types.RBACPolicy, int64, error) {
+	r := s.getRBAC()
+	if r == nil {
+		return nil, 0, fmt.Errorf("RBAC not initialized")
+	}
+
+	page, pageSize = NormalizePage(page, pageSize)
+
+	policies := r.GetFilteredPolicy(policyFieldResource, resource)
+	total := int64(len(policies))
+
+	// 计算当前页范围
+	start := (page - 1) * pageSize
+	if start >= len(policies) {
+		return []types.RBACPolicy{}, total, nil
+	}
+	end := min(start+pageSize, len(policies))
+
+	return convertCasbinPoliciesToTypes(policies[start:end]), total, nil
+}
+
 // ========== 批量操作 ==========
 
 // AssignRoles 批量为用户分配角色
@@ -439,7 +659,8 @@ func (s *rbacServiceImpl) AddPolicies(ctx context.Context, policies []types.RBAC
 		}
 	}
 
-	if err := r.AddPolicies(rules); err != nil {
+	added, err := r.AddPolicies(rules)
+	if err != nil {
 		if log != nil {
 			log.Error("failed to add policies", "count", len(policies), "error", err)
 		}
@@ -447,7 +668,56 @@ func (s *rbacServiceImpl) AddPolicies(ctx context.Context, policies []types.RBAC
 	}
 
 	if log != nil {
-		log.Info("policies added", "count", len(policies))
+		log.Info("policies added", "requested", len(policies), "added", len(added))
+	}
+
+	// 全部已存在时没有发生变更，不产生审计事件
+	if len(added) > 0 {
+		s.recordAudit(ctx, AuditEvent{Operation: AuditOpAddPolicy, Policies: convertCasbinPoliciesToTypes(added)})
+	}
+
+	return nil
+}
+
+// AssignPermissions 批量为角色分配权限
+// 通过 AddPolicies 一次性写入，角色已有的权限会被跳过，只写入新增的权限；
+// 去重与写入在单个 Gorm Adapter 事务中完成，失败或被并发修改打断时整体回滚并返回错误
+func (s *rbacServiceImpl) AssignPermissions(ctx context.Context, role, domain string, permissions []types.Permission) error {
+	r := s.getRBAC()
+	if r == nil {
+		return fmt.Errorf("RBAC not initialized")
+	}
+
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	log := s.getLogger()
+
+	rules := make([][]string, 0, len(permissions))
+	for _, p := range permissions {
+		if domain != "" {
+			rules = append(rules, []string{role, domain, p.Resource, p.Action})
+		} else {
+			rules = append(rules, []string{role, p.Resource, p.Action})
+		}
+	}
+
+	added, err := r.AddPolicies(rules)
+	if err != nil {
+		if log != nil {
+			log.Error("failed to assign permissions", "role", role, "domain", domain, "count", len(permissions), "error", err)
+		}
+		return fmt.Errorf("failed to assign permissions: %w", err)
+	}
+
+	if log != nil {
+		log.Info("permissions assigned", "role", role, "domain", domain, "requested", len(permissions), "added", len(added))
+	}
+
+	// 角色已拥有全部权限时没有发生变更，不产生审计事件
+	if len(added) > 0 {
+		s.recordAudit(ctx, AuditEvent{Operation: AuditOpAssignPermissions, Role: role, Domain: domain, Policies: convertCasbinPoliciesToTypes(added)})
 	}
 
 	return nil
@@ -455,6 +725,45 @@ func (s *rbacServiceImpl) AddPolicies(ctx context.Context, policies []types.RBAC
 
 // ========== 辅助函数 ==========
 
+// NormalizePage 规范化分页参数
+// page 小于 1 时取 1，pageSize 非法时取默认值，超过上限时截断为 MaxPageSize
+func NormalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return page, pageSize
+}
+
+// dedupePermissions 将Casbin策略转换为去重后的权限列表
+// 不同角色授予的相同 resource:action 只保留一份，顺序与首次出现一致
+func dedupePermissions(casbinPolicies [][]string) []types.Permission {
+	seen := make(map[string]struct{}, len(casbinPolicies))
+	permissions := make([]types.Permission, 0, len(casbinPolicies))
+	for _, p := range casbinPolicies {
+		if len(p) < 2 {
+			continue
+		}
+		// 最后两个字段固定为 [resource, action]
+		resource, action := p[len(p)-2], p[len(p)-1]
+		key := resource + ":" + action
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		permissions = append(permissions, types.Permission{
+			Resource: resource,
+			Action:   action,
+		})
+	}
+	return permissions
+}
+
 // convertCasbinPoliciesToTypes 将Casbin策略格式转换为types.RBACPolicy
 func convertCasbinPoliciesToTypes(casbinPolicies [][]string) []types.RBACPolicy {
 	policies := make([]types.RBACPolicy, 0, len(casbinPolicies))
@@ -0,0 +1,631 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rei0721/go-scaffold/pkg/rbac"
+	"github.com/rei0721/go-scaffold/types"
+)
+
+// fakeRBAC 测试用的内存RBAC实现
+// 策略统一存储为 [sub, dom, obj, act]，与内置模型保持一致
+type fakeRBAC struct {
+	policies [][]string
+	roles    map[string][]string // user -> roles
+
+	// addPoliciesCalls 记录 AddPolicies 调用次数
+	addPoliciesCalls int
+}
+
+func newFakeRBAC() *fakeRBAC {
+	return &fakeRBAC{roles: make(map[string][]string)}
+}
+
+func (f *fakeRBAC) Enforce(sub, obj, act string) (bool, error) {
+	return f.EnforceWithDomain(sub, "", obj, act)
+}
+
+func (f *fakeRBAC) EnforceWithDomain(sub, dom, obj, act string) (bool, error) {
+	for _, s := range f.implicitSubjects(sub) {
+		for _, p := range f.policies {
+			// 与内置模型一致，"*" 匹配任意资源或操作
+			if p[0] == s && p[1] == dom && (p[2] == obj || p[2] == "*") && (p[3] == act || p[3] == "*") {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeRBAC) AddRoleForUser(user, role string) error {
+	f.roles[user] = append(f.roles[user], role)
+	return nil
+}
+
+func (f *fakeRBAC) AddRoleForUserInDomain(user, role, domain string) error {
+	return f.AddRoleForUser(user, role)
+}
+
+func (f *fakeRBAC) DeleteRoleForUser(user, role string) error {
+	roles := f.roles[user][:0]
+	for _, r := range f.roles[user] {
+		if r != role {
+			roles = append(roles, r)
+		}
+	}
+	f.roles[user] = roles
+	return nil
+}
+
+func (f *fakeRBAC) DeleteRoleForUserInDomain(user, role, domain string) error {
+	return f.DeleteRoleForUser(user, role)
+}
+
+func (f *fakeRBAC) GetRolesForUser(user string) ([]string, error) {
+	return f.roles[user], nil
+}
+
+func (f *fakeRBAC) GetRolesForUserInDomain(user, domain string) ([]string, error) {
+	return f.roles[user], nil
+}
+
+// HasRoleForUser 不区分域,包含继承角色
+func (f *fakeRBAC) HasRoleForUser(user, role, domain string) (bool, error) {
+	return slices.Contains(f.implicitSubjects(user)[1:], role), nil
+}
+
+func (f *fakeRBAC) GetUsersForRole(role string) ([]string, error) {
+	var users []string
+	for user, roles := range f.roles {
+		for _, r := range roles {
+			if r == role {
+				users = append(users, user)
+			}
+		}
+	}
+	return users, nil
+}
+
+// CountUsersForRole 跳过持有策略或被其他主体持有的角色
+func (f *fakeRBAC) CountUsersForRole(role string) (int, error) {
+	users, _ := f.GetUsersForRole(role)
+	count := 0
+	for _, user := range users {
+		held, _ := f.GetUsersForRole(user)
+		if len(held) == 0 && len(f.GetFilteredPolicy(0, user)) == 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeRBAC) DeleteUnassignedRole(role string) error {
+	count, _ := f.CountUsersForRole(role)
+	if count > 0 {
+		return fmt.Errorf("%w: role %s is assigned to %d users", rbac.ErrRoleInUse, role, count)
+	}
+	return f.DeleteRole(role)
+}
+
+// DeleteRole 删除角色的分配、继承和策略
+func (f *fakeRBAC) DeleteRole(role string) error {
+	for user := range f.roles {
+		_ = f.DeleteRoleForUser(user, role)
+	}
+	delete(f.roles, role)
+
+	policies := f.policies[:0]
+	for _, p := range f.policies {
+		if p[0] != role {
+			policies = append(policies, p)
+		}
+	}
+	f.policies = policies
+	return nil
+}
+
+func (f *fakeRBAC) GetImplicitPermissionsForUser(user string) ([][]string, error) {
+	return f.GetImplicitPermissionsForUserInDomain(user, "")
+}
+
+func (f *fakeRBAC) GetImplicitPermissionsForUserInDomain(user, domain string) ([][]string, error) {
+	var result [][]string
+	for _, s := range f.implicitSubjects(user) {
+		for _, p := range f.policies {
+			if p[0] == s && p[1] == domain {
+				result = append(result, p)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRBAC) AddRoleInheritance(child, parent, domain string) error {
+	for _, s := range f.implicitSubjects(parent) {
+		if s == child {
+			return rbac.ErrRoleCycle
+		}
+	}
+	return f.AddRoleForUser(child, parent)
+}
+
+func (f *fakeRBAC) DeleteRoleInheritance(child, parent, domain string) error {
+	return f.DeleteRoleForUser(child, parent)
+}
+
+func (f *fakeRBAC) AddPolicy(sub, obj, act string) error {
+	return f.AddPolicyWithDomain(sub, "", obj, act)
+}
+
+func (f *fakeRBAC) AddPolicyWithDomain(sub, domain, obj, act string) error {
+	f.policies = append(f.policies, []string{sub, domain, obj, act})
+	return nil
+}
+
+func (f *fakeRBAC) RemovePolicy(sub, obj, act string) error {
+	return f.RemovePolicyWithDomain(sub, "", obj, act)
+}
+
+func (f *fakeRBAC) RemovePolicyWithDomain(sub, domain, obj, act string) error {
+	policies := f.policies[:0]
+	for _, p := range f.policies {
+		if !(p[0] == sub && p[1] == domain && p[2] == obj && p[3] == act) {
+			policies = append(policies, p)
+		}
+	}
+	f.policies = policies
+	return nil
+}
+
+func (f *fakeRBAC) GetPolicy() [][]string {
+	return f.policies
+}
+
+func (f *fakeRBAC) GetFilteredPolicy(fieldIndex int, fieldValues ...string) [][]string {
+	var result [][]string
+	for _, p := range f.policies {
+		match := true
+		for i, v := range fieldValues {
+			if v != "" && p[fieldIndex+i] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// AddPolicies 跳过已存在的条目，只返回实际写入的策略
+func (f *fakeRBAC) AddPolicies(rules [][]string) ([][]string, error) {
+	f.addPoliciesCalls++
+	var added [][]string
+	for _, rule := range rules {
+		if len(rule) == 3 {
+			rule = []string{rule[0], "", rule[1], rule[2]}
+		}
+		if len(f.GetFilteredPolicy(0, rule...)) == 0 {
+			f.policies = append(f.policies, rule)
+			added = append(added, rule)
+		}
+	}
+	return added, nil
+}
+
+func (f *fakeRBAC) RemovePolicies(rules [][]string) error {
+	for _, rule := range rules {
+		if len(rule) == 3 {
+			rule = []string{rule[0], "", rule[1], rule[2]}
+		}
+		_ = f.RemovePolicyWithDomain(rule[0], rule[1], rule[2], rule[3])
+	}
+	return nil
+}
+
+func (f *fakeRBAC) GetGroupingPolicy() [][]string {
+	var result [][]string
+	for user, roles := range f.roles {
+		for _, role := range roles {
+			result = append(result, []string{user, role, ""})
+		}
+	}
+	return result
+}
+
+// ImportPolicies 跳过已存在的条目，模拟 AddPoliciesEx 的行为
+func (f *fakeRBAC) ImportPolicies(policies, groupingPolicies [][]string) error {
+	for _, rule := range policies {
+		if len(rule) == 3 {
+			rule = []string{rule[0], "", rule[1], rule[2]}
+		}
+		if len(f.GetFilteredPolicy(0, rule...)) == 0 {
+			f.policies = append(f.policies, rule)
+		}
+	}
+	for _, rule := range groupingPolicies {
+		exists := false
+		for _, role := range f.roles[rule[0]] {
+			exists = exists || role == rule[1]
+		}
+		if !exists {
+			f.roles[rule[0]] = append(f.roles[rule[0]], rule[1])
+		}
+	}
+	return nil
+}
+
+func (f *fakeRBAC) LoadPolicy() error           { return nil }
+func (f *fakeRBAC) SavePolicy() error           { return nil }
+func (f *fakeRBAC) ClearCache() error           { return nil }
+func (f *fakeRBAC) CacheStats() rbac.CacheStats { return rbac.CacheStats{} }
+func (f *fakeRBAC) Close() error                { return nil }
+
+// implicitSubjects 返回主体自身及其直接/间接持有的所有角色
+func (f *fakeRBAC) implicitSubjects(sub string) []string {
+	seen := map[string]bool{sub: true}
+	queue := []string{sub}
+	for i := 0; i < len(queue); i++ {
+		for _, r := range f.roles[queue[i]] {
+			if !seen[r] {
+				seen[r] = true
+				queue = append(queue, r)
+			}
+		}
+	}
+	return queue
+}
+
+// newTestService 创建注入了 fakeRBAC 的服务
+func newTestService(f *fakeRBAC) RBACService {
+	svc := NewRBACService()
+	svc.SetRBAC(f)
+	return svc
+}
+
+// TestAssignPermissions_Success 测试批量分配权限成功
+func TestAssignPermissions_Success(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	perms := []types.Permission{
+		{Resource: "posts", Action: "read"},
+		{Resource: "posts", Action: "write"},
+		{Resource: "comments", Action: "delete"},
+	}
+
+	if err := svc.AssignPermissions(ctx, "editor", "", perms); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.addPoliciesCalls != 1 {
+		t.Fatalf("expected 1 batch call, got %d", f.addPoliciesCalls)
+	}
+
+	policies, err := svc.GetPoliciesByRole(ctx, "editor")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policies) != len(perms) {
+		t.Fatalf("expected %d policies, got %d", len(perms), len(policies))
+	}
+}
+
+// TestGetUserPermissions_Dedup 测试跨角色权限合并去重
+func TestGetUserPermissions_Dedup(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "read")
+	_ = f.AddPolicy("editor", "posts", "write")
+	_ = f.AddPolicy("viewer", "posts", "read")
+	_ = f.AddRoleForUser("1", "editor")
+	_ = f.AddRoleForUser("1", "viewer")
+
+	perms, err := svc.GetUserPermissions(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []types.Permission{
+		{Resource: "posts", Action: "read"},
+		{Resource: "posts", Action: "write"},
+	}
+	if len(perms) != len(want) {
+		t.Fatalf("expected %d permissions, got %d: %v", len(want), len(perms), perms)
+	}
+	for i := range want {
+		if perms[i] != want[i] {
+			t.Fatalf("permission[%d] = %v, want %v", i, perms[i], want[i])
+		}
+	}
+}
+
+// TestAssignParentRole_RejectsCycle 测试角色继承环被拒绝
+func TestAssignParentRole_RejectsCycle(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	if err := svc.AssignParentRole(ctx, "admin", "editor"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := svc.AssignParentRole(ctx, "editor", "admin")
+	if !errors.Is(err, rbac.ErrRoleCycle) {
+		t.Fatalf("expected ErrRoleCycle, got %v", err)
+	}
+}
+
+// TestDeleteRole_BlockedWhenAssigned 测试角色仍有分配时拒绝删除且不修改任何数据
+func TestDeleteRole_BlockedWhenAssigned(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "write")
+	_ = svc.AssignRole(ctx, 1, "editor")
+	_ = svc.AssignRole(ctx, 2, "editor")
+
+	err := svc.DeleteRole(ctx, "editor", false)
+	if !errors.Is(err, ErrRoleInUse) {
+		t.Fatalf("expected ErrRoleInUse, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2 users") {
+		t.Fatalf("expected affected count in error, got %v", err)
+	}
+
+	users, _ := svc.GetRoleUsers(ctx, "editor")
+	if len(users) != 2 {
+		t.Fatalf("expected assignments to be kept, got %v", users)
+	}
+	if len(f.GetFilteredPolicy(0, "editor")) != 1 {
+		t.Fatal("expected policies to be kept")
+	}
+}
+
+// TestDeleteRole_InheritedOnly 测试只被子角色继承的角色可以直接删除
+func TestDeleteRole_InheritedOnly(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "write")
+	_ = f.AddPolicy("viewer", "posts", "read")
+	_ = svc.AssignParentRole(ctx, "editor", "viewer")
+	_ = svc.AssignRole(ctx, 1, "editor")
+
+	if err := svc.DeleteRole(ctx, "viewer", false); err != nil {
+		t.Fatalf("expected role inherited only by other roles to be deletable, got %v", err)
+	}
+	if len(f.roles["editor"]) != 0 {
+		t.Fatalf("expected inheritance of deleted role to be removed, got %v", f.roles["editor"])
+	}
+
+	// editor 仍由用户 1 持有
+	if err := svc.DeleteRole(ctx, "editor", false); !errors.Is(err, ErrRoleInUse) {
+		t.Fatalf("expected ErrRoleInUse, got %v", err)
+	}
+}
+
+// TestDeleteRole_ForceCascades 测试强制删除级联移除角色的分配、继承和策略
+func TestDeleteRole_ForceCascades(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	sink := &fakeAuditSink{}
+	svc.SetAuditSink(sink)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "write")
+	_ = f.AddPolicy("viewer", "posts", "read")
+	_ = svc.AssignParentRole(ctx, "editor", "viewer")
+	_ = svc.AssignRole(ctx, 1, "editor")
+	_ = svc.AssignRole(ctx, 1, "author")
+
+	if err := svc.DeleteRole(ctx, "editor", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if users, _ := svc.GetRoleUsers(ctx, "editor"); len(users) != 0 {
+		t.Fatalf("expected no users for deleted role, got %v", users)
+	}
+	if roles, _ := svc.GetUserRoles(ctx, 1); len(roles) != 1 || roles[0] != "author" {
+		t.Fatalf("expected other roles to be kept, got %v", roles)
+	}
+	if len(f.GetFilteredPolicy(0, "editor")) != 0 {
+		t.Fatal("expected policies of deleted role to be removed")
+	}
+	if len(f.roles["editor"]) != 0 {
+		t.Fatalf("expected inheritance of deleted role to be removed, got %v", f.roles["editor"])
+	}
+	if len(f.GetFilteredPolicy(0, "viewer")) != 1 {
+		t.Fatal("expected policies of parent role to be kept")
+	}
+
+	last := sink.events[len(sink.events)-1]
+	if last.Operation != AuditOpDeleteRole || last.Role != "editor" || !last.Force {
+		t.Fatalf("unexpected audit event: %+v", last)
+	}
+}
+
+// TestListPoliciesByResource 测试按资源过滤分页
+func TestListPoliciesByResource(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "read")
+	_ = f.AddPolicy("editor", "posts", "write")
+	_ = f.AddPolicy("admin", "posts", "delete")
+	_ = f.AddPolicy("admin", "users", "delete")
+
+	list, total, err := svc.ListPoliciesByResource(ctx, "posts", 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("expected total 3, got %d", total)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 policies on page 1, got %d", len(list))
+	}
+	for _, p := range list {
+		if p.Resource != "posts" {
+			t.Fatalf("unexpected resource %q", p.Resource)
+		}
+	}
+
+	list, total, err = svc.ListPoliciesByResource(ctx, "posts", 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 || len(list) != 1 {
+		t.Fatalf("expected 1 policy on page 2 with total 3, got %d/%d", len(list), total)
+	}
+
+	list, total, err = svc.ListPoliciesByResource(ctx, "posts", 3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 || len(list) != 0 {
+		t.Fatalf("expected empty page 3 with total 3, got %d/%d", len(list), total)
+	}
+}
+
+// fakeAuditSink 记录收到的审计事件
+type fakeAuditSink struct {
+	events []AuditEvent
+}
+
+func (f *fakeAuditSink) Record(ctx context.Context, event AuditEvent) {
+	f.events = append(f.events, event)
+}
+
+// TestAssignRole_EmitsAuditEvent 测试分配角色产生一条审计事件
+func TestAssignRole_EmitsAuditEvent(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	sink := &fakeAuditSink{}
+	svc.SetAuditSink(sink)
+
+	ctx := WithActor(context.Background(), 99)
+	if err := svc.AssignRole(ctx, 1, "editor"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(sink.events))
+	}
+	e := sink.events[0]
+	if e.Actor != 99 || e.Operation != AuditOpAssignRole || e.UserID != 1 || e.Role != "editor" {
+		t.Fatalf("unexpected audit event: %+v", e)
+	}
+	if e.Timestamp.IsZero() {
+		t.Fatal("expected timestamp to be set")
+	}
+}
+
+// TestAssignPermissions_AuditsInsertedOnly 测试审计事件只包含实际写入的权限
+func TestAssignPermissions_AuditsInsertedOnly(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	sink := &fakeAuditSink{}
+	svc.SetAuditSink(sink)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "read")
+
+	perms := []types.Permission{
+		{Resource: "posts", Action: "read"},
+		{Resource: "posts", Action: "write"},
+	}
+	if err := svc.AssignPermissions(ctx, "editor", "", perms); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sink.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(sink.events))
+	}
+	want := []types.RBACPolicy{{Role: "editor", Resource: "posts", Action: "write"}}
+	if got := sink.events[0].Policies; !slices.Equal(got, want) {
+		t.Fatalf("expected audited policies %v, got %v", want, got)
+	}
+
+	// 再次分配时没有新增权限，不产生审计事件
+	if err := svc.AssignPermissions(ctx, "editor", "", perms); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sink.events) != 1 {
+		t.Fatalf("expected no audit event for a no-op, got %d events", len(sink.events))
+	}
+}
+
+// TestCheckPermissions_Batch 测试批量检查返回正确的结果映射
+func TestCheckPermissions_Batch(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	_ = f.AddPolicy("editor", "posts", "read")
+	_ = f.AddPolicy("editor", "comments", "*")
+	_ = f.AddRoleForUser("1", "editor")
+
+	results, err := svc.CheckPermissions(ctx, 1, "", []types.PermCheck{
+		{Resource: "posts", Action: "read"},
+		{Resource: "comments", Action: "delete"},
+		{Resource: "users", Action: "delete"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]bool{
+		"posts:read":      true,
+		"comments:delete": true,
+		"users:delete":    false,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(results))
+	}
+	for k, v := range want {
+		if results[k] != v {
+			t.Fatalf("results[%s] = %v, want %v", k, results[k], v)
+		}
+	}
+}
+
+// TestCheckPermissions_Domain 测试批量检查只匹配指定域的策略
+func TestCheckPermissions_Domain(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+	ctx := context.Background()
+
+	_ = f.AddPolicyWithDomain("editor", "tenantA", "posts", "write")
+	_ = f.AddRoleForUserInDomain("1", "editor", "tenantA")
+
+	checks := []types.PermCheck{{Resource: "posts", Action: "write"}}
+
+	results, err := svc.CheckPermissions(ctx, 1, "tenantA", checks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !results["posts:write"] {
+		t.Fatal("expected permission in tenantA")
+	}
+
+	results, err = svc.CheckPermissions(ctx, 1, "", checks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results["posts:write"] {
+		t.Fatal("expected no permission outside tenantA")
+	}
+}
@@ -0,0 +1,198 @@
+package rbac
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rei0721/go-scaffold/types"
+)
+
+// ErrUnsupportedPolicyFormat 不支持的导出/导入格式
+var ErrUnsupportedPolicyFormat = errors.New("unsupported policy format")
+
+// CSV 行类型，与 Casbin 策略文件的 ptype 一致
+const (
+	csvPolicyType   = "p"
+	csvGroupingType = "g"
+)
+
+// ExportPolicies 导出全部策略和角色分配
+func (s *rbacServiceImpl) ExportPolicies(ctx context.Context, format string) ([]byte, error) {
+	r := s.getRBAC()
+	if r == nil {
+		return nil, fmt.Errorf("RBAC not initialized")
+	}
+
+	snapshot := types.PolicySnapshot{
+		Policies:    convertCasbinPoliciesToTypes(r.GetPolicy()),
+		Assignments: convertGroupingPoliciesToTypes(r.GetGroupingPolicy()),
+	}
+
+	switch format {
+	case PolicyFormatJSON:
+		return json.MarshalIndent(snapshot, "", "  ")
+	case PolicyFormatCSV:
+		return encodePolicyCSV(snapshot)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPolicyFormat, format)
+	}
+}
+
+// ImportPolicies 导入策略和角色分配
+func (s *rbacServiceImpl) ImportPolicies(ctx context.Context, format string, data []byte) error {
+	r := s.getRBAC()
+	if r == nil {
+		return fmt.Errorf("RBAC not initialized")
+	}
+
+	var (
+		snapshot types.PolicySnapshot
+		err      error
+	)
+	switch format {
+	case PolicyFormatJSON:
+		err = json.Unmarshal(data, &snapshot)
+	case PolicyFormatCSV:
+		snapshot, err = decodePolicyCSV(data)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedPolicyFormat, format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse policies: %w", err)
+	}
+
+	log := s.getLogger()
+
+	rules := make([][]string, 0, len(snapshot.Policies))
+	for _, p := range snapshot.Policies {
+		if p.Role == "" || p.Resource == "" || p.Action == "" {
+			return fmt.Errorf("failed to parse policies: incomplete policy %+v", p)
+		}
+		rules = append(rules, []string{p.Role, p.Domain, p.Resource, p.Action})
+	}
+
+	grouping := make([][]string, 0, len(snapshot.Assignments))
+	for _, a := range snapshot.Assignments {
+		if a.Subject == "" || a.Role == "" {
+			return fmt.Errorf("failed to parse policies: incomplete assignment %+v", a)
+		}
+		grouping = append(grouping, []string{a.Subject, a.Role, a.Domain})
+	}
+
+	if err := r.ImportPolicies(dedupeRules(rules), dedupeRules(grouping)); err != nil {
+		if log != nil {
+			log.Error("failed to import policies", "policies", len(rules), "assignments", len(grouping), "error", err)
+		}
+		return fmt.Errorf("failed to import policies: %w", err)
+	}
+
+	if log != nil {
+		log.Info("policies imported", "policies", len(rules), "assignments", len(grouping))
+	}
+
+	s.recordAudit(ctx, AuditEvent{Operation: AuditOpImportPolicies, Policies: snapshot.Policies})
+
+	return nil
+}
+
+// encodePolicyCSV 将快照编码为 Casbin 策略文件格式
+func encodePolicyCSV(snapshot types.PolicySnapshot) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	for _, p := range snapshot.Policies {
+		if err := w.Write([]string{csvPolicyType, p.Role, p.Domain, p.Resource, p.Action}); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range snapshot.Assignments {
+		if err := w.Write([]string{csvGroupingType, a.Subject, a.Role, a.Domain}); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodePolicyCSV 解析 Casbin 策略文件格式
+// 支持 "#" 注释行和逗号后的空格；p 行的域可省略（4 列），g 行的域可省略（3 列）
+func decodePolicyCSV(data []byte) (types.PolicySnapshot, error) {
+	var snapshot types.PolicySnapshot
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return snapshot, err
+	}
+
+	for i, record := range records {
+		for j := range record {
+			record[j] = strings.TrimSpace(record[j])
+		}
+
+		switch {
+		case record[0] == csvPolicyType && len(record) == 5:
+			snapshot.Policies = append(snapshot.Policies, types.RBACPolicy{
+				Role: record[1], Domain: record[2], Resource: record[3], Action: record[4],
+			})
+		case record[0] == csvPolicyType && len(record) == 4:
+			snapshot.Policies = append(snapshot.Policies, types.RBACPolicy{
+				Role: record[1], Resource: record[2], Action: record[3],
+			})
+		case record[0] == csvGroupingType && (len(record) == 3 || len(record) == 4):
+			assignment := types.RoleAssignment{Subject: record[1], Role: record[2]}
+			if len(record) == 4 {
+				assignment.Domain = record[3]
+			}
+			snapshot.Assignments = append(snapshot.Assignments, assignment)
+		default:
+			return snapshot, fmt.Errorf("line %d: invalid record %q", i+1, strings.Join(record, ", "))
+		}
+	}
+
+	return snapshot, nil
+}
+
+// dedupeRules 去除重复的规则，保持首次出现的顺序
+func dedupeRules(rules [][]string) [][]string {
+	seen := make(map[string]struct{}, len(rules))
+	result := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		key := strings.Join(rule, "\x00")
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, rule)
+	}
+	return result
+}
+
+// convertGroupingPoliciesToTypes 将Casbin角色分配 [subject, role, domain] 转换为types.RoleAssignment
+func convertGroupingPoliciesToTypes(rules [][]string) []types.RoleAssignment {
+	assignments := make([]types.RoleAssignment, 0, len(rules))
+	for _, g := range rules {
+		if len(g) < 2 {
+			continue
+		}
+		assignment := types.RoleAssignment{Subject: g[0], Role: g[1]}
+		if len(g) > 2 {
+			assignment.Domain = g[2]
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
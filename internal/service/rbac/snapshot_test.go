@@ -0,0 +1,85 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// seedSnapshotRBAC 创建包含少量策略和角色分配的 fakeRBAC
+func seedSnapshotRBAC() *fakeRBAC {
+	f := newFakeRBAC()
+	_ = f.AddPolicy("editor", "posts", "write")
+	_ = f.AddPolicyWithDomain("admin", "tenantA", "users", "delete")
+	_ = f.AddRoleForUser("admin", "editor")
+	_ = f.AddRoleForUser("1", "admin")
+	return f
+}
+
+// TestExportImportPolicies_RoundTrip 测试 JSON 和 CSV 导出后导入到新环境内容一致
+func TestExportImportPolicies_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	for _, format := range []string{PolicyFormatJSON, PolicyFormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			data, err := newTestService(seedSnapshotRBAC()).ExportPolicies(ctx, format)
+			if err != nil {
+				t.Fatalf("ExportPolicies() failed: %v", err)
+			}
+
+			target := newFakeRBAC()
+			svc := newTestService(target)
+			if err := svc.ImportPolicies(ctx, format, data); err != nil {
+				t.Fatalf("ImportPolicies() failed: %v\n%s", err, data)
+			}
+
+			if len(target.policies) != 2 {
+				t.Fatalf("expected 2 policies, got %v", target.policies)
+			}
+			if ok, _ := target.EnforceWithDomain("1", "tenantA", "users", "delete"); !ok {
+				t.Fatal("expected user 1 to hold admin permissions after import")
+			}
+			if ok, _ := target.Enforce("1", "posts", "write"); !ok {
+				t.Fatal("expected inherited editor permission after import")
+			}
+
+			// 重复导入不产生重复条目
+			if err := svc.ImportPolicies(ctx, format, data); err != nil {
+				t.Fatalf("second ImportPolicies() failed: %v", err)
+			}
+			if len(target.policies) != 2 || len(target.GetGroupingPolicy()) != 2 {
+				t.Fatalf("re-import duplicated entries: %v / %v", target.policies, target.GetGroupingPolicy())
+			}
+		})
+	}
+}
+
+// TestImportPolicies_CasbinCSV 测试导入手写的 Casbin 策略文件
+func TestImportPolicies_CasbinCSV(t *testing.T) {
+	f := newFakeRBAC()
+	svc := newTestService(f)
+
+	data := []byte("# seed\np, viewer, posts, read\ng, 2, viewer\n")
+	if err := svc.ImportPolicies(context.Background(), PolicyFormatCSV, data); err != nil {
+		t.Fatalf("ImportPolicies() failed: %v", err)
+	}
+	if ok, _ := f.Enforce("2", "posts", "read"); !ok {
+		t.Fatal("expected user 2 to be allowed after import")
+	}
+}
+
+// TestImportPolicies_Invalid 测试格式错误和不支持的格式
+func TestImportPolicies_Invalid(t *testing.T) {
+	svc := newTestService(newFakeRBAC())
+	ctx := context.Background()
+
+	if err := svc.ImportPolicies(ctx, "yaml", nil); !errors.Is(err, ErrUnsupportedPolicyFormat) {
+		t.Fatalf("expected ErrUnsupportedPolicyFormat, got %v", err)
+	}
+	if err := svc.ImportPolicies(ctx, PolicyFormatCSV, []byte("x, a, b\n")); err == nil {
+		t.Fatal("expected error for unknown record type")
+	}
+	if err := svc.ImportPolicies(ctx, PolicyFormatJSON, []byte(`{"policies":[{"role":"a"}]}`)); err == nil {
+		t.Fatal("expected error for incomplete policy")
+	}
+}
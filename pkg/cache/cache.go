@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// Loader 缓存未命中时的回源函数
+type Loader func(ctx context.Context) (string, error)
+
 // Cache 定义缓存操作的接口
 // 提供统一的缓存访问API,隔离具体实现(Redis/Memcached等)
 //
@@ -32,10 +35,10 @@ type Cache interface {
 	//   key: 缓存键名
 	// 返回:
 	//   string: 键对应的值
-	//   error: 如果键不存在,返回 ErrKeyNotFound;其他错误返回具体错误信息
+	//   error: 如果键不存在,返回包装了 ErrKeyNotFound 的错误;其他错误返回具体错误信息
 	// 使用示例:
 	//   value, err := cache.Get(ctx, "user:123")
-	//   if err == cache.ErrKeyNotFound {
+	//   if errors.Is(err, cache.ErrKeyNotFound) {
 	//       // 键不存在,从数据库加载
 	//   }
 	Get(ctx context.Context, key string) (string, error)
@@ -55,6 +58,31 @@ type Cache interface {
 	//   err := cache.Set(ctx, "user:123", user, 1*time.Hour)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 
+	// GetOrSet 获取键的值,未命中时调用 loader 回源并写入缓存(Cache-Aside)
+	// 参数:
+	//   ctx: 上下文,同时传给 loader
+	//   key: 缓存键名
+	//   expiration: 回源结果的过期时间
+	//   loader: 回源函数,如从数据库加载并序列化
+	// 返回:
+	//   string: 缓存值或 loader 的结果
+	//   error: loader 返回的错误
+	// 注意:
+	//   - 同一键的并发未命中只会执行一次 loader,其余调用方共享结果,防止缓存击穿
+	//   - 共享执行时 loader 使用的是第一个调用方的 ctx
+	//   - 缓存读写失败不会返回错误,而是降级为直接调用 loader
+	//   - loader 返回错误时不写入缓存
+	// 使用示例:
+	//   data, err := cache.GetOrSet(ctx, "user:123", time.Hour, func(ctx context.Context) (string, error) {
+	//       user, err := repo.FindByID(ctx, 123)
+	//       if err != nil {
+	//           return "", err
+	//       }
+	//       b, err := json.Marshal(user)
+	//       return string(b), err
+	//   })
+	GetOrSet(ctx context.Context, key string, expiration time.Duration, loader Loader) (string, error)
+
 	// Delete 删除一个或多个键
 	// 参数:
 	//   ctx: 上下文
@@ -68,6 +96,23 @@ type Cache interface {
 	//   err := cache.Delete(ctx, "user:123", "user:456")
 	Delete(ctx context.Context, keys ...string) error
 
+	// DeleteByPattern 删除匹配 glob 模式的所有键
+	// 参数:
+	//   ctx: 上下文
+	//   pattern: glob 模式,如 "user:perms:*"
+	// 返回:
+	//   int: 实际删除的键数量
+	//   error: 扫描或删除失败时的错误,此时返回值为出错前已删除的数量
+	// 注意:
+	//   - 使用 SCAN 增量遍历,不使用 KEYS,不会长时间阻塞 Redis
+	//   - 非原子操作:遍历期间新写入的匹配键可能不会被删除
+	//   - 批量删除已知键请直接使用 Delete(ctx, keys...)
+	// 使用场景:
+	//   - 角色权限变更后批量清除用户权限缓存
+	// 使用示例:
+	//   n, err := cache.DeleteByPattern(ctx, "user:perms:*")
+	DeleteByPattern(ctx context.Context, pattern string) (int, error)
+
 	// Exists 检查键是否存在
 	// 参数:
 	//   ctx: 上下文
@@ -114,7 +159,9 @@ type Cache interface {
 	//   key: 键名
 	//   expiration: 过期时间
 	// 返回:
-	//   error: 设置失败时的错误,如果键不存在也返回错误
+	//   error: 设置失败时的错误,如果键不存在返回包装了 ErrKeyNotFound 的错误
+	// 注意:
+	//   - 与 Incr 配合实现固定窗口计数:首次 Incr 返回 1 时调用 Expire 设置窗口
 	// 使用场景:
 	//   - 延长缓存的有效期
 	//   - 设置动态过期时间
@@ -147,7 +194,7 @@ type Cache interface {
 	//   error: 操作失败时的错误
 	// 注意:
 	//   - 如果键不存在,会被初始化为 0 再加 1
-	//   - 如果键的值不是整数,会返回错误
+	//   - 如果键的值不是整数,返回包装了 ErrNotInteger 的错误,键的值保持不变
 	//   - 这是原子操作,线程安全
 	// 使用场景:
 	//   - 页面访问计数
@@ -163,7 +210,7 @@ type Cache interface {
 	//   key: 键名
 	// 返回:
 	//   int64: 减少后的值
-	//   error: 操作失败时的错误
+	//   error: 操作失败时的错误,键的值不是整数时包装 ErrNotInteger
 	// 使用场景:
 	//   - 库存扣减
 	//   - 剩余次数
@@ -178,11 +225,46 @@ type Cache interface {
 	//   value: 要增加的数量,可以是负数(相当于减少)
 	// 返回:
 	//   int64: 增加后的值
-	//   error: 操作失败时的错误
+	//   error: 操作失败时的错误,键的值不是整数时包装 ErrNotInteger
 	// 使用示例:
 	//   count, err := cache.IncrBy(ctx, "points:user123", 10)
 	IncrBy(ctx context.Context, key string, value int64) (int64, error)
 
+	// Publish 向频道发布消息
+	// 参数:
+	//   ctx: 上下文
+	//   channel: 频道名
+	//   message: 消息内容
+	// 返回:
+	//   error: 发布失败时的错误
+	// 注意:
+	//   - 只投递给发布时已订阅的实例,消息不会持久化
+	//   - 内存缓存为空操作,直接返回 nil
+	// 使用场景:
+	//   - 多实例部署时广播本地缓存失效通知
+	// 使用示例:
+	//   err := cache.Publish(ctx, "rbac:invalidate", "user:123")
+	Publish(ctx context.Context, channel, message string) error
+
+	// Subscribe 订阅频道,在后台为每条消息调用 handler
+	// 参数:
+	//   ctx: 上下文,取消后结束订阅
+	//   channel: 频道名
+	//   handler: 消息处理函数,在同一个后台协程中依次调用
+	// 返回:
+	//   error: 订阅失败或 handler 为 nil 时的错误
+	// 注意:
+	//   - 返回 nil 时订阅已确认,之后发布的消息都会送达
+	//   - handler 应尽快返回,耗时处理会阻塞后续消息
+	//   - Reload 后自动在新连接上重新订阅,切换期间发布的消息可能丢失
+	//   - Close 后订阅结束
+	//   - 内存缓存为空操作,handler 永远不会被调用
+	// 使用示例:
+	//   err := cache.Subscribe(ctx, "rbac:invalidate", func(msg string) {
+	//       localCache.Invalidate(msg)
+	//   })
+	Subscribe(ctx context.Context, channel string, handler func(msg string)) error
+
 	// Ping 测试与缓存服务器的连接
 	// 参数:
 	//   ctx: 上下文
@@ -229,4 +311,19 @@ type Cache interface {
 	//       log.Error("failed to reload cache", "error", err)
 	//   }
 	Reload(ctx context.Context, config *Config) error
+
+	// Namespace 返回带键前缀的缓存视图
+	// 参数:
+	//   prefix: 键前缀,通常以 ":" 结尾,如 "user:"
+	// 返回:
+	//   Cache: 共享底层连接的视图,所有键和频道自动加上前缀
+	// 注意:
+	//   - DeleteByPattern 只匹配当前命名空间,DeleteByPattern(ctx, "*") 清空整个命名空间
+	//   - 对视图再次调用 Namespace 时前缀依次拼接,如 "user:" + "perms:"
+	//   - 视图的 Close 为空操作,底层缓存仍由创建方关闭
+	// 使用示例:
+	//   users := cache.Namespace("user:")
+	//   perms := users.Namespace("perms:")
+	//   err := perms.Set(ctx, "123", data, time.Hour) // 实际键为 user:perms:123
+	Namespace(prefix string) Cache
 }
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// mapCache 测试用的最小缓存实现,只实现 Get/Set
+// 其余方法由内嵌的 Cache 接口提供(调用会 panic)
+type mapCache struct {
+	Cache
+	mu    sync.Mutex
+	items map[string]string
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{items: make(map[string]string)}
+}
+
+func (m *mapCache) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.items[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	return value, nil
+}
+
+func (m *mapCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = fmt.Sprint(value)
+	return nil
+}
+
+// TestGetOrSet_SingleFlight 测试并发未命中只回源一次
+func TestGetOrSet_SingleFlight(t *testing.T) {
+	c := newMapCache()
+	var group singleflight.Group
+	var calls atomic.Int32
+
+	loader := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return "loaded", nil
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			value, err := getOrSet(context.Background(), c, &group, "user:1", time.Minute, loader, nil)
+			if err == nil && value != "loaded" {
+				err = fmt.Errorf("value = %q, want %q", value, "loaded")
+			}
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("loader called %d times, want 1", n)
+	}
+	if value, _ := c.Get(context.Background(), "user:1"); value != "loaded" {
+		t.Fatalf("expected loaded value to be cached, got %q", value)
+	}
+}
+
+// TestGetOrSet_LoaderError 测试回源失败时不写入缓存
+func TestGetOrSet_LoaderError(t *testing.T) {
+	c := newMapCache()
+	var group singleflight.Group
+	wantErr := errors.New("db down")
+
+	_, err := getOrSet(context.Background(), c, &group, "user:1", time.Minute, func(context.Context) (string, error) {
+		return "", wantErr
+	}, nil)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected loader error, got %v", err)
+	}
+	if _, err := c.Get(context.Background(), "user:1"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected no cached value after loader error, got %v", err)
+	}
+}
+
+// TestJSONHelpers 测试 JSON 辅助函数的命中、未命中和格式错误
+func TestJSONHelpers(t *testing.T) {
+	type user struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	ctx := context.Background()
+	c := newMapCache()
+
+	if err := SetJSON(ctx, c, "user:1", user{ID: 1, Name: "alice"}, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("hit", func(t *testing.T) {
+		got, ok, err := GetJSON[user](ctx, c, "user:1")
+		if err != nil || !ok {
+			t.Fatalf("expected hit, got ok=%v err=%v", ok, err)
+		}
+		if got.ID != 1 || got.Name != "alice" {
+			t.Fatalf("unexpected value: %+v", got)
+		}
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		_, ok, err := GetJSON[user](ctx, c, "user:2")
+		if err != nil || ok {
+			t.Fatalf("expected miss without error, got ok=%v err=%v", ok, err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_ = c.Set(ctx, "user:3", "{not json", time.Minute)
+		got, ok, err := GetJSON[user](ctx, c, "user:3")
+		if err == nil || ok {
+			t.Fatalf("expected error for malformed value, got ok=%v err=%v", ok, err)
+		}
+		if got != (user{}) {
+			t.Fatalf("expected zero value on error, got %+v", got)
+		}
+	})
+}
+
+// TestCounterError 测试计数命令对非整数值的错误包装
+func TestCounterError(t *testing.T) {
+	err := counterError("incr", errors.New("ERR value is not an integer or out of range"))
+	if !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+
+	err = counterError("incr", errors.New("dial tcp: connection refused"))
+	if errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected connection error not to be ErrNotInteger, got %v", err)
+	}
+}
@@ -42,6 +42,10 @@ const (
 	// DefaultWriteTimeout 默认写入超时时间(秒)
 	// 向 Redis 写入命令的最大等待时间
 	DefaultWriteTimeout = 3
+
+	// DefaultScanCount DeleteByPattern 每次 SCAN 的建议返回数量
+	// 每批键扫描后通过 pipeline 删除,批次越大往返越少,但单次阻塞越久
+	DefaultScanCount = 100
 )
 
 // 日志消息常量
@@ -76,6 +80,9 @@ const (
 
 	// MsgCacheClosed 缓存关闭成功消息
 	MsgCacheClosed = "redis connection closed"
+
+	// MsgCacheResubscribeFailed 重载后重新订阅失败消息
+	MsgCacheResubscribeFailed = "failed to resubscribe redis channel"
 )
 
 // 错误消息常量
@@ -0,0 +1,21 @@
+package cache
+
+import "errors"
+
+// ErrKeyNotFound 键不存在
+// Get、Expire 等方法在键不存在时返回包装了该错误的 error，使用 errors.Is 判断:
+//
+//	if errors.Is(err, cache.ErrKeyNotFound) {
+//	    // 缓存未命中
+//	}
+var ErrKeyNotFound = errors.New("cache key not found")
+
+// ErrNotInteger 键的值不是整数
+// Incr、Decr、IncrBy 在键的值无法按整数处理(或结果溢出)时返回包装了该错误的 error
+var ErrNotInteger = errors.New("cache value is not an integer")
+
+// ErrNilHandler Subscribe 的 handler 为 nil
+var ErrNilHandler = errors.New("cache subscribe handler is nil")
+
+// redisNotIntegerMsg Redis 对非整数值执行计数命令时的错误信息
+const redisNotIntegerMsg = "not an integer"
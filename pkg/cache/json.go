@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SetJSON 将 v 序列化为 JSON 后写入缓存
+// 参数:
+//
+//	c: 缓存实例
+//	key: 缓存键名
+//	v: 要缓存的值
+//	expiration: 过期时间,0 表示永不过期
+//
+// 使用示例:
+//
+//	err := cache.SetJSON(ctx, c, "user:123", user, time.Hour)
+func SetJSON[T any](ctx context.Context, c Cache, key string, v T, expiration time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache value %s: %w", key, err)
+	}
+
+	return c.Set(ctx, key, string(data), expiration)
+}
+
+// GetJSON 读取缓存并反序列化为 T
+// 返回:
+//
+//	T: 反序列化后的值,未命中或出错时为零值
+//	bool: 是否命中
+//	error: 缓存读取失败或值无法反序列化时的错误,未命中不是错误
+//
+// 使用示例:
+//
+//	user, ok, err := cache.GetJSON[models.DBUser](ctx, c, "user:123")
+//	if err != nil {
+//	    // 缓存异常,降级到数据库
+//	}
+//	if !ok {
+//	    // 未命中,从数据库加载
+//	}
+func GetJSON[T any](ctx context.Context, c Cache, key string) (T, bool, error) {
+	var v T
+
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return v, false, nil
+		}
+		return v, false, err
+	}
+
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		var zero T
+		return zero, false, fmt.Errorf("failed to unmarshal cache value %s: %w", key, err)
+	}
+
+	return v, true, nil
+}
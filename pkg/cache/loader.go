@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// getOrSet GetOrSet 的通用实现,供各缓存后端复用
+// 工作流程:
+//  1. 读缓存,命中直接返回
+//  2. 未命中时按键合并并发请求,只有一个调用方执行回源
+//  3. 回源前再读一次缓存,避免前一轮回源刚写入后重复加载
+//  4. 回源成功后写入缓存,写入失败只记录日志
+func getOrSet(ctx context.Context, c Cache, group *singleflight.Group, key string, expiration time.Duration, loader Loader, logger Logger) (string, error) {
+	if value, err := c.Get(ctx, key); err == nil {
+		return value, nil
+	} else if !errors.Is(err, ErrKeyNotFound) && logger != nil {
+		// 缓存不可用时降级为直接回源
+		logger.Error("cache get failed, falling back to loader", "key", key, "error", err)
+	}
+
+	result, err, _ := group.Do(key, func() (interface{}, error) {
+		if value, err := c.Get(ctx, key); err == nil {
+			return value, nil
+		}
+
+		value, err := loader(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		if err := c.Set(ctx, key, value, expiration); err != nil && logger != nil {
+			logger.Error("cache set failed after load", "key", key, "error", err)
+		}
+		return value, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result.(string), nil
+}
@@ -0,0 +1,519 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// MemoryOptions 内存缓存配置
+type MemoryOptions struct {
+	// MaxEntries 最大条目数
+	// 超出时淘汰最近最少使用(LRU)的条目,0 表示不限制
+	MaxEntries int
+
+	// CleanupInterval 后台清理过期条目的间隔
+	// 0 表示不启动后台清理,只在访问时惰性删除
+	CleanupInterval time.Duration
+}
+
+// memoryEntry 缓存条目
+type memoryEntry struct {
+	key   string
+	value string
+	// expiresAt 过期时间,零值表示永不过期
+	expiresAt time.Time
+}
+
+// expired 判断条目在 now 时是否已过期
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryCache 进程内缓存实现
+// 适用于测试和不部署 Redis 的单实例场景
+// 注意:
+//   - 数据只存在于当前进程,多实例部署时各实例的缓存互不可见
+//   - 过期采用惰性删除,配置 CleanupInterval 后额外定期清理
+type memoryCache struct {
+	// mu 保护 items 和 lru
+	// Get 会调整 LRU 顺序,因此读写都使用互斥锁
+	mu sync.Mutex
+
+	// items 键到 LRU 链表节点的索引
+	items map[string]*list.Element
+
+	// lru 链表头部为最近使用,尾部为最久未使用
+	lru *list.List
+
+	// maxEntries 最大条目数,0 表示不限制
+	maxEntries int
+
+	// loads 合并同一键的并发回源请求
+	loads singleflight.Group
+
+	// stop 关闭后台清理协程
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMemory 创建内存缓存实例
+// 参数:
+//
+//	opts: 配置,可以为 nil(不限制条目数,不启动后台清理)
+//
+// 使用示例:
+//
+//	c := cache.NewMemory(&cache.MemoryOptions{
+//	    MaxEntries:      10000,
+//	    CleanupInterval: time.Minute,
+//	})
+//	defer c.Close()
+func NewMemory(opts *MemoryOptions) Cache {
+	if opts == nil {
+		opts = &MemoryOptions{}
+	}
+
+	m := &memoryCache{
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+		maxEntries: opts.MaxEntries,
+		stop:       make(chan struct{}),
+	}
+
+	if opts.CleanupInterval > 0 {
+		go m.cleanupLoop(opts.CleanupInterval)
+	}
+
+	return m
+}
+
+// cleanupLoop 定期删除过期条目
+func (m *memoryCache) cleanupLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.deleteExpired()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// deleteExpired 删除所有过期条目
+func (m *memoryCache) deleteExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, elem := range m.items {
+		if elem.Value.(*memoryEntry).expired(now) {
+			m.removeElement(elem)
+		}
+	}
+}
+
+// lookup 查找未过期的条目,过期条目会被顺带删除
+// 调用方必须持有 m.mu
+func (m *memoryCache) lookup(key string) (*list.Element, bool) {
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	if elem.Value.(*memoryEntry).expired(time.Now()) {
+		m.removeElement(elem)
+		return nil, false
+	}
+	return elem, true
+}
+
+// store 写入条目并标记为最近使用,超出容量时淘汰最久未使用的条目
+// 调用方必须持有 m.mu
+func (m *memoryCache) store(key, value string, expiresAt time.Time) {
+	if elem, ok := m.items[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.lru.MoveToFront(elem)
+		return
+	}
+
+	m.items[key] = m.lru.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+
+	if m.maxEntries > 0 {
+		for m.lru.Len() > m.maxEntries {
+			m.removeElement(m.lru.Back())
+		}
+	}
+}
+
+// removeElement 删除条目
+// 调用方必须持有 m.mu
+func (m *memoryCache) removeElement(elem *list.Element) {
+	m.lru.Remove(elem)
+	delete(m.items, elem.Value.(*memoryEntry).key)
+}
+
+// Get 获取键的值
+// 实现 Cache 接口
+func (m *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.lookup(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	m.lru.MoveToFront(elem)
+	return elem.Value.(*memoryEntry).value, nil
+}
+
+// Set 设置键值对
+// 实现 Cache 接口
+func (m *memoryCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	str, err := memoryValueString(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.store(key, str, expiresAt(expiration))
+	return nil
+}
+
+// GetOrSet 获取键的值,未命中时通过 loader 回源并写入缓存
+// 实现 Cache 接口
+func (m *memoryCache) GetOrSet(ctx context.Context, key string, expiration time.Duration, loader Loader) (string, error) {
+	return getOrSet(ctx, m, &m.loads, key, expiration, loader, nil)
+}
+
+// Delete 删除键
+// 实现 Cache 接口
+func (m *memoryCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := m.items[key]; ok {
+			m.removeElement(elem)
+		}
+	}
+	return nil
+}
+
+// DeleteByPattern 按模式删除键
+// 实现 Cache 接口
+func (m *memoryCache) DeleteByPattern(ctx context.Context, pattern string) (int, error) {
+	re, err := globToRegexp(pattern)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	deleted := 0
+	for key, elem := range m.items {
+		if !re.MatchString(key) {
+			continue
+		}
+		// 已过期的条目对调用方不可见,删除但不计数
+		if !elem.Value.(*memoryEntry).expired(now) {
+			deleted++
+		}
+		m.removeElement(elem)
+	}
+	return deleted, nil
+}
+
+// Exists 检查键是否存在
+// 实现 Cache 接口
+func (m *memoryCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for _, key := range keys {
+		if _, ok := m.lookup(key); ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MGet 批量获取
+// 实现 Cache 接口
+func (m *memoryCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	results := make([]interface{}, len(keys))
+	for i, key := range keys {
+		if elem, ok := m.lookup(key); ok {
+			m.lru.MoveToFront(elem)
+			results[i] = elem.Value.(*memoryEntry).value
+		}
+	}
+	return results, nil
+}
+
+// MSet 批量设置
+// 实现 Cache 接口
+func (m *memoryCache) MSet(ctx context.Context, pairs ...interface{}) error {
+	if len(pairs)%2 != 0 {
+		return fmt.Errorf("mset requires an even number of arguments")
+	}
+
+	// 先完成所有转换,保证要么全部写入要么全部不写入
+	keys := make([]string, 0, len(pairs)/2)
+	values := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return fmt.Errorf("mset key must be a string, got %T", pairs[i])
+		}
+		value, err := memoryValueString(pairs[i+1])
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 与 Redis MSET 一致,清除已有的过期时间
+	for i, key := range keys {
+		m.store(key, values[i], time.Time{})
+	}
+	return nil
+}
+
+// Expire 设置过期时间
+// 实现 Cache 接口
+func (m *memoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.lookup(key)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	// 与 Redis 一致,非正数的过期时间立即删除键
+	if expiration <= 0 {
+		m.removeElement(elem)
+		return nil
+	}
+
+	elem.Value.(*memoryEntry).expiresAt = time.Now().Add(expiration)
+	return nil
+}
+
+// TTL 获取剩余生存时间
+// 实现 Cache 接口
+func (m *memoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.lookup(key)
+	if !ok {
+		return -2, nil
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if entry.expiresAt.IsZero() {
+		return -1, nil
+	}
+	return time.Until(entry.expiresAt), nil
+}
+
+// Incr 原子加 1
+// 实现 Cache 接口
+func (m *memoryCache) Incr(ctx context.Context, key string) (int64, error) {
+	return m.incrBy("incr", key, 1)
+}
+
+// Decr 原子减 1
+// 实现 Cache 接口
+func (m *memoryCache) Decr(ctx context.Context, key string) (int64, error) {
+	return m.incrBy("decr", key, -1)
+}
+
+// IncrBy 原子增加指定值
+// 实现 Cache 接口
+func (m *memoryCache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return m.incrBy("incrby", key, value)
+}
+
+// incrBy 计数器的通用实现
+// 键不存在时从 0 开始,保留已有的过期时间
+func (m *memoryCache) incrBy(op, key string, delta int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var current int64
+	var expiresAt time.Time
+	if elem, ok := m.lookup(key); ok {
+		entry := elem.Value.(*memoryEntry)
+		n, err := strconv.ParseInt(entry.value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("memory %s failed: %w", op, ErrNotInteger)
+		}
+		current = n
+		expiresAt = entry.expiresAt
+	}
+
+	result := current + delta
+	// 溢出检查,与 Redis 行为一致
+	if (delta > 0 && result < current) || (delta < 0 && result > current) {
+		return 0, fmt.Errorf("memory %s failed: %w", op, ErrNotInteger)
+	}
+
+	m.store(key, strconv.FormatInt(result, 10), expiresAt)
+	return result, nil
+}
+
+// Publish 内存缓存只服务单个实例,没有需要通知的其他节点
+// 实现 Cache 接口
+func (m *memoryCache) Publish(ctx context.Context, channel, message string) error {
+	return nil
+}
+
+// Subscribe 内存缓存不会收到其他节点的消息,handler 永远不会被调用
+// 实现 Cache 接口
+func (m *memoryCache) Subscribe(ctx context.Context, channel string, handler func(msg string)) error {
+	if handler == nil {
+		return ErrNilHandler
+	}
+	return nil
+}
+
+// Ping 内存缓存始终可用
+// 实现 Cache 接口
+func (m *memoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close 停止后台清理并清空数据
+// 实现 Cache 接口
+func (m *memoryCache) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.stop)
+
+		m.mu.Lock()
+		m.items = make(map[string]*list.Element)
+		m.lru.Init()
+		m.mu.Unlock()
+	})
+	return nil
+}
+
+// Namespace 返回带键前缀的缓存视图
+// 实现 Cache 接口
+func (m *memoryCache) Namespace(prefix string) Cache {
+	return newNamespace(m, prefix)
+}
+
+// Reload 内存缓存没有连接配置,忽略 Redis 配置
+// 实现 Cache 接口
+func (m *memoryCache) Reload(ctx context.Context, config *Config) error {
+	return nil
+}
+
+// expiresAt 将相对过期时间转换为绝对时间,非正数表示永不过期
+func expiresAt(expiration time.Duration) time.Time {
+	if expiration <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(expiration)
+}
+
+// memoryValueString 按 go-redis 的规则将值转换为字符串,保证两种实现读出的值一致
+func memoryValueString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return strconv.FormatInt(v.Nanoseconds(), 10), nil
+	case encoding.BinaryMarshaler:
+		b, err := v.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("cache: can't marshal %T (implement encoding.BinaryMarshaler)", value)
+	}
+}
+
+// globToRegexp 将 Redis glob 模式转换为正则表达式
+// 支持 *、?、[abc]、[^a]、[a-z] 和 \ 转义
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			b.WriteString("(?s:.*)")
+		case '?':
+			b.WriteString("(?s:.)")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(pattern[i:]))
+				i = len(pattern)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMemory_TTLExpiry 测试过期条目不可见
+func TestMemory_TTLExpiry(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(nil)
+	defer c.Close()
+
+	if err := c.Set(ctx, "session:1", "alice", 20*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Set(ctx, "config", "forever", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ttl, _ := c.TTL(ctx, "session:1"); ttl <= 0 {
+		t.Fatalf("expected positive ttl, got %v", ttl)
+	}
+	if ttl, _ := c.TTL(ctx, "config"); ttl != -1 {
+		t.Fatalf("expected ttl -1 for key without expiration, got %v", ttl)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, err := c.Get(ctx, "session:1"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected expired key to be missing, got %v", err)
+	}
+	if ttl, _ := c.TTL(ctx, "session:1"); ttl != -2 {
+		t.Fatalf("expected ttl -2 for missing key, got %v", ttl)
+	}
+	if value, err := c.Get(ctx, "config"); err != nil || value != "forever" {
+		t.Fatalf("expected key without expiration to be kept, got %q, %v", value, err)
+	}
+}
+
+// TestMemory_CleanupLoop 测试后台清理删除过期条目
+func TestMemory_CleanupLoop(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(&MemoryOptions{CleanupInterval: 10 * time.Millisecond})
+	defer c.Close()
+
+	_ = c.Set(ctx, "k", "v", 5*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+
+	m := c.(*memoryCache)
+	m.mu.Lock()
+	n := len(m.items)
+	m.mu.Unlock()
+	if n != 0 {
+		t.Fatalf("expected cleanup loop to remove expired entry, %d left", n)
+	}
+}
+
+// TestMemory_LRUEviction 测试超出容量时淘汰最久未使用的条目
+func TestMemory_LRUEviction(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(&MemoryOptions{MaxEntries: 2})
+	defer c.Close()
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_ = c.Set(ctx, "b", "2", 0)
+
+	// 访问 a,使 b 成为最久未使用
+	if _, err := c.Get(ctx, "a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = c.Set(ctx, "c", "3", 0)
+
+	if _, err := c.Get(ctx, "b"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected b to be evicted, got %v", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, err := c.Get(ctx, key); err != nil {
+			t.Fatalf("expected %s to be kept, got %v", key, err)
+		}
+	}
+}
+
+// TestMemory_Counters 测试计数器与非整数值
+func TestMemory_Counters(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(nil)
+	defer c.Close()
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := c.Incr(ctx, "hits")
+		if err != nil || got != want {
+			t.Fatalf("Incr = %d, %v; want %d", got, err, want)
+		}
+	}
+	if got, _ := c.IncrBy(ctx, "hits", 10); got != 13 {
+		t.Fatalf("IncrBy = %d, want 13", got)
+	}
+	if got, _ := c.Decr(ctx, "hits"); got != 12 {
+		t.Fatalf("Decr = %d, want 12", got)
+	}
+
+	_ = c.Set(ctx, "name", "alice", 0)
+	if _, err := c.Incr(ctx, "name"); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+}
+
+// TestMemory_CounterWindow 测试首次 Incr 后设置的过期时间在后续递增中保留
+func TestMemory_CounterWindow(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(nil)
+	defer c.Close()
+
+	if count, err := c.Incr(ctx, "window"); err != nil || count != 1 {
+		t.Fatalf("Incr = %d, %v; want 1", count, err)
+	}
+	if err := c.Expire(ctx, "window", time.Minute); err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if count, err := c.IncrBy(ctx, "window", 4); err != nil || count != 5 {
+		t.Fatalf("IncrBy = %d, %v; want 5", count, err)
+	}
+	if ttl, err := c.TTL(ctx, "window"); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("expected window expiry to survive increments, got %v, %v", ttl, err)
+	}
+}
+
+// TestMemory_DeleteByPattern 测试只删除匹配前缀的键
+func TestMemory_DeleteByPattern(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(nil)
+	defer c.Close()
+
+	for _, key := range []string{"user:perms:1", "user:perms:2", "user:perms:3", "user:1", "perms:1"} {
+		_ = c.Set(ctx, key, "x", 0)
+	}
+
+	n, err := c.DeleteByPattern(ctx, "user:perms:*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("deleted %d keys, want 3", n)
+	}
+	if count, _ := c.Exists(ctx, "user:1", "perms:1"); count != 2 {
+		t.Fatalf("expected non-matching keys to be kept, %d left", count)
+	}
+}
+
+// TestMemory_ConcurrentAccess 测试并发读写(配合 -race 运行)
+func TestMemory_ConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(&MemoryOptions{MaxEntries: 50, CleanupInterval: time.Millisecond})
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := fmt.Sprintf("key:%d", (i*j)%100)
+				_ = c.Set(ctx, key, j, time.Millisecond*time.Duration(j%5))
+				_, _ = c.Get(ctx, key)
+				_, _ = c.Exists(ctx, key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	m := c.(*memoryCache)
+	m.mu.Lock()
+	n, listLen := len(m.items), m.lru.Len()
+	m.mu.Unlock()
+	if n > 50 || n != listLen {
+		t.Fatalf("entries = %d (list %d), want <= 50 and consistent", n, listLen)
+	}
+}
+
+// TestMemory_PubSubNoop 测试内存缓存的发布订阅为空操作
+func TestMemory_PubSubNoop(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemory(nil)
+	defer c.Close()
+
+	if err := c.Subscribe(ctx, "ch", nil); !errors.Is(err, ErrNilHandler) {
+		t.Fatalf("expected ErrNilHandler, got %v", err)
+	}
+
+	called := false
+	if err := c.Subscribe(ctx, "ch", func(string) { called = true }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Publish(ctx, "ch", "msg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Fatal("memory cache should not deliver messages")
+	}
+}
@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// namespaceCache 带键前缀的缓存视图
+// 所有键和频道在传给底层缓存前自动加上前缀,调用方只使用逻辑键
+// 视图不拥有底层连接: Close 为空操作,Reload 直接转发给底层缓存
+type namespaceCache struct {
+	base   Cache
+	prefix string
+}
+
+// newNamespace 创建缓存视图
+// 前缀为空时直接返回底层缓存;对视图再次调用 Namespace 时前缀依次拼接
+func newNamespace(base Cache, prefix string) Cache {
+	if prefix == "" {
+		return base
+	}
+	if ns, ok := base.(*namespaceCache); ok {
+		return &namespaceCache{base: ns.base, prefix: ns.prefix + prefix}
+	}
+	return &namespaceCache{base: base, prefix: prefix}
+}
+
+// key 返回带前缀的键
+func (n *namespaceCache) key(key string) string {
+	return n.prefix + key
+}
+
+// keys 返回带前缀的键列表
+func (n *namespaceCache) keys(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.key(key)
+	}
+	return prefixed
+}
+
+// Namespace 在当前前缀后追加前缀,返回新的视图
+// 实现 Cache 接口
+func (n *namespaceCache) Namespace(prefix string) Cache {
+	return newNamespace(n, prefix)
+}
+
+// Get 获取缓存值
+// 实现 Cache 接口
+func (n *namespaceCache) Get(ctx context.Context, key string) (string, error) {
+	return n.base.Get(ctx, n.key(key))
+}
+
+// Set 设置缓存值
+// 实现 Cache 接口
+func (n *namespaceCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return n.base.Set(ctx, n.key(key), value, expiration)
+}
+
+// GetOrSet 获取缓存值,未命中时回源并写入缓存
+// 实现 Cache 接口
+func (n *namespaceCache) GetOrSet(ctx context.Context, key string, expiration time.Duration, loader Loader) (string, error) {
+	return n.base.GetOrSet(ctx, n.key(key), expiration, loader)
+}
+
+// Delete 删除缓存
+// 实现 Cache 接口
+func (n *namespaceCache) Delete(ctx context.Context, keys ...string) error {
+	return n.base.Delete(ctx, n.keys(keys)...)
+}
+
+// DeleteByPattern 删除当前命名空间内匹配 glob 模式的所有键
+// 前缀中的 glob 元字符会被转义,DeleteByPattern(ctx, "*") 清空整个命名空间
+// 实现 Cache 接口
+func (n *namespaceCache) DeleteByPattern(ctx context.Context, pattern string) (int, error) {
+	return n.base.DeleteByPattern(ctx, escapeGlob(n.prefix)+pattern)
+}
+
+// Exists 检查键是否存在
+// 实现 Cache 接口
+func (n *namespaceCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	return n.base.Exists(ctx, n.keys(keys)...)
+}
+
+// MGet 批量获取
+// 实现 Cache 接口
+func (n *namespaceCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return n.base.MGet(ctx, n.keys(keys)...)
+}
+
+// MSet 批量设置
+// 实现 Cache 接口
+func (n *namespaceCache) MSet(ctx context.Context, pairs ...interface{}) error {
+	if len(pairs)%2 != 0 {
+		return fmt.Errorf("mset requires an even number of arguments")
+	}
+
+	prefixed := make([]interface{}, len(pairs))
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return fmt.Errorf("mset key must be a string, got %T", pairs[i])
+		}
+		prefixed[i] = n.key(key)
+		prefixed[i+1] = pairs[i+1]
+	}
+	return n.base.MSet(ctx, prefixed...)
+}
+
+// Expire 设置过期时间
+// 实现 Cache 接口
+func (n *namespaceCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return n.base.Expire(ctx, n.key(key), expiration)
+}
+
+// TTL 获取剩余过期时间
+// 实现 Cache 接口
+func (n *namespaceCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return n.base.TTL(ctx, n.key(key))
+}
+
+// Incr 自增 1
+// 实现 Cache 接口
+func (n *namespaceCache) Incr(ctx context.Context, key string) (int64, error) {
+	return n.base.Incr(ctx, n.key(key))
+}
+
+// Decr 自减 1
+// 实现 Cache 接口
+func (n *namespaceCache) Decr(ctx context.Context, key string) (int64, error) {
+	return n.base.Decr(ctx, n.key(key))
+}
+
+// IncrBy 增加指定值
+// 实现 Cache 接口
+func (n *namespaceCache) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
+	return n.base.IncrBy(ctx, n.key(key), value)
+}
+
+// Publish 向当前命名空间的频道发布消息
+// 实现 Cache 接口
+func (n *namespaceCache) Publish(ctx context.Context, channel, message string) error {
+	return n.base.Publish(ctx, n.key(channel), message)
+}
+
+// Subscribe 订阅当前命名空间的频道
+// 实现 Cache 接口
+func (n *namespaceCache) Subscribe(ctx context.Context, channel string, handler func(msg string)) error {
+	return n.base.Subscribe(ctx, n.key(channel), handler)
+}
+
+// Ping 检查底层缓存连接
+// 实现 Cache 接口
+func (n *namespaceCache) Ping(ctx context.Context) error {
+	return n.base.Ping(ctx)
+}
+
+// Close 视图不拥有底层连接,不做任何操作
+// 底层缓存由创建它的一方负责关闭
+// 实现 Cache 接口
+func (n *namespaceCache) Close() error {
+	return nil
+}
+
+// Reload 重新加载底层缓存的配置
+// 实现 Cache 接口
+func (n *namespaceCache) Reload(ctx context.Context, config *Config) error {
+	return n.base.Reload(ctx, config)
+}
+
+// escapeGlob 转义 glob 元字符,使字符串按字面匹配
+func escapeGlob(s string) string {
+	if !strings.ContainsAny(s, `*?[]\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(`*?[]\`, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestNamespace_PrefixedKeys 测试视图写入带前缀的键,不同命名空间的同名逻辑键互不影响
+func TestNamespace_PrefixedKeys(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemory(nil)
+	defer base.Close()
+
+	users := base.Namespace("user:")
+	perms := users.Namespace("perms:")
+
+	if err := users.Set(ctx, "1", "alice", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := perms.Set(ctx, "1", "admin", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if value, err := base.Get(ctx, "user:1"); err != nil || value != "alice" {
+		t.Errorf("base user:1 = %q, %v, want alice", value, err)
+	}
+	if value, err := base.Get(ctx, "user:perms:1"); err != nil || value != "admin" {
+		t.Errorf("base user:perms:1 = %q, %v, want admin", value, err)
+	}
+	if value, err := users.Get(ctx, "1"); err != nil || value != "alice" {
+		t.Errorf("users.Get(1) = %q, %v, want alice", value, err)
+	}
+	if value, err := perms.Get(ctx, "1"); err != nil || value != "admin" {
+		t.Errorf("perms.Get(1) = %q, %v, want admin", value, err)
+	}
+
+	if err := users.MSet(ctx, "2", "bob"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := base.Exists(ctx, "user:2"); n != 1 {
+		t.Errorf("MSet should write prefixed key, exists = %d", n)
+	}
+	if n, _ := users.Incr(ctx, "counter"); n != 1 {
+		t.Errorf("Incr() = %d, want 1", n)
+	}
+	if value, _ := base.Get(ctx, "user:counter"); value != "1" {
+		t.Errorf("base user:counter = %q, want 1", value)
+	}
+}
+
+// TestNamespace_DeleteByPattern 测试按模式删除只作用于当前命名空间
+func TestNamespace_DeleteByPattern(t *testing.T) {
+	ctx := context.Background()
+	base := NewMemory(nil)
+	defer base.Close()
+
+	_ = base.MSet(ctx, "user:perms:1", "a", "user:perms:2", "b", "user:1", "c", "order:1", "d")
+
+	perms := base.Namespace("user:").Namespace("perms:")
+	if n, err := perms.DeleteByPattern(ctx, "*"); err != nil || n != 2 {
+		t.Fatalf("DeleteByPattern(*) = %d, %v, want 2", n, err)
+	}
+	if n, _ := base.Exists(ctx, "user:1", "order:1"); n != 2 {
+		t.Errorf("keys outside namespace should be kept, exists = %d", n)
+	}
+
+	// 前缀中的 glob 元字符按字面匹配
+	_ = base.MSet(ctx, "a*:1", "x", "ab:1", "y")
+	if n, _ := base.Namespace("a*:").DeleteByPattern(ctx, "*"); n != 1 {
+		t.Errorf("escaped prefix should match literally, deleted %d", n)
+	}
+	if _, err := base.Get(ctx, "ab:1"); err != nil {
+		t.Errorf("ab:1 should be kept: %v", err)
+	}
+
+	// 视图的 Close 不关闭底层缓存
+	if err := perms.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := base.Get(ctx, "user:1"); err != nil {
+		t.Errorf("base should still be usable after closing view: %v", err)
+	}
+}
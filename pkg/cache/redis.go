@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 // redisCache Redis 缓存实现
@@ -37,6 +39,10 @@ type redisCache struct {
 	// logger 日志记录器(可选)
 	// 用于记录连接、操作等日志
 	logger Logger
+
+	// loads 合并同一键的并发回源请求
+	// 用于 GetOrSet 防止缓存击穿
+	loads singleflight.Group
 }
 
 // Logger 日志接口
@@ -151,7 +157,7 @@ func (r *redisCache) Get(ctx context.Context, key string) (string, error) {
 		// 检查是否是键不存在错误
 		if errors.Is(err, redis.Nil) {
 			// redis.Nil 表示键不存在,这是预期的情况,不是错误
-			return "", fmt.Errorf(ErrMsgKeyNotFound, key)
+			return "", fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 		}
 		// 其他错误
 		return "", fmt.Errorf(ErrMsgOperationFailed, "get", err)
@@ -197,6 +203,44 @@ func (r *redisCache) Delete(ctx context.Context, keys ...string) error {
 	return nil
 }
 
+// DeleteByPattern 按模式删除键
+// 实现 Cache 接口
+func (r *redisCache) DeleteByPattern(ctx context.Context, pattern string) (int, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	deleted := 0
+	var cursor uint64
+	for {
+		// SCAN 增量遍历,每次只返回一批键
+		keys, next, err := client.Scan(ctx, cursor, pattern, DefaultScanCount).Result()
+		if err != nil {
+			return deleted, fmt.Errorf(ErrMsgOperationFailed, "scan", err)
+		}
+
+		if len(keys) > 0 {
+			// 同一批键通过 pipeline 一次往返删除
+			pipe := client.Pipeline()
+			cmds := make([]*redis.IntCmd, 0, len(keys))
+			for _, key := range keys {
+				cmds = append(cmds, pipe.Del(ctx, key))
+			}
+			if _, err := pipe.Exec(ctx); err != nil {
+				return deleted, fmt.Errorf(ErrMsgOperationFailed, "delete", err)
+			}
+			for _, cmd := range cmds {
+				deleted += int(cmd.Val())
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
 // Exists 检查键是否存在
 // 实现 Cache 接口
 func (r *redisCache) Exists(ctx context.Context, keys ...string) (int64, error) {
@@ -277,12 +321,18 @@ func (r *redisCache) Expire(ctx context.Context, key string, expiration time.Dur
 
 	// ok 为 false 表示键不存在
 	if !ok {
-		return fmt.Errorf(ErrMsgKeyNotFound, key)
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 	}
 
 	return nil
 }
 
+// GetOrSet 获取键的值,未命中时通过 loader 回源并写入缓存
+// 实现 Cache 接口
+func (r *redisCache) GetOrSet(ctx context.Context, key string, expiration time.Duration, loader Loader) (string, error) {
+	return getOrSet(ctx, r, &r.loads, key, expiration, loader, r.logger)
+}
+
 // TTL 获取剩余生存时间
 // 实现 Cache 接口
 func (r *redisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
@@ -309,7 +359,7 @@ func (r *redisCache) Incr(ctx context.Context, key string) (int64, error) {
 	// 执行 INCR 命令
 	result, err := client.Incr(ctx, key).Result()
 	if err != nil {
-		return 0, fmt.Errorf(ErrMsgOperationFailed, "incr", err)
+		return 0, counterError("incr", err)
 	}
 
 	return result, nil
@@ -325,7 +375,7 @@ func (r *redisCache) Decr(ctx context.Context, key string) (int64, error) {
 	// 执行 DECR 命令
 	result, err := client.Decr(ctx, key).Result()
 	if err != nil {
-		return 0, fmt.Errorf(ErrMsgOperationFailed, "decr", err)
+		return 0, counterError("decr", err)
 	}
 
 	return result, nil
@@ -341,12 +391,120 @@ func (r *redisCache) IncrBy(ctx context.Context, key string, value int64) (int64
 	// 执行 INCRBY 命令
 	result, err := client.IncrBy(ctx, key, value).Result()
 	if err != nil {
-		return 0, fmt.Errorf(ErrMsgOperationFailed, "incrby", err)
+		return 0, counterError("incrby", err)
 	}
 
 	return result, nil
 }
 
+// Publish 发布消息
+// 实现 Cache 接口
+func (r *redisCache) Publish(ctx context.Context, channel, message string) error {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 PUBLISH 命令
+	if err := client.Publish(ctx, channel, message).Err(); err != nil {
+		return fmt.Errorf(ErrMsgOperationFailed, "publish", err)
+	}
+
+	return nil
+}
+
+// Subscribe 订阅频道
+// 实现 Cache 接口
+func (r *redisCache) Subscribe(ctx context.Context, channel string, handler func(msg string)) error {
+	if handler == nil {
+		return ErrNilHandler
+	}
+
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	ps, err := subscribe(ctx, client, channel)
+	if err != nil {
+		return err
+	}
+
+	go r.receive(ctx, client, ps, channel, handler)
+	return nil
+}
+
+// subscribe 在 client 上订阅频道并等待 Redis 确认
+// 确认后再返回,保证调用方之后发布的消息不会丢失
+func subscribe(ctx context.Context, client *redis.Client, channel string) (*redis.PubSub, error) {
+	ps := client.Subscribe(ctx, channel)
+	if _, err := ps.Receive(ctx); err != nil {
+		ps.Close()
+		return nil, fmt.Errorf(ErrMsgOperationFailed, "subscribe", err)
+	}
+	return ps, nil
+}
+
+// receive 把订阅消息分发给 handler,直到 ctx 取消或缓存关闭
+// Reload 关闭旧客户端会结束旧订阅,此时在新客户端上重新订阅
+func (r *redisCache) receive(ctx context.Context, client *redis.Client, ps *redis.PubSub, channel string, handler func(msg string)) {
+	for {
+		canceled := dispatch(ctx, ps, handler)
+		ps.Close()
+		if canceled {
+			return
+		}
+
+		r.mu.RLock()
+		current := r.client
+		r.mu.RUnlock()
+
+		// 客户端未变化说明是 Close 结束了订阅
+		if current == nil || current == client {
+			return
+		}
+
+		var err error
+		client = current
+		if ps, err = subscribe(ctx, client, channel); err != nil {
+			if r.logger != nil {
+				r.logger.Error(MsgCacheResubscribeFailed, "channel", channel, "error", err)
+			}
+			return
+		}
+	}
+}
+
+// dispatch 依次处理 ps 的消息,ctx 取消时返回 true,消息通道关闭时返回 false
+func dispatch(ctx context.Context, ps *redis.PubSub, handler func(msg string)) bool {
+	msgs := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case msg, ok := <-msgs:
+			if !ok {
+				return false
+			}
+			handler(msg.Payload)
+		}
+	}
+}
+
+// counterError 包装计数器命令的错误
+// 键的值不是整数(或结果溢出)时 Redis 返回 "ERR value is not an integer or out of range",
+// 此时额外包装 ErrNotInteger,调用方可以用 errors.Is 区分数据错误和连接错误
+func counterError(op string, err error) error {
+	if strings.Contains(err.Error(), redisNotIntegerMsg) {
+		return fmt.Errorf("redis %s failed: %w: %w", op, ErrNotInteger, err)
+	}
+	return fmt.Errorf(ErrMsgOperationFailed, op, err)
+}
+
+// Namespace 返回带键前缀的缓存视图
+// 实现 Cache 接口
+func (r *redisCache) Namespace(prefix string) Cache {
+	return newNamespace(r, prefix)
+}
+
 // Ping 测试连接
 // 实现 Cache 接口
 func (r *redisCache) Ping(ctx context.Context) error {
@@ -0,0 +1,398 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis 测试用的最小 Redis 服务
+// 支持 PING/SUBSCRIBE/PUBLISH、字符串键的 SET/DEL/SCAN 和计数器的 INCR/INCRBY/DECR/EXPIRE/TTL
+// 其余命令返回错误,go-redis 握手时的 HELLO 失败后会回退到 RESP2
+type fakeRedis struct {
+	ln net.Listener
+
+	mu   sync.Mutex
+	subs map[string][]net.Conn
+	data map[string]string
+	// expires 设置了过期时间的键,只用于 TTL 查询,不会主动淘汰
+	expires map[string]time.Time
+	// order 键的写入顺序,删除时不移除,作为 SCAN 的稳定游标
+	order []string
+
+	// scanPage SCAN 每页返回的键数,忽略客户端的 COUNT,便于测试多页游标
+	scanPage int
+	// scans 收到的 SCAN 命令次数
+	scans int
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeRedis{
+		ln:       ln,
+		subs:     make(map[string][]net.Conn),
+		data:     make(map[string]string),
+		expires:  make(map[string]time.Time),
+		scanPage: 10,
+	}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeRedis) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeRedis) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			io.WriteString(conn, "+PONG\r\n")
+		case "SUBSCRIBE":
+			for _, channel := range args[1:] {
+				s.subs[channel] = append(s.subs[channel], conn)
+				fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n%s:%d\r\n", bulk(channel), len(s.subs[channel]))
+			}
+		case "PUBLISH":
+			for _, sub := range s.subs[args[1]] {
+				fmt.Fprintf(sub, "*3\r\n$7\r\nmessage\r\n%s%s", bulk(args[1]), bulk(args[2]))
+			}
+			fmt.Fprintf(conn, ":%d\r\n", len(s.subs[args[1]]))
+		case "SET":
+			if _, ok := s.data[args[1]]; !ok {
+				s.order = append(s.order, args[1])
+			}
+			s.data[args[1]] = args[2]
+			delete(s.expires, args[1])
+			io.WriteString(conn, "+OK\r\n")
+		case "DEL":
+			deleted := 0
+			for _, key := range args[1:] {
+				if _, ok := s.data[key]; ok {
+					delete(s.data, key)
+					delete(s.expires, key)
+					deleted++
+				}
+			}
+			fmt.Fprintf(conn, ":%d\r\n", deleted)
+		case "SCAN":
+			s.scan(conn, args)
+		case "INCR":
+			s.incrBy(conn, args[1], 1)
+		case "DECR":
+			s.incrBy(conn, args[1], -1)
+		case "INCRBY":
+			delta, _ := strconv.ParseInt(args[2], 10, 64)
+			s.incrBy(conn, args[1], delta)
+		case "EXPIRE":
+			if _, ok := s.data[args[1]]; !ok {
+				io.WriteString(conn, ":0\r\n")
+				break
+			}
+			seconds, _ := strconv.Atoi(args[2])
+			s.expires[args[1]] = time.Now().Add(time.Duration(seconds) * time.Second)
+			io.WriteString(conn, ":1\r\n")
+		case "TTL":
+			s.ttl(conn, args[1])
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		s.mu.Unlock()
+	}
+}
+
+// scan 处理 SCAN cursor [MATCH pattern] [COUNT n]
+// 游标为 order 的偏移量,每页最多 scanPage 个键(过滤前),与真实 Redis 一样可能返回空页
+// 扫描期间删除键不会影响后续页,对应 Redis 对全程存在的键的返回保证
+// 调用方需要持有 s.mu
+func (s *fakeRedis) scan(conn net.Conn, args []string) {
+	s.scans++
+
+	cursor, _ := strconv.Atoi(args[1])
+	pattern := "*"
+	for i := 2; i+1 < len(args); i += 2 {
+		if strings.EqualFold(args[i], "MATCH") {
+			pattern = args[i+1]
+		}
+	}
+
+	end := min(cursor+s.scanPage, len(s.order))
+	var matched []string
+	for _, key := range s.order[min(cursor, end):end] {
+		if _, ok := s.data[key]; !ok {
+			continue
+		}
+		if ok, _ := path.Match(pattern, key); ok {
+			matched = append(matched, key)
+		}
+	}
+
+	next := end
+	if next >= len(s.order) {
+		next = 0
+	}
+	fmt.Fprintf(conn, "*2\r\n%s*%d\r\n", bulk(strconv.Itoa(next)), len(matched))
+	for _, key := range matched {
+		io.WriteString(conn, bulk(key))
+	}
+}
+
+// incrBy 处理 INCR/INCRBY/DECR,保留键原有的过期时间
+// 调用方需要持有 s.mu
+func (s *fakeRedis) incrBy(conn net.Conn, key string, delta int64) {
+	current := int64(0)
+	if value, ok := s.data[key]; ok {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			io.WriteString(conn, "-ERR value is not an integer or out of range\r\n")
+			return
+		}
+		current = n
+	} else {
+		s.order = append(s.order, key)
+	}
+	current += delta
+	s.data[key] = strconv.FormatInt(current, 10)
+	fmt.Fprintf(conn, ":%d\r\n", current)
+}
+
+// ttl 处理 TTL,键不存在返回 -2,没有过期时间返回 -1
+// 调用方需要持有 s.mu
+func (s *fakeRedis) ttl(conn net.Conn, key string) {
+	if _, ok := s.data[key]; !ok {
+		io.WriteString(conn, ":-2\r\n")
+		return
+	}
+	expiresAt, ok := s.expires[key]
+	if !ok {
+		io.WriteString(conn, ":-1\r\n")
+		return
+	}
+	fmt.Fprintf(conn, ":%d\r\n", int64(time.Until(expiresAt).Round(time.Second)/time.Second))
+}
+
+// newFakeRedisCache 创建连接到 server 的 Redis 缓存
+func newFakeRedisCache(t *testing.T, server *fakeRedis) Cache {
+	t.Helper()
+	config := DefaultConfig()
+	config.Host = "127.0.0.1"
+	config.Port = server.port()
+	config.MinIdleConns = 0
+
+	c, err := NewRedis(config, nil)
+	if err != nil {
+		t.Fatalf("NewRedis: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// readCommand 读取一条 RESP 数组格式的命令
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		value, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(value, "\r\n")
+	}
+	return args, nil
+}
+
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+// TestRedis_PublishSubscribe 测试发布的消息送达订阅方
+func TestRedis_PublishSubscribe(t *testing.T) {
+	c := newFakeRedisCache(t, newFakeRedis(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan string, 1)
+	if err := c.Subscribe(ctx, "rbac:invalidate", func(msg string) { received <- msg }); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if err := c.Publish(ctx, "rbac:invalidate", "user:123"); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		if msg != "user:123" {
+			t.Fatalf("expected user:123, got %q", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("published message was not delivered")
+	}
+}
+
+// TestRedis_DeleteByPattern 测试跨多页 SCAN 游标删除匹配的键
+func TestRedis_DeleteByPattern(t *testing.T) {
+	server := newFakeRedis(t)
+	server.scanPage = 3
+	c := newFakeRedisCache(t, server)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		if err := c.Set(ctx, fmt.Sprintf("user:%d", i), "v", 0); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+	if err := c.Set(ctx, "post:1", "v", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	deleted, err := c.DeleteByPattern(ctx, "user:*")
+	if err != nil {
+		t.Fatalf("DeleteByPattern: %v", err)
+	}
+	if deleted != 10 {
+		t.Fatalf("expected 10 keys deleted, got %d", deleted)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.scans != 4 {
+		t.Fatalf("expected SCAN to follow the cursor across 4 pages, got %d calls", server.scans)
+	}
+	if len(server.data) != 1 || server.data["post:1"] == "" {
+		t.Fatalf("expected only post:1 to remain, got %v", server.data)
+	}
+}
+
+// TestRedis_DeleteByPatternNoMatch 测试没有匹配的键时返回 0,空页也会继续扫描
+func TestRedis_DeleteByPatternNoMatch(t *testing.T) {
+	server := newFakeRedis(t)
+	server.scanPage = 1
+	c := newFakeRedisCache(t, server)
+	ctx := context.Background()
+
+	for _, key := range []string{"post:1", "post:2"} {
+		if err := c.Set(ctx, key, "v", 0); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+
+	deleted, err := c.DeleteByPattern(ctx, "user:*")
+	if err != nil {
+		t.Fatalf("DeleteByPattern: %v", err)
+	}
+	if deleted != 0 {
+		t.Fatalf("expected no keys deleted, got %d", deleted)
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.scans != 2 {
+		t.Fatalf("expected SCAN to continue past empty pages, got %d calls", server.scans)
+	}
+	if len(server.data) != 2 {
+		t.Fatalf("non-matching keys should not be deleted, got %v", server.data)
+	}
+}
+
+// TestRedis_Counters 测试计数器递增与非整数值
+func TestRedis_Counters(t *testing.T) {
+	c := newFakeRedisCache(t, newFakeRedis(t))
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := c.Incr(ctx, "hits")
+		if err != nil || got != want {
+			t.Fatalf("Incr = %d, %v; want %d", got, err, want)
+		}
+	}
+	if got, err := c.IncrBy(ctx, "hits", 10); err != nil || got != 13 {
+		t.Fatalf("IncrBy = %d, %v; want 13", got, err)
+	}
+	if got, err := c.Decr(ctx, "hits"); err != nil || got != 12 {
+		t.Fatalf("Decr = %d, %v; want 12", got, err)
+	}
+
+	if err := c.Set(ctx, "name", "alice", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if _, err := c.Incr(ctx, "name"); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+	if _, err := c.IncrBy(ctx, "name", 5); !errors.Is(err, ErrNotInteger) {
+		t.Fatalf("expected ErrNotInteger, got %v", err)
+	}
+}
+
+// TestRedis_CounterWindow 测试首次 Incr 返回 1 时设置过期时间,后续递增保留窗口
+func TestRedis_CounterWindow(t *testing.T) {
+	c := newFakeRedisCache(t, newFakeRedis(t))
+	ctx := context.Background()
+
+	if err := c.Expire(ctx, "window", time.Minute); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound before first Incr, got %v", err)
+	}
+
+	count, err := c.Incr(ctx, "window")
+	if err != nil || count != 1 {
+		t.Fatalf("Incr = %d, %v; want 1", count, err)
+	}
+	if ttl, _ := c.TTL(ctx, "window"); ttl >= 0 {
+		t.Fatalf("expected no expiry before Expire, got %v", ttl)
+	}
+	if err := c.Expire(ctx, "window", time.Minute); err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+
+	if count, err := c.IncrBy(ctx, "window", 4); err != nil || count != 5 {
+		t.Fatalf("IncrBy = %d, %v; want 5", count, err)
+	}
+	ttl, err := c.TTL(ctx, "window")
+	if err != nil {
+		t.Fatalf("TTL: %v", err)
+	}
+	if ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("expected window expiry to survive increments, got %v", ttl)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // app CLI 应用实现
@@ -14,14 +16,17 @@ type app struct {
 	version     string
 	description string
 	commands    map[string]Command
+	defaults    map[string]interface{}
+	persistent  *Parser
 	mu          sync.RWMutex
 }
 
 // NewApp 创建新的 CLI 应用
 func NewApp(name string) App {
 	return &app{
-		name:     name,
-		commands: make(map[string]Command),
+		name:       name,
+		commands:   make(map[string]Command),
+		persistent: &Parser{},
 	}
 }
 
@@ -72,6 +77,26 @@ func (a *app) AddCommand(cmd Command) error {
 	return nil
 }
 
+// PersistentFlags 返回全局选项集合
+// 其中的选项合并到每个子命令的解析器中，子命令自己的同名选项优先
+func (a *app) PersistentFlags() *Parser {
+	return a.persistent
+}
+
+// LoadDefaultsFromFile 从 YAML/JSON 文件加载选项默认值
+// 文件中的键对应选项长名称，对所有命令生效
+func (a *app) LoadDefaultsFromFile(path string) error {
+	values, err := loadDefaultsFile(path)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.defaults = values
+	a.mu.Unlock()
+	return nil
+}
+
 // Run 执行 CLI
 func (a *app) Run(args []string) error {
 	return a.RunWithIO(args, os.Stdin, os.Stdout, os.Stderr)
@@ -79,8 +104,19 @@ func (a *app) Run(args []string) error {
 
 // RunWithIO 执行 CLI，使用自定义 I/O
 func (a *app) RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	// 全局选项，必须位于命令名之前
+	global, args, err := parseGlobalArgs(args)
+	if err != nil {
+		return err
+	}
+	if global.configFile != "" {
+		if err := a.LoadDefaultsFromFile(global.configFile); err != nil {
+			return err
+		}
+	}
+
 	// 没有参数或只有 help 选项，显示帮助
-	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
+	if len(args) == 0 || isHelpArg(args[0]) {
 		a.printHelp(stdout)
 		return nil
 	}
@@ -95,40 +131,73 @@ func (a *app) RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer
 	cmdName := args[0]
 	a.mu.RLock()
 	cmd, exists := a.commands[cmdName]
+	defaults := a.defaults
 	a.mu.RUnlock()
 
+	// 内置补全命令，用户注册了同名命令时以用户命令为准
+	if !exists && cmdName == DefaultCompletionCommand {
+		return a.runCompletion(args[1:], stdout)
+	}
+
 	if !exists {
 		return &UsageError{
 			Message: fmt.Sprintf("%s: %s", ErrMsgCommandNotFound, cmdName),
 		}
 	}
 
-	// 解析命令选项
-	parser := newFlagParser(cmdName, cmd.Flags())
-	remainingArgs, err := parser.parse(args[1:])
-	if err != nil {
-		return err
+	if !global.verbose {
+		return runCommand(cmdName, cmd, args[1:], defaults, a.persistent, stdin, stdout, stderr)
 	}
+	return runVerbose(cmdName, stderr, func() error {
+		return runCommand(cmdName, cmd, args[1:], defaults, a.persistent, stdin, stdout, stderr)
+	})
+}
 
-	// 创建执行上下文
-	ctx := &Context{
-		Args:   remainingArgs,
-		Flags:  parser.getValues(),
-		Stdin:  stdin,
-		Stdout: stdout,
-		Stderr: stderr,
-	}
+// globalOptions 命令名之前的全局选项
+type globalOptions struct {
+	configFile string
+	verbose    bool
+}
+
+// parseGlobalArgs 解析命令名之前的 --config 和 --verbose，顺序不限
+func parseGlobalArgs(args []string) (globalOptions, []string, error) {
+	var opts globalOptions
+	for len(args) > 0 {
+		if args[0] == "--"+DefaultVerboseFlag {
+			opts.verbose = true
+			args = args[1:]
+			continue
+		}
 
-	// 执行命令
-	if err := cmd.Execute(ctx); err != nil {
-		return &CommandError{
-			Command: cmdName,
-			Message: "execution failed",
-			Cause:   err,
+		configFile, rest, err := splitConfigArg(args)
+		if err != nil {
+			return opts, nil, err
+		}
+		if len(rest) == len(args) {
+			break
 		}
+		opts.configFile, args = configFile, rest
 	}
+	return opts, args, nil
+}
 
-	return nil
+// runVerbose 执行命令并向 stderr 输出开始、耗时和退出原因
+func runVerbose(path string, stderr io.Writer, fn func() error) error {
+	fmt.Fprintf(stderr, "%s: %s\n", MsgCommandStarting, path)
+
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	code := GetExitCode(err)
+	if err == nil {
+		fmt.Fprintf(stderr, "%s: %s (elapsed %s, exit %d: %s)\n",
+			MsgCommandCompleted, path, elapsed, code, ExitReason(err))
+	} else {
+		fmt.Fprintf(stderr, "%s: %s (elapsed %s, exit %d: %s): %v\n",
+			MsgCommandFailed, path, elapsed, code, ExitReason(err), err)
+	}
+	return err
 }
 
 // printHelp 打印帮助信息
@@ -155,22 +224,27 @@ func (a *app) printHelp(w io.Writer) {
 		return
 	}
 
-	// 找到最长的命令名，用于对齐
+	// 按名称排序，保证输出稳定
+	names := make([]string, 0, len(a.commands))
 	maxLen := 0
 	for name := range a.commands {
+		names = append(names, name)
 		if len(name) > maxLen {
 			maxLen = len(name)
 		}
 	}
+	sort.Strings(names)
 
-	for name, cmd := range a.commands {
+	for _, name := range names {
 		padding := strings.Repeat(" ", maxLen-len(name)+2)
-		fmt.Fprintf(w, "  %s%s%s\n", name, padding, cmd.Description())
+		fmt.Fprintf(w, "  %s%s%s\n", name, padding, a.commands[name].Description())
 	}
 
 	fmt.Fprintln(w, "\nFlags:")
-	fmt.Fprintln(w, "  -h, --help       Show help information")
-	fmt.Fprintln(w, "  -v, --version    Show version information")
+	fmt.Fprintln(w, "  -h, --help             Show help information")
+	fmt.Fprintln(w, "  -v, --version          Show version information")
+	fmt.Fprintln(w, "      --config string    Load flag defaults from a YAML/JSON file")
+	fmt.Fprintln(w, "      --verbose          Print command duration and exit reason to stderr")
 
 	fmt.Fprintf(w, "\nRun '%s [command] --help' for more information on a command.\n", a.name)
 }
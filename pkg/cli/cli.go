@@ -2,6 +2,7 @@ package cli
 
 import (
 	"io"
+	"time"
 )
 
 // FlagType 表示选项的类型
@@ -16,6 +17,10 @@ const (
 	FlagTypeBool
 	// FlagTypeStringSlice 字符串数组类型
 	FlagTypeStringSlice
+	// FlagTypeFloat64 浮点数类型
+	FlagTypeFloat64
+	// FlagTypeDuration 时间间隔类型 (如 "5s", "1m30s")
+	FlagTypeDuration
 )
 
 // Flag 表示一个命令行选项
@@ -34,6 +39,9 @@ type Flag struct {
 	Description string
 	// EnvVar 环境变量名 (用于回退)
 	EnvVar string
+	// Secret 是否为敏感选项 (如密码)，也可以通过 Parser.SetSecret 设置
+	// 交互式提示时关闭终端回显，输入不会显示在屏幕上
+	Secret bool
 }
 
 // App CLI 应用接口
@@ -50,10 +58,18 @@ type App interface {
 	SetDescription(desc string)
 	// AddCommand 注册子命令
 	AddCommand(cmd Command) error
+	// PersistentFlags 返回全局选项集合，其中的选项对所有子命令生效
+	// 全局选项写在子命令名之后，与子命令选项一起解析；名称冲突时子命令选项优先
+	PersistentFlags() *Parser
 	// Run 执行 CLI，解析参数并路由到对应命令
 	Run(args []string) error
 	// RunWithIO 执行 CLI，使用自定义 I/O (用于测试)
 	RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+	// LoadDefaultsFromFile 从 YAML/JSON 文件加载选项默认值
+	// 优先级: 命令行选项 > 环境变量 > 配置文件 > Flag.Default
+	LoadDefaultsFromFile(path string) error
+	// GenerateCompletion 根据已注册的命令和选项生成 shell 补全脚本 (bash, zsh)
+	GenerateCompletion(shell string, w io.Writer) error
 }
 
 // Command 命令接口
@@ -70,6 +86,18 @@ type Command interface {
 	Execute(ctx *Context) error
 }
 
+// LongDescriber 可选接口，Command 实现后在 help 中输出详细描述
+type LongDescriber interface {
+	// LongDescription 返回详细描述，可以包含多行
+	LongDescription() string
+}
+
+// Exampler 可选接口，Command 实现后在 help 中输出 Examples 段落
+type Exampler interface {
+	// Examples 返回使用示例，每行一个示例
+	Examples() string
+}
+
 // Context 命令执行上下文
 type Context struct {
 	// Args 位置参数 (去除命令名和选项后的参数)
@@ -123,3 +151,23 @@ func (c *Context) GetStringSlice(name string) []string {
 	}
 	return nil
 }
+
+// GetFloat64 获取浮点数类型的选项值
+func (c *Context) GetFloat64(name string) float64 {
+	if v, ok := c.Flags[name]; ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+	}
+	return 0
+}
+
+// GetDuration 获取时间间隔类型的选项值
+func (c *Context) GetDuration(name string) time.Duration {
+	if v, ok := c.Flags[name]; ok {
+		if d, ok := v.(time.Duration); ok {
+			return d
+		}
+	}
+	return 0
+}
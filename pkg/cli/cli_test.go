@@ -0,0 +1,753 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// update 重新生成 testdata 下的 golden 文件: go test ./pkg/cli -update
+var update = flag.Bool("update", false, "update golden files")
+
+// testCommand 测试用命令，记录执行时的上下文
+type testCommand struct {
+	name  string
+	flags []Flag
+	ctx   *Context
+}
+
+func (c *testCommand) Name() string        { return c.name }
+func (c *testCommand) Description() string { return c.name + " description" }
+func (c *testCommand) Usage() string       { return c.name + " [flags]" }
+func (c *testCommand) Flags() []Flag       { return c.flags }
+
+func (c *testCommand) Execute(ctx *Context) error {
+	c.ctx = ctx
+	return nil
+}
+
+// run 执行 App 并返回标准输出
+func run(t *testing.T, a App, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	err := a.RunWithIO(args, nil, &stdout, io.Discard)
+	return stdout.String(), err
+}
+
+// newGroupApp 创建包含 db 命令组的 App
+func newGroupApp(t *testing.T) (App, *testCommand) {
+	t.Helper()
+
+	migrate := &testCommand{
+		name: "migrate",
+		flags: []Flag{
+			{Name: "dir", Type: FlagTypeString, Default: "./migrations", Description: "Migrations directory"},
+		},
+	}
+
+	db := NewCommandGroup("db", "Database operations")
+	if err := db.AddCommand(migrate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.AddCommand(&testCommand{name: "seed"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a := NewApp("mytool")
+	if err := a.AddCommand(db); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return a, migrate
+}
+
+// TestCommandGroup_Dispatch 测试命令组分发到子命令并解析其选项
+func TestCommandGroup_Dispatch(t *testing.T) {
+	a, migrate := newGroupApp(t)
+
+	if _, err := run(t, a, "db", "migrate", "--dir", "/tmp/m", "extra"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if migrate.ctx == nil {
+		t.Fatal("expected migrate to be executed")
+	}
+	if got := migrate.ctx.GetString("dir"); got != "/tmp/m" {
+		t.Errorf("dir = %q, want %q", got, "/tmp/m")
+	}
+	if len(migrate.ctx.Args) != 1 || migrate.ctx.Args[0] != "extra" {
+		t.Errorf("args = %v, want [extra]", migrate.ctx.Args)
+	}
+}
+
+// TestCommandGroup_UnknownSubcommand 测试未知子命令返回用法错误
+func TestCommandGroup_UnknownSubcommand(t *testing.T) {
+	a, _ := newGroupApp(t)
+
+	_, err := run(t, a, "db", "rollback")
+
+	var usageErr *UsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("expected UsageError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "rollback") {
+		t.Errorf("expected error to mention subcommand, got: %v", err)
+	}
+	if GetExitCode(err) != ExitUsage {
+		t.Errorf("exit code = %d, want %d", GetExitCode(err), ExitUsage)
+	}
+}
+
+// TestCommandGroup_Help 测试命令组帮助列出子命令
+func TestCommandGroup_Help(t *testing.T) {
+	a, _ := newGroupApp(t)
+
+	for _, args := range [][]string{{"db"}, {"db", "--help"}} {
+		out, err := run(t, a, args...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []string{"db [command]", "migrate", "seed"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("help for %v missing %q:\n%s", args, want, out)
+			}
+		}
+		// 按注册顺序输出
+		if strings.Index(out, "migrate") > strings.Index(out, "seed") {
+			t.Errorf("expected subcommands in registration order:\n%s", out)
+		}
+	}
+}
+
+// TestStringSliceFlag 测试字符串数组选项的重复和逗号分隔写法
+func TestStringSliceFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"repeated", []string{"-t", "a", "-t", "b"}, []string{"a", "b"}},
+		{"comma separated", []string{"-t", "a,b"}, []string{"a", "b"}},
+		{"mixed long and short", []string{"--tag", "a", "-t", "b,c"}, []string{"a", "b", "c"}},
+		{"default", nil, []string{"x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &testCommand{
+				name: "build",
+				flags: []Flag{
+					{Name: "tag", ShortName: "t", Type: FlagTypeStringSlice, Default: []string{"x"}},
+				},
+			}
+			a := NewApp("mytool")
+			_ = a.AddCommand(cmd)
+
+			if _, err := run(t, a, append([]string{"build"}, tt.args...)...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := cmd.ctx.GetStringSlice("tag")
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("tag = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRequiredFlag 测试必填选项可由短选项或环境变量满足
+func TestRequiredFlag(t *testing.T) {
+	const envVar = "CLI_TEST_OUTPUT"
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{"long name", []string{"--output", "a"}, "", "a", false},
+		{"short name", []string{"-o", "b"}, "", "b", false},
+		{"env fallback", nil, "c", "c", false},
+		{"flag overrides env", []string{"-o", "d"}, "c", "d", false},
+		{"missing", nil, "", "", true},
+		{"empty value", []string{"--output="}, "", "", true},
+		{"empty short value", []string{"-o", ""}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envVar, tt.env)
+
+			cmd := &testCommand{
+				name: "build",
+				flags: []Flag{
+					{Name: "output", ShortName: "o", Type: FlagTypeString, Required: true, EnvVar: envVar},
+				},
+			}
+			a := NewApp("mytool")
+			_ = a.AddCommand(cmd)
+
+			_, err := run(t, a, append([]string{"build"}, tt.args...)...)
+			if tt.wantErr {
+				var usageErr *UsageError
+				if !errors.As(err, &usageErr) {
+					t.Fatalf("expected UsageError, got %v", err)
+				}
+				if !strings.Contains(err.Error(), "-o") || !strings.Contains(err.Error(), envVar) {
+					t.Errorf("expected error to mention short name and env var, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := cmd.ctx.GetString("output"); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetEnvFallback 测试通过 Parser 为子命令选项设置环境变量回退
+func TestSetEnvFallback(t *testing.T) {
+	const envVar = "CLI_TEST_TOKEN"
+
+	cmd := &testCommand{
+		name:  "login",
+		flags: []Flag{{Name: "token", Type: FlagTypeString, Required: true}},
+	}
+	a := NewApp("mytool")
+	_ = a.AddCommand(cmd)
+	a.PersistentFlags().SetEnvFallback("token", envVar)
+
+	t.Setenv(envVar, "")
+	_, err := run(t, a, "login")
+	var usageErr *UsageError
+	if !errors.As(err, &usageErr) || !strings.Contains(err.Error(), envVar) {
+		t.Fatalf("expected missing flag error mentioning $%s, got %v", envVar, err)
+	}
+
+	t.Setenv(envVar, "secret")
+	if _, err := run(t, a, "login"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cmd.ctx.GetString("token"); got != "secret" {
+		t.Errorf("token = %q, want %q", got, "secret")
+	}
+}
+
+// TestInvalidDefaults 测试环境变量或配置文件中无法解析的默认值返回 UsageError
+func TestInvalidDefaults(t *testing.T) {
+	const envVar = "CLI_TEST_PORT"
+
+	dir := t.TempDir()
+	badFile := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(badFile, []byte("timeout: soon\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want string
+	}{
+		{"env int", []string{"serve"}, "abc", `--port: "abc" from $` + envVar + ` is not a valid int`},
+		{"file duration", []string{"--config", badFile, "serve"}, "", `--timeout: "soon" from config file is not a valid duration`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envVar, tt.env)
+
+			cmd := &testCommand{
+				name: "serve",
+				flags: []Flag{
+					{Name: "port", Type: FlagTypeInt, Default: 80, EnvVar: envVar},
+					{Name: "timeout", Type: FlagTypeDuration, Default: "30s"},
+				},
+			}
+			a := NewApp("mytool")
+			_ = a.AddCommand(cmd)
+
+			_, err := run(t, a, tt.args...)
+			var usageErr *UsageError
+			if !errors.As(err, &usageErr) {
+				t.Fatalf("expected UsageError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), tt.want)
+			}
+			if cmd.ctx != nil {
+				t.Error("command should not run with an invalid default")
+			}
+		})
+	}
+}
+
+// TestFloatAndDurationFlags 测试浮点数和时间间隔选项
+func TestFloatAndDurationFlags(t *testing.T) {
+	newCmd := func() *testCommand {
+		return &testCommand{
+			name: "bench",
+			flags: []Flag{
+				{Name: "rate", ShortName: "r", Type: FlagTypeFloat64, Default: 1.0},
+				{Name: "timeout", Type: FlagTypeDuration, Default: "30s"},
+			},
+		}
+	}
+
+	cmd := newCmd()
+	a := NewApp("mytool")
+	_ = a.AddCommand(cmd)
+
+	if _, err := run(t, a, "bench", "-timeout", "5s", "-rate", "1.5"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cmd.ctx.GetDuration("timeout"); got != 5*time.Second {
+		t.Errorf("timeout = %v, want %v", got, 5*time.Second)
+	}
+	if got := cmd.ctx.GetFloat64("rate"); got != 1.5 {
+		t.Errorf("rate = %v, want %v", got, 1.5)
+	}
+
+	// 默认值
+	cmd = newCmd()
+	a = NewApp("mytool")
+	_ = a.AddCommand(cmd)
+
+	if _, err := run(t, a, "bench"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cmd.ctx.GetDuration("timeout"); got != 30*time.Second {
+		t.Errorf("default timeout = %v, want %v", got, 30*time.Second)
+	}
+	if got := cmd.ctx.GetFloat64("rate"); got != 1.0 {
+		t.Errorf("default rate = %v, want %v", got, 1.0)
+	}
+
+	// 非法值
+	_, err := run(t, a, "bench", "-timeout", "soon")
+	var usageErr *UsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("expected UsageError for invalid duration, got %v", err)
+	}
+}
+
+// TestGenerateCompletion 测试补全脚本包含所有命令和选项
+func TestGenerateCompletion(t *testing.T) {
+	a, _ := newGroupApp(t)
+	_ = a.AddCommand(&testCommand{
+		name: "build",
+		flags: []Flag{
+			{Name: "output", ShortName: "o", Type: FlagTypeString},
+			{Name: "tag", Type: FlagTypeStringSlice},
+		},
+	})
+
+	out, err := run(t, a, "completion", "bash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"--output", "-o", "--tag", "--dir", "db", "migrate", "seed", "complete -F _mytool mytool"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("bash completion missing %q:\n%s", want, out)
+		}
+	}
+
+	var zsh bytes.Buffer
+	if err := a.GenerateCompletion("zsh", &zsh); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(zsh.String(), "'--dir:Migrations directory'") {
+		t.Errorf("zsh completion missing flag description:\n%s", zsh.String())
+	}
+
+	_, err = run(t, a, "completion", "fish")
+	var usageErr *UsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("expected UsageError for unsupported shell, got %v", err)
+	}
+}
+
+// docCommand 实现 LongDescriber 和 Exampler 的测试命令
+type docCommand struct {
+	testCommand
+}
+
+func (c *docCommand) LongDescription() string {
+	return "Generate model files from the database schema.\nExisting files are kept unless --force is set."
+}
+
+func (c *docCommand) Examples() string {
+	return `mytool generate --model User
+mytool generate -m User -o ./models --force`
+}
+
+// TestCommandHelp_Golden 测试命令帮助输出与 golden 文件一致
+func TestCommandHelp_Golden(t *testing.T) {
+	cmd := &docCommand{testCommand{
+		name: "generate",
+		flags: []Flag{
+			{Name: "model", ShortName: "m", Type: FlagTypeString, Required: true, Description: "Model name"},
+			{Name: "output", ShortName: "o", Type: FlagTypeString, Default: "./models", EnvVar: "OUTPUT_DIR", Description: "Output directory"},
+			{Name: "force", Type: FlagTypeBool, Default: false, Description: "Overwrite existing files"},
+		},
+	}}
+	a := NewApp("mytool")
+	_ = a.AddCommand(cmd)
+
+	out, err := run(t, a, "generate", "--help")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cmd.ctx != nil {
+		t.Fatal("expected command not to be executed for --help")
+	}
+
+	golden := filepath.Join("testdata", "help_generate.golden")
+	if *update {
+		if err := os.WriteFile(golden, []byte(out), 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if out != string(want) {
+		t.Errorf("help output mismatch\n--- got ---\n%s\n--- want ---\n%s", out, want)
+	}
+}
+
+// TestLoadDefaultsFromFile 测试配置文件默认值的优先级
+func TestLoadDefaultsFromFile(t *testing.T) {
+	const envVar = "CLI_TEST_HOST"
+
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "tool.yaml")
+	if err := os.WriteFile(yamlFile, []byte("port: 9000\nhost: file.local\ntags: [a, b]\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	jsonFile := filepath.Join(dir, "tool.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"port": 7000}`), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      string
+		wantPort int
+		wantHost string
+	}{
+		{"file overrides default", []string{"--config", yamlFile, "serve"}, "", 9000, "file.local"},
+		{"flag overrides file", []string{"--config", yamlFile, "serve", "-p", "8080"}, "", 8080, "file.local"},
+		{"env overrides file", []string{"--config=" + yamlFile, "serve"}, "env.local", 9000, "env.local"},
+		{"json file", []string{"--config", jsonFile, "serve"}, "", 7000, "localhost"},
+		{"no file", []string{"serve"}, "", 80, "localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envVar, tt.env)
+
+			cmd := &testCommand{
+				name: "serve",
+				flags: []Flag{
+					{Name: "port", ShortName: "p", Type: FlagTypeInt, Default: 80},
+					{Name: "host", Type: FlagTypeString, Default: "localhost", EnvVar: envVar},
+					{Name: "tags", Type: FlagTypeStringSlice},
+				},
+			}
+			a := NewApp("mytool")
+			_ = a.AddCommand(cmd)
+
+			if _, err := run(t, a, tt.args...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := cmd.ctx.GetInt("port"); got != tt.wantPort {
+				t.Errorf("port = %d, want %d", got, tt.wantPort)
+			}
+			if got := cmd.ctx.GetString("host"); got != tt.wantHost {
+				t.Errorf("host = %q, want %q", got, tt.wantHost)
+			}
+		})
+	}
+
+	// 数组值
+	cmd := &testCommand{name: "serve", flags: []Flag{{Name: "tags", Type: FlagTypeStringSlice}}}
+	a := NewApp("mytool")
+	_ = a.AddCommand(cmd)
+	if err := a.LoadDefaultsFromFile(yamlFile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := run(t, a, "serve"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(cmd.ctx.GetStringSlice("tags"), ","); got != "a,b" {
+		t.Errorf("tags = %q, want %q", got, "a,b")
+	}
+
+	// 文件不存在
+	err := a.LoadDefaultsFromFile(filepath.Join(dir, "missing.yaml"))
+	if GetExitCode(err) != ExitConfig {
+		t.Errorf("exit code = %d, want %d (err: %v)", GetExitCode(err), ExitConfig, err)
+	}
+}
+
+// TestVerbose_Timing 测试 --verbose 输出耗时和退出原因，不影响标准输出
+func TestVerbose_Timing(t *testing.T) {
+	a := NewApp("mytool")
+	serve := &testCommand{name: "serve"}
+	if err := a.AddCommand(serve); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := a.RunWithIO([]string{"--verbose", "serve"}, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if serve.ctx == nil {
+		t.Fatal("command was not executed")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("verbose output should go to stderr, stdout = %q", stdout.String())
+	}
+
+	out := stderr.String()
+	if !strings.Contains(out, MsgCommandStarting+": serve") {
+		t.Errorf("missing starting message:\n%s", out)
+	}
+	completed := regexp.MustCompile(MsgCommandCompleted + `: serve \(elapsed [0-9.]+[nµm]?s, exit 0: success\)`)
+	if !completed.MatchString(out) {
+		t.Errorf("missing completion message with duration:\n%s", out)
+	}
+
+	// 参数错误与运行时错误区分退出原因
+	stderr.Reset()
+	err := a.RunWithIO([]string{"--config=", "--verbose", "serve", "--unknown"}, nil, io.Discard, &stderr)
+	if GetExitCode(err) != ExitUsage {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), MsgCommandFailed+": serve") || !strings.Contains(stderr.String(), "exit 2: "+ExitReasonUsage) {
+		t.Errorf("missing failure message with usage reason:\n%s", stderr.String())
+	}
+
+	// 未开启时不输出
+	stderr.Reset()
+	if err := a.RunWithIO([]string{"serve"}, nil, io.Discard, &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("non-verbose run should not write to stderr, got %q", stderr.String())
+	}
+}
+
+// TestExitReason 测试退出原因分类
+func TestExitReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ExitReasonSuccess},
+		{&UsageError{Message: "bad"}, ExitReasonUsage},
+		{&CommandError{Command: "serve", Message: "boom"}, ExitReasonRuntime},
+		{errors.New("plain"), ExitReasonRuntime},
+		{&ConfigError{File: "a.yaml", Message: "bad"}, ExitReasonConfig},
+		{&CancelledError{}, ExitReasonInterrupted},
+	}
+	for _, tt := range tests {
+		if got := ExitReason(tt.err); got != tt.want {
+			t.Errorf("ExitReason(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestPromptMissing 测试交互式提示填写缺失的必填选项，敏感选项不回显
+func TestPromptMissing(t *testing.T) {
+	flags := []Flag{
+		{Name: "name", Type: FlagTypeString, Required: true, Description: "User name"},
+		{Name: "password", Type: FlagTypeString, Required: true, Secret: true},
+		{Name: "port", Type: FlagTypeInt, Required: true},
+	}
+
+	var out bytes.Buffer
+	p := newFlagParser("create-user", flags)
+	p.promptIn = strings.NewReader("alice\ns3cret\n8080\n")
+	p.promptOut = &out
+	if _, err := p.parse([]string{"--port", "9090"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values := p.getValues()
+	if values["name"] != "alice" || values["password"] != "s3cret" {
+		t.Errorf("unexpected values: %v", values)
+	}
+	// 命令行已提供的选项不提示
+	if values["port"] != 9090 {
+		t.Errorf("port = %v, want 9090", values["port"])
+	}
+
+	prompts := out.String()
+	if !strings.Contains(prompts, "--name (User name): ") {
+		t.Errorf("missing name prompt: %q", prompts)
+	}
+	if !strings.Contains(prompts, "--password [input hidden]: ") {
+		t.Errorf("missing masked password prompt: %q", prompts)
+	}
+	if strings.Contains(prompts, "s3cret") || strings.Contains(prompts, "--port") {
+		t.Errorf("unexpected prompt output: %q", prompts)
+	}
+
+	// 输入为空时仍然报缺失错误
+	p = newFlagParser("create-user", flags[:1])
+	p.promptIn = strings.NewReader("\n")
+	p.promptOut = io.Discard
+	_, err := p.parse(nil)
+	var usageErr *UsageError
+	if !errors.As(err, &usageErr) || !strings.Contains(err.Error(), "--name") {
+		t.Fatalf("expected missing --name usage error, got %v", err)
+	}
+}
+
+// TestPromptMissing_NonTTY 测试未开启提示或输入不是终端时不提示，保持缺失即报错
+func TestPromptMissing_NonTTY(t *testing.T) {
+	for _, optIn := range []bool{false, true} {
+		cmd := &testCommand{
+			name:  "login",
+			flags: []Flag{{Name: "token", Type: FlagTypeString, Required: true, Secret: true}},
+		}
+		a := NewApp("tool")
+		if err := a.AddCommand(cmd); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var stderr bytes.Buffer
+		stdin := strings.NewReader("abc\n")
+		if optIn {
+			a.PersistentFlags().PromptMissing(stdin, &stderr)
+		}
+
+		err := a.RunWithIO([]string{"login"}, stdin, io.Discard, &stderr)
+		if GetExitCode(err) != ExitUsage {
+			t.Fatalf("optIn=%v: expected usage error, got %v", optIn, err)
+		}
+		if stderr.Len() != 0 || cmd.ctx != nil {
+			t.Errorf("optIn=%v: non-terminal stdin should not be prompted, stderr %q", optIn, stderr.String())
+		}
+	}
+}
+
+// TestSetSecret 测试通过 Parser 标记的敏感选项在提示时隐藏输入
+func TestSetSecret(t *testing.T) {
+	opts := &Parser{}
+	opts.SetSecret("password")
+
+	own := []Flag{{Name: "password", Type: FlagTypeString, Required: true}}
+	flags := opts.apply(own)
+	if own[0].Secret {
+		t.Fatal("apply should not modify the command's flags")
+	}
+
+	var out bytes.Buffer
+	p := newFlagParser("login", flags)
+	p.promptIn = strings.NewReader("s3cret\n")
+	p.promptOut = &out
+	if _, err := p.parse(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := p.getValues()["password"]; got != "s3cret" {
+		t.Errorf("password = %v, want s3cret", got)
+	}
+	if prompts := out.String(); !strings.Contains(prompts, "--password [input hidden]: ") || strings.Contains(prompts, "s3cret") {
+		t.Errorf("unexpected prompt output: %q", prompts)
+	}
+}
+
+// TestPersistentFlags 测试全局选项对未声明它的子命令生效，名称冲突时子命令选项优先
+func TestPersistentFlags(t *testing.T) {
+	a, migrate := newGroupApp(t)
+	serve := &testCommand{
+		name: "serve",
+		flags: []Flag{
+			{Name: "log-level", Type: FlagTypeInt, Default: 3, Description: "Numeric log level"},
+			{Name: "port", ShortName: "v", Type: FlagTypeInt, Default: 8080, Description: "Port"},
+		},
+	}
+	if err := a.AddCommand(serve); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	persistent := a.PersistentFlags()
+	for _, f := range []Flag{
+		{Name: "verbose", ShortName: "v", Type: FlagTypeBool, Description: "Verbose output"},
+		{Name: "log-level", Type: FlagTypeString, Default: "info", Description: "Log level"},
+	} {
+		if err := persistent.AddFlag(f); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := persistent.AddFlag(Flag{Name: "verbose", Type: FlagTypeBool}); err == nil {
+		t.Error("expected error for duplicate persistent flag")
+	}
+
+	// 嵌套子命令没有声明 --verbose，全局选项仍然生效
+	if _, err := run(t, a, "db", "migrate", "--verbose", "-v", "--log-level", "debug"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !migrate.ctx.GetBool("verbose") {
+		t.Error("expected persistent --verbose to be honored by db migrate")
+	}
+	if got := migrate.ctx.GetString("log-level"); got != "debug" {
+		t.Errorf("log-level = %q, want debug", got)
+	}
+	if got := migrate.ctx.GetString("dir"); got != "./migrations" {
+		t.Errorf("own flag dir = %q, want default", got)
+	}
+
+	// 未使用时取全局选项的默认值
+	if _, err := run(t, a, "db", "migrate"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migrate.ctx.GetBool("verbose") || migrate.ctx.GetString("log-level") != "info" {
+		t.Errorf("unexpected persistent defaults: %v", migrate.ctx.Flags)
+	}
+
+	// 子命令的 --log-level 和 -v 优先，全局 --verbose 仍可用长名称
+	if _, err := run(t, a, "serve", "--log-level", "5", "-v", "9000", "--verbose"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := serve.ctx.GetInt("log-level"); got != 5 {
+		t.Errorf("log-level = %d, want 5 (subcommand flag)", got)
+	}
+	if got := serve.ctx.GetInt("port"); got != 9000 {
+		t.Errorf("port = %d, want 9000", got)
+	}
+	if !serve.ctx.GetBool("verbose") {
+		t.Error("expected persistent --verbose to be honored by serve")
+	}
+
+	// 命令帮助中单独列出未被覆盖的全局选项
+	out, err := run(t, a, "serve", "--help")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, global, ok := strings.Cut(out, "Global Flags:")
+	if !ok {
+		t.Fatalf("missing global flags section:\n%s", out)
+	}
+	if !strings.Contains(global, "    --verbose") || strings.Contains(global, "log-level") {
+		t.Errorf("unexpected global flags section:\n%s", out)
+	}
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// completionNode 补全树中的一个节点
+// path 为命令路径（根节点为空），words 为该路径下可补全的候选项
+type completionNode struct {
+	path  string
+	group bool
+	words []completionWord
+}
+
+// completionWord 补全候选项
+type completionWord struct {
+	name        string
+	description string
+}
+
+// GenerateCompletion 生成 shell 补全脚本
+// 脚本内容直接来自已注册的命令和 Flag 元数据，新增命令或选项后重新生成即可
+func (a *app) GenerateCompletion(shell string, w io.Writer) error {
+	nodes := a.completionNodes()
+
+	switch shell {
+	case ShellBash:
+		writeBashCompletion(w, a.name, nodes)
+	case ShellZsh:
+		writeZshCompletion(w, a.name, nodes)
+	default:
+		return &UsageError{
+			Command: DefaultCompletionCommand,
+			Message: fmt.Sprintf("%s: %q (supported: %s, %s)", ErrMsgUnsupportedShell, shell, ShellBash, ShellZsh),
+		}
+	}
+	return nil
+}
+
+// runCompletion 执行内置的 completion 命令
+func (a *app) runCompletion(args []string, stdout io.Writer) error {
+	if len(args) == 0 || isHelpArg(args[0]) {
+		fmt.Fprintf(stdout, "Usage:\n  %s %s [%s|%s]\n", a.name, DefaultCompletionCommand, ShellBash, ShellZsh)
+		fmt.Fprintf(stdout, "\nExample:\n  source <(%s %s %s)\n", a.name, DefaultCompletionCommand, ShellBash)
+		return nil
+	}
+	return a.GenerateCompletion(args[0], stdout)
+}
+
+// completionNodes 遍历命令树，收集每个命令路径的候选项
+func (a *app) completionNodes() []completionNode {
+	a.mu.RLock()
+	names := make([]string, 0, len(a.commands))
+	for name := range a.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	cmds := make([]Command, 0, len(names))
+	for _, name := range names {
+		cmds = append(cmds, a.commands[name])
+	}
+	a.mu.RUnlock()
+
+	root := completionNode{group: true}
+	for _, cmd := range cmds {
+		root.words = append(root.words, completionWord{name: cmd.Name(), description: cmd.Description()})
+	}
+	root.words = append(root.words,
+		completionWord{name: "--help", description: "Show help information"},
+		completionWord{name: "--version", description: "Show version information"},
+	)
+
+	persistent := a.persistent.Flags()
+	nodes := []completionNode{root}
+	for _, cmd := range cmds {
+		nodes = appendCompletionNodes(nodes, cmd.Name(), cmd, persistent)
+	}
+	return nodes
+}
+
+// appendCompletionNodes 递归收集命令及其子命令的补全节点
+// 普通命令的候选项包含合并后的全局选项
+func appendCompletionNodes(nodes []completionNode, path string, cmd Command, persistent []Flag) []completionNode {
+	node := completionNode{path: path}
+
+	if group, ok := cmd.(*CommandGroup); ok {
+		node.group = true
+		subs := group.Commands()
+		for _, sub := range subs {
+			node.words = append(node.words, completionWord{name: sub.Name(), description: sub.Description()})
+		}
+		node.words = append(node.words, completionWord{name: "--help", description: "Show help information"})
+
+		nodes = append(nodes, node)
+		for _, sub := range subs {
+			nodes = appendCompletionNodes(nodes, path+" "+sub.Name(), sub, persistent)
+		}
+		return nodes
+	}
+
+	for _, f := range mergePersistentFlags(cmd.Flags(), persistent) {
+		node.words = append(node.words, completionWord{name: "--" + f.Name, description: f.Description})
+		if f.ShortName != "" {
+			node.words = append(node.words, completionWord{name: "-" + f.ShortName, description: f.Description})
+		}
+	}
+	node.words = append(node.words, completionWord{name: "--help", description: "Show help information"})
+
+	return append(nodes, node)
+}
+
+// groupPathPattern 生成匹配所有命令组路径的 case 模式
+// 只有位于命令组下的位置参数才会被视为子命令，普通命令之后的参数（如选项值）不再改变路径
+func groupPathPattern(nodes []completionNode) string {
+	var patterns []string
+	for _, node := range nodes {
+		if node.group {
+			patterns = append(patterns, fmt.Sprintf("%q", node.path))
+		}
+	}
+	return strings.Join(patterns, "|")
+}
+
+// completionFuncName 生成补全函数名
+func completionFuncName(appName string) string {
+	return "_" + strings.NewReplacer("-", "_", ".", "_").Replace(appName)
+}
+
+// writeBashCompletion 输出 bash 补全脚本
+func writeBashCompletion(w io.Writer, appName string, nodes []completionNode) {
+	fn := completionFuncName(appName)
+
+	fmt.Fprintf(w, "# bash completion for %s\n", appName)
+	fmt.Fprintf(w, "# Usage: source <(%s %s %s)\n\n", appName, DefaultCompletionCommand, ShellBash)
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintln(w, `    local cur cmdpath word opts i`)
+	fmt.Fprintln(w, `    COMPREPLY=()`)
+	fmt.Fprintln(w, `    cur="${COMP_WORDS[COMP_CWORD]}"`)
+	fmt.Fprintln(w, `    cmdpath=""`)
+	fmt.Fprintln(w, `    for ((i=1; i<COMP_CWORD; i++)); do`)
+	fmt.Fprintln(w, `        word="${COMP_WORDS[i]}"`)
+	fmt.Fprintln(w, `        [[ "$word" == -* ]] && continue`)
+	fmt.Fprintln(w, `        case "$cmdpath" in`)
+	fmt.Fprintf(w, "            %s) cmdpath=\"${cmdpath:+$cmdpath }$word\" ;;\n", groupPathPattern(nodes))
+	fmt.Fprintln(w, `        esac`)
+	fmt.Fprintln(w, `    done`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    case "$cmdpath" in`)
+	for _, node := range nodes {
+		names := make([]string, 0, len(node.words))
+		for _, word := range node.words {
+			names = append(names, word.name)
+		}
+		fmt.Fprintf(w, "        %q) opts=%q ;;\n", node.path, strings.Join(names, " "))
+	}
+	fmt.Fprintln(w, `        *) opts="" ;;`)
+	fmt.Fprintln(w, `    esac`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    COMPREPLY=( $(compgen -W "$opts" -- "$cur") )`)
+	fmt.Fprintln(w, `}`)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "complete -F %s %s\n", fn, appName)
+}
+
+// writeZshCompletion 输出 zsh 补全脚本（带描述）
+func writeZshCompletion(w io.Writer, appName string, nodes []completionNode) {
+	fn := completionFuncName(appName)
+
+	fmt.Fprintf(w, "#compdef %s\n\n", appName)
+	fmt.Fprintf(w, "# zsh completion for %s\n", appName)
+	fmt.Fprintf(w, "# Usage: source <(%s %s %s)\n\n", appName, DefaultCompletionCommand, ShellZsh)
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintln(w, `    local cmdpath="" word i`)
+	fmt.Fprintln(w, `    local -a opts`)
+	fmt.Fprintln(w, `    for ((i=2; i<CURRENT; i++)); do`)
+	fmt.Fprintln(w, `        word="${words[i]}"`)
+	fmt.Fprintln(w, `        [[ "$word" == -* ]] && continue`)
+	fmt.Fprintln(w, `        case "$cmdpath" in`)
+	fmt.Fprintf(w, "            %s) cmdpath=\"${cmdpath:+$cmdpath }$word\" ;;\n", groupPathPattern(nodes))
+	fmt.Fprintln(w, `        esac`)
+	fmt.Fprintln(w, `    done`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    case "$cmdpath" in`)
+	for _, node := range nodes {
+		fmt.Fprintf(w, "        %q)\n", node.path)
+		fmt.Fprintln(w, "            opts=(")
+		for _, word := range node.words {
+			fmt.Fprintf(w, "                %s\n", zshQuote(word.name+":"+word.description))
+		}
+		fmt.Fprintln(w, "            ) ;;")
+	}
+	fmt.Fprintln(w, `    esac`)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `    _describe 'command' opts`)
+	fmt.Fprintln(w, `}`)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "compdef %s %s\n", fn, appName)
+}
+
+// zshQuote 使用单引号转义字符串
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -25,10 +25,14 @@ const (
 	ErrMsgMissingRequired = "missing required flag"
 	// ErrMsgDuplicateCommand 重复的命令名
 	ErrMsgDuplicateCommand = "duplicate command name"
+	// ErrMsgDuplicateFlag 重复的选项名
+	ErrMsgDuplicateFlag = "duplicate flag name"
 	// ErrMsgCancelled 操作已取消
 	ErrMsgCancelled = "operation cancelled"
 	// ErrMsgInvalidFlagValue 无效的选项值
 	ErrMsgInvalidFlagValue = "invalid flag value"
+	// ErrMsgUnsupportedShell 不支持的 shell 类型
+	ErrMsgUnsupportedShell = "unsupported shell"
 )
 
 // 默认值
@@ -37,4 +41,42 @@ const (
 	DefaultHelpFlag = "help"
 	// DefaultVersionFlag version 选项名
 	DefaultVersionFlag = "version"
+	// DefaultConfigFlag 全局配置文件选项名
+	DefaultConfigFlag = "config"
+	// DefaultCompletionCommand 内置补全命令名
+	DefaultCompletionCommand = "completion"
+	// DefaultVerboseFlag 全局详细输出选项名
+	DefaultVerboseFlag = "verbose"
+)
+
+// 详细模式下输出到 stderr 的执行信息
+const (
+	// MsgCommandStarting 命令开始执行
+	MsgCommandStarting = "command starting"
+	// MsgCommandCompleted 命令执行成功
+	MsgCommandCompleted = "command completed"
+	// MsgCommandFailed 命令执行失败
+	MsgCommandFailed = "command failed"
+)
+
+// 退出原因,与退出码一一对应
+const (
+	// ExitReasonSuccess 成功
+	ExitReasonSuccess = "success"
+	// ExitReasonUsage 参数错误
+	ExitReasonUsage = "usage error"
+	// ExitReasonRuntime 运行时错误
+	ExitReasonRuntime = "runtime error"
+	// ExitReasonConfig 配置错误
+	ExitReasonConfig = "config error"
+	// ExitReasonInterrupted 用户中断
+	ExitReasonInterrupted = "interrupted"
+)
+
+// 支持补全的 shell 类型
+const (
+	// ShellBash bash
+	ShellBash = "bash"
+	// ShellZsh zsh
+	ShellZsh = "zsh"
 )
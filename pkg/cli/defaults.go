@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadDefaultsFile 读取 YAML/JSON 配置文件为选项默认值
+// 键为选项长名称，如:
+//
+//	port: 9000
+//	tags: [a, b]
+//	timeout: 5s
+func loadDefaultsFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ConfigError{File: path, Message: "failed to read file", Cause: err}
+	}
+
+	values := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	default:
+		return nil, &ConfigError{File: path, Message: fmt.Sprintf("unsupported file format %q (supported: .yaml, .yml, .json)", filepath.Ext(path))}
+	}
+	if err != nil {
+		return nil, &ConfigError{File: path, Message: "failed to parse file", Cause: err}
+	}
+
+	// 数组统一转换为 []string，便于字符串数组选项直接使用
+	for key, val := range values {
+		if list, ok := val.([]interface{}); ok {
+			strs := make([]string, 0, len(list))
+			for _, item := range list {
+				strs = append(strs, fmt.Sprint(item))
+			}
+			values[key] = strs
+		}
+	}
+
+	return values, nil
+}
+
+// splitConfigArg 解析位于命令名之前的全局 --config 选项
+// 支持 "--config file" 和 "--config=file" 两种写法，返回文件路径和剩余参数
+func splitConfigArg(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", args, nil
+	}
+
+	name := "--" + DefaultConfigFlag
+	switch {
+	case args[0] == name:
+		if len(args) < 2 {
+			return "", nil, &UsageError{Message: fmt.Sprintf("flag needs an argument: %s", name)}
+		}
+		return args[1], args[2:], nil
+	case strings.HasPrefix(args[0], name+"="):
+		return strings.TrimPrefix(args[0], name+"="), args[1:], nil
+	default:
+		return "", args, nil
+	}
+}
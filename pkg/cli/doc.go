@@ -76,6 +76,16 @@ Defining a command:
 	    return nil
 	}
 
+Grouping subcommands:
+
+	db := cli.NewCommandGroup("db", "Database operations")
+	db.AddCommand(&MigrateCommand{})
+	db.AddCommand(&SeedCommand{})
+	app.AddCommand(db)
+
+	// mytool db migrate --dir ./migrations
+	// mytool db --help   lists migrate and seed
+
 # Testing
 
 Commands are testable using custom I/O:
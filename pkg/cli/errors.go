@@ -46,6 +46,31 @@ func (e *CommandError) ExitCode() int {
 	return ExitError
 }
 
+// ConfigError 表示配置文件错误
+type ConfigError struct {
+	File    string
+	Message string
+	Cause   error
+}
+
+// Error 实现 error 接口
+func (e *ConfigError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("config %s: %s: %v", e.File, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("config %s: %s", e.File, e.Message)
+}
+
+// Unwrap 实现 errors.Unwrap 接口
+func (e *ConfigError) Unwrap() error {
+	return e.Cause
+}
+
+// ExitCode 返回退出码
+func (e *ConfigError) ExitCode() int {
+	return ExitConfig
+}
+
 // CancelledError 表示用户取消操作
 type CancelledError struct {
 	Message string
@@ -82,3 +107,20 @@ func GetExitCode(err error) int {
 	}
 	return ExitError
 }
+
+// ExitReason 返回错误对应的退出原因
+// 按 GetExitCode 的结果分类,未知的退出码视为运行时错误
+func ExitReason(err error) string {
+	switch GetExitCode(err) {
+	case ExitSuccess:
+		return ExitReasonSuccess
+	case ExitUsage:
+		return ExitReasonUsage
+	case ExitConfig:
+		return ExitReasonConfig
+	case ExitInterrupted:
+		return ExitReasonInterrupted
+	default:
+		return ExitReasonRuntime
+	}
+}
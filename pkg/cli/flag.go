@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // flagParser 选项解析器
@@ -14,6 +17,14 @@ type flagParser struct {
 	flags  []Flag
 	values map[string]interface{}
 	fs     *flag.FlagSet
+
+	// defaults 配置文件提供的默认值，优先级高于 Flag.Default
+	defaults map[string]interface{}
+
+	// promptIn/promptOut 不为 nil 时，缺失的必填选项通过交互式提示填写
+	// 仅在调用了 Parser.PromptMissing 且输入为终端时设置，非交互环境保持缺失即报错
+	promptIn  io.Reader
+	promptOut io.Writer
 }
 
 // newFlagParser 创建选项解析器
@@ -32,11 +43,17 @@ func (p *flagParser) parse(args []string) ([]string, error) {
 
 	// 注册所有选项
 	for _, f := range p.flags {
-		p.registerFlag(f)
+		if err := p.registerFlag(f); err != nil {
+			return nil, err
+		}
 	}
 
 	// 解析参数
 	if err := p.fs.Parse(args); err != nil {
+		// -h/--help 由调用方输出命令帮助
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
 		return nil, &UsageError{Message: err.Error()}
 	}
 
@@ -45,6 +62,13 @@ func (p *flagParser) parse(args []string) ([]string, error) {
 		return nil, err
 	}
 
+	// 交互式提示缺失的必填选项
+	if p.promptIn != nil {
+		if err := p.promptMissing(p.promptIn, p.promptOut); err != nil {
+			return nil, err
+		}
+	}
+
 	// 验证必填选项
 	if err := p.validate(); err != nil {
 		return nil, err
@@ -55,15 +79,21 @@ func (p *flagParser) parse(args []string) ([]string, error) {
 }
 
 // registerFlag 注册单个选项到 flag.FlagSet
-func (p *flagParser) registerFlag(f Flag) {
-	// 从环境变量获取默认值
-	defaultVal := f.Default
+// 环境变量、配置文件或 Flag.Default 提供的默认值无法转换为选项类型时返回 UsageError，
+// 避免 PORT=abc 这类错误配置被静默当作零值
+func (p *flagParser) registerFlag(f Flag) error {
+	// 默认值优先级: 环境变量 > 配置文件 > Flag.Default
+	defaultVal, source := f.Default, "default"
+	if v, ok := p.defaults[f.Name]; ok {
+		defaultVal, source = v, "config file"
+	}
 	if f.EnvVar != "" {
 		if envVal := os.Getenv(f.EnvVar); envVal != "" {
-			defaultVal = envVal
+			defaultVal, source = envVal, "$"+f.EnvVar
 		}
 	}
 
+	var err error
 	switch f.Type {
 	case FlagTypeString:
 		defStr := ""
@@ -71,52 +101,116 @@ func (p *flagParser) registerFlag(f Flag) {
 			defStr = fmt.Sprint(defaultVal)
 		}
 		p.fs.String(f.Name, defStr, f.Description)
-		if f.ShortName != "" {
-			p.fs.String(f.ShortName, defStr, f.Description)
-		}
 
 	case FlagTypeInt:
 		defInt := 0
 		if defaultVal != nil {
-			if i, ok := defaultVal.(int); ok {
-				defInt = i
-			} else if s, ok := defaultVal.(string); ok {
-				defInt, _ = strconv.Atoi(s)
+			switch d := defaultVal.(type) {
+			case int:
+				defInt = d
+			case float64:
+				// JSON 数字解析为 float64，只接受整数值
+				if d != math.Trunc(d) {
+					err = errInvalidDefault
+				}
+				defInt = int(d)
+			case string:
+				defInt, err = strconv.Atoi(d)
+			default:
+				err = errInvalidDefault
 			}
 		}
 		p.fs.Int(f.Name, defInt, f.Description)
-		if f.ShortName != "" {
-			p.fs.Int(f.ShortName, defInt, f.Description)
-		}
 
 	case FlagTypeBool:
 		defBool := false
 		if defaultVal != nil {
-			if b, ok := defaultVal.(bool); ok {
-				defBool = b
-			} else if s, ok := defaultVal.(string); ok {
-				defBool, _ = strconv.ParseBool(s)
+			switch d := defaultVal.(type) {
+			case bool:
+				defBool = d
+			case string:
+				defBool, err = strconv.ParseBool(d)
+			default:
+				err = errInvalidDefault
 			}
 		}
 		p.fs.Bool(f.Name, defBool, f.Description)
-		if f.ShortName != "" {
-			p.fs.Bool(f.ShortName, defBool, f.Description)
+
+	case FlagTypeFloat64:
+		defFloat := 0.0
+		if defaultVal != nil {
+			switch d := defaultVal.(type) {
+			case float64:
+				defFloat = d
+			case int:
+				defFloat = float64(d)
+			case string:
+				defFloat, err = strconv.ParseFloat(d, 64)
+			default:
+				err = errInvalidDefault
+			}
 		}
+		p.fs.Float64(f.Name, defFloat, f.Description)
+
+	case FlagTypeDuration:
+		var defDuration time.Duration
+		if defaultVal != nil {
+			switch d := defaultVal.(type) {
+			case time.Duration:
+				defDuration = d
+			case string:
+				defDuration, err = time.ParseDuration(d)
+			default:
+				err = errInvalidDefault
+			}
+		}
+		p.fs.Duration(f.Name, defDuration, f.Description)
 
 	case FlagTypeStringSlice:
-		// 字符串数组暂时不使用标准库 flag，需要自定义实现
-		// 这里简化处理，使用逗号分隔的字符串
-		defStr := ""
+		// 长短选项共用同一个 Value，重复出现时追加
+		val := &stringSliceValue{}
 		if defaultVal != nil {
-			if slice, ok := defaultVal.([]string); ok {
-				defStr = strings.Join(slice, ",")
+			switch d := defaultVal.(type) {
+			case []string:
+				val.values = append([]string(nil), d...)
+			case string:
+				val.values = splitCommaList(d)
 			}
 		}
-		p.fs.String(f.Name, defStr, f.Description+" (comma-separated)")
-		if f.ShortName != "" {
-			p.fs.String(f.ShortName, defStr, f.Description+" (comma-separated)")
+		p.fs.Var(val, f.Name, f.Description+" (repeatable, comma-separated)")
+	}
+
+	// 短选项与长选项共用同一个 Value，无论用户使用哪种写法都写入同一处
+	if f.ShortName != "" {
+		if long := p.fs.Lookup(f.Name); long != nil {
+			p.fs.Var(long.Value, f.ShortName, long.Usage)
 		}
 	}
+
+	if err != nil {
+		return &UsageError{Message: fmt.Sprintf("%s: --%s: %v from %s is not a valid %s",
+			ErrMsgInvalidFlagValue, f.Name, formatDefaultValue(defaultVal), source, flagValueTypeName(f.Type))}
+	}
+	return nil
+}
+
+// errInvalidDefault 默认值的 Go 类型无法转换为选项类型
+var errInvalidDefault = errors.New("invalid default value")
+
+// formatDefaultValue 格式化错误信息中的默认值，字符串带引号以便看出空白
+func formatDefaultValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+// flagValueTypeName 选项类型在错误信息中的名称
+func flagValueTypeName(t FlagType) string {
+	if t == FlagTypeBool {
+		return "bool"
+	}
+	return flagTypeName(t)
 }
 
 // extractValues 从 flag.FlagSet 提取解析后的值
@@ -124,19 +218,8 @@ func (p *flagParser) extractValues() error {
 	for _, f := range p.flags {
 		var val interface{}
 
-		// 优先检查短选项（如果用户使用了短选项）
-		// 然后再检查长选项
-		var flagToUse *flag.Flag
-		if f.ShortName != "" {
-			if flg := p.fs.Lookup(f.ShortName); flg != nil {
-				flagToUse = flg
-			}
-		}
-		// 如果短选项没有被使用，或者没有短选项，使用长选项
-		if flagToUse == nil {
-			flagToUse = p.fs.Lookup(f.Name)
-		}
-
+		// 短选项与长选项共用 Value，按长选项读取即可
+		flagToUse := p.fs.Lookup(f.Name)
 		if flagToUse == nil {
 			continue
 		}
@@ -153,13 +236,20 @@ func (p *flagParser) extractValues() error {
 			v, _ := strconv.ParseBool(flagToUse.Value.String())
 			val = v
 
+		case FlagTypeFloat64:
+			v, _ := strconv.ParseFloat(flagToUse.Value.String(), 64)
+			val = v
+
+		case FlagTypeDuration:
+			v, _ := time.ParseDuration(flagToUse.Value.String())
+			val = v
+
 		case FlagTypeStringSlice:
-			str := flagToUse.Value.String()
-			if str != "" {
-				val = strings.Split(str, ",")
-			} else {
-				val = []string{}
+			slice := []string{}
+			if sv, ok := flagToUse.Value.(*stringSliceValue); ok {
+				slice = append(slice, sv.values...)
 			}
+			val = slice
 		}
 
 		p.values[f.Name] = val
@@ -169,40 +259,104 @@ func (p *flagParser) extractValues() error {
 }
 
 // validate 验证必填选项
+// 字符串/数组类型的必填选项按最终值判断: 值为空即视为缺失，
+// 因此 --name="" 与未提供一样会报错，环境变量或默认值提供了非空值时视为已提供。
+// 其他类型在以下任一情况下视为已提供:
+//   - 命令行中使用了长选项或短选项
+//   - 配置的环境变量非空
+//   - 设置了 Flag.Default 或配置文件默认值
 func (p *flagParser) validate() error {
+	if missing := p.missingRequired(); len(missing) > 0 {
+		return &UsageError{Message: requiredFlagMessage(missing[0])}
+	}
+	return nil
+}
+
+// missingRequired 返回未提供的必填选项，判断规则见 validate
+func (p *flagParser) missingRequired() []Flag {
+	provided := make(map[string]bool)
+	p.fs.Visit(func(flg *flag.Flag) {
+		provided[flg.Name] = true
+	})
+
+	var missing []Flag
 	for _, f := range p.flags {
 		if !f.Required {
 			continue
 		}
 
-		val, exists := p.values[f.Name]
-		if !exists {
-			return &UsageError{
-				Message: fmt.Sprintf("required flag --%s not provided", f.Name),
-			}
-		}
-
-		// 检查是否为零值
+		satisfied := false
 		switch f.Type {
 		case FlagTypeString:
-			if val == "" {
-				return &UsageError{
-					Message: fmt.Sprintf("required flag --%s cannot be empty", f.Name),
-				}
-			}
+			satisfied = p.values[f.Name] != ""
 		case FlagTypeStringSlice:
-			if slice, ok := val.([]string); !ok || len(slice) == 0 {
-				return &UsageError{
-					Message: fmt.Sprintf("required flag --%s cannot be empty", f.Name),
-				}
-			}
+			slice, ok := p.values[f.Name].([]string)
+			satisfied = ok && len(slice) > 0
+		default:
+			_, fromFile := p.defaults[f.Name]
+			satisfied = provided[f.Name] || (f.ShortName != "" && provided[f.ShortName]) ||
+				(f.EnvVar != "" && os.Getenv(f.EnvVar) != "") ||
+				f.Default != nil || fromFile
+		}
+
+		if !satisfied {
+			missing = append(missing, f)
 		}
 	}
 
-	return nil
+	return missing
+}
+
+// requiredFlagMessage 生成必填选项缺失的错误信息
+func requiredFlagMessage(f Flag) string {
+	name := "--" + f.Name
+	if f.ShortName != "" {
+		name += " (-" + f.ShortName + ")"
+	}
+	if f.EnvVar != "" {
+		return fmt.Sprintf("%s: %s (or set $%s)", ErrMsgMissingRequired, name, f.EnvVar)
+	}
+	return fmt.Sprintf("%s: %s", ErrMsgMissingRequired, name)
 }
 
 // getValues 返回解析后的选项值
 func (p *flagParser) getValues() map[string]interface{} {
 	return p.values
 }
+
+// stringSliceValue 字符串数组选项的 flag.Value 实现
+// 支持重复选项 (-t a -t b) 和逗号分隔 (-t a,b)，两种方式可以混用
+// 命令行首次出现时替换默认值，之后追加
+type stringSliceValue struct {
+	values []string
+	set    bool
+}
+
+// String 实现 flag.Value 接口
+func (s *stringSliceValue) String() string {
+	if s == nil {
+		return ""
+	}
+	return strings.Join(s.values, ",")
+}
+
+// Set 实现 flag.Value 接口
+func (s *stringSliceValue) Set(value string) error {
+	if !s.set {
+		s.values = nil
+		s.set = true
+	}
+	s.values = append(s.values, splitCommaList(value)...)
+	return nil
+}
+
+// splitCommaList 按逗号拆分并去除空白项
+func splitCommaList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// CommandGroup 命令组
+// 本身不执行业务逻辑，根据第一个位置参数分发到已注册的子命令
+// 子命令可以是普通 Command，也可以是嵌套的 CommandGroup
+//
+// 示例:
+//
+//	db := cli.NewCommandGroup("db", "Database operations")
+//	db.AddCommand(&MigrateCommand{})
+//	app.AddCommand(db)
+//
+//	// mytool db migrate --dir ./migrations
+type CommandGroup struct {
+	name        string
+	description string
+	commands    map[string]Command
+	order       []string
+	mu          sync.RWMutex
+}
+
+// NewCommandGroup 创建命令组
+func NewCommandGroup(name, description string) *CommandGroup {
+	return &CommandGroup{
+		name:        name,
+		description: description,
+		commands:    make(map[string]Command),
+	}
+}
+
+// Name 返回命令组名称
+func (g *CommandGroup) Name() string {
+	return g.name
+}
+
+// Description 返回命令组描述
+func (g *CommandGroup) Description() string {
+	return g.description
+}
+
+// Usage 返回使用说明
+func (g *CommandGroup) Usage() string {
+	return fmt.Sprintf("%s [command] [flags]", g.name)
+}
+
+// Flags 命令组没有自己的选项，选项由子命令解析
+func (g *CommandGroup) Flags() []Flag {
+	return nil
+}
+
+// AddCommand 注册子命令
+func (g *CommandGroup) AddCommand(cmd Command) error {
+	if cmd == nil {
+		return fmt.Errorf("command cannot be nil")
+	}
+
+	cmdName := cmd.Name()
+	if cmdName == "" {
+		return fmt.Errorf("command name cannot be empty")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.commands[cmdName]; exists {
+		return fmt.Errorf("%s: %s", ErrMsgDuplicateCommand, cmdName)
+	}
+
+	g.commands[cmdName] = cmd
+	g.order = append(g.order, cmdName)
+	return nil
+}
+
+// Command 按名称查找子命令
+func (g *CommandGroup) Command(name string) (Command, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	cmd, ok := g.commands[name]
+	return cmd, ok
+}
+
+// Commands 按注册顺序返回所有子命令
+func (g *CommandGroup) Commands() []Command {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	cmds := make([]Command, 0, len(g.order))
+	for _, name := range g.order {
+		cmds = append(cmds, g.commands[name])
+	}
+	return cmds
+}
+
+// Execute 分发到子命令
+// 通过 App 运行时由 App 直接路由，此方法用于将命令组作为独立 Command 使用
+func (g *CommandGroup) Execute(ctx *Context) error {
+	return runCommand(g.name, g, ctx.Args, nil, nil, ctx.Stdin, ctx.Stdout, ctx.Stderr)
+}
+
+// printHelp 打印命令组帮助信息
+func (g *CommandGroup) printHelp(w io.Writer, path string) {
+	if g.description != "" {
+		fmt.Fprintf(w, "%s\n", g.description)
+	}
+
+	fmt.Fprintln(w, "\nUsage:")
+	fmt.Fprintf(w, "  %s [command] [flags]\n", path)
+
+	fmt.Fprintln(w, "\nAvailable Commands:")
+	cmds := g.Commands()
+	if len(cmds) == 0 {
+		fmt.Fprintln(w, "  (no commands registered)")
+		return
+	}
+
+	// 找到最长的命令名，用于对齐
+	maxLen := 0
+	for _, cmd := range cmds {
+		if len(cmd.Name()) > maxLen {
+			maxLen = len(cmd.Name())
+		}
+	}
+
+	for _, cmd := range cmds {
+		padding := strings.Repeat(" ", maxLen-len(cmd.Name())+2)
+		fmt.Fprintf(w, "  %s%s%s\n", cmd.Name(), padding, cmd.Description())
+	}
+
+	fmt.Fprintf(w, "\nRun '%s [command] --help' for more information on a command.\n", path)
+}
+
+// runCommand 执行命令
+// path 为完整命令路径（如 "db migrate"），用于错误和帮助信息
+// 命令组会继续按 args[0] 向下分发，普通命令解析选项后执行
+// defaults 为配置文件提供的选项默认值，opts 提供合并到普通命令的全局选项和选项级设置，均可以为 nil
+func runCommand(path string, cmd Command, args []string, defaults map[string]interface{}, opts *Parser, stdin io.Reader, stdout, stderr io.Writer) error {
+	if group, ok := cmd.(*CommandGroup); ok {
+		if len(args) == 0 || isHelpArg(args[0]) {
+			group.printHelp(stdout, path)
+			return nil
+		}
+
+		sub, exists := group.Command(args[0])
+		if !exists {
+			return &UsageError{
+				Command: path,
+				Message: fmt.Sprintf("%s: %s", ErrMsgCommandNotFound, args[0]),
+			}
+		}
+		return runCommand(path+" "+sub.Name(), sub, args[1:], defaults, opts, stdin, stdout, stderr)
+	}
+
+	// 解析命令选项
+	persistent := opts.Flags()
+	parser := newFlagParser(path, opts.apply(mergePersistentFlags(cmd.Flags(), persistent)))
+	parser.defaults = defaults
+	parser.promptIn, parser.promptOut = opts.promptIO()
+	remainingArgs, err := parser.parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		printCommandHelp(stdout, path, cmd, persistent)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// 创建执行上下文
+	ctx := &Context{
+		Args:   remainingArgs,
+		Flags:  parser.getValues(),
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	// 执行命令
+	if err := cmd.Execute(ctx); err != nil {
+		return &CommandError{
+			Command: path,
+			Message: "execution failed",
+			Cause:   err,
+		}
+	}
+
+	return nil
+}
+
+// isHelpArg 判断参数是否为帮助选项
+func isHelpArg(arg string) bool {
+	return arg == "--help" || arg == "-h"
+}
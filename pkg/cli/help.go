@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// printCommandHelp 打印普通命令的帮助信息
+// 段落顺序: 描述、详细描述、Usage、Flags、Global Flags、Examples
+// 选项按声明顺序输出，保证 help 输出稳定；与命令选项冲突的全局选项不输出
+func printCommandHelp(w io.Writer, path string, cmd Command, persistent []Flag) {
+	if desc := cmd.Description(); desc != "" {
+		fmt.Fprintf(w, "%s\n", desc)
+	}
+
+	if ld, ok := cmd.(LongDescriber); ok {
+		if long := strings.TrimSpace(ld.LongDescription()); long != "" {
+			fmt.Fprintf(w, "\n%s\n", long)
+		}
+	}
+
+	// path 形如 "db migrate"，Usage() 以命令名开头，替换最后一段
+	prefix := strings.TrimSuffix(path, cmd.Name())
+	usage := cmd.Usage()
+	if usage == "" {
+		usage = cmd.Name() + " [flags]"
+	}
+	fmt.Fprintln(w, "\nUsage:")
+	fmt.Fprintf(w, "  %s%s\n", prefix, usage)
+
+	own := cmd.Flags()
+	fmt.Fprintln(w, "\nFlags:")
+	writeFlagTable(w, own)
+
+	if global := mergePersistentFlags(own, persistent)[len(own):]; len(global) > 0 {
+		fmt.Fprintln(w, "\nGlobal Flags:")
+		writeFlagRows(w, global)
+	}
+
+	if ex, ok := cmd.(Exampler); ok {
+		if examples := strings.TrimSpace(ex.Examples()); examples != "" {
+			fmt.Fprintln(w, "\nExamples:")
+			for _, line := range strings.Split(examples, "\n") {
+				fmt.Fprintf(w, "  %s\n", strings.TrimSpace(line))
+			}
+		}
+	}
+}
+
+// writeFlagTable 按声明顺序输出选项表，末尾追加 --help
+func writeFlagTable(w io.Writer, flags []Flag) {
+	help := Flag{Name: DefaultHelpFlag, ShortName: "h", Type: FlagTypeBool, Description: "Show help information"}
+	writeFlagRows(w, append(append([]Flag(nil), flags...), help))
+}
+
+// writeFlagRows 按声明顺序输出选项，描述列对齐
+func writeFlagRows(w io.Writer, flags []Flag) {
+	names := make([]string, 0, len(flags))
+	descs := make([]string, 0, len(flags))
+
+	for _, f := range flags {
+		names = append(names, flagSignature(f))
+		descs = append(descs, flagDescription(f))
+	}
+
+	maxLen := 0
+	for _, name := range names {
+		if len(name) > maxLen {
+			maxLen = len(name)
+		}
+	}
+
+	for i, name := range names {
+		padding := strings.Repeat(" ", maxLen-len(name)+4)
+		fmt.Fprintf(w, "  %s%s%s\n", name, padding, descs[i])
+	}
+}
+
+// flagSignature 生成选项签名，如 "-o, --output string"
+// 没有短选项时保留缩进，使长选项对齐
+func flagSignature(f Flag) string {
+	sig := "    --" + f.Name
+	if f.ShortName != "" {
+		sig = "-" + f.ShortName + ", --" + f.Name
+	}
+	if typeName := flagTypeName(f.Type); typeName != "" {
+		sig += " " + typeName
+	}
+	return sig
+}
+
+// flagDescription 生成选项描述，附加默认值、环境变量和必填标记
+// 只要显式设置了 Default 就展示，零值（0、false、""）也不例外；未设置则不展示
+func flagDescription(f Flag) string {
+	desc := f.Description
+	if f.Default != nil {
+		desc += fmt.Sprintf(" (default %s)", formatDefault(f))
+	}
+	if f.EnvVar != "" {
+		desc += fmt.Sprintf(" (env $%s)", f.EnvVar)
+	}
+	if f.Required {
+		desc += " (required)"
+	}
+	return strings.TrimSpace(desc)
+}
+
+// formatDefault 格式化默认值
+func formatDefault(f Flag) string {
+	switch d := f.Default.(type) {
+	case string:
+		if f.Type == FlagTypeString {
+			return fmt.Sprintf("%q", d)
+		}
+		return d
+	case []string:
+		return "[" + strings.Join(d, ",") + "]"
+	case time.Duration:
+		return d.String()
+	default:
+		return fmt.Sprint(d)
+	}
+}
+
+// flagTypeName 选项类型在 help 中的名称，布尔选项不需要值
+func flagTypeName(t FlagType) string {
+	switch t {
+	case FlagTypeString:
+		return "string"
+	case FlagTypeInt:
+		return "int"
+	case FlagTypeStringSlice:
+		return "strings"
+	case FlagTypeFloat64:
+		return "float"
+	case FlagTypeDuration:
+		return "duration"
+	default:
+		return ""
+	}
+}
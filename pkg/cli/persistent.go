@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Parser 选项集合
+// App.PersistentFlags 返回的 Parser 定义全局选项，解析时合并到每个子命令的选项中，
+// 子命令无需重复声明 --log-level 等公共选项
+//
+// 示例:
+//
+//	app.PersistentFlags().AddFlag(cli.Flag{Name: "log-level", Type: cli.FlagTypeString, Default: "info"})
+//
+//	// mytool db migrate --log-level debug
+//	level := ctx.GetString("log-level")
+type Parser struct {
+	mu    sync.RWMutex
+	flags []Flag
+
+	// envFallback 选项长名称到环境变量名的映射
+	envFallback map[string]string
+	// secret 标记为敏感的选项长名称
+	secret map[string]bool
+
+	// promptIn/promptOut 由 PromptMissing 设置，为 nil 时不提示
+	promptIn  io.Reader
+	promptOut io.Writer
+}
+
+// AddFlag 添加选项
+// 长选项名称为空或重复时返回错误
+func (p *Parser) AddFlag(f Flag) error {
+	if f.Name == "" {
+		return fmt.Errorf("flag name cannot be empty")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, existing := range p.flags {
+		if existing.Name == f.Name {
+			return fmt.Errorf("%s: %s", ErrMsgDuplicateFlag, f.Name)
+		}
+	}
+
+	p.flags = append(p.flags, f)
+	return nil
+}
+
+// Flags 按添加顺序返回所有选项
+func (p *Parser) Flags() []Flag {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return append([]Flag(nil), p.flags...)
+}
+
+// SetEnvFallback 为选项设置环境变量回退
+// 对全局选项和所有子命令中名为 name 的选项生效，覆盖 Flag.EnvVar；
+// 命令行未提供该选项时读取 envVar，必填选项也可由非空的环境变量满足
+//
+// 示例:
+//
+//	app.PersistentFlags().SetEnvFallback("token", "MYTOOL_TOKEN")
+func (p *Parser) SetEnvFallback(name, envVar string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.envFallback == nil {
+		p.envFallback = make(map[string]string)
+	}
+	p.envFallback[name] = envVar
+}
+
+// SetSecret 将选项标记为敏感选项，效果与 Flag.Secret 相同
+// 对全局选项和所有子命令中名为 name 的选项生效，交互式提示时输入不回显
+func (p *Parser) SetSecret(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.secret == nil {
+		p.secret = make(map[string]bool)
+	}
+	p.secret[name] = true
+}
+
+// PromptMissing 开启缺失必填选项的交互式提示
+// 开启后，in 为终端时缺失的必填选项不直接报错，而是在 out 上逐个提示并从 in 读取；
+// in 不是终端 (管道、CI) 时保持缺失即报错。未调用时从不提示
+//
+// 示例:
+//
+//	app.PersistentFlags().PromptMissing(os.Stdin, os.Stderr)
+func (p *Parser) PromptMissing(in io.Reader, out io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.promptIn, p.promptOut = in, out
+}
+
+// promptIO 返回 PromptMissing 设置的输入输出，in 不是终端时返回 nil
+func (p *Parser) promptIO() (io.Reader, io.Writer) {
+	if p == nil {
+		return nil, nil
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.promptIn == nil || !isTerminal(p.promptIn) {
+		return nil, nil
+	}
+	return p.promptIn, p.promptOut
+}
+
+// apply 返回应用了 Parser 级设置 (环境变量回退、敏感选项) 的选项副本，不修改传入的切片
+func (p *Parser) apply(flags []Flag) []Flag {
+	if p == nil {
+		return flags
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if len(p.envFallback) == 0 && len(p.secret) == 0 {
+		return flags
+	}
+
+	applied := append([]Flag(nil), flags...)
+	for i := range applied {
+		if envVar, ok := p.envFallback[applied[i].Name]; ok {
+			applied[i].EnvVar = envVar
+		}
+		if p.secret[applied[i].Name] {
+			applied[i].Secret = true
+		}
+	}
+	return applied
+}
+
+// mergePersistentFlags 将全局选项合并到命令自己的选项之后
+// 命令选项优先: 长名称冲突的全局选项被忽略，仅短名称冲突时去掉全局选项的短名称
+// 长短名称共用 flag.FlagSet 的命名空间，因此两者都参与冲突判断
+func mergePersistentFlags(own, persistent []Flag) []Flag {
+	if len(persistent) == 0 {
+		return own
+	}
+
+	used := make(map[string]bool, len(own)*2)
+	for _, f := range own {
+		used[f.Name] = true
+		if f.ShortName != "" {
+			used[f.ShortName] = true
+		}
+	}
+
+	merged := append([]Flag(nil), own...)
+	for _, f := range persistent {
+		if used[f.Name] {
+			continue
+		}
+		if used[f.ShortName] {
+			f.ShortName = ""
+		}
+
+		used[f.Name] = true
+		if f.ShortName != "" {
+			used[f.ShortName] = true
+		}
+		merged = append(merged, f)
+	}
+	return merged
+}
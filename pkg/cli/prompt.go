@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// promptMissing 交互式提示填写缺失的必填选项
+// 依次提示每个未提供的必填选项，读取一行输入作为选项值；
+// Secret 选项在终端下通过 term.ReadPassword 读取，输入不回显，也不会写回 out。
+// 输入为空或读到 EOF 时跳过该选项，由 validate 返回缺失错误
+func (p *flagParser) promptMissing(in io.Reader, out io.Writer) error {
+	missing := p.missingRequired()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	reader := bufio.NewReader(in)
+	for _, f := range missing {
+		value, err := promptFlag(reader, in, out, f)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			continue
+		}
+
+		if err := p.fs.Set(f.Name, value); err != nil {
+			return &UsageError{Message: fmt.Sprintf("%s: --%s: %v", ErrMsgInvalidFlagValue, f.Name, err)}
+		}
+	}
+
+	return p.extractValues()
+}
+
+// promptFlag 输出提示并读取一行输入
+func promptFlag(reader *bufio.Reader, in io.Reader, out io.Writer, f Flag) (string, error) {
+	label := "--" + f.Name
+	if f.Description != "" {
+		label += " (" + f.Description + ")"
+	}
+	if f.Secret {
+		label += " [input hidden]"
+	}
+	fmt.Fprintf(out, "%s: ", label)
+
+	if f.Secret && isTerminal(in) {
+		value, err := term.ReadPassword(int(in.(*os.File).Fd()))
+		// 回显关闭时用户的换行不会显示，补一个换行
+		fmt.Fprintln(out)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(value), "\r\n"), nil
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// isTerminal 判断输入是否为终端
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -11,6 +11,16 @@ const (
 	// DefaultConnMaxLifetime 默认连接最大生命周期
 	// 如果配置中未指定,使用此默认值
 	DefaultConnMaxLifetime = time.Hour
+
+	// DefaultHealthCheckInterval 健康检查的默认间隔
+	DefaultHealthCheckInterval = 30 * time.Second
+
+	// DefaultHealthCheckTimeout 单次健康检查 Ping 的默认超时时间
+	DefaultHealthCheckTimeout = 5 * time.Second
+
+	// DefaultSlowThreshold 默认慢查询阈值
+	// Config.SlowThresholdMs 为 0 时使用
+	DefaultSlowThreshold = 200 * time.Millisecond
 )
 
 // 错误消息常量
@@ -7,8 +7,10 @@
 package database
 
 import (
+	"context"
 	"time"
 
+	"github.com/rei0721/go-scaffold/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -100,6 +102,18 @@ type Config struct {
 	// - 定期刷新连接,防止数据库端超时
 	// 推荐值: 5-30 分钟
 	MaxLifetime time.Duration `mapstructure:"maxLifetime"`
+
+	// SlowThresholdMs 慢查询阈值(毫秒)
+	// 执行时间超过阈值的 SQL 以 warn 级别记录,附带耗时和影响行数
+	// 0 表示使用 DefaultSlowThreshold,< 0 表示不记录慢查询
+	// 仅在设置 Logger 时生效
+	SlowThresholdMs int `mapstructure:"slowThresholdMs"`
+
+	// Logger SQL 日志记录器
+	// 设置后 GORM 日志通过它输出:普通 SQL 为 debug、慢查询为 warn、执行错误为 error,
+	// 是否输出由 Logger 自身的级别决定
+	// 为 nil 时使用 GORM 默认日志
+	Logger logger.Logger `mapstructure:"-"`
 }
 
 // Reloader 定义数据库配置重载接口
@@ -152,9 +166,33 @@ type Database interface {
 	// - 健康检查接口
 	// - 初始化时验证配置是否正确
 	// - 定期检查连接状态
+	// 参数:
+	//   ctx: 上下文,用于超时控制,避免数据库无响应时长时间阻塞
 	// 返回:
 	//   error: 如果连接失败或不可用
-	Ping() error
+	Ping(ctx context.Context) error
+
+	// Transaction 在事务中执行函数
+	// 自动处理事务的开启、提交和回滚,ctx 会传递给事务中的所有操作
+	// 参数:
+	//   ctx: 上下文,用于超时控制和取消
+	//   fn: 事务函数,使用传入的 tx 执行数据库操作
+	// 返回:
+	//   error: fn 返回的错误或提交失败的错误
+	// 行为:
+	//   - fn 返回 nil 时提交
+	//   - fn 返回错误时回滚,并原样返回该错误
+	//   - fn 发生 panic 时回滚并重新抛出 panic
+	// 使用示例:
+	//   err := db.Transaction(ctx, func(tx *gorm.DB) error {
+	//       if err := tx.Create(&user).Error; err != nil {
+	//           return err
+	//       }
+	//       return tx.Create(&userRole).Error
+	//   })
+	// 注意:
+	//   需要超时、隔离级别或重试等高级控制时使用 pkg/dbtx
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
 
 	// Reloader 嵌入重载接口
 	// 支持数据库配置的热更新
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// testUser 测试用的用户模型
+type testUser struct {
+	ID   int64  `gorm:"primaryKey"`
+	Name string `gorm:"size:100"`
+}
+
+// testUserRole 测试用的用户角色模型
+type testUserRole struct {
+	ID     int64 `gorm:"primaryKey"`
+	UserID int64
+	Role   string `gorm:"size:50"`
+}
+
+// setupTestDatabase 创建基于临时文件的 SQLite 数据库
+func setupTestDatabase(t *testing.T) Database {
+	t.Helper()
+
+	db, err := New(&Config{
+		Driver: DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "test.db"),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := db.DB().AutoMigrate(&testUser{}, &testUserRole{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+// countRows 统计表中的行数
+func countRows(t *testing.T, db Database, model interface{}) int64 {
+	t.Helper()
+	var n int64
+	if err := db.DB().Model(model).Count(&n).Error; err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	return n
+}
+
+// TestTransaction_Commit 测试两次写入都被提交
+func TestTransaction_Commit(t *testing.T) {
+	db := setupTestDatabase(t)
+
+	err := db.Transaction(context.Background(), func(tx *gorm.DB) error {
+		user := &testUser{Name: "alice"}
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Create(&testUserRole{UserID: user.ID, Role: "user"}).Error
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := countRows(t, db, &testUser{}); n != 1 {
+		t.Errorf("users = %d, want 1", n)
+	}
+	if n := countRows(t, db, &testUserRole{}); n != 1 {
+		t.Errorf("user roles = %d, want 1", n)
+	}
+}
+
+// TestTransaction_RollbackOnError 测试返回错误时回滚且原样返回错误
+func TestTransaction_RollbackOnError(t *testing.T) {
+	db := setupTestDatabase(t)
+	errAssign := errors.New("assign role failed")
+
+	err := db.Transaction(context.Background(), func(tx *gorm.DB) error {
+		if err := tx.Create(&testUser{Name: "bob"}).Error; err != nil {
+			return err
+		}
+		return errAssign
+	})
+	if !errors.Is(err, errAssign) {
+		t.Fatalf("expected %v, got %v", errAssign, err)
+	}
+
+	if n := countRows(t, db, &testUser{}); n != 0 {
+		t.Errorf("users = %d, want 0 after rollback", n)
+	}
+}
+
+// TestTransaction_RollbackOnPanic 测试 panic 时回滚并重新抛出
+func TestTransaction_RollbackOnPanic(t *testing.T) {
+	db := setupTestDatabase(t)
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected panic to be re-raised")
+			}
+		}()
+		_ = db.Transaction(context.Background(), func(tx *gorm.DB) error {
+			tx.Create(&testUser{Name: "carol"})
+			panic("boom")
+		})
+	}()
+
+	if n := countRows(t, db, &testUser{}); n != 0 {
+		t.Errorf("users = %d, want 0 after rollback", n)
+	}
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
@@ -76,21 +77,35 @@ func (d *database) Close() error {
 // - 健康检查接口
 // - 启动时验证数据库连接
 // - 定期检查连接状态
+// 参数:
+//
+//	ctx: 上下文,用于超时控制
+//
 // 返回:
 //
 //	error: 如果连接失败或超时
-func (d *database) Ping() error {
+func (d *database) Ping(ctx context.Context) error {
+	// 在锁外执行 ping,避免数据库无响应时阻塞 Reload
 	d.mu.RLock()
-	defer d.mu.RUnlock()
+	sqlDB := d.sqlDB
+	d.mu.RUnlock()
 
-	if d.sqlDB != nil {
+	if sqlDB != nil {
 		// 执行 ping 操作
-		// 会建立一个测试连接并立即关闭
-		return d.sqlDB.Ping()
+		// 会从连接池取出一个连接验证可用性
+		return sqlDB.PingContext(ctx)
 	}
 	return nil
 }
 
+// Transaction 在事务中执行函数
+// 实现 Database 接口
+// 委托给 GORM 的 Transaction,fn 返回错误或 panic 时回滚,否则提交
+// 事务开始时获取当前的 DB 实例,执行期间发生 Reload 不影响本事务
+func (d *database) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return d.DB().WithContext(ctx).Transaction(fn)
+}
+
 // Reload 使用新配置重新加载数据库连接
 // 实现 Reloader 接口
 // 这个方法允许在运行时热更新数据库配置,无需重启应用
@@ -124,7 +139,9 @@ func (d *database) Reload(cfg *Config) error {
 
 	// 2. 验证新连接是否可用
 	// 执行 Ping 测试,确保新连接确实可用
-	if err := newDB.Ping(); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultReloadTimeout)
+	defer cancel()
+	if err := newDB.Ping(ctx); err != nil {
 		// 新连接不可用,关闭它并返回错误
 		_ = newDB.Close()
 		return fmt.Errorf("new database connection ping failed: %w", err)
@@ -236,8 +253,11 @@ func NewWithHooks(cfg *Config, hooks ...Hook) (Database, error) {
 	// - NamingStrategy: 命名策略(表名、列名转换)
 	// - NowFunc: 自定义时间函数
 	// - DryRun: 模拟运行,不实际执行 SQL
-	// 这里使用空配置,采用 GORM 默认值
+	// 设置了 Logger 时替换 GORM 日志,其余采用 GORM 默认值
 	gormCfg := &gorm.Config{}
+	if cfg.Logger != nil {
+		gormCfg.Logger = newGormLogger(cfg.Logger, slowThreshold(cfg))
+	}
 
 	// 3. 打开数据库连接
 	// gorm.Open 会:
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// gormLogger 将 GORM 日志转发到 logger.Logger
+// 日志级别映射:
+// - 普通 SQL: Debug,仅在应用日志级别为 debug 时输出
+// - 慢查询: Warn,附带耗时和影响行数
+// - 执行错误: Error (记录不存在不视为错误)
+// 最终是否输出由 logger.Logger 的级别决定,运行时调整应用日志级别同样生效
+type gormLogger struct {
+	log           logger.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// newGormLogger 创建 GORM 日志适配器
+// 参数:
+//
+//	log: 日志记录器
+//	slowThreshold: 慢查询阈值,<= 0 时不记录慢查询
+func newGormLogger(log logger.Logger, slowThreshold time.Duration) *gormLogger {
+	return &gormLogger{
+		log:           log,
+		level:         gormlogger.Info,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// slowThreshold 根据配置返回慢查询阈值
+// SlowThresholdMs 为 0 时使用 DefaultSlowThreshold,< 0 表示不记录慢查询
+func slowThreshold(cfg *Config) time.Duration {
+	switch {
+	case cfg.SlowThresholdMs < 0:
+		return 0
+	case cfg.SlowThresholdMs == 0:
+		return DefaultSlowThreshold
+	default:
+		return time.Duration(cfg.SlowThresholdMs) * time.Millisecond
+	}
+}
+
+// LogMode 实现 gormlogger.Interface
+// 返回新实例,不影响其他会话 (如 db.Debug())
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+// Info 实现 gormlogger.Interface
+func (l *gormLogger) Info(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Info("gorm: "+msg, "args", args)
+	}
+}
+
+// Warn 实现 gormlogger.Interface
+func (l *gormLogger) Warn(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warn("gorm: "+msg, "args", args)
+	}
+}
+
+// Error 实现 gormlogger.Interface
+func (l *gormLogger) Error(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Error("gorm: "+msg, "args", args)
+	}
+}
+
+// Trace 实现 gormlogger.Interface
+// 每条 SQL 执行后调用,按错误、慢查询、普通查询的优先级输出
+func (l *gormLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.log.Error("sql error", "error", err, "sql", sql, "duration", elapsed, "rows", rows)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.log.Warn("slow sql", "sql", sql, "duration", elapsed, "rows", rows, "threshold", l.slowThreshold)
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		l.log.Debug("sql", "sql", sql, "duration", elapsed, "rows", rows)
+	}
+}
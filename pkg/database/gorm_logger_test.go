@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/executor"
+	"github.com/rei0721/go-scaffold/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// recordLogger 记录日志调用的 logger.Logger 实现
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+// logEntry 一条日志记录
+type logEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+func (l *recordLogger) record(level, msg string, kv []interface{}) {
+	fields := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		if key, ok := kv[i].(string); ok {
+			fields[key] = kv[i+1]
+		}
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: fields})
+	l.mu.Unlock()
+}
+
+func (l *recordLogger) Debug(msg string, kv ...interface{})  { l.record("debug", msg, kv) }
+func (l *recordLogger) Info(msg string, kv ...interface{})   { l.record("info", msg, kv) }
+func (l *recordLogger) Warn(msg string, kv ...interface{})   { l.record("warn", msg, kv) }
+func (l *recordLogger) Error(msg string, kv ...interface{})  { l.record("error", msg, kv) }
+func (l *recordLogger) Fatal(msg string, kv ...interface{})  { l.record("fatal", msg, kv) }
+func (l *recordLogger) With(kv ...interface{}) logger.Logger { return l }
+func (l *recordLogger) Sync() error                          { return nil }
+func (l *recordLogger) SetExecutor(exec executor.Manager)    {}
+func (l *recordLogger) Reload(cfg *logger.Config) error      { return nil }
+func (l *recordLogger) SetLevel(level string) error          { return nil }
+
+// find 返回第一条指定级别且 sql 字段包含 substr 的日志
+func (l *recordLogger) find(level, substr string) (logEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if sql, _ := e.fields["sql"].(string); e.level == level && strings.Contains(sql, substr) {
+			return e, true
+		}
+	}
+	return logEntry{}, false
+}
+
+// TestGormLogger_ForwardsSQL 测试设置 Logger 后 SQL 通过它输出
+func TestGormLogger_ForwardsSQL(t *testing.T) {
+	log := &recordLogger{}
+	db, err := New(&Config{
+		Driver: DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "test.db"),
+		Logger: log,
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := db.DB().AutoMigrate(&testUser{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if err := db.DB().Create(&testUser{Name: "alice"}).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	entry, ok := log.find("debug", "INSERT INTO `test_users`")
+	if !ok {
+		t.Fatalf("expected insert to be logged at debug, got %+v", log.entries)
+	}
+	if rows, _ := entry.fields["rows"].(int64); rows != 1 {
+		t.Errorf("rows = %v, want 1", entry.fields["rows"])
+	}
+
+	// 记录不存在不视为错误
+	var user testUser
+	err = db.DB().First(&user, 42).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got %v", err)
+	}
+	if _, ok := log.find("error", "SELECT"); ok {
+		t.Error("record not found should not be logged as error")
+	}
+}
+
+// TestGormLogger_SlowQuery 测试超过阈值的 SQL 以 warn 级别记录
+func TestGormLogger_SlowQuery(t *testing.T) {
+	log := &recordLogger{}
+	l := newGormLogger(log, slowThreshold(&Config{SlowThresholdMs: 100}))
+
+	sql := func() (string, int64) { return "SELECT * FROM users", 3 }
+	l.Trace(context.Background(), time.Now(), sql, nil)
+	l.Trace(context.Background(), time.Now().Add(-time.Second), sql, nil)
+
+	entry, ok := log.find("warn", "SELECT * FROM users")
+	if !ok {
+		t.Fatalf("expected slow query warning, got %+v", log.entries)
+	}
+	if d, _ := entry.fields["duration"].(time.Duration); d < time.Second {
+		t.Errorf("duration = %v, want >= 1s", entry.fields["duration"])
+	}
+	if rows, _ := entry.fields["rows"].(int64); rows != 3 {
+		t.Errorf("rows = %v, want 3", entry.fields["rows"])
+	}
+	if len(log.entries) != 2 || log.entries[0].level != "debug" {
+		t.Errorf("expected fast query at debug and slow query at warn, got %+v", log.entries)
+	}
+
+	// 阈值 < 0 时不记录慢查询
+	log = &recordLogger{}
+	l = newGormLogger(log, slowThreshold(&Config{SlowThresholdMs: -1}))
+	l.Trace(context.Background(), time.Now().Add(-time.Second), sql, nil)
+	if _, ok := log.find("warn", "SELECT"); ok {
+		t.Error("slow query logging should be disabled")
+	}
+}
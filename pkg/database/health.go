@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// HealthStatus 数据库健康状态
+type HealthStatus struct {
+	// Healthy 最近一次检查是否成功
+	Healthy bool `json:"healthy"`
+
+	// LastCheck 最近一次检查的时间
+	// 为零值表示尚未检查
+	LastCheck time.Time `json:"lastCheck"`
+
+	// LastError 最近一次检查失败的错误信息
+	// 检查成功时为空
+	LastError string `json:"lastError,omitempty"`
+
+	// ConsecutiveFailures 连续失败次数
+	// 检查成功后清零
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+}
+
+// HealthMonitorOptions 健康监控选项
+type HealthMonitorOptions struct {
+	// Interval 检查间隔
+	// <= 0 时使用 DefaultHealthCheckInterval
+	Interval time.Duration
+
+	// Timeout 单次 Ping 的超时时间
+	// <= 0 时使用 DefaultHealthCheckTimeout
+	Timeout time.Duration
+}
+
+// HealthMonitor 数据库健康监控
+// 在后台定期 Ping 数据库,记录最近的健康状态
+// 只报告健康状态,不重建连接池: database/sql 会自动重新拨号,
+// 而重建连接池会关闭启动时已注入到仓储、事务管理器等组件中的 *sql.DB
+// 使用示例:
+//
+//	monitor := database.NewHealthMonitor(db, nil, log)
+//	monitor.Start()
+//	defer monitor.Stop()
+//
+//	status := monitor.Status() // 用于深度健康检查端点
+type HealthMonitor struct {
+	db   Database
+	opts HealthMonitorOptions
+
+	// logger 日志记录器,可以为 nil
+	logger atomic.Value
+
+	mu     sync.RWMutex
+	status HealthStatus
+
+	// checkMu 保证同一时间只有一个检查在执行
+	checkMu sync.Mutex
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewHealthMonitor 创建数据库健康监控
+// 参数:
+//
+//	db: 要监控的数据库
+//	opts: 监控选项,可以为 nil(使用默认值)
+//	log: 日志记录器,可以为 nil(不记录日志)
+//
+// 返回:
+//
+//	*HealthMonitor: 健康监控实例,需要调用 Start 启动后台检查
+func NewHealthMonitor(db Database, opts *HealthMonitorOptions, log logger.Logger) *HealthMonitor {
+	var o HealthMonitorOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = DefaultHealthCheckInterval
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultHealthCheckTimeout
+	}
+
+	m := &HealthMonitor{
+		db:   db,
+		opts: o,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if log != nil {
+		m.logger.Store(log)
+	}
+	return m
+}
+
+// SetLogger 设置日志记录器
+func (m *HealthMonitor) SetLogger(log logger.Logger) {
+	m.logger.Store(log)
+}
+
+// getLogger 获取日志记录器,未设置时返回 nil
+func (m *HealthMonitor) getLogger() logger.Logger {
+	if log, ok := m.logger.Load().(logger.Logger); ok {
+		return log
+	}
+	return nil
+}
+
+// Start 启动后台健康检查
+// 立即执行一次检查,之后按 Interval 定期检查
+// 重复调用无效
+func (m *HealthMonitor) Start() {
+	m.startOnce.Do(func() {
+		go m.loop()
+	})
+}
+
+// Stop 停止后台健康检查并等待检查协程退出
+// 未调用 Start 时直接返回,重复调用无效
+func (m *HealthMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+	// 未启动时 loop 不会运行,startOnce 保证之后也不会再启动
+	m.startOnce.Do(func() { close(m.done) })
+	<-m.done
+}
+
+// loop 后台检查循环
+func (m *HealthMonitor) loop() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	m.Check(context.Background())
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.Check(context.Background())
+		}
+	}
+}
+
+// Status 返回最近一次检查的健康状态
+// 不会触发新的检查,适合在请求路径中调用
+func (m *HealthMonitor) Status() HealthStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// Check 立即执行一次健康检查并返回结果
+// 参数:
+//
+//	ctx: 上下文,Ping 的超时在此基础上叠加 Timeout
+//
+// 返回:
+//
+//	HealthStatus: 本次检查后的健康状态
+func (m *HealthMonitor) Check(ctx context.Context) HealthStatus {
+	m.checkMu.Lock()
+	defer m.checkMu.Unlock()
+
+	pingCtx, cancel := context.WithTimeout(ctx, m.opts.Timeout)
+	err := m.db.Ping(pingCtx)
+	cancel()
+
+	prev := m.Status()
+	status := HealthStatus{LastCheck: time.Now(), Healthy: err == nil}
+	log := m.getLogger()
+
+	if err == nil {
+		if !prev.Healthy && !prev.LastCheck.IsZero() && log != nil {
+			log.Info("database connection recovered", "failures", prev.ConsecutiveFailures)
+		}
+		return m.setStatus(status)
+	}
+
+	status.LastError = err.Error()
+	status.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+	if log != nil {
+		log.Warn("database health check failed", "error", err, "failures", status.ConsecutiveFailures)
+	}
+
+	return m.setStatus(status)
+}
+
+// setStatus 保存并返回健康状态
+func (m *HealthMonitor) setStatus(status HealthStatus) HealthStatus {
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+	return status
+}
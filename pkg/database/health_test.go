@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePingDatabase 可控制 Ping 结果的数据库
+// 嵌入接口满足其余方法,测试中调用其他方法会 panic
+type fakePingDatabase struct {
+	Database
+
+	mu      sync.Mutex
+	pingErr error
+}
+
+func (f *fakePingDatabase) Ping(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pingErr
+}
+
+func (f *fakePingDatabase) setPingErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pingErr = err
+}
+
+// TestHealthMonitor_Recovery 测试 Ping 失败时报告不健康,恢复后报告健康
+func TestHealthMonitor_Recovery(t *testing.T) {
+	db := &fakePingDatabase{pingErr: errors.New("connection reset")}
+	m := NewHealthMonitor(db, nil, nil)
+
+	status := m.Check(context.Background())
+	if status.Healthy || status.LastError == "" || status.ConsecutiveFailures != 1 {
+		t.Fatalf("expected unhealthy after failed ping, got %+v", status)
+	}
+	if m.Status() != status {
+		t.Fatalf("Status() = %+v, want %+v", m.Status(), status)
+	}
+
+	db.setPingErr(nil)
+	status = m.Check(context.Background())
+	if !status.Healthy || status.LastError != "" || status.ConsecutiveFailures != 0 {
+		t.Fatalf("expected healthy after recovery, got %+v", status)
+	}
+}
+
+// TestHealthMonitor_ReportOnly 测试连续失败只累计次数,不触碰连接池
+// fakePingDatabase 除 Ping 外的方法都会 panic,重建连接池会导致测试失败
+func TestHealthMonitor_ReportOnly(t *testing.T) {
+	db := &fakePingDatabase{pingErr: errors.New("connection reset")}
+	m := NewHealthMonitor(db, nil, nil)
+
+	var status HealthStatus
+	for i := 0; i < 5; i++ {
+		status = m.Check(context.Background())
+	}
+	if status.Healthy || status.ConsecutiveFailures != 5 {
+		t.Fatalf("expected 5 consecutive failures, got %+v", status)
+	}
+}
+
+// TestHealthMonitor_StartStop 测试后台检查启动后立即检查,Stop 后退出
+func TestHealthMonitor_StartStop(t *testing.T) {
+	db := &fakePingDatabase{}
+	m := NewHealthMonitor(db, &HealthMonitorOptions{Interval: time.Hour}, nil)
+
+	m.Start()
+	deadline := time.Now().Add(time.Second)
+	for m.Status().LastCheck.IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected an initial check after Start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	m.Stop()
+	m.Stop()
+
+	if !m.Status().Healthy {
+		t.Errorf("expected healthy, got %+v", m.Status())
+	}
+
+	// 未启动的监控可以直接 Stop
+	NewHealthMonitor(db, nil, nil).Stop()
+}
@@ -26,6 +26,18 @@ const (
 
 	// ErrMsgShutdownTimeout 关闭超时的错误消息
 	ErrMsgShutdownTimeout = "shutdown timeout exceeded"
+
+	// ErrMsgTaskPanic 任务 panic 的错误消息模板
+	ErrMsgTaskPanic = "%w: %v"
+
+	// ErrMsgDuplicateTask 重复任务的错误消息模板
+	ErrMsgDuplicateTask = "%w: pool=%s key=%s"
+)
+
+// 日志消息常量
+const (
+	// MsgTaskPanic 任务 panic 被恢复时的日志消息
+	MsgTaskPanic = "executor task panicked"
 )
 
 // 预定义错误
@@ -47,6 +59,14 @@ var (
 	// ErrInvalidConfig 无效配置错误
 	// 配置验证失败时返回
 	ErrInvalidConfig = errors.New("invalid config")
+
+	// ErrDuplicateTask 重复任务错误
+	// ExecuteWithKey 提交的 key 已有任务在排队或执行时返回
+	ErrDuplicateTask = errors.New("duplicate task")
+
+	// ErrTaskPanic 任务 panic 错误
+	// 通过 Submit 提交的任务发生 panic 时,Future.Get 返回包装了此错误的 error
+	ErrTaskPanic = errors.New("task panicked")
 )
 
 // 默认配置常量
@@ -76,6 +96,9 @@ const (
 	// 5 秒适用于大多数场景
 	ShutdownTimeout = 5 * time.Second
 
+	// drainPollInterval 关闭时检查任务是否完成的间隔
+	drainPollInterval = 10 * time.Millisecond
+
 	// MinPoolSize 最小池大小
 	// 确保池至少有一个 worker
 	MinPoolSize = 1
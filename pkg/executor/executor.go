@@ -7,7 +7,10 @@
 // - 接口化设计,便于依赖注入和单元测试
 package executor
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // PoolName 定义池的名称类型
 // 使用类型别名提供类型安全,防止字符串拼写错误
@@ -107,6 +110,44 @@ type Manager interface {
 	//   }
 	Execute(poolName PoolName, task func()) error
 
+	// ExecuteWithKey 向指定池提交带去重键的任务
+	// 同一个池中相同 key 的任务尚未执行完成时,新提交的任务被丢弃
+	// 用于合并重复的后台任务,如同一用户的缓存刷新
+	// 参数:
+	//   poolName: 池名称
+	//   key: 去重键
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 与 Execute 相同,重复提交时返回 ErrDuplicateTask
+	// 使用示例:
+	//   err := mgr.ExecuteWithKey("background", "refresh:user:42", refresh)
+	//   if errors.Is(err, executor.ErrDuplicateTask) {
+	//       // 已有相同任务在排队或执行,无需处理
+	//   }
+	ExecuteWithKey(poolName PoolName, key string, task func()) error
+
+	// ExecuteNamed 向指定池提交带名称的任务
+	// 与 Execute 相同,任务 panic 时日志中带上 name,便于定位是哪个任务出错
+	// 参数:
+	//   poolName: 池名称
+	//   name: 任务名称,如 "send-welcome-email"
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 与 Execute 相同
+	// 使用示例:
+	//   err := mgr.ExecuteNamed("background", "send-welcome-email", func() {
+	//       mailer.SendWelcome(user)
+	//   })
+	ExecuteNamed(poolName PoolName, name string, task func()) error
+
+	// SetLogger 设置记录任务 panic 的日志器
+	// 参数:
+	//   logger: 日志器,为 nil 时 panic 信息输出到标准错误
+	// 注意:
+	//   - 任务 panic 会被恢复,不会导致进程崩溃,池继续可用
+	//   - 日志包含池名、任务名、panic 值和调用栈
+	SetLogger(logger Logger)
+
 	// Reload 使用新配置热重载所有池
 	// 这是一个原子操作,失败时保持原配置不变
 	// 参数:
@@ -131,6 +172,23 @@ type Manager interface {
 	//   }
 	Reload(configs []Config) error
 
+	// Stats 返回指定池的运行统计
+	// 参数:
+	//   poolName: 池名称
+	// 返回:
+	//   PoolStats: 容量、正在执行的任务数、排队和拒绝计数等
+	//   error: 池不存在或管理器已关闭时的错误
+	// 使用示例:
+	//   stats, err := mgr.Stats("http")
+	//   if err == nil && stats.Running >= stats.Capacity {
+	//       log.Warn("executor pool saturated", "pool", stats.Name)
+	//   }
+	Stats(poolName PoolName) (PoolStats, error)
+
+	// Pools 返回所有池的名称,按名称排序
+	// 配合 Stats 遍历所有池,如暴露指标端点
+	Pools() []PoolName
+
 	// Shutdown 优雅关闭管理器
 	// 停止接收新任务,等待现有任务完成
 	// 流程:
@@ -144,4 +202,37 @@ type Manager interface {
 	// 使用示例:
 	//   defer mgr.Shutdown()
 	Shutdown()
+
+	// ShutdownWithContext 优雅关闭管理器,并报告关闭时的任务情况
+	// 与 Shutdown 相同,但等待时间由 ctx 控制
+	// 参数:
+	//   ctx: 等待任务完成的上下文,取消或超时后不再等待
+	// 返回:
+	//   ShutdownResult: 关闭时未完成的任务中,已完成和被放弃的数量
+	// 注意:
+	//   - 被放弃的任务不会被中断,只是不再等待其完成
+	// 使用示例:
+	//   ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	//   defer cancel()
+	//   if result := mgr.ShutdownWithContext(ctx); result.Abandoned > 0 {
+	//       log.Warn("executor shutdown abandoned tasks", "count", result.Abandoned)
+	//   }
+	ShutdownWithContext(ctx context.Context) ShutdownResult
+}
+
+// Logger 日志接口
+// 定义 executor 包需要的日志方法,pkg/logger.Logger 满足此接口
+// 不直接依赖 pkg/logger,因为 pkg/logger 依赖 executor 做异步写入
+type Logger interface {
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// ShutdownResult 关闭结果
+// 统计范围为关闭开始时已提交但尚未完成的任务(含排队中的任务)
+type ShutdownResult struct {
+	// Drained 在截止时间前完成的任务数
+	Drained int `json:"drained"`
+
+	// Abandoned 截止时间到达时仍未完成的任务数
+	Abandoned int `json:"abandoned"`
 }
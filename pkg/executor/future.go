@@ -0,0 +1,109 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+)
+
+// Future 表示一个异步任务的结果
+// 由 Submit 返回,任务完成后可以多次调用 Get 读取同一结果
+type Future[T any] interface {
+	// Get 等待任务完成并返回结果
+	// 参数:
+	//   ctx: 等待的上下文,取消或超时后立即返回 ctx.Err(),任务本身继续执行
+	// 返回:
+	//   T: 任务返回值
+	//   error: 任务返回的错误、ErrTaskPanic 或 ctx.Err()
+	Get(ctx context.Context) (T, error)
+
+	// Done 返回任务完成时关闭的 channel
+	// 用于 select 等待多个 Future
+	Done() <-chan struct{}
+}
+
+// future 实现 Future 接口
+// done 关闭前写入 value 和 err,关闭后只读,无需加锁
+type future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Get 等待任务完成并返回结果
+func (f *future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done 返回任务完成时关闭的 channel
+func (f *future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Submit 向指定池提交带返回值的任务
+// Manager.Execute 是 fire-and-forget 的,需要等待结果或错误时使用 Submit
+// Go 的接口方法不支持类型参数,所以这里是包级函数
+// 参数:
+//
+//	ctx: 任务上下文,会传给 fn;任务开始执行前 ctx 已取消则不再调用 fn,直接返回 ctx.Err()
+//	m: 执行器管理器
+//	poolName: 池名称
+//	fn: 任务函数
+//
+// 返回:
+//
+//	Future[T]: 任务结果
+//	error: 提交失败时的错误,与 Execute 相同(ErrPoolNotFound、ErrPoolOverload、ErrManagerClosed)
+//
+// 注意:
+//
+//	fn 发生 panic 时 Get 返回包装了 ErrTaskPanic 的错误;
+//	panic 随后交给池的恢复逻辑,与 Execute 的任务一样计入 PoolStats.Panics 并记录堆栈
+//
+// 使用示例:
+//
+//	userF, err := executor.Submit(ctx, mgr, "database", func(ctx context.Context) (*User, error) {
+//	    return repo.FindByID(ctx, id)
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	ordersF, err := executor.Submit(ctx, mgr, "database", func(ctx context.Context) ([]Order, error) {
+//	    return orderRepo.ListByUser(ctx, id)
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	user, err := userF.Get(ctx)
+//	orders, err := ordersF.Get(ctx)
+func Submit[T any](ctx context.Context, m Manager, poolName PoolName, fn func(ctx context.Context) (T, error)) (Future[T], error) {
+	f := &future[T]{done: make(chan struct{})}
+
+	err := m.Execute(poolName, func() {
+		defer close(f.done)
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = fmt.Errorf(ErrMsgTaskPanic, ErrTaskPanic, r)
+				// 重新 panic,由池的 wrapTaskWithRecover 计数并记录堆栈
+				// 此时原 panic 的栈帧仍在调用栈上,日志中能看到真正出错的位置
+				panic(r)
+			}
+		}()
+
+		if err := ctx.Err(); err != nil {
+			f.err = err
+			return
+		}
+		f.value, f.err = fn(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
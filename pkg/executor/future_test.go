@@ -0,0 +1,164 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestManager 创建测试用的执行器管理器
+func newTestManager(t *testing.T) Manager {
+	t.Helper()
+	mgr, err := NewManager([]Config{{Name: "test", Size: 4}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(mgr.Shutdown)
+	return mgr
+}
+
+// TestSubmit_Success 测试获取任务返回值
+func TestSubmit_Success(t *testing.T) {
+	mgr := newTestManager(t)
+	ctx := context.Background()
+
+	futures := make([]Future[int], 0, 3)
+	for i := 1; i <= 3; i++ {
+		n := i
+		f, err := Submit(ctx, mgr, "test", func(ctx context.Context) (int, error) {
+			return n * n, nil
+		})
+		if err != nil {
+			t.Fatalf("submit failed: %v", err)
+		}
+		futures = append(futures, f)
+	}
+
+	sum := 0
+	for _, f := range futures {
+		v, err := f.Get(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sum += v
+	}
+	if sum != 14 {
+		t.Fatalf("sum = %d, want 14", sum)
+	}
+}
+
+// TestSubmit_Error 测试任务错误和 panic 的传递
+func TestSubmit_Error(t *testing.T) {
+	mgr := newTestManager(t)
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	f, err := Submit(ctx, mgr, "test", func(ctx context.Context) (string, error) {
+		return "", errBoom
+	})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := f.Get(ctx); !errors.Is(err, errBoom) {
+		t.Fatalf("expected task error, got %v", err)
+	}
+
+	f, err = Submit(ctx, mgr, "test", func(ctx context.Context) (string, error) {
+		panic("unexpected")
+	})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := f.Get(ctx); !errors.Is(err, ErrTaskPanic) {
+		t.Fatalf("expected ErrTaskPanic, got %v", err)
+	}
+
+	if _, err := Submit(ctx, mgr, "missing", func(ctx context.Context) (int, error) {
+		return 0, nil
+	}); err == nil {
+		t.Fatal("expected error for unknown pool")
+	}
+}
+
+// TestSubmit_ContextCancel 测试等待超时和任务上下文取消
+func TestSubmit_ContextCancel(t *testing.T) {
+	mgr := newTestManager(t)
+
+	release := make(chan struct{})
+	f, err := Submit(context.Background(), mgr, "test", func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	// Get 的 ctx 超时后立即返回,任务继续执行
+	waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := f.Get(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	close(release)
+	if v, err := f.Get(context.Background()); err != nil || v != 1 {
+		t.Fatalf("Get = %d, %v; want 1, nil", v, err)
+	}
+
+	// 任务 ctx 已取消时不执行 fn
+	taskCtx, cancelTask := context.WithCancel(context.Background())
+	cancelTask()
+	called := false
+	f, err = Submit(taskCtx, mgr, "test", func(ctx context.Context) (int, error) {
+		called = true
+		return 0, nil
+	})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := f.Get(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if called {
+		t.Fatal("fn should not run when task context is already cancelled")
+	}
+}
+
+// TestSubmit_PanicReported 测试 Submit 任务 panic 后计入池统计并记录堆栈
+func TestSubmit_PanicReported(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "work", Size: 1}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	log := &recordLogger{}
+	mgr.SetLogger(log)
+
+	ctx := context.Background()
+	f, err := Submit(ctx, mgr, "work", func(ctx context.Context) (int, error) {
+		panic("nil map")
+	})
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	if _, err := f.Get(ctx); !errors.Is(err, ErrTaskPanic) {
+		t.Fatalf("expected ErrTaskPanic, got %v", err)
+	}
+	waitStats(t, mgr, "work", func(s PoolStats) bool { return s.Panics == 1 })
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	if len(log.entries) != 1 {
+		t.Fatalf("expected 1 panic log, got %d", len(log.entries))
+	}
+	entry := log.entries[0]
+	if entry["msg"] != MsgTaskPanic || entry["panic"] != "nil map" {
+		t.Fatalf("unexpected panic log: %v", entry)
+	}
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "TestSubmit_PanicReported") {
+		t.Fatalf("expected stack trace to include the panicking task, got %q", stack)
+	}
+}
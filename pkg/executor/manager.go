@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -30,6 +31,10 @@ type manager struct {
 	// closed 标记管理器是否已关闭
 	// 使用 atomic 实现无锁检查
 	closed atomic.Bool
+
+	// logger 记录任务 panic 的日志器
+	// 池在 panic 时读取,SetLogger 后对已有池立即生效
+	logger atomic.Pointer[Logger]
 }
 
 // NewManager 创建一个新的执行器管理器
@@ -60,6 +65,8 @@ func NewManager(configs []Config) (Manager, error) {
 		return nil, fmt.Errorf(ErrMsgInvalidConfig, fmt.Errorf("no configs provided"))
 	}
 
+	m := &manager{}
+
 	// 创建池 map
 	pools := make(map[PoolName]*poolWrapper, len(configs))
 
@@ -73,7 +80,7 @@ func NewManager(configs []Config) (Manager, error) {
 		}
 
 		// 创建池
-		pool, err := newPoolWrapper(cfg)
+		pool, err := newPoolWrapper(cfg, m.getLogger)
 		if err != nil {
 			// 创建失败,清理已创建的池
 			releasePools(pools)
@@ -83,9 +90,8 @@ func NewManager(configs []Config) (Manager, error) {
 		pools[cfg.Name] = pool
 	}
 
-	return &manager{
-		pools: pools,
-	}, nil
+	m.pools = pools
+	return m, nil
 }
 
 // Execute 向指定池提交任务
@@ -103,6 +109,13 @@ func NewManager(configs []Config) (Manager, error) {
 //
 //	使用读锁保护,允许并发调用
 func (m *manager) Execute(poolName PoolName, task func()) error {
+	return m.ExecuteNamed(poolName, "", task)
+}
+
+// ExecuteNamed 向指定池提交带名称的任务
+// 实现 Manager 接口
+// name 只用于 panic 日志,不参与去重
+func (m *manager) ExecuteNamed(poolName PoolName, name string, task func()) error {
 	// 快速检查管理器是否已关闭
 	// 使用 atomic 无锁检查,性能更好
 	if m.closed.Load() {
@@ -121,7 +134,7 @@ func (m *manager) Execute(poolName PoolName, task func()) error {
 	}
 
 	// 提交任务到池
-	if err := pool.Submit(task); err != nil {
+	if err := pool.Submit(name, task); err != nil {
 		// 如果是池过载错误,添加池名称信息
 		if err == ErrPoolOverload {
 			return fmt.Errorf(ErrMsgPoolOverload, poolName)
@@ -132,6 +145,35 @@ func (m *manager) Execute(poolName PoolName, task func()) error {
 	return nil
 }
 
+// ExecuteWithKey 向指定池提交带去重键的任务
+// 实现 Manager 接口
+// 相同 key 的任务尚未完成时返回 ErrDuplicateTask
+// 注意:
+//
+//	去重范围为单个池;Reload 后新池不继承旧池中的 key
+func (m *manager) ExecuteWithKey(poolName PoolName, key string, task func()) error {
+	if m.closed.Load() {
+		return ErrManagerClosed
+	}
+
+	m.mu.RLock()
+	pool, exists := m.pools[poolName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf(ErrMsgPoolNotFound, poolName)
+	}
+
+	if err := pool.SubmitWithKey(key, task); err != nil {
+		if err == ErrPoolOverload {
+			return fmt.Errorf(ErrMsgPoolOverload, poolName)
+		}
+		return err
+	}
+
+	return nil
+}
+
 // Reload 使用新配置重新加载所有池
 // 实现 Manager 接口
 // 这是一个原子操作,遵循以下步骤:
@@ -172,7 +214,7 @@ func (m *manager) Reload(configs []Config) error {
 		}
 
 		// 创建新池
-		pool, err := newPoolWrapper(cfg)
+		pool, err := newPoolWrapper(cfg, m.getLogger)
 		if err != nil {
 			// 创建失败,清理所有新池
 			releasePools(newPools)
@@ -205,6 +247,24 @@ func (m *manager) Reload(configs []Config) error {
 	return nil
 }
 
+// SetLogger 设置记录任务 panic 的日志器
+// 实现 Manager 接口
+func (m *manager) SetLogger(logger Logger) {
+	if logger == nil {
+		m.logger.Store(nil)
+		return
+	}
+	m.logger.Store(&logger)
+}
+
+// getLogger 返回当前的日志器,未设置时返回 nil
+func (m *manager) getLogger() Logger {
+	if l := m.logger.Load(); l != nil {
+		return *l
+	}
+	return nil
+}
+
 // Shutdown 优雅关闭管理器
 // 实现 Manager 接口
 // 步骤:
@@ -217,6 +277,16 @@ func (m *manager) Reload(configs []Config) error {
 //	调用后管理器不可再使用
 //	会阻塞直到所有任务完成或超时
 func (m *manager) Shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+
+	m.ShutdownWithContext(ctx)
+}
+
+// ShutdownWithContext 优雅关闭管理器,并报告关闭时的任务情况
+// 实现 Manager 接口
+// 所有池并发等待,ctx 取消或超时后汇总各池被放弃的任务数
+func (m *manager) ShutdownWithContext(ctx context.Context) ShutdownResult {
 	// 标记为已关闭
 	// 使用 atomic 确保线程安全
 	m.closed.Store(true)
@@ -227,8 +297,28 @@ func (m *manager) Shutdown() {
 	m.pools = make(map[PoolName]*poolWrapper) // 清空池 map
 	m.mu.Unlock()
 
-	// 在锁外释放池
-	releasePools(pools)
+	// 在锁外并发等待各池
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result ShutdownResult
+	)
+	wg.Add(len(pools))
+	for _, pool := range pools {
+		pool := pool // 捕获循环变量
+		go func() {
+			defer wg.Done()
+			r := pool.Drain(ctx)
+
+			mu.Lock()
+			result.Drained += r.Drained
+			result.Abandoned += r.Abandoned
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return result
 }
 
 // releasePools 释放池 map 中的所有池
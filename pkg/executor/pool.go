@@ -1,13 +1,22 @@
 package executor
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/panjf2000/ants/v2"
 )
 
+// panicOutput 未注入日志器时 panic 的输出位置
+// 写到标准错误,避免混入使用标准输出传递数据的程序(如 CLI 命令)
+var panicOutput io.Writer = os.Stderr
+
 // poolWrapper 封装 ants.Pool,提供额外功能
 // 设计考虑:
 // - 包装 ants 池,提供 panic 恢复
@@ -23,18 +32,35 @@ type poolWrapper struct {
 
 	// config 池配置,用于重建
 	config Config
+
+	// 任务统计,用于 Stats
+	// ants 的 Running 统计的是 worker 数量,空闲 worker 在过期前也会计入,
+	// 所以正在执行的任务数需要自己统计
+	running   atomic.Int64
+	pending   atomic.Int64 // 已提交未完成的任务数(含排队中),用于关闭时统计
+	submitted atomic.Uint64
+	completed atomic.Uint64
+	rejected  atomic.Uint64
+	panics    atomic.Uint64
+
+	// keys 排队或执行中任务的去重键,用于 ExecuteWithKey
+	keys sync.Map
+
+	// logger 返回记录 panic 的日志器,由 manager 提供,可能返回 nil
+	logger func() Logger
 }
 
 // newPoolWrapper 创建新的池包装器
 // 参数:
 //
 //	cfg: 池配置
+//	logger: 返回记录 panic 的日志器,可以为 nil
 //
 // 返回:
 //
 //	*poolWrapper: 池包装器实例
 //	error: 创建失败时的错误
-func newPoolWrapper(cfg Config) (*poolWrapper, error) {
+func newPoolWrapper(cfg Config, logger func() Logger) (*poolWrapper, error) {
 	// 验证配置
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf(ErrMsgInvalidConfig, err)
@@ -60,6 +86,7 @@ func newPoolWrapper(cfg Config) (*poolWrapper, error) {
 		name:   cfg.Name,
 		pool:   pool,
 		config: cfg,
+		logger: logger,
 	}, nil
 }
 
@@ -67,19 +94,39 @@ func newPoolWrapper(cfg Config) (*poolWrapper, error) {
 // 自动包装任务以提供 panic 恢复
 // 参数:
 //
+//	name: 任务名称,用于 panic 日志,可以为空
 //	task: 要执行的任务函数
 //
 // 返回:
 //
 //	error: 提交失败时的错误
-func (p *poolWrapper) Submit(task func()) error {
+func (p *poolWrapper) Submit(name string, task func()) error {
 	// 包装任务,添加 panic 恢复
-	wrapped := wrapTaskWithRecover(p.name, task)
+	wrapped := p.wrapTaskWithRecover(name, task)
+
+	// 统计正在执行和已完成的任务数
+	// 放在 recover 包装之外,panic 的任务同样计入完成
+	tracked := func() {
+		p.running.Add(1)
+		defer func() {
+			p.running.Add(-1)
+			p.completed.Add(1)
+			p.pending.Add(-1)
+		}()
+		wrapped()
+	}
 
 	// 提交到 ants 池
-	if err := p.pool.Submit(wrapped); err != nil {
+	// 阻塞模式下 Submit 可能等待空闲 worker,先计入 pending 以统计排队中的任务
+	// submitted 同样先计入,避免任务在 Submit 返回前完成时 Completed 大于 Submitted
+	p.pending.Add(1)
+	p.submitted.Add(1)
+	if err := p.pool.Submit(tracked); err != nil {
+		p.pending.Add(-1)
+		p.submitted.Add(^uint64(0))
 		// 转换 ants 错误为项目错误
 		if err == ants.ErrPoolOverload {
+			p.rejected.Add(1)
 			return ErrPoolOverload
 		}
 		if err == ants.ErrPoolClosed {
@@ -91,6 +138,59 @@ func (p *poolWrapper) Submit(task func()) error {
 	return nil
 }
 
+// SubmitWithKey 提交带去重键的任务
+// key 已有任务在排队或执行时返回 ErrDuplicateTask,任务完成(含 panic)后 key 被释放
+func (p *poolWrapper) SubmitWithKey(key string, task func()) error {
+	if _, loaded := p.keys.LoadOrStore(key, struct{}{}); loaded {
+		return fmt.Errorf(ErrMsgDuplicateTask, ErrDuplicateTask, p.name, key)
+	}
+
+	// 去重键同时作为任务名称,panic 日志中可以看到是哪个 key 的任务
+	err := p.Submit(key, func() {
+		defer p.keys.Delete(key)
+		task()
+	})
+	if err != nil {
+		p.keys.Delete(key)
+	}
+	return err
+}
+
+// Drain 等待已提交的任务完成,然后释放池
+// 参数:
+//
+//	ctx: 等待的上下文,取消或超时后不再等待
+//
+// 返回:
+//
+//	ShutdownResult: 开始等待时未完成的任务中,已完成和被放弃的数量
+func (p *poolWrapper) Drain(ctx context.Context) ShutdownResult {
+	defer p.Release()
+
+	inflight := int(p.pending.Load())
+	if inflight == 0 {
+		return ShutdownResult{}
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		remaining := int(p.pending.Load())
+		if remaining <= 0 {
+			return ShutdownResult{Drained: inflight}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			// 计数期间可能有任务完成,被放弃的数量不超过开始时的数量
+			remaining = min(int(p.pending.Load()), inflight)
+			return ShutdownResult{Drained: inflight - remaining, Abandoned: remaining}
+		}
+	}
+}
+
 // Release 释放池资源
 // 优雅关闭,等待所有任务完成
 func (p *poolWrapper) Release() {
@@ -156,32 +256,45 @@ func (p *poolWrapper) Cap() int {
 	return p.pool.Cap()
 }
 
+// Stats 返回池的运行统计
+func (p *poolWrapper) Stats() PoolStats {
+	// 先读 Completed 再读 Submitted,已完成的任务一定已计入 Submitted,
+	// 两次读取之间新提交并完成的任务不会让 Completed 大于 Submitted
+	completed := p.completed.Load()
+	stats := PoolStats{
+		Name:      p.name,
+		Capacity:  p.Cap(),
+		Running:   int(p.running.Load()),
+		Workers:   p.Running(),
+		Free:      p.Free(),
+		Submitted: p.submitted.Load(),
+		Completed: completed,
+		Rejected:  p.rejected.Load(),
+		Panics:    p.panics.Load(),
+	}
+	if p.pool != nil {
+		stats.Waiting = p.pool.Waiting()
+	}
+	return stats
+}
+
 // wrapTaskWithRecover 包装任务,添加 panic 恢复
 // 这是一个关键的安全机制,确保任何 panic 都不会导致进程崩溃
 // 参数:
 //
-//	poolName: 池名称,用于日志
+//	name: 任务名称,用于日志,可以为空
 //	task: 原始任务函数
 //
 // 返回:
 //
 //	func(): 包装后的任务函数
-func wrapTaskWithRecover(poolName PoolName, task func()) func() {
+func (p *poolWrapper) wrapTaskWithRecover(name string, task func()) func() {
 	return func() {
 		// 使用 defer + recover 捕获 panic
 		defer func() {
 			if r := recover(); r != nil {
-				// 捕获到 panic,记录详细信息
-				// 注意: 这里我们不能直接使用 logger,因为:
-				// 1. pkg 层不应依赖 internal 层
-				// 2. logger 可能还未初始化
-				// 3. 避免循环依赖
-				// 最佳实践是让业务层注入 logger 或使用标准库
-				fmt.Printf("[EXECUTOR PANIC] pool=%s panic=%v\n", poolName, r)
-				// 在真实场景中,可以考虑:
-				// - 通过回调函数记录到日志
-				// - 发送到监控系统
-				// - 增加 panic 计数器
+				p.panics.Add(1)
+				p.reportPanic(name, r, debug.Stack())
 			}
 		}()
 
@@ -190,6 +303,29 @@ func wrapTaskWithRecover(poolName PoolName, task func()) func() {
 	}
 }
 
+// reportPanic 记录被恢复的 panic
+// 优先使用注入的日志器,未注入时输出到标准错误;设置了全局 panic 处理器时同时通知它
+func (p *poolWrapper) reportPanic(name string, recovered interface{}, stack []byte) {
+	var log Logger
+	if p.logger != nil {
+		log = p.logger()
+	}
+
+	if log != nil {
+		log.Error(MsgTaskPanic,
+			"pool", p.name,
+			"task", name,
+			"panic", recovered,
+			"stack", string(stack))
+	} else {
+		fmt.Fprintf(panicOutput, "[EXECUTOR PANIC] pool=%s task=%s panic=%v\n%s\n", p.name, name, recovered, stack)
+	}
+
+	if handler := getPanicHandler(); handler != nil {
+		handler.HandlePanic(p.name, recovered)
+	}
+}
+
 // panicHandler 是一个可选的 panic 处理器接口
 // 业务层可以通过此接口自定义 panic 处理逻辑
 type panicHandler interface {
@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordLogger 记录 Error 调用的日志器
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []map[string]interface{}
+}
+
+func (l *recordLogger) Error(msg string, keysAndValues ...interface{}) {
+	entry := map[string]interface{}{"msg": msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		entry[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+// TestExecuteNamed_PanicRecovered 测试任务 panic 后池仍可用,panic 被记录并计数
+func TestExecuteNamed_PanicRecovered(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "work", Size: 1}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	log := &recordLogger{}
+	mgr.SetLogger(log)
+
+	if err := mgr.ExecuteNamed("work", "send-email", func() { panic("smtp down") }); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+	waitStats(t, mgr, "work", func(s PoolStats) bool { return s.Panics == 1 })
+
+	// 池只有一个 worker,panic 后仍能执行新任务
+	done := make(chan struct{})
+	if err := mgr.Execute("work", func() { close(done) }); err != nil {
+		t.Fatalf("failed to submit task after panic: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pool did not survive the panic")
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	if len(log.entries) != 1 {
+		t.Fatalf("expected 1 panic log, got %d", len(log.entries))
+	}
+	entry := log.entries[0]
+	if entry["msg"] != MsgTaskPanic || entry["pool"] != PoolName("work") || entry["task"] != "send-email" || entry["panic"] != "smtp down" {
+		t.Fatalf("unexpected panic log: %v", entry)
+	}
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "goroutine") {
+		t.Fatalf("expected stack trace in panic log, got %q", stack)
+	}
+}
+
+// TestReportPanic_FallbackToStderr 测试未注入日志器时 panic 输出到标准错误
+func TestReportPanic_FallbackToStderr(t *testing.T) {
+	var buf bytes.Buffer
+	old := panicOutput
+	panicOutput = &buf
+	defer func() { panicOutput = old }()
+
+	p := &poolWrapper{name: "work"}
+	p.reportPanic("send-email", "smtp down", []byte("goroutine 1 [running]"))
+
+	out := buf.String()
+	for _, want := range []string{"pool=work", "task=send-email", "panic=smtp down", "goroutine 1"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in panic output, got %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestShutdownWithContext_Abandoned 测试截止时间到达时报告未完成的任务
+func TestShutdownWithContext_Abandoned(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "work", Size: 4}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 3; i++ {
+		if err := mgr.Execute("work", func() { <-release }); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+	}
+	if err := mgr.Execute("work", func() {}); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	waitStats(t, mgr, "work", func(s PoolStats) bool { return s.Running == 3 && s.Completed == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result := mgr.ShutdownWithContext(ctx)
+
+	if result.Abandoned != 3 || result.Drained != 0 {
+		t.Fatalf("unexpected shutdown result: %+v", result)
+	}
+	if err := mgr.Execute("work", func() {}); !errors.Is(err, ErrManagerClosed) {
+		t.Fatalf("expected ErrManagerClosed after shutdown, got %v", err)
+	}
+}
+
+// TestShutdownWithContext_Drained 测试任务在截止时间前完成时全部计入 Drained
+func TestShutdownWithContext_Drained(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "work", Size: 4}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := mgr.Execute("work", func() { time.Sleep(20 * time.Millisecond) }); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result := mgr.ShutdownWithContext(ctx)
+
+	if result.Drained != 2 || result.Abandoned != 0 {
+		t.Fatalf("unexpected shutdown result: %+v", result)
+	}
+}
+
+// TestExecuteWithKey_Dedup 测试相同 key 的任务未完成时被丢弃,完成后可再次提交
+func TestExecuteWithKey_Dedup(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "work", Size: 4}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	release := make(chan struct{})
+	if err := mgr.ExecuteWithKey("work", "refresh:1", func() { <-release }); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if err := mgr.ExecuteWithKey("work", "refresh:1", func() {}); !errors.Is(err, ErrDuplicateTask) {
+		t.Fatalf("expected ErrDuplicateTask, got %v", err)
+	}
+	if err := mgr.ExecuteWithKey("work", "refresh:2", func() {}); err != nil {
+		t.Fatalf("different key should be accepted: %v", err)
+	}
+
+	close(release)
+	waitStats(t, mgr, "work", func(s PoolStats) bool { return s.Completed == 2 })
+
+	if err := mgr.ExecuteWithKey("work", "refresh:1", func() {}); err != nil {
+		t.Fatalf("key should be released after completion: %v", err)
+	}
+}
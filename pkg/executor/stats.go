@@ -0,0 +1,84 @@
+package executor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PoolStats 池的运行统计
+// 用于容量规划、指标暴露和饱和告警
+// 注意:
+//
+//	Reload 会创建新池,统计计数随之重置
+type PoolStats struct {
+	// Name 池名称
+	Name PoolName `json:"name"`
+
+	// Capacity 池容量,即最大并发 worker 数量
+	Capacity int `json:"capacity"`
+
+	// Running 正在执行的任务数
+	Running int `json:"running"`
+
+	// Workers 当前存活的 worker 数量(含等待过期回收的空闲 worker)
+	Workers int `json:"workers"`
+
+	// Free 还可以创建的 worker 数量
+	Free int `json:"free"`
+
+	// Waiting 阻塞模式下等待提交的任务数(排队中)
+	Waiting int `json:"waiting"`
+
+	// Submitted 成功提交的任务总数
+	// 任务开始执行前已计入,Completed 不会大于 Submitted
+	Submitted uint64 `json:"submitted"`
+
+	// Completed 执行完成的任务总数(包括 panic 的任务)
+	Completed uint64 `json:"completed"`
+
+	// Rejected 因池过载被拒绝的任务总数(仅 NonBlocking=true)
+	Rejected uint64 `json:"rejected"`
+
+	// Panics 发生 panic 并被恢复的任务总数
+	Panics uint64 `json:"panics"`
+}
+
+// Stats 返回指定池的运行统计
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	PoolStats: 池统计快照
+//	error: 池不存在或管理器已关闭时的错误
+func (m *manager) Stats(poolName PoolName) (PoolStats, error) {
+	if m.closed.Load() {
+		return PoolStats{}, ErrManagerClosed
+	}
+
+	m.mu.RLock()
+	pool, exists := m.pools[poolName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return PoolStats{}, fmt.Errorf(ErrMsgPoolNotFound, poolName)
+	}
+	return pool.Stats(), nil
+}
+
+// Pools 返回所有池的名称
+// 实现 Manager 接口
+// 按名称排序,保证输出稳定
+func (m *manager) Pools() []PoolName {
+	m.mu.RLock()
+	names := make([]PoolName, 0, len(m.pools))
+	for name := range m.pools {
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
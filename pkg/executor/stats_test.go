@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// waitStats 轮询直到统计满足条件或超时
+func waitStats(t *testing.T, mgr Manager, name PoolName, cond func(PoolStats) bool) PoolStats {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		stats, err := mgr.Stats(name)
+		if err != nil {
+			t.Fatalf("stats failed: %v", err)
+		}
+		if cond(stats) {
+			return stats
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met, last stats: %+v", stats)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestStats_SubmittedBeforeRun 测试任务开始执行时已计入 Submitted
+// 任务可能在 Submit 返回前就执行完成,Completed 任何时候都不应大于 Submitted
+func TestStats_SubmittedBeforeRun(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "work", Size: 4}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	const n = 200
+	seen := make(chan PoolStats, n)
+	for i := 0; i < n; i++ {
+		if err := mgr.Execute("work", func() {
+			stats, _ := mgr.Stats("work")
+			seen <- stats
+		}); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		stats := <-seen
+		if stats.Submitted == 0 || stats.Completed >= stats.Submitted {
+			t.Fatalf("running task observed inconsistent stats: %+v", stats)
+		}
+	}
+}
+
+// TestStats_Running 测试 Running 反映正在执行的任务
+func TestStats_Running(t *testing.T) {
+	mgr, err := NewManager([]Config{
+		{Name: "work", Size: 4},
+		{Name: "background", Size: 1, NonBlocking: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	if got, want := mgr.Pools(), []PoolName{"background", "work"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Pools() = %v, want %v", got, want)
+	}
+
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		if err := mgr.Execute("work", func() { <-release }); err != nil {
+			t.Fatalf("execute failed: %v", err)
+		}
+	}
+
+	stats := waitStats(t, mgr, "work", func(s PoolStats) bool { return s.Running == 3 })
+	if stats.Capacity != 4 || stats.Submitted != 3 {
+		t.Fatalf("unexpected stats while running: %+v", stats)
+	}
+
+	close(release)
+	waitStats(t, mgr, "work", func(s PoolStats) bool { return s.Running == 0 && s.Completed == 3 })
+
+	// 非阻塞池满时计入 Rejected
+	block := make(chan struct{})
+	defer close(block)
+	_ = mgr.Execute("background", func() { <-block })
+	waitStats(t, mgr, "background", func(s PoolStats) bool { return s.Running == 1 })
+	if err := mgr.Execute("background", func() {}); err == nil {
+		t.Fatal("expected overload error")
+	}
+	if stats, _ := mgr.Stats("background"); stats.Rejected != 1 || stats.Submitted != 1 {
+		t.Fatalf("expected rejected task not to count as submitted: %+v", stats)
+	}
+
+	if _, err := mgr.Stats("missing"); err == nil {
+		t.Fatal("expected error for unknown pool")
+	}
+}
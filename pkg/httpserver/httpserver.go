@@ -3,6 +3,7 @@ package httpserver
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
 	"sync/atomic"
@@ -16,6 +17,11 @@ type httpServer struct {
 	// server 标准库 http.Server 实例
 	server *http.Server
 
+	// listener server 使用的监听器
+	// Serve 在 goroutine 中启动,Shutdown 可能早于 Serve 登记监听器,
+	// 重新绑定同一端口前需要显式关闭
+	listener net.Listener
+
 	// handler HTTP 请求处理器 (Gin Router)
 	handler Handler
 
@@ -123,47 +129,79 @@ func (s *httpServer) Start(ctx context.Context) error {
 		s.config.Port = port
 	}
 
+	// 同步绑定端口，端口占用等启动错误直接返回给调用方
+	// 之前在 goroutine 中 ListenAndServe，Start 总是返回 nil，绑定失败只会写入无人读取的 errChan
+	server, ln, err := s.listen(s.config)
+	if err != nil {
+		s.state.Store(int32(stateStopped))
+		return &ServerError{
+			Op:      "start",
+			Message: ErrMsgServerStartFailed,
+			Err:     err,
+		}
+	}
+
+	// 记录启动信息
+	s.logger.Info(fmt.Sprintf("starting HTTP server on http://%s", server.Addr), "addr", server.Addr)
+
+	// 设置状态为运行中
+	s.server = server
+	s.listener = ln
+	s.state.Store(int32(stateRunning))
+
+	// 在新的 goroutine 中处理请求
+	s.serve(server, ln, "start", ErrMsgServerStartFailed)
+
+	return nil
+}
+
+// listen 按配置创建 HTTP 服务器并同步绑定监听地址
+// 地址不合法时使用默认 host
+func (s *httpServer) listen(cfg *Config) (*http.Server, net.Listener, error) {
 	// 构造监听地址
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
 	// 校验地址是否合法
 	if err := utils.IsValidHTTPListenAddr(addr); err != nil {
 		// 如果地址不合法，使用默认 host
 		s.logger.Warn("invalid listen address, using default host", "addr", addr, "error", err)
-		addr = fmt.Sprintf("%s:%d", DefaultHost, s.config.Port)
+		addr = fmt.Sprintf("%s:%d", DefaultHost, cfg.Port)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// 创建 HTTP 服务器实例
-	s.server = &http.Server{
+	server := &http.Server{
 		Addr:         addr,
 		Handler:      s.handler,
-		ReadTimeout:  s.config.ReadTimeout,
-		WriteTimeout: s.config.WriteTimeout,
-		IdleTimeout:  s.config.IdleTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
 	}
+	return server, ln, nil
+}
 
-	// 记录启动信息
-	s.logger.Info(fmt.Sprintf("starting HTTP server on http://%s", addr), "addr", addr)
-
-	// 在新的 goroutine 中启动服务器
+// serve 在新的 goroutine 中处理 ln 上的请求
+// 运行期间的错误写入 errChan（不阻塞），并将状态置为已停止
+func (s *httpServer) serve(server *http.Server, ln net.Listener, op, message string) {
 	go func() {
-		// 设置状态为运行中
-		s.state.Store(int32(stateRunning))
-
-		// 启动服务器并开始监听
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
 			// ErrServerClosed 是正常的关闭，不是错误
 			s.logger.Error("HTTP server error", "error", err)
-			s.errChan <- &ServerError{
-				Op:      "start",
-				Message: ErrMsgServerStartFailed,
+			select {
+			case s.errChan <- &ServerError{
+				Op:      op,
+				Message: message,
 				Err:     err,
+			}:
+			default:
 			}
 			s.state.Store(int32(stateStopped))
 		}
 	}()
-
-	return nil
 }
 
 // Shutdown 优雅关闭服务器
@@ -202,6 +240,11 @@ func (s *httpServer) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// Errors 返回服务器运行期间的错误通道
+func (s *httpServer) Errors() <-chan error {
+	return s.errChan
+}
+
 // Reload 热重载配置（原子操作）
 func (s *httpServer) Reload(ctx context.Context, cfg *Config) error {
 	if cfg == nil {
@@ -240,19 +283,40 @@ func (s *httpServer) Reload(ctx context.Context, cfg *Config) error {
 	// 保存旧服务器实例
 	oldServer := s.server
 
-	// 构造新的监听地址
+	// 检查监听地址是否变化
+	oldAddr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 	newAddr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
-	// 检查端口是否变化
-	oldAddr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
-	portChanged := newAddr != oldAddr
+	if newAddr != oldAddr {
+		// 地址变化时先绑定新地址，失败则旧服务器继续运行
+		server, ln, err := s.listen(cfg)
+		if err != nil {
+			s.logger.Error("failed to bind new address during reload, keeping old server", "addr", newAddr, "error", err)
+			return &ServerError{
+				Op:      "reload",
+				Message: ErrMsgReloadFailed,
+				Err:     err,
+			}
+		}
 
-	// 如果端口变化，需要先关闭旧服务器
-	if portChanged {
-		s.logger.Info("port changed, shutting down old server first", "old", oldAddr, "new", newAddr)
+		s.logger.Info(fmt.Sprintf("restarting HTTP server on http://%s", server.Addr), "addr", server.Addr, "old", oldAddr)
+		s.server = server
+		s.listener = ln
+		s.config = cfg
+		s.serve(server, ln, "reload", ErrMsgReloadFailed)
 
-		// 创建一个临时上下文用于关闭旧服务器
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
+		// 新服务器已接管，后台关闭旧服务器
+		go func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
+			defer cancel()
+
+			if err := oldServer.Shutdown(shutdownCtx); err != nil {
+				s.logger.Error("failed to shutdown old server after reload", "error", err)
+			}
+		}()
+	} else {
+		// 地址不变时端口仍被旧服务器占用，只能先关闭旧服务器再重新绑定
+		shutdownCtx, cancel := context.WithTimeout(ctx, DefaultWriteTimeout)
 		defer cancel()
 
 		if err := oldServer.Shutdown(shutdownCtx); err != nil {
@@ -263,49 +327,25 @@ func (s *httpServer) Reload(ctx context.Context, cfg *Config) error {
 				Err:     err,
 			}
 		}
-	}
-
-	// 更新配置
-	s.config = cfg
+		// 确保端口已释放,监听器已关闭时返回的错误可以忽略
+		_ = s.listener.Close()
 
-	// 校验地址是否合法
-	if err := utils.IsValidHTTPListenAddr(newAddr); err != nil {
-		s.logger.Warn("invalid listen address, using default host", "addr", newAddr, "error", err)
-		newAddr = fmt.Sprintf("%s:%d", DefaultHost, cfg.Port)
-	}
-
-	// 创建新的服务器实例
-	s.server = &http.Server{
-		Addr:         newAddr,
-		Handler:      s.handler,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-		IdleTimeout:  cfg.IdleTimeout,
-	}
-
-	// 启动新服务器
-	go func() {
-		s.logger.Info(fmt.Sprintf("restarting HTTP server on http://%s", newAddr), "addr", newAddr)
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.logger.Error("reloaded HTTP server error", "error", err)
-			s.errChan <- &ServerError{
+		server, ln, err := s.listen(cfg)
+		if err != nil {
+			s.state.Store(int32(stateStopped))
+			s.logger.Error("failed to rebind address during reload, server stopped", "addr", newAddr, "error", err)
+			return &ServerError{
 				Op:      "reload",
 				Message: ErrMsgReloadFailed,
 				Err:     err,
 			}
 		}
-	}()
 
-	// 如果端口未变化，现在关闭旧服务器
-	if !portChanged && oldServer != nil {
-		go func() {
-			shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
-			defer cancel()
-
-			if err := oldServer.Shutdown(shutdownCtx); err != nil {
-				s.logger.Error("failed to shutdown old server after reload", "error", err)
-			}
-		}()
+		s.logger.Info(fmt.Sprintf("restarting HTTP server on http://%s", server.Addr), "addr", server.Addr)
+		s.server = server
+		s.listener = ln
+		s.config = cfg
+		s.serve(server, ln, "reload", ErrMsgReloadFailed)
 	}
 
 	s.logger.Info("HTTP server reloaded successfully")
@@ -0,0 +1,134 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// freePort 返回一个当前未被占用的本地端口
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find free port: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+	return port
+}
+
+// occupyPort 占用一个本地端口直到测试结束
+func occupyPort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to occupy port: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// startTestServer 在 port 上启动返回 "ok" 的服务器
+func startTestServer(t *testing.T, port int) HTTPServer {
+	t.Helper()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	})
+	s, err := New(handler, &Config{Host: "127.0.0.1", Port: port}, logger.Default())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+	return s
+}
+
+// get 请求 port 上的服务器
+func get(port int) error {
+	client := &http.Client{Timeout: time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/", port))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TestStart_PortInUse 测试端口被占用时 Start 直接返回错误
+func TestStart_PortInUse(t *testing.T) {
+	port := occupyPort(t)
+
+	s, err := New(http.NotFoundHandler(), &Config{Host: "127.0.0.1", Port: port}, logger.Default())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	err = s.Start(context.Background())
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) || serverErr.Op != "start" {
+		t.Fatalf("expected start ServerError, got %v", err)
+	}
+	if got := serverState(s.(*httpServer).state.Load()); got != stateStopped {
+		t.Fatalf("expected stopped state, got %s", got)
+	}
+}
+
+// TestReload_PortInUse 测试新端口被占用时 Reload 返回错误且旧服务器继续运行
+func TestReload_PortInUse(t *testing.T) {
+	oldPort := freePort(t)
+	s := startTestServer(t, oldPort)
+
+	busy := occupyPort(t)
+	err := s.Reload(context.Background(), &Config{Host: "127.0.0.1", Port: busy})
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) || serverErr.Op != "reload" {
+		t.Fatalf("expected reload ServerError, got %v", err)
+	}
+
+	if err := get(oldPort); err != nil {
+		t.Fatalf("old server should keep serving after failed reload: %v", err)
+	}
+	if got := s.(*httpServer).config.Port; got != oldPort {
+		t.Fatalf("config should be unchanged after failed reload, got port %d", got)
+	}
+}
+
+// TestReload_NewPort 测试 Reload 切换到新端口后在新端口提供服务
+func TestReload_NewPort(t *testing.T) {
+	s := startTestServer(t, freePort(t))
+
+	newPort := freePort(t)
+	if err := s.Reload(context.Background(), &Config{Host: "127.0.0.1", Port: newPort}); err != nil {
+		t.Fatalf("Reload() failed: %v", err)
+	}
+	if err := get(newPort); err != nil {
+		t.Fatalf("new server should be serving: %v", err)
+	}
+}
+
+// TestReload_SamePort 测试地址不变时 Reload 重新绑定同一端口
+func TestReload_SamePort(t *testing.T) {
+	port := freePort(t)
+	s := startTestServer(t, port)
+
+	err := s.Reload(context.Background(), &Config{Host: "127.0.0.1", Port: port, ReadTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Reload() failed: %v", err)
+	}
+	if err := get(port); err != nil {
+		t.Fatalf("server should be serving on the same port: %v", err)
+	}
+}
@@ -12,8 +12,8 @@ import (
 // 提供统一的 HTTP 服务器抽象，支持启动、关闭和配置热更新
 type HTTPServer interface {
 	// Start 启动 HTTP 服务器（非阻塞）
-	// 在新的 goroutine 中启动服务器，立即返回
-	// 如果服务器已在运行，返回错误
+	// 同步绑定监听地址，然后在新的 goroutine 中处理请求
+	// 如果服务器已在运行或端口绑定失败（如端口被占用），返回错误
 	// 参数:
 	//   ctx: 上下文，用于控制启动过程
 	// 返回:
@@ -38,6 +38,13 @@ type HTTPServer interface {
 	//   error: 重载失败时的错误
 	Reload(ctx context.Context, cfg *Config) error
 
+	// Errors 返回服务器运行期间的错误通道
+	// Start 成功后,请求处理 goroutine 意外退出(如监听器被关闭)时写入一个错误
+	// 调用方应与关闭信号一起 select,避免服务器已停止而进程仍在等待
+	// 返回:
+	//   <-chan error: 只读错误通道,整个生命周期内不会关闭
+	Errors() <-chan error
+
 	// SetExecutor 设置协程池管理器（延迟注入）
 	// 用于异步处理HTTP相关任务
 	// 参数:
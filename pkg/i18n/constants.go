@@ -33,6 +33,10 @@ const (
 	// FilenameFormatYml YML 文件格式标识
 	// YAML 的另一种常见扩展名,功能与 yaml 相同
 	FilenameFormatYml = "yml"
+
+	// PluralCountParam TPlural 自动注入的数量参数名
+	// 消息中使用 {count} 引用
+	PluralCountParam = "count"
 )
 
 // SupportedLanguages 支持的语言列表
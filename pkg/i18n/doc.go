@@ -60,14 +60,14 @@
 //
 // # 在 Gin 中使用
 //
-// 创建中间件提取语言:
+// 创建中间件提取语言(项目中见 internal/middleware.I18n):
 //
-//	func I18nMiddleware(i18n i18n.I18n) gin.HandlerFunc {
+//	func I18nMiddleware(i18nApp i18n.I18n) gin.HandlerFunc {
 //	    return func(c *gin.Context) {
-//	        // 从 Accept-Language 头部获取语言
-//	        lang := c.GetHeader("Accept-Language")
-//	        if lang == "" || !i18n.IsSupported(lang) {
-//	            lang = i18n.GetDefaultLanguage()
+//	        // 按 Accept-Language 的权重依次匹配支持的语言
+//	        lang, ok := i18nApp.Match(i18n.ParseAcceptLanguage(c.GetHeader(i18n.LanguageHeader))...)
+//	        if !ok {
+//	            lang = i18nApp.GetDefaultLanguage()
 //	        }
 //	        // 存储到上下文
 //	        c.Set("lang", lang)
@@ -48,6 +48,24 @@ type I18n interface {
 	//   - 系统级提示
 	MustT(lang string, messageID string, templateData ...map[string]interface{}) string
 
+	// TPlural 翻译带复数形式的消息,并替换 {name} 形式的命名参数
+	// 参数:
+	//   lang: 目标语言,不支持时使用默认语言
+	//   key: 消息 ID,消息需按 CLDR 复数类别定义(至少 other,英语等还需要 one)
+	//   count: 数量,用于选择复数形式,同时作为 {count} 参数
+	//   args: 命名参数,替换消息中的 {name};未提供的参数保持原样
+	// 返回:
+	//   string: 翻译后的消息文本
+	//   error: 消息不存在或缺少对应复数形式时的错误
+	// 翻译文件示例:
+	//   inbox.new_messages:
+	//     one: "You have {count} new message"
+	//     other: "You have {count} new messages"
+	// 使用示例:
+	//   msg, err := i18n.TPlural("en-US", "inbox.new_messages", 3, nil)
+	//   // You have 3 new messages
+	TPlural(lang, key string, count int, args map[string]any) (string, error)
+
 	// IsSupported 检查语言是否被支持
 	// 参数:
 	//   lang: 语言代码
@@ -60,6 +78,18 @@ type I18n interface {
 	//   string: 默认语言代码
 	GetDefaultLanguage() string
 
+	// Match 从候选语言中选出第一个支持的语言
+	// 参数:
+	//   candidates: 候选语言,按优先级从高到低排列,如 ParseAcceptLanguage 的结果
+	// 返回:
+	//   string: 匹配到的支持语言(配置中的写法,如 "zh-CN")
+	//   bool: 是否匹配成功
+	// 匹配规则:
+	//   - 大小写和分隔符不敏感: "zh-cn"、"zh_CN" 都匹配 "zh-CN"
+	//   - 精确匹配失败时按基础语言匹配: "en-GB"、"en" 匹配 "en-US"
+	//   - 逐个候选匹配,排在前面的候选即使只能按基础语言匹配也优先
+	Match(candidates ...string) (string, bool)
+
 	// LoadMessages 从指定目录加载翻译文件
 	// 支持 JSON 和 YAML 格式
 	// 参数:
@@ -100,6 +130,10 @@ type i18nImpl struct {
 	// supportedLanguages 支持的语言集合
 	// 使用 map 提高查询效率
 	supportedLanguages map[string]bool
+
+	// languages 支持的语言列表,保持配置顺序
+	// 用于 Match 的基础语言匹配,保证结果确定
+	languages []string
 }
 
 // New 创建一个新的 I18n 实例
@@ -156,6 +190,7 @@ func New(cfg *Config) (I18n, error) {
 		bundle:             bundle,
 		defaultLanguage:    cfg.DefaultLanguage,
 		supportedLanguages: supportedLangs,
+		languages:          cfg.SupportedLanguages,
 	}
 
 	// 如果指定了消息目录,加载翻译文件
@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Match 从候选语言中选出第一个支持的语言
+// 实现 I18n 接口
+func (impl *i18nImpl) Match(candidates ...string) (string, bool) {
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+
+		// 规范化写法,如 zh_cn -> zh-CN
+		tag, err := language.Parse(strings.ReplaceAll(candidate, "_", "-"))
+		if err != nil {
+			continue
+		}
+
+		// 1. 精确匹配
+		for _, lang := range impl.languages {
+			if supported, err := language.Parse(lang); err == nil && supported == tag {
+				return lang, true
+			}
+		}
+
+		// 2. 基础语言匹配
+		base, _ := tag.Base()
+		for _, lang := range impl.languages {
+			supported, err := language.Parse(lang)
+			if err != nil {
+				continue
+			}
+			if supportedBase, _ := supported.Base(); supportedBase == base {
+				return lang, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ParseAcceptLanguage 解析 Accept-Language 头部
+// 参数:
+//
+//	header: Accept-Language 头部的值,如 "en-GB,en;q=0.9,zh-CN;q=0.8"
+//
+// 返回:
+//
+//	[]string: 按权重从高到低排列的语言代码,q=0 的语言被忽略;格式无效时返回 nil
+//
+// 使用示例:
+//
+//	lang, ok := i18nApp.Match(i18n.ParseAcceptLanguage(c.GetHeader(i18n.LanguageHeader))...)
+func ParseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+
+	tags, weights, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	langs := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if weights[i] <= 0 {
+			continue
+		}
+		langs = append(langs, tag.String())
+	}
+	return langs
+}
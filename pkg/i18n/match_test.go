@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMatch 测试候选语言匹配
+func TestMatch(t *testing.T) {
+	i := Default()
+
+	tests := []struct {
+		name       string
+		candidates []string
+		want       string
+		ok         bool
+	}{
+		{"exact", []string{"en-US"}, LanguageEnglish, true},
+		{"case insensitive", []string{"zh-cn"}, LanguageChinese, true},
+		{"underscore", []string{"zh_CN"}, LanguageChinese, true},
+		{"base language", []string{"en-GB"}, LanguageEnglish, true},
+		{"base only", []string{"zh"}, LanguageChinese, true},
+		{"first candidate wins", []string{"en", "zh-CN"}, LanguageEnglish, true},
+		{"skip unsupported", []string{"fr-FR", "", "zh-CN"}, LanguageChinese, true},
+		{"no match", []string{"fr-FR", "not a tag"}, "", false},
+		{"empty", nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := i.Match(tt.candidates...)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: Match(%v) = %q, %v; want %q, %v", tt.name, tt.candidates, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestParseAcceptLanguage 测试按权重排序和忽略 q=0
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("zh-CN;q=0.8, en-US, fr;q=0")
+	if want := []string{"en-US", "zh-CN"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := ParseAcceptLanguage(""); got != nil {
+		t.Errorf("empty header: got %v", got)
+	}
+}
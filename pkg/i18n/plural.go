@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// placeholderPattern 匹配 {name} 形式的命名参数
+// 参数名由字母、数字和下划线组成,不能以数字开头
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// TPlural 翻译带复数形式的消息
+// 实现 I18n 接口
+// 复数类别由 go-i18n 按 CLDR 规则根据语言和 count 选择:
+//   - en-US: 1 为 one,其他为 other
+//   - zh-CN: 只有 other
+func (impl *i18nImpl) TPlural(lang, key string, count int, args map[string]any) (string, error) {
+	// 如果语言不支持,使用默认语言
+	if !impl.IsSupported(lang) {
+		lang = impl.defaultLanguage
+	}
+
+	localizer := i18n.NewLocalizer(impl.bundle, lang)
+	msg, err := localizer.Localize(&i18n.LocalizeConfig{
+		MessageID:   key,
+		PluralCount: count,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to translate plural message '%s': %w", key, err)
+	}
+
+	// count 默认作为 {count} 参数,args 中显式提供时以 args 为准
+	params := make(map[string]any, len(args)+1)
+	params[PluralCountParam] = count
+	for name, value := range args {
+		params[name] = value
+	}
+	return interpolate(msg, params), nil
+}
+
+// interpolate 替换消息中的 {name} 参数
+// 未提供的参数保持原样,便于发现遗漏
+func interpolate(msg string, params map[string]any) string {
+	return placeholderPattern.ReplaceAllStringFunc(msg, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if value, ok := params[name]; ok {
+			return fmt.Sprint(value)
+		}
+		return placeholder
+	})
+}
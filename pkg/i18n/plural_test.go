@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const pluralTestEnUS = `
+inbox.new_messages:
+  one: "{name}, you have {count} new message"
+  other: "{name}, you have {count} new messages"
+`
+
+const pluralTestZhCN = `
+inbox.new_messages:
+  other: "{name}，你有 {count} 条新消息"
+`
+
+// newPluralTestI18n 创建加载了复数测试消息的实例
+func newPluralTestI18n(t *testing.T) I18n {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"en-US.yaml": pluralTestEnUS, "zh-CN.yaml": pluralTestZhCN}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	i, err := New(&Config{
+		DefaultLanguage:    LanguageChinese,
+		SupportedLanguages: []string{LanguageChinese, LanguageEnglish},
+		MessagesDir:        dir,
+	})
+	if err != nil {
+		t.Fatalf("failed to create i18n: %v", err)
+	}
+	return i
+}
+
+// TestTPlural 测试复数形式选择和参数替换
+func TestTPlural(t *testing.T) {
+	i := newPluralTestI18n(t)
+	args := map[string]any{"name": "Alice"}
+
+	tests := []struct {
+		lang  string
+		count int
+		want  string
+	}{
+		{LanguageEnglish, 1, "Alice, you have 1 new message"},
+		{LanguageEnglish, 0, "Alice, you have 0 new messages"},
+		{LanguageEnglish, 5, "Alice, you have 5 new messages"},
+		{LanguageChinese, 1, "Alice，你有 1 条新消息"},
+		{LanguageChinese, 5, "Alice，你有 5 条新消息"},
+		// 不支持的语言回退到默认语言
+		{LanguageJapanese, 2, "Alice，你有 2 条新消息"},
+	}
+	for _, tt := range tests {
+		got, err := i.TPlural(tt.lang, "inbox.new_messages", tt.count, args)
+		if err != nil {
+			t.Errorf("%s/%d: unexpected error: %v", tt.lang, tt.count, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s/%d: got %q, want %q", tt.lang, tt.count, got, tt.want)
+		}
+	}
+}
+
+// TestTPlural_Errors 测试缺失消息和未提供的参数
+func TestTPlural_Errors(t *testing.T) {
+	i := newPluralTestI18n(t)
+
+	if _, err := i.TPlural(LanguageEnglish, "missing.key", 1, nil); err == nil {
+		t.Error("expected error for missing message")
+	}
+
+	// 未提供的参数保持原样
+	got, err := i.TPlural(LanguageEnglish, "inbox.new_messages", 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "{name}, you have 2 new messages"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
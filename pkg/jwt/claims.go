@@ -0,0 +1,103 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// reservedClaims 保留的声明名称
+// 包括 RFC 7519 注册声明和本包 Claims 使用的字段,自定义声明不能覆盖
+var reservedClaims = map[string]struct{}{
+	"iss":        {},
+	"sub":        {},
+	"aud":        {},
+	"exp":        {},
+	"nbf":        {},
+	"iat":        {},
+	"jti":        {},
+	"user_id":    {},
+	"username":   {},
+	"token_type": {},
+	"family":     {},
+}
+
+// IsReservedClaim 判断声明名称是否保留
+// GenerateTokenWithClaims 拒绝使用保留名称的自定义声明,防止覆盖过期时间、用户身份等字段
+func IsReservedClaim(name string) bool {
+	_, ok := reservedClaims[name]
+	return ok
+}
+
+// GenerateTokenWithClaims 生成携带自定义声明的访问令牌
+// 实现JWT接口的GenerateTokenWithClaims方法
+func (m *jwtManager) GenerateTokenWithClaims(userID int64, username string, extra map[string]any) (string, error) {
+	// 先检查保留名称,不部分写入
+	for name := range extra {
+		if IsReservedClaim(name) {
+			return "", fmt.Errorf(ErrMsgReservedClaim, ErrReservedClaim, name)
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	claims, err := m.newClaims(userID, username, TokenTypeAccess, "", time.Now(), m.expiresIn)
+	if err != nil {
+		return "", err
+	}
+
+	mapClaims, err := toMapClaims(claims)
+	if err != nil {
+		return "", err
+	}
+	for name, value := range extra {
+		mapClaims[name] = value
+	}
+	return m.signClaims(mapClaims)
+}
+
+// ParseClaims 验证访问令牌并返回全部声明
+// 实现JWT接口的ParseClaims方法
+func (m *jwtManager) ParseClaims(tokenString string) (map[string]any, error) {
+	mapClaims := jwt.MapClaims{}
+
+	// 使用 json.Number 解码数值,避免 user_id 等 int64 在 float64 中丢失精度
+	m.mu.RLock()
+	_, err := m.parse(tokenString, mapClaims, jwt.WithJSONNumber())
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// 刷新令牌不能当作访问令牌使用
+	if tokenType, _ := mapClaims["token_type"].(string); tokenType == TokenTypeRefresh {
+		return nil, ErrWrongTokenType
+	}
+	jti, _ := mapClaims["jti"].(string)
+	family, _ := mapClaims["family"].(string)
+	if err := m.checkRevoked(jti, family); err != nil {
+		return nil, err
+	}
+	return mapClaims, nil
+}
+
+// toMapClaims 将结构体载荷转换为 MapClaims
+// 数值使用 json.Number 保存,重新编码时保持原样
+func toMapClaims(claims *Claims) (jwt.MapClaims, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf(ErrMsgEncodeClaims, err)
+	}
+
+	mapClaims := jwt.MapClaims{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&mapClaims); err != nil {
+		return nil, fmt.Errorf(ErrMsgEncodeClaims, err)
+	}
+	return mapClaims, nil
+}
@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestGenerateTokenWithClaims_RoundTrip 测试自定义声明的往返
+func TestGenerateTokenWithClaims_RoundTrip(t *testing.T) {
+	m := newTestJWT(t)
+
+	// 超过 float64 精度的 int64,确保解析时不丢失精度
+	const userID int64 = 1<<62 + 1
+	token, err := m.GenerateTokenWithClaims(userID, "alice", map[string]any{
+		"tenant": "acme",
+		"roles":  []string{"admin", "editor"},
+		"scopes": map[string]any{"orders": "read"},
+	})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	claims, err := m.ParseClaims(token)
+	if err != nil {
+		t.Fatalf("failed to parse claims: %v", err)
+	}
+	if claims["tenant"] != "acme" {
+		t.Errorf("tenant: got %v", claims["tenant"])
+	}
+	if want := []any{"admin", "editor"}; !reflect.DeepEqual(claims["roles"], want) {
+		t.Errorf("roles: got %v, want %v", claims["roles"], want)
+	}
+	if want := map[string]any{"orders": "read"}; !reflect.DeepEqual(claims["scopes"], want) {
+		t.Errorf("scopes: got %v, want %v", claims["scopes"], want)
+	}
+	if id, _ := claims["user_id"].(json.Number).Int64(); id != userID {
+		t.Errorf("user_id: got %v, want %d", claims["user_id"], userID)
+	}
+
+	// 标准校验仍然可用,自定义声明被忽略
+	std, err := m.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("token should pass ValidateToken: %v", err)
+	}
+	if std.UserID != userID || std.Username != "alice" || std.TokenType != TokenTypeAccess {
+		t.Errorf("unexpected standard claims: %+v", std)
+	}
+}
+
+// TestGenerateTokenWithClaims_Reserved 测试保留声明不能被覆盖
+func TestGenerateTokenWithClaims_Reserved(t *testing.T) {
+	m := newTestJWT(t)
+
+	for _, name := range []string{"exp", "iss", "jti", "user_id", "username", "token_type"} {
+		_, err := m.GenerateTokenWithClaims(1, "alice", map[string]any{name: "override"})
+		if !errors.Is(err, ErrReservedClaim) {
+			t.Errorf("claim %q: expected ErrReservedClaim, got %v", name, err)
+		}
+	}
+}
+
+// TestParseClaims_RejectsRefreshToken 测试 ParseClaims 拒绝刷新令牌
+func TestParseClaims_RejectsRefreshToken(t *testing.T) {
+	m := newTestJWT(t)
+
+	_, refresh, _ := m.GenerateTokenPair(1, "alice")
+	if _, err := m.ParseClaims(refresh); !errors.Is(err, ErrWrongTokenType) {
+		t.Errorf("expected ErrWrongTokenType, got %v", err)
+	}
+	if _, err := m.ParseClaims("not-a-token"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken, got %v", err)
+	}
+}
@@ -7,8 +7,29 @@ const (
 	// DefaultExpiresIn 默认过期时间（1小时）
 	DefaultExpiresIn = 3600
 
+	// DefaultRefreshExpiresIn 默认刷新令牌过期时间（7天）
+	DefaultRefreshExpiresIn = 7 * 24 * 3600
+
 	// DefaultIssuer 默认签发者
 	DefaultIssuer = "go-scaffold"
+
+	// jtiBytes 令牌ID和家族标识的随机字节数
+	jtiBytes = 16
+
+	// CacheKeyPrefixRevoked 令牌黑名单缓存键前缀,后接 jti
+	CacheKeyPrefixRevoked = "jwt:revoked:"
+
+	// CacheKeyPrefixRevokedFamily 令牌家族黑名单缓存键前缀,后接 Family
+	CacheKeyPrefixRevokedFamily = "jwt:revoked-family:"
+)
+
+// 令牌类型
+const (
+	// TokenTypeAccess 访问令牌
+	TokenTypeAccess = "access"
+
+	// TokenTypeRefresh 刷新令牌
+	TokenTypeRefresh = "refresh"
 )
 
 // 预定义错误
@@ -27,6 +48,31 @@ var (
 
 	// ErrMissingSecret 缺少签名密钥
 	ErrMissingSecret = errors.New("jwt secret is required")
+
+	// ErrWrongTokenType 令牌类型不匹配
+	// 如把刷新令牌当作访问令牌使用,或反之
+	ErrWrongTokenType = errors.New("wrong token type")
+
+	// ErrTokenRevoked token 已被吊销
+	ErrTokenRevoked = errors.New("token has been revoked")
+
+	// ErrMissingTokenID token 缺少 jti,无法吊销
+	ErrMissingTokenID = errors.New("token has no id")
+
+	// ErrCacheNotSet 未注入缓存,无法使用黑名单
+	ErrCacheNotSet = errors.New("jwt cache not set")
+
+	// ErrVerifyOnly 只验证模式下不能签发令牌
+	ErrVerifyOnly = errors.New("jwt manager is verify-only")
+
+	// ErrMissingKey 缺少签名或验证密钥
+	ErrMissingKey = errors.New("jwt key is required")
+
+	// ErrUnsupportedKey 不支持的密钥类型
+	ErrUnsupportedKey = errors.New("unsupported jwt key")
+
+	// ErrReservedClaim 自定义声明使用了保留名称
+	ErrReservedClaim = errors.New("reserved claim name")
 )
 
 // 错误消息常量
@@ -48,4 +94,40 @@ const (
 
 	// ErrMsgSecretTooShort 密钥太短错误消息
 	ErrMsgSecretTooShort = "jwt secret must be at least 32 characters"
+
+	// ErrMsgWrongTokenType 令牌类型不匹配错误消息
+	ErrMsgWrongTokenType = "wrong token type"
+
+	// ErrMsgTokenRevoked token 已吊销错误消息
+	ErrMsgTokenRevoked = "token has been revoked"
+
+	// ErrMsgMissingTokenID token 缺少 jti 错误消息
+	ErrMsgMissingTokenID = "token has no id"
+
+	// ErrMsgCacheNotSet 未注入缓存错误消息
+	ErrMsgCacheNotSet = "jwt cache not set"
+
+	// ErrMsgCheckRevoked 查询黑名单失败错误消息
+	ErrMsgCheckRevoked = "failed to check token revocation: %w"
+
+	// ErrMsgRevokeToken 写入黑名单失败错误消息
+	ErrMsgRevokeToken = "failed to revoke token: %w"
+
+	// ErrMsgUnexpectedAlg 签名算法不匹配错误消息
+	ErrMsgUnexpectedAlg = "unexpected signing method: %v"
+
+	// ErrMsgKeyMismatch 公钥与私钥不匹配错误消息
+	ErrMsgKeyMismatch = "jwt public key does not match private key"
+
+	// ErrMsgUnsupportedKey 不支持的密钥类型错误消息
+	ErrMsgUnsupportedKey = "%w: %T"
+
+	// ErrMsgUnsupportedCurve 不支持的椭圆曲线错误消息
+	ErrMsgUnsupportedCurve = "%w: curve %s"
+
+	// ErrMsgReservedClaim 保留声明名称错误消息
+	ErrMsgReservedClaim = "%w: %s"
+
+	// ErrMsgEncodeClaims 编码载荷失败错误消息
+	ErrMsgEncodeClaims = "failed to encode claims: %w"
 )
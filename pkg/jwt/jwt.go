@@ -1,7 +1,10 @@
 package jwt
 
 import (
+	"context"
+
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rei0721/go-scaffold/pkg/cache"
 )
 
 // JWT 定义JWT操作接口
@@ -21,10 +24,31 @@ type JWT interface {
 	//   error: 生成失败时的错误
 	// 业务流程:
 	//   1. 创建claims载荷
-	//   2. 使用HMAC-SHA256算法签名
+	//   2. 使用配置的算法签名（默认 HMAC-SHA256）
 	//   3. 生成完整的JWT token
 	GenerateToken(userID int64, username string) (string, error)
 
+	// GenerateTokenWithClaims 生成携带自定义声明的访问令牌
+	// 参数:
+	//   userID: 用户ID
+	//   username: 用户名
+	//   extra: 自定义声明,如 roles、scopes、tenant,值必须可以 JSON 编码
+	// 返回:
+	//   string: JWT token字符串
+	//   error: extra 包含保留名称时返回 ErrReservedClaim,见 IsReservedClaim
+	// 说明:
+	//   自定义声明与标准字段位于同一层级,ValidateToken 会忽略它们,
+	//   需要读取时使用 ParseClaims
+	GenerateTokenWithClaims(userID int64, username string, extra map[string]any) (string, error)
+
+	// ParseClaims 验证访问令牌并返回全部声明
+	// 参数:
+	//   tokenString: JWT token字符串
+	// 返回:
+	//   map[string]any: 标准声明和自定义声明,数值类型为 json.Number 以保证 int64 精度
+	//   error: 验证失败时的错误,与 ValidateToken 相同
+	ParseClaims(tokenString string) (map[string]any, error)
+
 	// ValidateToken 验证并解析令牌
 	// 参数:
 	//   tokenString: JWT token字符串
@@ -41,15 +65,80 @@ type JWT interface {
 	//   4. 返回claims
 	ValidateToken(tokenString string) (*Claims, error)
 
-	// RefreshToken 刷新令牌（可选实现）
+	// RefreshToken 刷新访问令牌
 	// 参数:
-	//   tokenString: 旧的JWT token字符串
+	//   tokenString: 旧的访问令牌,必须仍然有效
 	// 返回:
-	//   string: 新的JWT token字符串
-	//   error: 刷新失败时的错误
-	// 注意:
-	//   当前实现可能暂不支持此功能,返回 ErrNotImplemented
+	//   string: 新的访问令牌,有效期重新计算
+	//   error: 旧令牌验证失败时的错误,与 ValidateToken 相同
+	// 说明:
+	//   新令牌保留旧令牌的 Family 和自定义声明(roles、scopes 等),jti 重新生成
 	RefreshToken(tokenString string) (string, error)
+
+	// GenerateTokenPair 生成访问令牌和刷新令牌
+	// 参数:
+	//   userID: 用户ID
+	//   username: 用户名
+	// 返回:
+	//   access: 访问令牌,有效期 ExpiresIn
+	//   refresh: 刷新令牌,有效期 RefreshExpiresIn
+	//   err: 生成失败时的错误
+	// 说明:
+	//   两个令牌的 TokenType 不同,不能互相替代
+	//   每次调用都会开启一个新的令牌家族(Family)
+	GenerateTokenPair(userID int64, username string) (access, refresh string, err error)
+
+	// ValidateRefreshToken 验证并解析刷新令牌
+	// 参数:
+	//   tokenString: 刷新令牌字符串
+	// 返回:
+	//   *Claims: 解析后的载荷信息,包含 Family 和 ID(jti)
+	//   error: 验证失败时的错误,访问令牌返回 ErrWrongTokenType
+	// 说明:
+	//   令牌已被吊销时同时返回载荷和 ErrTokenRevoked,
+	//   调用方可以据此用 RevokeFamily 吊销被复用的令牌家族
+	ValidateRefreshToken(tokenString string) (*Claims, error)
+
+	// RotateTokenPair 使用已验证的刷新令牌签发新的令牌对
+	// 参数:
+	//   refreshClaims: ValidateRefreshToken 返回的载荷
+	// 返回:
+	//   access: 新的访问令牌
+	//   refresh: 新的刷新令牌,Family 不变,ID(jti) 更新
+	//   err: 生成失败时的错误
+	// 复用检测:
+	//   调用方轮换后应使用 Revoke 吊销已使用的刷新令牌,
+	//   之后再收到该令牌时 ValidateRefreshToken 返回 ErrTokenRevoked,说明令牌被复用,应吊销整个家族
+	RotateTokenPair(refreshClaims *Claims) (access, refresh string, err error)
+
+	// Revoke 吊销令牌
+	// 参数:
+	//   ctx: 上下文
+	//   tokenString: 要吊销的访问令牌或刷新令牌
+	// 返回:
+	//   error: 吊销失败时的错误,如:
+	//     - ErrCacheNotSet: 未注入缓存,无法记录黑名单
+	//     - ErrMissingTokenID: 令牌没有 jti,无法吊销
+	// 说明:
+	//   将令牌的 jti 写入缓存黑名单,过期时间与令牌剩余有效期一致
+	//   之后 ValidateToken/ValidateRefreshToken 会返回 ErrTokenRevoked
+	//   已过期的令牌无需吊销,直接返回 nil
+	Revoke(ctx context.Context, tokenString string) error
+
+	// RevokeFamily 吊销整个令牌家族
+	// 参数:
+	//   ctx: 上下文
+	//   family: 令牌家族标识,见 Claims.Family
+	// 返回:
+	//   error: 未注入缓存时返回 ErrCacheNotSet
+	// 说明:
+	//   家族内所有访问令牌和刷新令牌都会失效,用于登出和刷新令牌复用检测
+	//   黑名单条目的过期时间为刷新令牌有效期,届时家族内的令牌都已过期
+	RevokeFamily(ctx context.Context, family string) error
+
+	// SetCache 设置黑名单使用的缓存（延迟注入）
+	// 未注入时不检查黑名单,Revoke 返回 ErrCacheNotSet
+	SetCache(c cache.Cache)
 }
 
 // Claims JWT载荷
@@ -67,6 +156,15 @@ type Claims struct {
 	// 用于显示或日志记录
 	Username string `json:"username"`
 
+	// TokenType 令牌类型
+	// 可选值: TokenTypeAccess, TokenTypeRefresh
+	// 为空时按访问令牌处理,兼容旧版本签发的令牌
+	TokenType string `json:"token_type,omitempty"`
+
+	// Family 刷新令牌家族标识
+	// 同一次登录轮换出的所有令牌共享同一个 Family,用于检测刷新令牌复用
+	Family string `json:"family,omitempty"`
+
 	// jwt.RegisteredClaims 包含标准JWT字段:
 	// - Issuer: 签发者
 	// - Subject: 主题
@@ -94,6 +192,11 @@ type Config struct {
 	// - 业务场景: 根据业务敏感度调整
 	ExpiresIn int
 
+	// RefreshExpiresIn 刷新令牌有效期（秒）
+	// 默认: 604800（7天）
+	// 应明显长于 ExpiresIn,用于在访问令牌过期后换取新令牌
+	RefreshExpiresIn int
+
 	// Issuer 签发者
 	// 标识令牌由哪个系统签发
 	// 用于多系统环境下区分token来源
@@ -1,12 +1,17 @@
 package jwt
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rei0721/go-scaffold/pkg/cache"
 )
 
 // jwtManager 实现 JWT 接口
@@ -16,19 +21,35 @@ import (
 // - 配置驱动: 通过Config初始化
 // - 错误明确: 提供清晰的错误信息
 type jwtManager struct {
-	// secret 签名密钥
-	// 使用HMAC-SHA256算法时的密钥
+	// method 签名算法
+	// 验证时只接受该算法签名的令牌,防御算法混淆攻击
+	method jwt.SigningMethod
+
+	// signKey 签名密钥
+	// HS256 为 []byte 密钥,RS256 为 *rsa.PrivateKey,ES256 为 *ecdsa.PrivateKey
+	// 只验证模式下为 nil
 	// 必须保密,不能泄露
-	secret []byte
+	signKey interface{}
+
+	// verifyKey 验证密钥
+	// HS256 与 signKey 相同,非对称算法为对应的公钥
+	verifyKey interface{}
 
 	// expiresIn token有效期
 	// 从签发时间开始计算
 	expiresIn time.Duration
 
+	// refreshExpiresIn 刷新令牌有效期
+	refreshExpiresIn time.Duration
+
 	// issuer 签发者标识
 	// 用于标识token的来源
 	issuer string
 
+	// cache 黑名单缓存
+	// 使用 atomic.Value 支持延迟注入,存储 cache.Cache
+	cache atomic.Value
+
 	// mu 读写锁
 	// 保护配置字段的并发访问
 	// 读多写少的场景使用RWMutex性能更好
@@ -62,23 +83,41 @@ func New(cfg *Config) (JWT, error) {
 		return nil, errors.New(ErrMsgSecretTooShort)
 	}
 
-	// 3. 设置默认值
+	// 3. 创建实例
+	secret := []byte(cfg.Secret)
+	return newManager(cfg, jwt.SigningMethodHS256, secret, secret), nil
+}
+
+// newManager 使用指定算法和密钥创建管理器,并为未设置的配置项填充默认值
+// cfg 为 nil 时全部使用默认值
+func newManager(cfg *Config, method jwt.SigningMethod, signKey, verifyKey interface{}) *jwtManager {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
 	expiresIn := cfg.ExpiresIn
 	if expiresIn <= 0 {
 		expiresIn = DefaultExpiresIn
 	}
 
+	refreshExpiresIn := cfg.RefreshExpiresIn
+	if refreshExpiresIn <= 0 {
+		refreshExpiresIn = DefaultRefreshExpiresIn
+	}
+
 	issuer := cfg.Issuer
 	if issuer == "" {
 		issuer = DefaultIssuer
 	}
 
-	// 4. 创建实例
 	return &jwtManager{
-		secret:    []byte(cfg.Secret),
-		expiresIn: time.Duration(expiresIn) * time.Second,
-		issuer:    issuer,
-	}, nil
+		method:           method,
+		signKey:          signKey,
+		verifyKey:        verifyKey,
+		expiresIn:        time.Duration(expiresIn) * time.Second,
+		refreshExpiresIn: time.Duration(refreshExpiresIn) * time.Second,
+		issuer:           issuer,
+	}
 }
 
 // GenerateToken 生成访问令牌
@@ -94,51 +133,17 @@ func New(cfg *Config) (JWT, error) {
 //	error: 生成失败时的错误
 //
 // 业务流程:
-//  1. 创建claims载荷
-//  2. 创建JWT token对象
-//  3. 使用HMAC-SHA256算法签名
-//  4. 生成完整的token字符串
+//  1. 创建claims载荷（含随机 jti）
+//  2. 使用配置的算法签名（默认 HMAC-SHA256）
+//  3. 生成完整的token字符串
 func (m *jwtManager) GenerateToken(userID int64, username string) (string, error) {
 	// 使用读锁保护配置读取
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// 1. 创建claims
-	now := time.Now()
-	claims := &Claims{
-		UserID:   userID,
-		Username: username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			// 签发者
-			Issuer: m.issuer,
-
-			// 签发时间
-			IssuedAt: jwt.NewNumericDate(now),
-
-			// 过期时间 = 当前时间 + 有效期
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.expiresIn)),
-
-			// 生效时间 = 当前时间
-			// token立即生效,不设置延迟
-			NotBefore: jwt.NewNumericDate(now),
-		},
-	}
-
-	// 2. 创建token对象
-	// SigningMethodHS256 使用HMAC-SHA256算法
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// 3. 签名并生成token字符串
-	// SignedString会:
-	// - 将header和claims编码为base64
-	// - 使用secret对它们进行HMAC-SHA256签名
-	// - 拼接成完整的JWT: header.claims.signature
-	tokenString, err := token.SignedString(m.secret)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
-	}
-
-	return tokenString, nil
+	// 签发带 jti 的访问令牌,jti 用于吊销
+	// 单独签发的访问令牌不属于任何令牌家族
+	return m.sign(userID, username, TokenTypeAccess, "", time.Now(), m.expiresIn)
 }
 
 // ValidateToken 验证并解析令牌
@@ -157,27 +162,101 @@ func (m *jwtManager) GenerateToken(userID int64, username string) (string, error
 //  2. 验证签名
 //  3. 检查过期时间
 //  4. 检查生效时间
-//  5. 提取claims
+//  5. 检查黑名单（已注入缓存时）
+//  6. 提取claims
 func (m *jwtManager) ValidateToken(tokenString string) (*Claims, error) {
 	// 使用读锁保护配置读取
+	m.mu.RLock()
+	claims, err := m.parseToken(tokenString)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// 刷新令牌不能当作访问令牌使用
+	if claims.TokenType == TokenTypeRefresh {
+		return nil, ErrWrongTokenType
+	}
+	if err := m.checkRevoked(claims.ID, claims.Family); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RefreshToken 刷新令牌
+// 实现JWT接口的RefreshToken方法
+// 流程:
+//  1. 验证旧token（不允许刷新已过期的token）
+//  2. 以相同的用户信息和 Family 生成新载荷,时间声明和 jti 重新生成
+//  3. 复制旧token的自定义声明(roles、scopes 等)
+func (m *jwtManager) RefreshToken(tokenString string) (string, error) {
+	// 1. 验证旧token
+	claims, err := m.ValidateToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+	all, err := m.ParseClaims(tokenString)
+	if err != nil {
+		return "", err
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	// 2. 生成新载荷,保留令牌家族
+	newClaims, err := m.newClaims(claims.UserID, claims.Username, TokenTypeAccess, claims.Family, time.Now(), m.expiresIn)
+	if err != nil {
+		return "", err
+	}
+	mapClaims, err := toMapClaims(newClaims)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. 保留名称由新载荷决定,其余声明原样复制
+	for name, value := range all {
+		if !IsReservedClaim(name) {
+			mapClaims[name] = value
+		}
+	}
+	return m.signClaims(mapClaims)
+}
+
+// parseToken 解析并验证令牌签名和时间声明,不检查令牌类型
+// 调用方需要持有读锁
+func (m *jwtManager) parseToken(tokenString string) (*Claims, error) {
+	token, err := m.parse(tokenString, &Claims{})
+	if err != nil {
+		return nil, err
+	}
+
+	// 提取claims
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		// token解析成功但claims类型不匹配
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// parse 将令牌解析到指定的 claims 并验证签名和时间声明
+// 调用方需要持有读锁
+func (m *jwtManager) parse(tokenString string, claims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
 	// 1. 解析token
 	// ParseWithClaims会:
 	// - 解析token字符串
 	// - 使用keyFunc验证签名
 	// - 检查标准声明（过期时间、生效时间等）
-	// - 将载荷解析到Claims结构
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// 验证签名算法
-		// 防止攻击者使用其他算法（如none）绕过签名验证
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	// - 将载荷解析到claims
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// 验证签名算法必须与配置一致
+		// 防止攻击者使用其他算法（如none,或用公钥作为 HS256 密钥）绕过签名验证
+		if token.Method.Alg() != m.method.Alg() {
+			return nil, fmt.Errorf(ErrMsgUnexpectedAlg, token.Header["alg"])
 		}
 		// 返回密钥用于验证签名
-		return m.secret, nil
-	})
+		return m.verifyKey, nil
+	}, opts...)
 
 	// 2. 处理解析错误
 	if err != nil {
@@ -195,36 +274,230 @@ func (m *jwtManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	// 3. 提取claims
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
-		// token解析成功但claims类型不匹配或token无效
+	if !token.Valid {
 		return nil, ErrInvalidToken
 	}
+	return token, nil
+}
 
-	// 4. 返回claims
+// GenerateTokenPair 生成访问令牌和刷新令牌
+// 实现JWT接口的GenerateTokenPair方法
+// 每次调用生成新的 Family,表示一次新的登录会话
+func (m *jwtManager) GenerateTokenPair(userID int64, username string) (string, string, error) {
+	family, err := newTokenID()
+	if err != nil {
+		return "", "", err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.signPair(userID, username, family)
+}
+
+// ValidateRefreshToken 验证并解析刷新令牌
+// 实现JWT接口的ValidateRefreshToken方法
+// 与 ValidateToken 的区别: 只接受 TokenType 为 refresh 的令牌,已吊销时仍返回载荷
+func (m *jwtManager) ValidateRefreshToken(tokenString string) (*Claims, error) {
+	m.mu.RLock()
+	claims, err := m.parseToken(tokenString)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != TokenTypeRefresh || claims.Family == "" {
+		return nil, ErrWrongTokenType
+	}
+	if err := m.checkRevoked(claims.ID, claims.Family); err != nil {
+		// 载荷已通过签名验证,吊销时返回给调用方用于复用检测
+		if errors.Is(err, ErrTokenRevoked) {
+			return claims, err
+		}
+		return nil, err
+	}
 	return claims, nil
 }
 
-// RefreshToken 刷新令牌
-// 实现JWT接口的RefreshToken方法
-// 注意: 当前实现为占位符,可根据需求实现
-// 典型的实现方式:
-//  1. 验证旧token（允许一定程度的过期）
-//  2. 提取用户信息
-//  3. 生成新token
-//  4. 可选：记录刷新事件
-func (m *jwtManager) RefreshToken(tokenString string) (string, error) {
-	// 1. 验证旧token
-	// 注意: 某些实现允许在一定时间窗口内刷新过期的token
-	claims, err := m.ValidateToken(tokenString)
+// RotateTokenPair 使用已验证的刷新令牌签发新的令牌对
+// 实现JWT接口的RotateTokenPair方法
+// 新刷新令牌沿用原 Family,ID(jti) 重新生成
+func (m *jwtManager) RotateTokenPair(refreshClaims *Claims) (string, string, error) {
+	if refreshClaims == nil || refreshClaims.TokenType != TokenTypeRefresh || refreshClaims.Family == "" {
+		return "", "", ErrWrongTokenType
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.signPair(refreshClaims.UserID, refreshClaims.Username, refreshClaims.Family)
+}
+
+// signPair 签发同一家族的访问令牌和刷新令牌
+// 调用方需要持有读锁
+func (m *jwtManager) signPair(userID int64, username, family string) (string, string, error) {
+	now := time.Now()
+
+	access, err := m.sign(userID, username, TokenTypeAccess, family, now, m.expiresIn)
+	if err != nil {
+		return "", "", err
+	}
+	refresh, err := m.sign(userID, username, TokenTypeRefresh, family, now, m.refreshExpiresIn)
+	if err != nil {
+		return "", "", err
+	}
+	return access, refresh, nil
+}
+
+// sign 生成带 jti 的令牌
+// 调用方需要持有读锁
+func (m *jwtManager) sign(userID int64, username, tokenType, family string, now time.Time, ttl time.Duration) (string, error) {
+	claims, err := m.newClaims(userID, username, tokenType, family, now, ttl)
 	if err != nil {
-		// 如果token已过期但在允许的刷新窗口内,可以继续
-		// 这里简化处理,不允许过期token刷新
 		return "", err
 	}
+	return m.signClaims(claims)
+}
+
+// newClaims 创建带随机 jti 的载荷
+// 调用方需要持有读锁
+func (m *jwtManager) newClaims(userID int64, username, tokenType, family string, now time.Time, ttl time.Duration) (*Claims, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Claims{
+		UserID:    userID,
+		Username:  username,
+		TokenType: tokenType,
+		Family:    family,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    m.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}, nil
+}
+
+// signClaims 使用配置的算法和密钥签名载荷
+// 只验证模式下返回 ErrVerifyOnly
+func (m *jwtManager) signClaims(claims jwt.Claims) (string, error) {
+	if m.signKey == nil {
+		return "", ErrVerifyOnly
+	}
+
+	tokenString, err := jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return tokenString, nil
+}
+
+// Revoke 吊销令牌
+// 实现JWT接口的Revoke方法
+// 黑名单条目的过期时间等于令牌剩余有效期,令牌过期后条目自动清除
+func (m *jwtManager) Revoke(ctx context.Context, tokenString string) error {
+	c := m.getCache()
+	if c == nil {
+		return ErrCacheNotSet
+	}
+
+	m.mu.RLock()
+	claims, err := m.parseToken(tokenString)
+	m.mu.RUnlock()
+	if err != nil {
+		// 已过期的令牌本身就无法通过验证,无需加入黑名单
+		if errors.Is(err, ErrExpiredToken) {
+			return nil
+		}
+		return err
+	}
+	if claims.ID == "" {
+		return ErrMissingTokenID
+	}
 
-	// 2. 生成新token
-	// 使用相同的用户信息,但更新时间戳
-	return m.GenerateToken(claims.UserID, claims.Username)
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := c.Set(ctx, CacheKeyPrefixRevoked+claims.ID, "1", ttl); err != nil {
+		return fmt.Errorf(ErrMsgRevokeToken, err)
+	}
+	return nil
+}
+
+// RevokeFamily 吊销整个令牌家族
+// 实现JWT接口的RevokeFamily方法
+// 家族中最晚签发的刷新令牌在 refreshExpiresIn 内过期,条目保留同样长的时间即可
+func (m *jwtManager) RevokeFamily(ctx context.Context, family string) error {
+	c := m.getCache()
+	if c == nil {
+		return ErrCacheNotSet
+	}
+	if family == "" {
+		return nil
+	}
+
+	m.mu.RLock()
+	ttl := m.refreshExpiresIn
+	m.mu.RUnlock()
+
+	if err := c.Set(ctx, CacheKeyPrefixRevokedFamily+family, "1", ttl); err != nil {
+		return fmt.Errorf(ErrMsgRevokeToken, err)
+	}
+	return nil
+}
+
+// SetCache 设置黑名单使用的缓存
+// 实现JWT接口，支持延迟注入
+// 使用 atomic.Value 实现原子替换，无需加锁
+func (m *jwtManager) SetCache(c cache.Cache) {
+	m.cache.Store(c)
+}
+
+// getCache 获取当前缓存（内部辅助方法）
+func (m *jwtManager) getCache() cache.Cache {
+	if c := m.cache.Load(); c != nil {
+		return c.(cache.Cache)
+	}
+	return nil
+}
+
+// checkRevoked 检查令牌本身(jti)或所属家族是否在黑名单中
+// 未注入缓存或令牌既没有 jti 也没有 Family 时跳过检查
+// 查询缓存失败时拒绝令牌,避免缓存故障期间已吊销的令牌重新生效
+func (m *jwtManager) checkRevoked(jti, family string) error {
+	c := m.getCache()
+	if c == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, 2)
+	if jti != "" {
+		keys = append(keys, CacheKeyPrefixRevoked+jti)
+	}
+	if family != "" {
+		keys = append(keys, CacheKeyPrefixRevokedFamily+family)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	n, err := c.Exists(context.Background(), keys...)
+	if err != nil {
+		return fmt.Errorf(ErrMsgCheckRevoked, err)
+	}
+	if n > 0 {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// newTokenID 生成随机的令牌ID,用于 jti 和 Family
+func newTokenID() (string, error) {
+	b := make([]byte, jtiBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,262 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rei0721/go-scaffold/pkg/cache"
+)
+
+const testSecret = "test-secret-at-least-32-characters-long"
+
+// newTestJWT 创建测试用的 JWT 管理器
+func newTestJWT(t *testing.T) JWT {
+	t.Helper()
+	m, err := New(&Config{Secret: testSecret, ExpiresIn: 60, RefreshExpiresIn: 3600})
+	if err != nil {
+		t.Fatalf("failed to create jwt manager: %v", err)
+	}
+	return m
+}
+
+// TestGenerateTokenPair 测试令牌对的类型和有效期
+func TestGenerateTokenPair(t *testing.T) {
+	m := newTestJWT(t)
+
+	access, refresh, err := m.GenerateTokenPair(42, "alice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	accessClaims, err := m.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("access token should be valid: %v", err)
+	}
+	refreshClaims, err := m.ValidateRefreshToken(refresh)
+	if err != nil {
+		t.Fatalf("refresh token should be valid: %v", err)
+	}
+
+	if accessClaims.UserID != 42 || refreshClaims.Username != "alice" {
+		t.Errorf("unexpected claims: %+v, %+v", accessClaims, refreshClaims)
+	}
+	if accessClaims.Family == "" || accessClaims.Family != refreshClaims.Family {
+		t.Errorf("tokens should share a family: %q, %q", accessClaims.Family, refreshClaims.Family)
+	}
+	if accessClaims.ID == refreshClaims.ID {
+		t.Errorf("tokens should have distinct jti")
+	}
+	if !refreshClaims.ExpiresAt.After(accessClaims.ExpiresAt.Time) {
+		t.Errorf("refresh token should outlive access token")
+	}
+
+	// 类型不能互相替代
+	if _, err := m.ValidateToken(refresh); !errors.Is(err, ErrWrongTokenType) {
+		t.Errorf("refresh token used as access: got %v", err)
+	}
+	if _, err := m.ValidateRefreshToken(access); !errors.Is(err, ErrWrongTokenType) {
+		t.Errorf("access token used as refresh: got %v", err)
+	}
+
+	// 每次登录开启新的家族
+	_, refresh2, _ := m.GenerateTokenPair(42, "alice")
+	claims2, _ := m.ValidateRefreshToken(refresh2)
+	if claims2.Family == refreshClaims.Family {
+		t.Errorf("new pair should start a new family")
+	}
+}
+
+// TestRotateTokenPair 测试轮换保留家族并更新 jti
+func TestRotateTokenPair(t *testing.T) {
+	m := newTestJWT(t)
+
+	_, refresh, _ := m.GenerateTokenPair(7, "bob")
+	old, err := m.ValidateRefreshToken(refresh)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, rotated, err := m.RotateTokenPair(old)
+	if err != nil {
+		t.Fatalf("rotate failed: %v", err)
+	}
+	claims, err := m.ValidateRefreshToken(rotated)
+	if err != nil {
+		t.Fatalf("rotated refresh token should be valid: %v", err)
+	}
+	if claims.Family != old.Family || claims.ID == old.ID {
+		t.Errorf("rotation should keep family and change jti: old=%+v new=%+v", old, claims)
+	}
+
+	if _, err := m.ValidateToken(mustAccess(t, m, old)); err != nil {
+		t.Fatalf("rotated access token should be valid: %v", err)
+	}
+
+	if _, _, err := m.RotateTokenPair(&Claims{TokenType: TokenTypeAccess}); !errors.Is(err, ErrWrongTokenType) {
+		t.Errorf("rotating with access claims: got %v", err)
+	}
+}
+
+// mustAccess 轮换并返回新的访问令牌
+func mustAccess(t *testing.T, m JWT, claims *Claims) string {
+	t.Helper()
+	access, _, err := m.RotateTokenPair(claims)
+	if err != nil {
+		t.Fatalf("rotate failed: %v", err)
+	}
+	return access
+}
+
+// TestValidateRefreshToken_Expired 测试拒绝过期的刷新令牌
+func TestValidateRefreshToken_Expired(t *testing.T) {
+	m := newTestJWT(t)
+
+	past := time.Now().Add(-2 * time.Hour)
+	claims := &Claims{
+		UserID:    1,
+		Username:  "carol",
+		TokenType: TokenTypeRefresh,
+		Family:    "family",
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(past),
+			NotBefore: jwt.NewNumericDate(past),
+			ExpiresAt: jwt.NewNumericDate(past.Add(time.Hour)),
+		},
+	}
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	if _, err := m.ValidateRefreshToken(expired); !errors.Is(err, ErrExpiredToken) {
+		t.Fatalf("expected ErrExpiredToken, got %v", err)
+	}
+}
+
+// TestRevoke 测试吊销的令牌无法通过验证,其他令牌不受影响
+func TestRevoke(t *testing.T) {
+	m := newTestJWT(t)
+	ctx := context.Background()
+
+	revoked, _ := m.GenerateToken(1, "alice")
+	other, _ := m.GenerateToken(1, "alice")
+
+	// 未注入缓存时无法吊销
+	if err := m.Revoke(ctx, revoked); !errors.Is(err, ErrCacheNotSet) {
+		t.Fatalf("expected ErrCacheNotSet, got %v", err)
+	}
+
+	c := cache.NewMemory(nil)
+	defer c.Close()
+	m.SetCache(c)
+
+	if err := m.Revoke(ctx, revoked); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	if _, err := m.ValidateToken(revoked); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("revoked token: expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := m.ValidateToken(other); err != nil {
+		t.Errorf("other token should still be valid: %v", err)
+	}
+
+	// 刷新令牌同样可以吊销
+	_, refresh, _ := m.GenerateTokenPair(1, "alice")
+	if err := m.Revoke(ctx, refresh); err != nil {
+		t.Fatalf("revoke refresh failed: %v", err)
+	}
+	claims, err := m.ValidateRefreshToken(refresh)
+	if !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("revoked refresh token: expected ErrTokenRevoked, got %v", err)
+	}
+	// 吊销的刷新令牌仍返回载荷,用于复用检测
+	if claims == nil || claims.Family == "" {
+		t.Errorf("revoked refresh token should still return claims, got %+v", claims)
+	}
+}
+
+// TestRevokeFamily 测试吊销家族后家族内的访问令牌和刷新令牌都失效
+func TestRevokeFamily(t *testing.T) {
+	m, err := New(&Config{Secret: testSecret})
+	if err != nil {
+		t.Fatalf("failed to create jwt manager: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := m.RevokeFamily(ctx, "family"); !errors.Is(err, ErrCacheNotSet) {
+		t.Fatalf("expected ErrCacheNotSet without cache, got %v", err)
+	}
+
+	c := cache.NewMemory(nil)
+	defer c.Close()
+	m.SetCache(c)
+
+	_, refresh, _ := m.GenerateTokenPair(1, "alice")
+	claims, err := m.ValidateRefreshToken(refresh)
+	if err != nil {
+		t.Fatalf("validate refresh failed: %v", err)
+	}
+	access, rotated, err := m.RotateTokenPair(claims)
+	if err != nil {
+		t.Fatalf("rotate failed: %v", err)
+	}
+	otherAccess, _, _ := m.GenerateTokenPair(1, "alice")
+
+	if err := m.RevokeFamily(ctx, claims.Family); err != nil {
+		t.Fatalf("revoke family failed: %v", err)
+	}
+
+	if _, err := m.ValidateToken(access); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("access token of revoked family: expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := m.ParseClaims(access); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("ParseClaims of revoked family: expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := m.ValidateRefreshToken(rotated); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("refresh token of revoked family: expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err := m.ValidateToken(otherAccess); err != nil {
+		t.Errorf("token of another family should still be valid: %v", err)
+	}
+}
+
+// TestRevoke_ExpiresWithToken 测试黑名单条目随令牌一起过期
+func TestRevoke_ExpiresWithToken(t *testing.T) {
+	m, err := New(&Config{Secret: testSecret, ExpiresIn: 1})
+	if err != nil {
+		t.Fatalf("failed to create jwt manager: %v", err)
+	}
+	c := cache.NewMemory(nil)
+	defer c.Close()
+	m.SetCache(c)
+
+	ctx := context.Background()
+	token, _ := m.GenerateToken(1, "alice")
+	claims, _ := m.ValidateToken(token)
+	if err := m.Revoke(ctx, token); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+
+	key := CacheKeyPrefixRevoked + claims.ID
+	ttl, err := c.TTL(ctx, key)
+	if err != nil {
+		t.Fatalf("ttl failed: %v", err)
+	}
+	if ttl <= 0 || ttl > time.Second {
+		t.Errorf("blacklist ttl %v should not exceed token lifetime", ttl)
+	}
+
+	// 令牌过期后黑名单条目也被清除
+	time.Sleep(time.Until(claims.ExpiresAt.Time) + 100*time.Millisecond)
+	if n, _ := c.Exists(ctx, key); n != 0 {
+		t.Errorf("blacklist entry should expire with the token")
+	}
+
+	// 过期令牌无需吊销
+	if err := m.Revoke(ctx, token); err != nil {
+		t.Errorf("revoking expired token should be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// NewWithRSA 创建使用 RS256 签名的 JWT 管理器
+// 适用于多服务场景:签发服务持有私钥,其他服务用 VerifyOnly 和公钥验证
+// 参数:
+//
+//	cfg: JWT配置,Secret 字段被忽略;为 nil 时使用默认有效期和签发者
+//	privKey: RSA 私钥,用于签名
+//	pubKey: RSA 公钥,用于验证;为 nil 时从私钥推导
+//
+// 返回:
+//
+//	JWT: JWT接口实例
+//	error: 缺少私钥或公钥与私钥不匹配时的错误
+func NewWithRSA(cfg *Config, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey) (JWT, error) {
+	if privKey == nil {
+		return nil, ErrMissingKey
+	}
+	if pubKey == nil {
+		pubKey = &privKey.PublicKey
+	} else if !pubKey.Equal(privKey.Public()) {
+		return nil, errors.New(ErrMsgKeyMismatch)
+	}
+
+	return newManager(cfg, jwt.SigningMethodRS256, privKey, pubKey), nil
+}
+
+// NewWithECDSA 创建使用 ECDSA 签名的 JWT 管理器
+// 签名算法由曲线决定: P-256 使用 ES256,P-384 使用 ES384,P-521 使用 ES512
+// 参数:
+//
+//	cfg: JWT配置,Secret 字段被忽略;为 nil 时使用默认有效期和签发者
+//	privKey: ECDSA 私钥,用于签名
+//	pubKey: ECDSA 公钥,用于验证;为 nil 时从私钥推导
+//
+// 返回:
+//
+//	JWT: JWT接口实例
+//	error: 缺少私钥、公钥与私钥不匹配或曲线不支持时的错误
+func NewWithECDSA(cfg *Config, privKey *ecdsa.PrivateKey, pubKey *ecdsa.PublicKey) (JWT, error) {
+	if privKey == nil {
+		return nil, ErrMissingKey
+	}
+	if pubKey == nil {
+		pubKey = &privKey.PublicKey
+	} else if !pubKey.Equal(privKey.Public()) {
+		return nil, errors.New(ErrMsgKeyMismatch)
+	}
+
+	method, err := ecdsaMethod(pubKey.Curve)
+	if err != nil {
+		return nil, err
+	}
+	return newManager(cfg, method, privKey, pubKey), nil
+}
+
+// VerifyOnly 创建只验证令牌的 JWT 管理器
+// 适用于只需要校验令牌、不负责签发的服务,无需持有私钥
+// 参数:
+//
+//	cfg: JWT配置,只使用 Issuer 等非密钥字段;可以为 nil
+//	pubKey: 公钥,支持 *rsa.PublicKey(RS256)和 *ecdsa.PublicKey(按曲线选择 ES256/ES384/ES512)
+//
+// 返回:
+//
+//	JWT: JWT接口实例,签发类方法返回 ErrVerifyOnly
+//	error: 公钥为空或类型不支持时的错误
+func VerifyOnly(cfg *Config, pubKey crypto.PublicKey) (JWT, error) {
+	switch key := pubKey.(type) {
+	case *rsa.PublicKey:
+		if key == nil {
+			return nil, ErrMissingKey
+		}
+		return newManager(cfg, jwt.SigningMethodRS256, nil, key), nil
+
+	case *ecdsa.PublicKey:
+		if key == nil {
+			return nil, ErrMissingKey
+		}
+		method, err := ecdsaMethod(key.Curve)
+		if err != nil {
+			return nil, err
+		}
+		return newManager(cfg, method, nil, key), nil
+
+	case nil:
+		return nil, ErrMissingKey
+
+	default:
+		return nil, fmt.Errorf(ErrMsgUnsupportedKey, ErrUnsupportedKey, pubKey)
+	}
+}
+
+// ecdsaMethod 根据曲线选择 ECDSA 签名算法
+func ecdsaMethod(curve elliptic.Curve) (jwt.SigningMethod, error) {
+	switch curve {
+	case elliptic.P256():
+		return jwt.SigningMethodES256, nil
+	case elliptic.P384():
+		return jwt.SigningMethodES384, nil
+	case elliptic.P521():
+		return jwt.SigningMethodES512, nil
+	default:
+		return nil, fmt.Errorf(ErrMsgUnsupportedCurve, ErrUnsupportedKey, curve.Params().Name)
+	}
+}
@@ -0,0 +1,125 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TestNewWithRSA 测试私钥签名、公钥验证
+func TestNewWithRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	signer, err := NewWithRSA(nil, key, nil)
+	if err != nil {
+		t.Fatalf("failed to create rsa manager: %v", err)
+	}
+	verifier, err := VerifyOnly(nil, &key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	token, err := signer.GenerateToken(1, "alice")
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	claims, err := verifier.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("public key should validate token: %v", err)
+	}
+	if claims.UserID != 1 || claims.Username != "alice" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	// 只验证模式不能签发令牌
+	if _, err := verifier.GenerateToken(1, "alice"); !errors.Is(err, ErrVerifyOnly) {
+		t.Errorf("verify-only sign: expected ErrVerifyOnly, got %v", err)
+	}
+
+	// 公钥与私钥不匹配
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := NewWithRSA(nil, key, &other.PublicKey); err == nil {
+		t.Error("expected error for mismatched key pair")
+	}
+}
+
+// TestNewWithECDSA 测试 ES256 签名和验证
+func TestNewWithECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+
+	signer, err := NewWithECDSA(nil, key, nil)
+	if err != nil {
+		t.Fatalf("failed to create ecdsa manager: %v", err)
+	}
+	verifier, err := VerifyOnly(nil, &key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	access, refresh, err := signer.GenerateTokenPair(2, "bob")
+	if err != nil {
+		t.Fatalf("failed to sign token pair: %v", err)
+	}
+	if _, err := verifier.ValidateToken(access); err != nil {
+		t.Errorf("public key should validate access token: %v", err)
+	}
+	if _, err := verifier.ValidateRefreshToken(refresh); err != nil {
+		t.Errorf("public key should validate refresh token: %v", err)
+	}
+}
+
+// TestValidateToken_RejectsUnexpectedAlg 测试拒绝非配置算法签名的令牌
+func TestValidateToken_RejectsUnexpectedAlg(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	verifier, err := VerifyOnly(nil, &key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		UserID:    1,
+		Username:  "mallory",
+		TokenType: TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+
+	// 算法混淆: 以公开的公钥作为 HS256 密钥伪造令牌
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(pubDER)
+	if err != nil {
+		t.Fatalf("failed to sign forged token: %v", err)
+	}
+	if _, err := verifier.ValidateToken(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("HS256 token with RSA configured: expected ErrInvalidToken, got %v", err)
+	}
+
+	// HS256 管理器签发的令牌同样被拒绝
+	hs := newTestJWT(t)
+	token, _ := hs.GenerateToken(1, "alice")
+	if _, err := verifier.ValidateToken(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("HS256 token with RSA configured: expected ErrInvalidToken, got %v", err)
+	}
+}
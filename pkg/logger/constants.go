@@ -38,6 +38,10 @@ const (
 	OutputFile   = "file"   // 仅输出到文件
 	OutputBoth   = "both"   // 同时输出到文件和控制台
 
+	// TraceIDField FromContext 附加的追踪 ID 字段名
+	// 与 HTTP 访问日志中的字段名保持一致,便于按请求检索日志
+	TraceIDField = "traceId"
+
 	// MsgLoggerReloading 日志重载中消息
 	MsgLoggerReloading = "reloading logger configuration"
 
@@ -46,4 +50,7 @@ const (
 
 	// ErrMsgReloadFailed 重载失败的错误消息
 	ErrMsgReloadFailed = "failed to reload logger: %w"
+
+	// ErrMsgInvalidLevel 无效日志级别的错误消息
+	ErrMsgInvalidLevel = "invalid log level %q"
 )
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"sync"
+)
+
+// loggerCtxKey 存储 Logger 的 context 键
+// 使用私有类型避免与其他包的键冲突
+type loggerCtxKey struct{}
+
+// traceIDCtxKey 存储 TraceID 的 context 键
+type traceIDCtxKey struct{}
+
+var (
+	// fallback 上下文中没有 Logger 时使用的默认 Logger
+	// 延迟创建且只创建一次,避免每次 FromContext 都构建新的 zap 实例
+	fallback     Logger
+	fallbackOnce sync.Once
+)
+
+// NewContext 返回携带 Logger 的新 context
+// 通常由 HTTP 中间件在请求开始时调用,存入应用的 Logger
+// 参数:
+//
+//	ctx: 父 context
+//	l: 要存入的 Logger
+//
+// 返回:
+//
+//	context.Context: 携带 Logger 的 context
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// WithTraceID 返回携带 TraceID 的新 context
+// 之后通过 FromContext 获取的 Logger 会自动附加 TraceIDField 字段
+// 参数:
+//
+//	ctx: 父 context
+//	traceID: 请求追踪 ID
+//
+// 返回:
+//
+//	context.Context: 携带 TraceID 的 context
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDCtxKey{}, traceID)
+}
+
+// TraceIDFromContext 从 context 中获取 TraceID
+// 不存在时返回空字符串
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(traceIDCtxKey{}).(string)
+	return id
+}
+
+// FromContext 从 context 中获取 Logger
+// 如果 context 中有 TraceID,返回的 Logger 会附加 TraceIDField 字段
+// 参数:
+//
+//	ctx: 请求 context,可以为 nil
+//
+// 返回:
+//
+//	Logger: context 中的 Logger;不存在时返回 Default() 创建的共享实例
+//
+// 使用示例:
+//
+//	func (s *userService) Create(ctx context.Context, req *Request) error {
+//	    logger.FromContext(ctx).Info("creating user", "username", req.Username)
+//	    // 输出包含 traceId 字段,可以与 HTTP 访问日志关联
+//	}
+func FromContext(ctx context.Context) Logger {
+	var l Logger
+	if ctx != nil {
+		l, _ = ctx.Value(loggerCtxKey{}).(Logger)
+	}
+	if l == nil {
+		fallbackOnce.Do(func() {
+			fallback = Default()
+		})
+		l = fallback
+	}
+
+	if traceID := TraceIDFromContext(ctx); traceID != "" {
+		return l.With(TraceIDField, traceID)
+	}
+	return l
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFromContext_TraceID 测试通过 FromContext 记录的日志携带 TraceID
+func TestFromContext_TraceID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(&Config{
+		Level:    "info",
+		Format:   "json",
+		Output:   "file",
+		FilePath: path,
+	})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	ctx := NewContext(context.Background(), log)
+	FromContext(ctx).Info("without-trace")
+
+	ctx = WithTraceID(ctx, "trace-123")
+	if got := TraceIDFromContext(ctx); got != "trace-123" {
+		t.Fatalf("TraceIDFromContext: got %q", got)
+	}
+	FromContext(ctx).Info("with-trace", "userId", 1)
+	_ = log.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	entries := map[string]map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid json log line %q: %v", line, err)
+		}
+		msg, _ := entry["message"].(string)
+		entries[msg] = entry
+	}
+
+	if entry, ok := entries["with-trace"]; !ok || entry[TraceIDField] != "trace-123" {
+		t.Errorf("expected %s=trace-123, got %v", TraceIDField, entry)
+	}
+	if entry, ok := entries["without-trace"]; !ok || entry[TraceIDField] != nil {
+		t.Errorf("expected no %s field, got %v", TraceIDField, entry)
+	}
+}
+
+// TestFromContext_Fallback 测试 context 中没有 Logger 时返回默认 Logger
+func TestFromContext_Fallback(t *testing.T) {
+	if FromContext(context.Background()) == nil {
+		t.Fatal("FromContext returned nil")
+	}
+	// nil context 不应 panic
+	if FromContext(nil) == nil {
+		t.Fatal("FromContext(nil) returned nil")
+	}
+	if TraceIDFromContext(context.Background()) != "" {
+		t.Fatal("expected empty trace id")
+	}
+}
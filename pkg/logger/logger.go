@@ -151,6 +151,15 @@ type Reloader interface {
 	//   - 失败时保持原有 logger 不变
 	//   - 新 logger 创建成功后才替换旧 logger
 	Reload(cfg *Config) error
+
+	// SetLevel 运行时调整日志级别,无需重建 logger
+	// 参数:
+	//   level: 日志级别(debug/info/warn/error/fatal)
+	// 返回:
+	//   error: 级别无效时的错误,原级别保持不变
+	// 使用场景:
+	//   生产环境临时开启 debug 日志排查问题
+	SetLevel(level string) error
 }
 
 // Config 保存日志配置
@@ -42,6 +42,11 @@ type zapLogger struct {
 	// 相比原始的 Logger,牺牲一点性能换取更好的易用性
 	sugar *zap.SugaredLogger
 
+	// level 原子日志级别
+	// 所有 Core 共享同一个 AtomicLevel,SetLevel 修改后立即对所有输出生效
+	// 无需重建 logger
+	level zap.AtomicLevel
+
 	// config 保存配置用于 Reload 时对比
 	// 也用于确保重载时使用正确的配置
 	config *Config
@@ -73,7 +78,8 @@ type zapLogger struct {
 //  4. 包装为 SugaredLogger
 func New(cfg *Config) (Logger, error) {
 	// 1. 解析日志级别
-	level := zapParseLevel(parseLevel(cfg.Level))
+	// 使用 AtomicLevel,支持运行时通过 SetLevel 调整
+	level := zap.NewAtomicLevelAt(zapParseLevel(parseLevel(cfg.Level)))
 
 	output := strings.ToLower(cfg.Output)
 
@@ -130,6 +136,7 @@ func New(cfg *Config) (Logger, error) {
 	// 4. 返回 SugaredLogger
 	return &zapLogger{
 		sugar:  zapLog.Sugar(),
+		level:  level,
 		config: cfg,
 	}, nil
 }
@@ -170,6 +177,7 @@ func Default() Logger {
 //	info: 正常信息,生产环境默认
 //	warn/warning: 警告信息
 //	error: 错误信息
+//	fatal: 致命错误
 //	默认: info(如果输入无效)
 func zapParseLevel(level Level) zapcore.Level {
 	// ToLower 确保不区分大小写
@@ -190,6 +198,9 @@ func zapParseLevel(level Level) zapcore.Level {
 	case LevelError:
 		// 错误级别,只记录错误
 		return zapcore.ErrorLevel
+	case LevelFatal:
+		// 致命级别,只记录 fatal 日志
+		return zapcore.FatalLevel
 	default:
 		// 默认使用 info 级别
 		// 这是一个安全的默认值
@@ -502,10 +513,12 @@ func (l *zapLogger) With(keysAndValues ...interface{}) Logger {
 	// 不会修改原始 logger,而是返回新实例
 	l.mu.RLock()
 	sugar := l.sugar
+	level := l.level
 	config := l.config
 	l.mu.RUnlock()
 	return &zapLogger{
 		sugar:  sugar.With(keysAndValues...),
+		level:  level,
 		config: config,
 	}
 }
@@ -603,6 +616,7 @@ func (l *zapLogger) Reload(cfg *Config) error {
 	// 这样外部持有的 Logger 接口引用仍然有效
 	newZapLogger := newLogger.(*zapLogger)
 	l.sugar = newZapLogger.sugar
+	l.level = newZapLogger.level
 	l.config = cfg
 
 	// 4. 释放写锁
@@ -620,3 +634,36 @@ func (l *zapLogger) Reload(cfg *Config) error {
 
 	return nil
 }
+
+// SetLevel 运行时调整日志级别
+// 实现 Reloader 接口
+// 只修改 AtomicLevel,不重建 logger,开销很小
+// 参数:
+//
+//	level: 日志级别字符串(debug/info/warn/error/fatal,不区分大小写)
+//
+// 返回:
+//
+//	error: 级别无效时返回错误,原级别保持不变
+//
+// 注意:
+//   - 通过 With 创建的子 logger 与父 logger 共享级别,会同时生效
+//   - Reload 之后父 logger 使用新的级别,之前创建的子 logger 不受影响
+func (l *zapLogger) SetLevel(level string) error {
+	lvl, ok := LevelNames[strings.ToLower(level)]
+	if !ok {
+		return fmt.Errorf(ErrMsgInvalidLevel, level)
+	}
+
+	l.mu.Lock()
+	l.level.SetLevel(zapParseLevel(lvl))
+	if l.config != nil {
+		// 复制配置,避免修改调用方持有的 Config
+		cfg := *l.config
+		cfg.Level = strings.ToLower(level)
+		l.config = &cfg
+	}
+	l.mu.Unlock()
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package logger
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -157,3 +160,53 @@ func TestDefault(t *testing.T) {
 	log.Debug("debug from default logger")
 	log.Info("info from default logger")
 }
+
+// TestSetLevel_TogglesDebug 测试运行时切换级别后 debug 日志出现和消失
+func TestSetLevel_TogglesDebug(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(&Config{
+		Level:    "info",
+		Format:   "json",
+		Output:   "file",
+		FilePath: path,
+	})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	child := log.With("component", "test")
+
+	log.Debug("debug-before")
+	if err := log.SetLevel("DEBUG"); err != nil {
+		t.Fatalf("failed to set level: %v", err)
+	}
+	log.Debug("debug-enabled")
+	child.Debug("debug-child")
+	if err := log.SetLevel("warn"); err != nil {
+		t.Fatalf("failed to set level: %v", err)
+	}
+	log.Debug("debug-after")
+	log.Info("info-after")
+
+	if err := log.SetLevel("verbose"); err == nil {
+		t.Fatal("expected error for invalid level")
+	}
+	log.Debug("debug-invalid")
+	_ = log.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	out := string(data)
+
+	for _, msg := range []string{"debug-enabled", "debug-child"} {
+		if !strings.Contains(out, msg) {
+			t.Errorf("expected %q to be logged, got:\n%s", msg, out)
+		}
+	}
+	for _, msg := range []string{"debug-before", "debug-after", "info-after", "debug-invalid"} {
+		if strings.Contains(out, msg) {
+			t.Errorf("expected %q not to be logged, got:\n%s", msg, out)
+		}
+	}
+}
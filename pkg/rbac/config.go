@@ -24,6 +24,19 @@ type Config struct {
 	// 仅在EnableCache=true时生效
 	CacheTTL time.Duration
 
+	// 缓存条目上限（DefaultConfig 为 10000，0 表示不限制）
+	// 达到上限时淘汰最久未使用的条目，避免大量用户/资源组合撑满内存
+	// 仅在EnableCache=true时生效
+	CacheMaxEntries int
+
+	// 是否禁用策略变更时的缓存失效（默认false）
+	// 默认情况下，角色策略变更后会查出持有该角色的所有用户（含继承），
+	// 并逐个清除其缓存条目，使撤销权限立即生效。
+	// 代价：每次变更需一次角色-用户反查，且每个受影响用户需扫描一遍缓存，
+	// 角色关联用户较多时开销为 O(用户数 × 缓存条目数)。
+	// 设置为true时不做任何失效处理，完全依赖 CacheTTL 过期
+	DisablePolicyCacheInvalidation bool
+
 	// 是否自动保存策略（默认true）
 	// 设置为true时，每次策略变更都会立即持久化到数据库
 	// 设置为false时，需要手动调用SavePolicy()
@@ -37,12 +50,13 @@ type Config struct {
 // DefaultConfig 返回默认配置
 func DefaultConfig(db *gorm.DB) *Config {
 	return &Config{
-		DB:          db,
-		ModelPath:   "",
-		EnableCache: true,
-		CacheTTL:    30 * time.Minute,
-		AutoSave:    true,
-		TablePrefix: "",
+		DB:              db,
+		ModelPath:       "",
+		EnableCache:     true,
+		CacheTTL:        30 * time.Minute,
+		CacheMaxEntries: DefaultCacheMaxEntries,
+		AutoSave:        true,
+		TablePrefix:     "",
 	}
 }
 
@@ -54,5 +68,8 @@ func (c *Config) Validate() error {
 	if c.CacheTTL <= 0 {
 		c.CacheTTL = 30 * time.Minute
 	}
+	if c.CacheMaxEntries < 0 {
+		c.CacheMaxEntries = 0
+	}
 	return nil
 }
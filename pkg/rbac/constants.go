@@ -5,4 +5,6 @@ const (
 	DefaultTablePrefix = "rbac_"
 	// 默认表名
 	DefaultTableName = "casbin_rule"
+	// 默认缓存条目上限
+	DefaultCacheMaxEntries = 10000
 )
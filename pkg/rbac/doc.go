@@ -57,6 +57,26 @@ RBAC模型包含以下核心概念：
 	// 检查：alice在tenant1域中能否读取data？
 	ok, err := rbac.EnforceWithDomain("alice", "tenant1", "data", "read")
 
+角色继承：
+
+	// admin 继承 editor 的全部权限，editor 又继承 viewer（可传递）
+	rbac.AddRoleInheritance("admin", "editor", "")
+	rbac.AddRoleInheritance("editor", "viewer", "")
+
+	// 形成环时返回 ErrRoleCycle
+	err := rbac.AddRoleInheritance("viewer", "admin", "")
+
+通配符：
+
+	// 资源或操作为 "*" 时匹配任意值
+	rbac.AddPolicy("editor", "posts", "*")  // posts:* 可对posts执行任意操作
+	rbac.AddPolicy("root", "*", "*")        // *:* 拥有全部权限
+
+	// editor 可以删除 posts
+	ok, err := rbac.Enforce("alice", "posts", "delete")
+
+注意：通配符由内置模型的 matcher 实现，使用 ModelPath 指定外部模型时需自行支持。
+
 批量操作：
 
 	// 批量添加策略
@@ -65,14 +85,14 @@ RBAC模型包含以下核心概念：
 	    {"admin", "users", "write"},
 	    {"admin", "posts", "read"},
 	}
-	rbac.AddPolicies(rules)
+	added, err := rbac.AddPolicies(rules)
 
 # 最佳实践
 
 1. 角色命名：使用小写和下划线，如 "super_admin", "content_editor"
 2. 资源命名：使用复数形式，如 "users", "posts"
 3. 操作命名：使用标准HTTP动词，如 "read", "write", "delete"
-4. 缓存管理：策略变更后会自动清除缓存，无需手动处理
+4. 缓存管理：策略变更后会自动清除受影响用户的缓存，无需手动处理（可通过 DisablePolicyCacheInvalidation 关闭）
 
 # 性能优化
 
@@ -99,7 +119,7 @@ Casbin会自动创建 casbin_rule 表，结构如下：
 
 - pkg/jwt：处理身份认证（用户是谁）
 - pkg/rbac：处理授权（用户能做什么）
-- pkg/cache：通用缓存，rbac内部使用sync.Map做权限结果缓存
+- pkg/cache：通用缓存，rbac内部使用LRU做权限结果缓存
 
 # 线程安全
 
@@ -17,6 +17,15 @@ var (
 	// ErrRoleNotFound 角色不存在
 	ErrRoleNotFound = errors.New("role not found")
 
+	// ErrRoleCycle 角色继承形成环
+	ErrRoleCycle = errors.New("role inheritance cycle")
+
+	// ErrRoleInUse 角色仍分配给用户
+	ErrRoleInUse = errors.New("role is still assigned")
+
+	// ErrPolicyConflict 批量写入期间策略被并发修改，本次写入已回滚
+	ErrPolicyConflict = errors.New("policy modified concurrently")
+
 	// ErrLoadPolicy 加载策略失败
 	ErrLoadPolicy = errors.New("failed to load policy")
 
@@ -32,4 +41,6 @@ const (
 	ErrMsgRemovePolicyFailed = "remove policy failed: %w"
 	ErrMsgAddRoleFailed      = "add role failed: %w"
 	ErrMsgRemoveRoleFailed   = "remove role failed: %w"
+	ErrMsgImportFailed       = "import policies failed: %w"
+	ErrMsgDeleteRoleFailed   = "delete role failed: %w"
 )
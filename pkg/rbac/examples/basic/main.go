@@ -91,8 +91,8 @@ func main() {
 		{"manager", "reports", "write"},
 		{"manager", "reports", "delete"},
 	}
-	r.AddPolicies(rules)
-	fmt.Println("批量添加了3条策略给manager角色")
+	added, _ := r.AddPolicies(rules)
+	fmt.Printf("批量添加了%d条策略给manager角色\n", len(added))
 
 	// 9. 查询示例
 	fmt.Println("\n=== 查询示例 ===")
@@ -66,6 +66,14 @@ type RBAC interface {
 	// GetRolesForUserInDomain 获取用户在指定域中的角色
 	GetRolesForUserInDomain(user, domain string) ([]string, error)
 
+	// HasRoleForUser 检查用户在指定域中是否持有角色
+	// 包含通过角色继承间接获得的角色
+	// 参数:
+	//   user: 用户ID
+	//   role: 角色名称
+	//   domain: 域名，为空表示全局
+	HasRoleForUser(user, role, domain string) (bool, error)
+
 	// GetUsersForRole 获取拥有指定角色的所有用户
 	// 参数:
 	//   role: 角色名称
@@ -73,6 +81,53 @@ type RBAC interface {
 	//   []string: 用户ID列表
 	GetUsersForRole(role string) ([]string, error)
 
+	// CountUsersForRole 统计直接持有指定角色的用户数量
+	// 统计所有域中的角色分配，不含继承该角色的子角色
+	// 自身持有策略或被其他主体持有的主体视为角色
+	// 参数:
+	//   role: 角色名称
+	// 返回:
+	//   int: 角色分配数量
+	CountUsersForRole(role string) (int, error)
+
+	// DeleteRole 在单个数据库事务中删除角色
+	// 同时删除所有域中该角色的分配（用户-角色、角色继承）和该角色的策略，任一失败则整体回滚
+	// 参数:
+	//   role: 角色名称
+	DeleteRole(role string) error
+
+	// DeleteUnassignedRole 在角色没有分配给任何用户时删除角色
+	// 检查与删除在同一把锁内完成，期间通过本实例的角色分配会等待删除结束
+	// 参数:
+	//   role: 角色名称
+	// 返回:
+	//   error: 仍有用户持有角色时返回包装了 ErrRoleInUse 的错误，包含受影响的数量
+	DeleteUnassignedRole(role string) error
+
+	// AddRoleInheritance 设置角色继承关系
+	// child 将继承 parent 的全部权限（可传递，如 admin -> editor -> viewer）
+	// 参数:
+	//   child: 子角色
+	//   parent: 父角色
+	//   domain: 域名，为空表示全局
+	// 返回:
+	//   error: 形成环时返回 ErrRoleCycle
+	// 示例:
+	//   rbac.AddRoleInheritance("admin", "editor", "")
+	AddRoleInheritance(child, parent, domain string) error
+
+	// DeleteRoleInheritance 删除角色继承关系
+	DeleteRoleInheritance(child, parent, domain string) error
+
+	// GetImplicitPermissionsForUser 获取用户的所有有效权限（无域）
+	// 包含通过角色（及角色继承）获得的权限
+	// 返回:
+	//   [][]string: 策略列表，每个策略是[sub, dom, obj, act]
+	GetImplicitPermissionsForUser(user string) ([][]string, error)
+
+	// GetImplicitPermissionsForUserInDomain 获取用户在指定域中的所有有效权限
+	GetImplicitPermissionsForUserInDomain(user, domain string) ([][]string, error)
+
 	// ========== 策略管理 ==========
 
 	// AddPolicy 添加策略
@@ -100,26 +155,42 @@ type RBAC interface {
 
 	// GetFilteredPolicy 获取过滤后的策略
 	// 参数:
-	//   fieldIndex: 字段索引（0=sub, 1=obj, 2=act）
+	//   fieldIndex: 字段索引（0=sub, 1=dom, 2=obj, 3=act）
 	//   fieldValues: 过滤值
 	GetFilteredPolicy(fieldIndex int, fieldValues ...string) [][]string
 
+	// GetGroupingPolicy 获取所有角色分配关系（用户-角色及角色继承）
+	// 返回:
+	//   [][]string: 分配列表，每条是[user或子角色, role或父角色, dom]
+	GetGroupingPolicy() [][]string
+
 	// ========== 批量操作 ==========
 
 	// AddPolicies 批量添加策略
+	// 已存在的策略会被跳过，只写入新增的策略；检查与写入在同一个事务中完成
 	// 参数:
 	//   rules: 策略列表，每个策略是[sub, obj, act]或[sub, dom, obj, act]
+	// 返回:
+	//   [][]string: 实际写入的策略，统一为[sub, dom, obj, act]格式
+	//   error: 写入失败或被并发修改打断（ErrPolicyConflict）时返回错误
 	// 示例:
 	//   rules := [][]string{
 	//       {"admin", "users", "read"},
 	//       {"admin", "users", "write"},
 	//   }
-	//   rbac.AddPolicies(rules)
-	AddPolicies(rules [][]string) error
+	//   added, err := rbac.AddPolicies(rules)
+	AddPolicies(rules [][]string) ([][]string, error)
 
 	// RemovePolicies 批量删除策略
 	RemovePolicies(rules [][]string) error
 
+	// ImportPolicies 在单个数据库事务中导入策略和角色分配
+	// 已存在的条目会被跳过，重复导入同一份数据不会产生重复记录；任一写入失败则整体回滚
+	// 参数:
+	//   policies: 策略列表，每个策略是[sub, obj, act]或[sub, dom, obj, act]
+	//   groupingPolicies: 角色分配列表，每条是[user, role]或[user, role, dom]
+	ImportPolicies(policies, groupingPolicies [][]string) error
+
 	// ========== 工具方法 ==========
 
 	// LoadPolicy 从存储加载策略
@@ -134,7 +205,21 @@ type RBAC interface {
 	// 当策略变更时，应该清除缓存以确保一致性
 	ClearCache() error
 
+	// CacheStats 返回权限检查缓存的统计信息
+	// 可用于监控热点接口的缓存命中率
+	CacheStats() CacheStats
+
 	// Close 关闭RBAC实例
 	// 释放资源
 	Close() error
 }
+
+// CacheStats 权限检查缓存统计
+type CacheStats struct {
+	// Hits 命中缓存的检查次数
+	Hits uint64
+	// Misses 未命中缓存、调用 Casbin Enforce 的检查次数
+	Misses uint64
+	// Entries 当前缓存条目数（含尚未清理的过期条目）
+	Entries int
+}
@@ -1,10 +1,14 @@
 package rbac
 
 import (
+	"container/list"
 	"embed"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/casbin/casbin/v3"
@@ -19,12 +23,31 @@ var modelFS embed.FS
 type rbacImpl struct {
 	enforcer *casbin.Enforcer
 	config   *Config
-	cache    sync.Map // 权限检查结果缓存 map[string]cacheEntry
-	mu       sync.RWMutex
+
+	// mu 保护 cache、lru 和 bySub
+	// 命中时会调整 LRU 顺序，因此读写都使用互斥锁
+	mu    sync.Mutex
+	cache map[cacheKey]*list.Element       // 权限检查结果缓存，键到 LRU 链表节点的索引
+	lru   *list.List                       // 头部为最近使用，尾部为最久未使用
+	bySub map[string]map[cacheKey]struct{} // 主体到其缓存键的索引，按用户失效时无需扫描全部缓存
+
+	// roleMu 串行化角色分配的写入和角色删除
+	// 保证 DeleteUnassignedRole 检查分配数量与删除之间没有新的分配
+	roleMu sync.Mutex
+
+	hits   atomic.Uint64 // 缓存命中次数
+	misses atomic.Uint64 // 缓存未命中次数
+}
+
+// cacheKey 权限检查缓存键
+// 使用结构体按字段比较，任意字段中包含分隔符也不会与其他请求冲突
+type cacheKey struct {
+	sub, dom, obj, act string
 }
 
 // cacheEntry 缓存条目
 type cacheEntry struct {
+	key       cacheKey
 	result    bool
 	expiresAt time.Time
 }
@@ -45,6 +68,7 @@ type cacheEntry struct {
 //	rbac, err := rbac.New(&rbac.Config{
 //	    DB: db,
 //	    EnableCache: true,
+//	    CacheMaxEntries: rbac.DefaultCacheMaxEntries,
 //	})
 func New(cfg *Config) (RBAC, error) {
 	if cfg == nil {
@@ -107,6 +131,9 @@ func New(cfg *Config) (RBAC, error) {
 	return &rbacImpl{
 		enforcer: enforcer,
 		config:   cfg,
+		cache:    make(map[cacheKey]*list.Element),
+		bySub:    make(map[string]map[cacheKey]struct{}),
+		lru:      list.New(),
 	}, nil
 }
 
@@ -157,6 +184,9 @@ func (r *rbacImpl) AddRoleForUserInDomain(user, role, domain string) error {
 		return ErrEnforcerNotInitialized
 	}
 
+	r.roleMu.Lock()
+	defer r.roleMu.Unlock()
+
 	_, err := r.enforcer.AddRoleForUser(user, role, domain)
 	if err != nil {
 		return fmt.Errorf(ErrMsgAddRoleFailed, err)
@@ -213,6 +243,20 @@ func (r *rbacImpl) GetRolesForUserInDomain(user, domain string) ([]string, error
 	return roles, nil
 }
 
+// HasRoleForUser 检查用户在指定域中是否持有角色（含继承）
+func (r *rbacImpl) HasRoleForUser(user, role, domain string) (bool, error) {
+	if r.enforcer == nil {
+		return false, ErrEnforcerNotInitialized
+	}
+
+	roles, err := r.enforcer.GetImplicitRolesForUser(user, domain)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(roles, role), nil
+}
+
 // GetUsersForRole 获取拥有指定角色的所有用户
 func (r *rbacImpl) GetUsersForRole(role string) ([]string, error) {
 	if r.enforcer == nil {
@@ -227,6 +271,197 @@ func (r *rbacImpl) GetUsersForRole(role string) ([]string, error) {
 	return users, nil
 }
 
+// CountUsersForRole 统计直接持有指定角色的主体数量（所有域）
+// g 规则为 [user或子角色, role, dom]，按第二个字段过滤
+func (r *rbacImpl) CountUsersForRole(role string) (int, error) {
+	if r.enforcer == nil {
+		return 0, ErrEnforcerNotInitialized
+	}
+
+	return r.countUsersForRole(role)
+}
+
+// countUsersForRole 统计持有角色的用户数量，跳过本身是角色的主体
+func (r *rbacImpl) countUsersForRole(role string) (int, error) {
+	rules, err := r.enforcer.GetFilteredGroupingPolicy(1, role)
+	if err != nil {
+		return 0, err
+	}
+
+	users := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		sub := rule[0]
+		isRole, err := r.isRole(sub)
+		if err != nil {
+			return 0, err
+		}
+		if !isRole {
+			users[sub] = struct{}{}
+		}
+	}
+
+	return len(users), nil
+}
+
+// isRole 判断主体是否为角色
+// Casbin 不区分用户和角色，自身持有策略或被其他主体持有的主体视为角色
+func (r *rbacImpl) isRole(sub string) (bool, error) {
+	held, err := r.enforcer.GetFilteredGroupingPolicy(1, sub)
+	if err != nil {
+		return false, err
+	}
+	if len(held) > 0 {
+		return true, nil
+	}
+
+	policies, err := r.enforcer.GetFilteredPolicy(0, sub)
+	if err != nil {
+		return false, err
+	}
+	return len(policies) > 0, nil
+}
+
+// DeleteRole 在单个事务中删除角色及其关联
+// 依次删除持有该角色的分配、该角色继承的父角色和该角色的策略，
+// 事务失败时 Gorm Adapter 会回滚数据库并重新加载内存中的策略
+func (r *rbacImpl) DeleteRole(role string) error {
+	if r.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	r.roleMu.Lock()
+	defer r.roleMu.Unlock()
+
+	return r.deleteRole(role)
+}
+
+// DeleteUnassignedRole 在角色没有分配给任何用户时删除角色
+func (r *rbacImpl) DeleteUnassignedRole(role string) error {
+	if r.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	r.roleMu.Lock()
+	defer r.roleMu.Unlock()
+
+	count, err := r.countUsersForRole(role)
+	if err != nil {
+		return fmt.Errorf(ErrMsgDeleteRoleFailed, err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: role %s is assigned to %d users", ErrRoleInUse, role, count)
+	}
+
+	return r.deleteRole(role)
+}
+
+// deleteRole 删除角色的事务实现，调用方需要持有 roleMu
+func (r *rbacImpl) deleteRole(role string) error {
+	adapter, ok := r.enforcer.GetAdapter().(*gormadapter.Adapter)
+	if !ok {
+		return fmt.Errorf(ErrMsgDeleteRoleFailed, fmt.Errorf("unsupported adapter %T", r.enforcer.GetAdapter()))
+	}
+
+	err := adapter.Transaction(r.enforcer, func(e casbin.IEnforcer) error {
+		if _, err := e.RemoveFilteredGroupingPolicy(1, role); err != nil {
+			return err
+		}
+		if _, err := e.RemoveFilteredGroupingPolicy(0, role); err != nil {
+			return err
+		}
+		if _, err := e.RemoveFilteredPolicy(0, role); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(ErrMsgDeleteRoleFailed, err)
+	}
+
+	// 删除角色影响所有持有它的用户（含继承），直接清空缓存
+	if r.config.EnableCache {
+		return r.ClearCache()
+	}
+
+	return nil
+}
+
+// AddRoleInheritance 设置角色继承关系
+// Casbin 中角色继承与用户分配角色共用 g 规则：g(child, parent, dom)
+func (r *rbacImpl) AddRoleInheritance(child, parent, domain string) error {
+	if r.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	r.roleMu.Lock()
+	defer r.roleMu.Unlock()
+
+	// 环检测：parent 已直接或间接继承 child 时拒绝
+	if child == parent {
+		return fmt.Errorf("%w: %s -> %s", ErrRoleCycle, child, parent)
+	}
+	ancestors, err := r.enforcer.GetImplicitRolesForUser(parent, domain)
+	if err != nil {
+		return fmt.Errorf(ErrMsgAddRoleFailed, err)
+	}
+	for _, role := range ancestors {
+		if role == child {
+			return fmt.Errorf("%w: %s -> %s", ErrRoleCycle, child, parent)
+		}
+	}
+
+	if _, err := r.enforcer.AddRoleForUser(child, parent, domain); err != nil {
+		return fmt.Errorf(ErrMsgAddRoleFailed, err)
+	}
+
+	// 继承关系影响所有持有 child 的用户，规模不可控，直接清空缓存
+	if r.config.EnableCache {
+		if err := r.ClearCache(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteRoleInheritance 删除角色继承关系
+func (r *rbacImpl) DeleteRoleInheritance(child, parent, domain string) error {
+	if r.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	if _, err := r.enforcer.DeleteRoleForUser(child, parent, domain); err != nil {
+		return fmt.Errorf(ErrMsgRemoveRoleFailed, err)
+	}
+
+	if r.config.EnableCache {
+		if err := r.ClearCache(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetImplicitPermissionsForUser 获取用户的所有有效权限（无域）
+func (r *rbacImpl) GetImplicitPermissionsForUser(user string) ([][]string, error) {
+	return r.GetImplicitPermissionsForUserInDomain(user, "")
+}
+
+// GetImplicitPermissionsForUserInDomain 获取用户在指定域中的所有有效权限
+func (r *rbacImpl) GetImplicitPermissionsForUserInDomain(user, domain string) ([][]string, error) {
+	if r.enforcer == nil {
+		return nil, ErrEnforcerNotInitialized
+	}
+
+	permissions, err := r.enforcer.GetImplicitPermissionsForUser(user, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
 // ========== 策略管理 ==========
 
 // AddPolicy 添加策略（无域）
@@ -245,11 +480,9 @@ func (r *rbacImpl) AddPolicyWithDomain(sub, domain, obj, act string) error {
 		return fmt.Errorf(ErrMsgAddPolicyFailed, err)
 	}
 
-	// 清除缓存
+	// 清除受影响用户的缓存
 	if r.config.EnableCache {
-		if err := r.ClearCache(); err != nil {
-			return err
-		}
+		r.invalidatePolicyCache([][]string{{sub, domain, obj, act}})
 	}
 
 	return nil
@@ -271,11 +504,9 @@ func (r *rbacImpl) RemovePolicyWithDomain(sub, domain, obj, act string) error {
 		return fmt.Errorf(ErrMsgRemovePolicyFailed, err)
 	}
 
-	// 清除缓存
+	// 清除受影响用户的缓存
 	if r.config.EnableCache {
-		if err := r.ClearCache(); err != nil {
-			return err
-		}
+		r.invalidatePolicyCache([][]string{{sub, domain, obj, act}})
 	}
 
 	return nil
@@ -299,27 +530,63 @@ func (r *rbacImpl) GetFilteredPolicy(fieldIndex int, fieldValues ...string) [][]
 	return policies
 }
 
+// GetGroupingPolicy 获取所有角色分配关系
+func (r *rbacImpl) GetGroupingPolicy() [][]string {
+	if r.enforcer == nil {
+		return nil
+	}
+	rules, _ := r.enforcer.GetGroupingPolicy()
+	return rules
+}
+
 // ========== 批量操作 ==========
 
 // AddPolicies 批量添加策略
-func (r *rbacImpl) AddPolicies(rules [][]string) error {
+// 已存在的策略和批内重复的策略会被跳过，只写入新增的条目，并返回实际写入的策略
+// casbin 的 AddPolicies 在任一条目已存在时会整体放弃并返回 false，因此需要先去重；
+// 去重与写入在同一个 Gorm Adapter 事务中完成，写入被并发修改打断时回滚并返回 ErrPolicyConflict
+func (r *rbacImpl) AddPolicies(rules [][]string) ([][]string, error) {
 	if r.enforcer == nil {
-		return ErrEnforcerNotInitialized
+		return nil, ErrEnforcerNotInitialized
 	}
 
-	_, err := r.enforcer.AddPolicies(rules)
-	if err != nil {
-		return fmt.Errorf(ErrMsgAddPolicyFailed, err)
+	adapter, ok := r.enforcer.GetAdapter().(*gormadapter.Adapter)
+	if !ok {
+		return nil, fmt.Errorf(ErrMsgAddPolicyFailed, fmt.Errorf("unsupported adapter %T", r.enforcer.GetAdapter()))
 	}
 
-	// 清除缓存
-	if r.config.EnableCache {
-		if err := r.ClearCache(); err != nil {
+	rules = normalizeRules(rules)
+
+	var added [][]string
+	err := adapter.Transaction(r.enforcer, func(e casbin.IEnforcer) error {
+		missing, err := missingPolicies(e, rules)
+		if err != nil {
 			return err
 		}
+		if len(missing) == 0 {
+			return nil
+		}
+
+		ok, err := e.AddPolicies(missing)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrPolicyConflict
+		}
+		added = missing
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(ErrMsgAddPolicyFailed, err)
 	}
 
-	return nil
+	// 清除受影响用户的缓存
+	if r.config.EnableCache && len(added) > 0 {
+		r.invalidatePolicyCache(added)
+	}
+
+	return added, nil
 }
 
 // RemovePolicies 批量删除策略
@@ -328,16 +595,59 @@ func (r *rbacImpl) RemovePolicies(rules [][]string) error {
 		return ErrEnforcerNotInitialized
 	}
 
+	rules = normalizeRules(rules)
 	_, err := r.enforcer.RemovePolicies(rules)
 	if err != nil {
 		return fmt.Errorf(ErrMsgRemovePolicyFailed, err)
 	}
 
-	// 清除缓存
+	// 清除受影响用户的缓存
 	if r.config.EnableCache {
-		if err := r.ClearCache(); err != nil {
-			return err
+		r.invalidatePolicyCache(rules)
+	}
+
+	return nil
+}
+
+// ImportPolicies 在单个事务中导入策略和角色分配
+// 使用 AddPoliciesEx/AddGroupingPoliciesEx 跳过已存在的条目，
+// 事务失败时 Gorm Adapter 会回滚数据库并重新加载内存中的策略
+func (r *rbacImpl) ImportPolicies(policies, groupingPolicies [][]string) error {
+	if r.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	adapter, ok := r.enforcer.GetAdapter().(*gormadapter.Adapter)
+	if !ok {
+		return fmt.Errorf(ErrMsgImportFailed, fmt.Errorf("unsupported adapter %T", r.enforcer.GetAdapter()))
+	}
+
+	policies = normalizeRules(policies)
+	groupingPolicies = normalizeGroupingRules(groupingPolicies)
+
+	r.roleMu.Lock()
+	defer r.roleMu.Unlock()
+
+	err := adapter.Transaction(r.enforcer, func(e casbin.IEnforcer) error {
+		if len(policies) > 0 {
+			if _, err := e.AddPoliciesEx(policies); err != nil {
+				return err
+			}
+		}
+		if len(groupingPolicies) > 0 {
+			if _, err := e.AddGroupingPoliciesEx(groupingPolicies); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(ErrMsgImportFailed, err)
+	}
+
+	// 导入可能影响任意用户，直接清空缓存
+	if r.config.EnableCache {
+		return r.ClearCache()
 	}
 
 	return nil
@@ -383,14 +693,29 @@ func (r *rbacImpl) ClearCache() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.cache = sync.Map{}
+	r.resetCache()
 	return nil
 }
 
+// CacheStats 返回权限检查缓存的统计信息
+func (r *rbacImpl) CacheStats() CacheStats {
+	r.mu.Lock()
+	entries := r.lru.Len()
+	r.mu.Unlock()
+
+	return CacheStats{
+		Hits:    r.hits.Load(),
+		Misses:  r.misses.Load(),
+		Entries: entries,
+	}
+}
+
 // Close 关闭RBAC实例
 func (r *rbacImpl) Close() error {
 	// Casbin enforcer 没有Close方法，只需清理资源
-	r.cache = sync.Map{}
+	r.mu.Lock()
+	r.resetCache()
+	r.mu.Unlock()
 	r.enforcer = nil
 	return nil
 }
@@ -399,31 +724,77 @@ func (r *rbacImpl) Close() error {
 
 // getCached 从缓存获取权限检查结果
 func (r *rbacImpl) getCached(sub, dom, obj, act string) (bool, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	key := r.cacheKey(sub, dom, obj, act)
-	if val, ok := r.cache.Load(key); ok {
-		entry := val.(cacheEntry)
+	key := cacheKey{sub: sub, dom: dom, obj: obj, act: act}
+	if elem, ok := r.cache[key]; ok {
+		entry := elem.Value.(*cacheEntry)
 		if time.Now().Before(entry.expiresAt) {
+			r.lru.MoveToFront(elem)
+			r.hits.Add(1)
 			return entry.result, true
 		}
 		// 缓存已过期，删除
-		r.cache.Delete(key)
+		r.removeElement(elem)
 	}
+	r.misses.Add(1)
 	return false, false
 }
 
 // setCache 设置缓存
+// 超过 CacheMaxEntries 时淘汰最久未使用的条目
 func (r *rbacImpl) setCache(sub, dom, obj, act string, result bool) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	key := r.cacheKey(sub, dom, obj, act)
-	r.cache.Store(key, cacheEntry{
-		result:    result,
-		expiresAt: time.Now().Add(r.config.CacheTTL),
-	})
+	key := cacheKey{sub: sub, dom: dom, obj: obj, act: act}
+	expiresAt := time.Now().Add(r.config.CacheTTL)
+
+	if elem, ok := r.cache[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.result = result
+		entry.expiresAt = expiresAt
+		r.lru.MoveToFront(elem)
+		return
+	}
+
+	r.cache[key] = r.lru.PushFront(&cacheEntry{key: key, result: result, expiresAt: expiresAt})
+	keys, ok := r.bySub[sub]
+	if !ok {
+		keys = make(map[cacheKey]struct{})
+		r.bySub[sub] = keys
+	}
+	keys[key] = struct{}{}
+
+	if limit := r.config.CacheMaxEntries; limit > 0 {
+		for r.lru.Len() > limit {
+			r.removeElement(r.lru.Back())
+		}
+	}
+}
+
+// removeElement 删除缓存条目
+// 调用方必须持有 r.mu
+func (r *rbacImpl) removeElement(elem *list.Element) {
+	r.lru.Remove(elem)
+	key := elem.Value.(*cacheEntry).key
+	delete(r.cache, key)
+
+	if keys, ok := r.bySub[key.sub]; ok {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(r.bySub, key.sub)
+		}
+	}
+}
+
+// resetCache 清空缓存
+// 调用方必须持有 r.mu
+func (r *rbacImpl) resetCache() {
+	r.cache = make(map[cacheKey]*list.Element)
+	r.bySub = make(map[string]map[cacheKey]struct{})
+	r.lru.Init()
 }
 
 // clearUserCache 清除用户相关的缓存
@@ -431,22 +802,92 @@ func (r *rbacImpl) clearUserCache(user string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// 遍历删除所有包含该用户的缓存
-	r.cache.Range(func(key, value interface{}) bool {
-		keyStr := key.(string)
-		if len(keyStr) > len(user) && keyStr[:len(user)] == user {
-			r.cache.Delete(key)
+	// 通过主体索引只访问该用户的条目，开销与缓存总量无关
+	for key := range r.bySub[user] {
+		r.removeElement(r.cache[key])
+	}
+}
+
+// invalidatePolicyCache 策略变更后清除受影响主体的缓存
+// 受影响的主体包括策略的 subject 本身（通常是角色），
+// 以及直接或通过角色继承持有该角色的所有用户。
+// 反查用户失败时退化为清空全部缓存，保证不会残留过期的授权结果
+func (r *rbacImpl) invalidatePolicyCache(rules [][]string) {
+	if r.config.DisablePolicyCacheInvalidation {
+		return
+	}
+
+	seen := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 2 {
+			continue
 		}
-		return true
-	})
+		sub, dom := rule[0], rule[1]
+		key := sub + "\x00" + dom
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		r.clearUserCache(sub)
+
+		users, err := r.enforcer.GetImplicitUsersForRole(sub, dom)
+		if err != nil {
+			_ = r.ClearCache()
+			return
+		}
+		for _, user := range users {
+			r.clearUserCache(user)
+		}
+	}
+}
+
+// normalizeRules 将不带域的策略 [sub, obj, act] 补齐为 [sub, "", obj, act]
+// 模型的 policy_definition 固定为 sub, dom, obj, act，与 AddPolicy 的行为保持一致
+func normalizeRules(rules [][]string) [][]string {
+	normalized := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) == 3 {
+			rule = []string{rule[0], "", rule[1], rule[2]}
+		}
+		normalized = append(normalized, rule)
+	}
+	return normalized
+}
+
+// missingPolicies 过滤掉已存在的策略和批内重复的策略
+func missingPolicies(e casbin.IEnforcer, rules [][]string) ([][]string, error) {
+	seen := make(map[string]struct{}, len(rules))
+	missing := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		key := strings.Join(rule, "\x00")
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		exists, err := e.HasPolicy(rule)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missing = append(missing, rule)
+		}
+	}
+	return missing, nil
 }
 
-// cacheKey 生成缓存键
-func (r *rbacImpl) cacheKey(sub, dom, obj, act string) string {
-	if dom == "" {
-		return fmt.Sprintf("%s:%s:%s", sub, obj, act)
+// normalizeGroupingRules 将不带域的角色分配 [user, role] 补齐为 [user, role, ""]
+// 模型的 role_definition 固定为 g = _, _, _，与 AddRoleForUser 的行为保持一致
+func normalizeGroupingRules(rules [][]string) [][]string {
+	normalized := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) == 2 {
+			rule = []string{rule[0], rule[1], ""}
+		}
+		normalized = append(normalized, rule)
 	}
-	return fmt.Sprintf("%s:%s:%s:%s", sub, dom, obj, act)
+	return normalized
 }
 
 // GetModelPath 获取模型文件路径（用于测试）
@@ -0,0 +1,490 @@
+package rbac
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestRBAC 创建基于内存 SQLite 的RBAC实例
+func setupTestRBAC(t *testing.T, cfg *Config) *rbacImpl {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	if cfg == nil {
+		cfg = DefaultConfig(db)
+	}
+	cfg.DB = db
+
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create rbac: %v", err)
+	}
+	t.Cleanup(func() { _ = r.Close() })
+
+	return r.(*rbacImpl)
+}
+
+// hasCachedEntries 判断用户是否存在缓存条目
+func hasCachedEntries(r *rbacImpl, user string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.cache {
+		if key.sub == user {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheContains 判断缓存中是否存在指定条目
+func cacheContains(r *rbacImpl, sub, dom, obj, act string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.cache[cacheKey{sub: sub, dom: dom, obj: obj, act: act}]
+	return ok
+}
+
+// TestPolicyChange_InvalidatesRoleUsers 测试角色策略变更只清除受影响用户的缓存
+func TestPolicyChange_InvalidatesRoleUsers(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicy("admin", "posts", "delete"))
+	mustNoErr(t, r.AddPolicy("editor", "posts", "write"))
+	mustNoErr(t, r.AddRoleForUser("1", "admin"))
+	mustNoErr(t, r.AddRoleForUser("2", "editor"))
+
+	// 预热缓存
+	if ok, _ := r.Enforce("1", "posts", "delete"); !ok {
+		t.Fatal("expected user 1 to be allowed before revoke")
+	}
+	if ok, _ := r.Enforce("2", "posts", "write"); !ok {
+		t.Fatal("expected user 2 to be allowed")
+	}
+
+	mustNoErr(t, r.RemovePolicy("admin", "posts", "delete"))
+
+	if hasCachedEntries(r, "1") {
+		t.Fatal("expected cache of user 1 (holds admin) to be invalidated")
+	}
+	if !hasCachedEntries(r, "2") {
+		t.Fatal("expected cache of user 2 (unaffected) to be kept")
+	}
+
+	// 撤销立即生效
+	if ok, _ := r.Enforce("1", "posts", "delete"); ok {
+		t.Fatal("expected user 1 to be denied after revoke")
+	}
+}
+
+// TestPolicyChange_InvalidationDisabled 测试禁用失效后依赖TTL
+func TestPolicyChange_InvalidationDisabled(t *testing.T) {
+	cfg := DefaultConfig(nil)
+	cfg.DisablePolicyCacheInvalidation = true
+	r := setupTestRBAC(t, cfg)
+
+	mustNoErr(t, r.AddPolicy("admin", "posts", "delete"))
+	mustNoErr(t, r.AddRoleForUser("1", "admin"))
+
+	if ok, _ := r.Enforce("1", "posts", "delete"); !ok {
+		t.Fatal("expected user 1 to be allowed")
+	}
+
+	mustNoErr(t, r.RemovePolicy("admin", "posts", "delete"))
+
+	if !hasCachedEntries(r, "1") {
+		t.Fatal("expected cache to be kept when invalidation is disabled")
+	}
+}
+
+// TestCache_HitsAndPolicyInvalidation 测试重复检查命中缓存，AddPolicy 使相关条目失效
+func TestCache_HitsAndPolicyInvalidation(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddRoleForUser("1", "editor"))
+
+	if ok, _ := r.Enforce("1", "posts", "write"); ok {
+		t.Fatal("expected user 1 to be denied before policy is added")
+	}
+	if ok, _ := r.Enforce("1", "posts", "write"); ok {
+		t.Fatal("expected cached deny for user 1")
+	}
+	stats := r.CacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Fatalf("unexpected stats after repeated check: %+v", stats)
+	}
+
+	// 给角色授权后，持有该角色的用户缓存失效，新策略立即生效
+	mustNoErr(t, r.AddPolicy("editor", "posts", "write"))
+	if hasCachedEntries(r, "1") {
+		t.Fatal("expected cache of user 1 (holds editor) to be invalidated")
+	}
+	if ok, _ := r.Enforce("1", "posts", "write"); !ok {
+		t.Fatal("expected user 1 to be allowed after AddPolicy")
+	}
+	if stats := r.CacheStats(); stats.Misses != 2 {
+		t.Fatalf("expected a miss after invalidation, got %+v", stats)
+	}
+}
+
+// TestCache_KeysDoNotCollide 测试字段中含分隔符的请求不会复用彼此的缓存结果
+func TestCache_KeysDoNotCollide(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicyWithDomain("a:b", "c", "d", "e"))
+
+	if ok, _ := r.EnforceWithDomain("a:b", "c", "d", "e"); !ok {
+		t.Fatal("expected a:b to be allowed in domain c")
+	}
+	// 拼接成字符串时两次请求的键相同，结果会被错误复用
+	if ok, _ := r.EnforceWithDomain("a", "b:c", "d", "e"); ok {
+		t.Fatal("expected a to be denied in domain b:c")
+	}
+	if entries := r.CacheStats().Entries; entries != 2 {
+		t.Fatalf("expected 2 cache entries, got %d", entries)
+	}
+}
+
+// TestCache_SubjectIndex 测试按用户失效只清除该用户的条目，索引随淘汰同步更新
+func TestCache_SubjectIndex(t *testing.T) {
+	cfg := DefaultConfig(nil)
+	cfg.CacheMaxEntries = 3
+	r := setupTestRBAC(t, cfg)
+
+	for _, req := range [][2]string{{"1", "posts"}, {"12", "posts"}, {"1", "users"}} {
+		if _, err := r.Enforce(req[0], req[1], "read"); err != nil {
+			t.Fatalf("Enforce() failed: %v", err)
+		}
+	}
+
+	r.clearUserCache("1")
+	if hasCachedEntries(r, "1") || !hasCachedEntries(r, "12") {
+		t.Fatal("expected only the cache of user 1 to be cleared")
+	}
+
+	// 写满后淘汰 12 的条目，索引中不应残留
+	for _, obj := range []string{"a", "b", "c"} {
+		if _, err := r.Enforce("2", obj, "read"); err != nil {
+			t.Fatalf("Enforce() failed: %v", err)
+		}
+	}
+	r.mu.Lock()
+	_, stale12 := r.bySub["12"]
+	_, stale1 := r.bySub["1"]
+	indexed := len(r.bySub["2"])
+	r.mu.Unlock()
+	if stale1 || stale12 || indexed != 3 {
+		t.Fatalf("unexpected subject index: stale1=%v stale12=%v indexed=%d", stale1, stale12, indexed)
+	}
+}
+
+// TestCache_MaxEntries 测试缓存条目数不超过上限
+func TestCache_MaxEntries(t *testing.T) {
+	cfg := DefaultConfig(nil)
+	cfg.CacheMaxEntries = 2
+	r := setupTestRBAC(t, cfg)
+
+	for _, obj := range []string{"a", "b", "c", "d"} {
+		if _, err := r.Enforce("1", obj, "read"); err != nil {
+			t.Fatalf("Enforce() failed: %v", err)
+		}
+	}
+	if entries := r.CacheStats().Entries; entries != 2 {
+		t.Fatalf("expected 2 cache entries, got %d", entries)
+	}
+
+	// 最近使用的条目保留，最久未使用的被淘汰
+	if !cacheContains(r, "1", "", "d", "read") || !cacheContains(r, "1", "", "c", "read") {
+		t.Fatal("expected most recently used entries to be kept")
+	}
+
+	// 命中 c 后插入 e，应淘汰 d 而不是 c
+	if _, err := r.Enforce("1", "c", "read"); err != nil {
+		t.Fatalf("Enforce() failed: %v", err)
+	}
+	if _, err := r.Enforce("1", "e", "read"); err != nil {
+		t.Fatalf("Enforce() failed: %v", err)
+	}
+	if !cacheContains(r, "1", "", "c", "read") || cacheContains(r, "1", "", "d", "read") {
+		t.Fatal("expected least recently used entry to be evicted")
+	}
+
+	mustNoErr(t, r.ClearCache())
+	if entries := r.CacheStats().Entries; entries != 0 {
+		t.Fatalf("expected no cache entries after ClearCache, got %d", entries)
+	}
+}
+
+// TestRoleInheritance_TwoLevel 测试两级角色继承的权限传递
+func TestRoleInheritance_TwoLevel(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicy("viewer", "posts", "read"))
+	mustNoErr(t, r.AddPolicy("editor", "posts", "write"))
+	mustNoErr(t, r.AddPolicy("admin", "posts", "delete"))
+
+	// admin -> editor -> viewer
+	mustNoErr(t, r.AddRoleInheritance("admin", "editor", ""))
+	mustNoErr(t, r.AddRoleInheritance("editor", "viewer", ""))
+	mustNoErr(t, r.AddRoleForUser("1", "admin"))
+
+	for _, act := range []string{"read", "write", "delete"} {
+		ok, err := r.Enforce("1", "posts", act)
+		mustNoErr(t, err)
+		if !ok {
+			t.Fatalf("expected admin user to inherit posts:%s", act)
+		}
+	}
+
+	perms, err := r.GetImplicitPermissionsForUser("1")
+	mustNoErr(t, err)
+	if len(perms) != 3 {
+		t.Fatalf("expected 3 implicit permissions, got %d: %v", len(perms), perms)
+	}
+}
+
+// TestHasRoleForUser 测试角色检查包含继承角色并区分域
+func TestHasRoleForUser(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	// superadmin -> admin
+	mustNoErr(t, r.AddRoleInheritance("superadmin", "admin", ""))
+	mustNoErr(t, r.AddRoleForUser("1", "superadmin"))
+	mustNoErr(t, r.AddRoleForUserInDomain("2", "admin", "tenant1"))
+
+	tests := []struct {
+		name       string
+		user       string
+		domain     string
+		wantResult bool
+	}{
+		{"inherited", "1", "", true},
+		{"domain", "2", "tenant1", true},
+		{"other domain", "2", "tenant2", false},
+		{"global", "2", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := r.HasRoleForUser(tt.user, "admin", tt.domain)
+			mustNoErr(t, err)
+			if ok != tt.wantResult {
+				t.Fatalf("HasRoleForUser(%s, admin, %q) = %v, want %v", tt.user, tt.domain, ok, tt.wantResult)
+			}
+		})
+	}
+}
+
+// TestCountUsersForRole_SkipsRoles 测试统计分配数量时不计入继承该角色的子角色
+func TestCountUsersForRole_SkipsRoles(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicy("editor", "posts", "write"))
+	mustNoErr(t, r.AddRoleInheritance("editor", "viewer", ""))
+	mustNoErr(t, r.AddRoleForUser("1", "viewer"))
+	mustNoErr(t, r.AddRoleForUserInDomain("1", "viewer", "tenant1"))
+	mustNoErr(t, r.AddRoleForUser("2", "editor"))
+
+	count, err := r.CountUsersForRole("viewer")
+	mustNoErr(t, err)
+	if count != 1 {
+		t.Fatalf("expected 1 user for viewer, got %d", count)
+	}
+
+	err = r.DeleteUnassignedRole("viewer")
+	if !errors.Is(err, ErrRoleInUse) {
+		t.Fatalf("expected ErrRoleInUse, got %v", err)
+	}
+	users, err := r.GetUsersForRole("viewer")
+	mustNoErr(t, err)
+	if len(users) != 2 {
+		t.Fatalf("expected assignments to be kept, got %v", users)
+	}
+}
+
+// TestRoleInheritance_Cycle 测试拒绝形成环的继承关系
+func TestRoleInheritance_Cycle(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddRoleInheritance("admin", "editor", ""))
+	mustNoErr(t, r.AddRoleInheritance("editor", "viewer", ""))
+
+	tests := []struct {
+		name          string
+		child, parent string
+	}{
+		{"self", "admin", "admin"},
+		{"direct", "editor", "admin"},
+		{"transitive", "viewer", "admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.AddRoleInheritance(tt.child, tt.parent, "")
+			if !errors.Is(err, ErrRoleCycle) {
+				t.Fatalf("expected ErrRoleCycle, got %v", err)
+			}
+		})
+	}
+}
+
+func mustNoErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestEnforce_Wildcard 测试资源/操作通配符匹配
+func TestEnforce_Wildcard(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicy("editor", "posts", "*"))
+	mustNoErr(t, r.AddPolicy("root", "*", "*"))
+	mustNoErr(t, r.AddRoleForUser("1", "editor"))
+	mustNoErr(t, r.AddRoleForUser("2", "root"))
+
+	tests := []struct {
+		name     string
+		user     string
+		obj, act string
+		want     bool
+	}{
+		{"resource wildcard grants action", "1", "posts", "delete", true},
+		{"resource wildcard scoped to resource", "1", "users", "delete", false},
+		{"global wildcard", "2", "users", "delete", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 两次检查分别覆盖缓存未命中和命中
+			for i := 0; i < 2; i++ {
+				got, err := r.Enforce(tt.user, tt.obj, tt.act)
+				mustNoErr(t, err)
+				if got != tt.want {
+					t.Fatalf("Enforce(%s, %s, %s) = %v, want %v", tt.user, tt.obj, tt.act, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestDomain_Isolation 测试在租户 A 分配的角色不会在租户 B 生效
+func TestDomain_Isolation(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicyWithDomain("admin", "tenantA", "posts", "delete"))
+	mustNoErr(t, r.AddPolicyWithDomain("admin", "tenantB", "posts", "delete"))
+	mustNoErr(t, r.AddRoleForUserInDomain("1", "admin", "tenantA"))
+
+	tests := []struct {
+		name     string
+		dom, obj string
+		want     bool
+	}{
+		{"granted tenant", "tenantA", "posts", true},
+		{"other tenant", "tenantB", "posts", false},
+		{"no domain", "", "posts", false},
+		{"no domain with colon object", "", "tenantA:posts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 两次检查分别覆盖缓存未命中和命中
+			for i := 0; i < 2; i++ {
+				got, err := r.EnforceWithDomain("1", tt.dom, tt.obj, "delete")
+				mustNoErr(t, err)
+				if got != tt.want {
+					t.Fatalf("EnforceWithDomain(1, %q, %q, delete) = %v, want %v", tt.dom, tt.obj, got, tt.want)
+				}
+			}
+		})
+	}
+
+	roles, err := r.GetRolesForUserInDomain("1", "tenantB")
+	mustNoErr(t, err)
+	if len(roles) != 0 {
+		t.Fatalf("expected no roles in tenantB, got %v", roles)
+	}
+}
+
+// TestAddPolicies_PartialOverlap 测试批量添加时已存在的策略被跳过，新增策略仍然写入
+func TestAddPolicies_PartialOverlap(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	mustNoErr(t, r.AddPolicy("editor", "posts", "read"))
+
+	added, err := r.AddPolicies([][]string{
+		{"editor", "posts", "read"},
+		{"editor", "posts", "write"},
+		{"editor", "posts", "write"},
+	})
+	mustNoErr(t, err)
+	if len(added) != 1 || !slices.Equal(added[0], []string{"editor", "", "posts", "write"}) {
+		t.Fatalf("expected only the new policy to be reported, got %v", added)
+	}
+
+	if ok, _ := r.Enforce("editor", "posts", "write"); !ok {
+		t.Fatal("expected new policy to be added despite overlap")
+	}
+	if ok, _ := r.Enforce("editor", "posts", "read"); !ok {
+		t.Fatal("expected existing policy to remain")
+	}
+
+	var n int64
+	mustNoErr(t, r.config.DB.Table(DefaultTableName).Count(&n).Error)
+	if n != 2 {
+		t.Fatalf("expected 2 rows, got %d", n)
+	}
+}
+
+// TestImportPolicies_Idempotent 测试导入后可查询，重复导入不产生重复记录
+func TestImportPolicies_Idempotent(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	policies := [][]string{
+		{"editor", "posts", "write"},
+		{"admin", "tenantA", "users", "delete"},
+	}
+	grouping := [][]string{
+		{"admin", "editor"},
+		{"1", "admin", "tenantA"},
+	}
+
+	mustNoErr(t, r.ImportPolicies(policies, grouping))
+
+	if got := len(r.GetPolicy()); got != 2 {
+		t.Fatalf("expected 2 policies, got %d: %v", got, r.GetPolicy())
+	}
+	if got := len(r.GetGroupingPolicy()); got != 2 {
+		t.Fatalf("expected 2 grouping policies, got %d: %v", got, r.GetGroupingPolicy())
+	}
+	if ok, _ := r.EnforceWithDomain("1", "tenantA", "users", "delete"); !ok {
+		t.Fatal("expected imported role assignment to take effect")
+	}
+
+	countRows := func() int64 {
+		var n int64
+		mustNoErr(t, r.config.DB.Table(DefaultTableName).Count(&n).Error)
+		return n
+	}
+	before := countRows()
+	if before != 4 {
+		t.Fatalf("expected 4 rows after import, got %d", before)
+	}
+
+	// 再次导入（含一条新策略）只写入新增的条目
+	policies = append(policies, []string{"viewer", "posts", "read"})
+	mustNoErr(t, r.ImportPolicies(policies, grouping))
+
+	if after := countRows(); after != before+1 {
+		t.Fatalf("expected %d rows after re-import, got %d", before+1, after)
+	}
+}
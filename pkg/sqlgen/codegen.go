@@ -3,6 +3,8 @@ package sqlgen
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // ============================================================================
@@ -12,6 +14,12 @@ import (
 // CodeGenerator Go 代码生成器
 type CodeGenerator struct {
 	options *ReverseOptions
+
+	// dialect 目标方言,用于计算 InsertBatch 的默认批大小
+	dialect Dialect
+
+	// queryTimeout DAO 方法的查询超时,见 Config.QueryTimeout
+	queryTimeout time.Duration
 }
 
 // NewCodeGenerator 创建新的代码生成器
@@ -46,18 +54,29 @@ func (c *CodeGenerator) Generate(schema *Schema) string {
 	sb.WriteString(fmt.Sprintf("type %s struct {\n", schema.Name))
 
 	// 字段
+	indexTags := buildIndexTags(schema)
 	for _, field := range schema.Fields {
-		c.writeField(&sb, field)
+		c.writeField(&sb, field, indexTags[strings.ToLower(field.Column.Name)])
+	}
+
+	// 关联字段
+	for _, rel := range schema.Relations {
+		c.writeRelation(&sb, rel)
 	}
 
 	sb.WriteString("}\n")
 
+	// 枚举类型及常量
+	for _, enum := range schema.Enums {
+		c.writeEnum(&sb, enum)
+	}
+
 	// TableName 方法
 	if c.options.WithTableName {
 		sb.WriteString("\n")
 		sb.WriteString(fmt.Sprintf("// TableName overrides the table name\n"))
 		sb.WriteString(fmt.Sprintf("func (%s) TableName() string {\n", schema.Name))
-		sb.WriteString(fmt.Sprintf("\treturn \"%s\"\n", schema.TableName))
+		sb.WriteString(fmt.Sprintf("\treturn \"%s\"\n", schema.QualifiedTableName()))
 		sb.WriteString("}\n")
 	}
 
@@ -65,7 +84,8 @@ func (c *CodeGenerator) Generate(schema *Schema) string {
 }
 
 // writeField 写入字段定义
-func (c *CodeGenerator) writeField(sb *strings.Builder, field Field) {
+// indexTags 为该列所属索引对应的 GORM tag,如 "uniqueIndex:uk_email"
+func (c *CodeGenerator) writeField(sb *strings.Builder, field Field, indexTags []string) {
 	// 字段注释
 	if c.options.WithComments && field.Comment != "" {
 		sb.WriteString(fmt.Sprintf("\t// %s %s\n", field.Name, field.Comment))
@@ -75,7 +95,7 @@ func (c *CodeGenerator) writeField(sb *strings.Builder, field Field) {
 	sb.WriteString(fmt.Sprintf("\t%s %s", field.Name, field.Type))
 
 	// Tags
-	tags := c.buildTags(field)
+	tags := c.buildTags(field, indexTags)
 	if tags != "" {
 		sb.WriteString(fmt.Sprintf(" `%s`", tags))
 	}
@@ -84,12 +104,12 @@ func (c *CodeGenerator) writeField(sb *strings.Builder, field Field) {
 }
 
 // buildTags 构建 struct tags
-func (c *CodeGenerator) buildTags(field Field) string {
+func (c *CodeGenerator) buildTags(field Field, indexTags []string) string {
 	var tags []string
 
 	// GORM Tag
 	if c.options.Tags&TagGorm != 0 {
-		gormTag := c.buildGormTag(field)
+		gormTag := c.buildGormTag(field, indexTags)
 		if gormTag != "" {
 			tags = append(tags, fmt.Sprintf("gorm:\"%s\"", gormTag))
 		}
@@ -120,11 +140,34 @@ func (c *CodeGenerator) buildTags(field Field) string {
 		}
 	}
 
+	// Binding Tag
+	if c.options.Tags&TagBinding != 0 {
+		if bindingTag := buildBindingTag(field); bindingTag != "" {
+			tags = append(tags, fmt.Sprintf("binding:\"%s\"", bindingTag))
+		}
+	}
+
 	return strings.Join(tags, " ")
 }
 
+// buildBindingTag 构建 gin binding tag,使生成的模型可直接作为请求 DTO 校验
+// 非空且没有自增和默认值的列为 required,字符串列按 VARCHAR/CHAR 长度生成 max=N
+func buildBindingTag(field Field) string {
+	var rules []string
+
+	if field.Column.NotNull && !field.Column.AutoIncrement && field.Column.Default == "" {
+		rules = append(rules, "required")
+	}
+
+	if field.Type == "string" && field.Column.Size > 0 && strings.Contains(strings.ToUpper(field.Column.Type), "CHAR") {
+		rules = append(rules, fmt.Sprintf("max=%d", field.Column.Size))
+	}
+
+	return strings.Join(rules, ",")
+}
+
 // buildGormTag 构建 GORM tag
-func (c *CodeGenerator) buildGormTag(field Field) string {
+func (c *CodeGenerator) buildGormTag(field Field, indexTags []string) string {
 	var parts []string
 
 	// column
@@ -160,6 +203,9 @@ func (c *CodeGenerator) buildGormTag(field Field) string {
 		parts = append(parts, fmt.Sprintf("size:%d", field.Column.Size))
 	}
 
+	// index / uniqueIndex
+	parts = append(parts, indexTags...)
+
 	// comment
 	if field.Column.Comment != "" && c.options.WithComments {
 		parts = append(parts, fmt.Sprintf("comment:%s", field.Column.Comment))
@@ -168,6 +214,182 @@ func (c *CodeGenerator) buildGormTag(field Field) string {
 	return strings.Join(parts, ";")
 }
 
+// writeRelation 写入关联字段定义
+func (c *CodeGenerator) writeRelation(sb *strings.Builder, rel RelationInfo) {
+	sb.WriteString(fmt.Sprintf("\t%s %s", rel.Name, rel.GoType()))
+
+	var tags []string
+	if c.options.Tags&TagGorm != 0 {
+		tags = append(tags, fmt.Sprintf("gorm:\"%s\"", rel.GormTag()))
+	}
+	if c.options.Tags&TagJson != 0 {
+		tags = append(tags, fmt.Sprintf("json:\"%s,omitempty\"", convertNaming(rel.Name, c.options.JSONNaming)))
+	}
+	if len(tags) > 0 {
+		sb.WriteString(fmt.Sprintf(" `%s`", strings.Join(tags, " ")))
+	}
+
+	sb.WriteString("\n")
+}
+
+// buildRelations 根据外键推导 schema 的关联字段
+// 参数:
+//
+//	schema: 要生成关联字段的表
+//	all: 同一批解析的所有表,用于确定对方的结构体名和 has-many 关系
+//
+// 只处理单列外键;belongs-to 字段名取外键列去掉 _id 后缀 (如 user_id -> User),
+// has-many 字段名取对方表名 (如 posts -> Posts),与已有字段重名时跳过
+func buildRelations(schema *Schema, all []*Schema) []RelationInfo {
+	structName := func(table string) string {
+		for _, s := range all {
+			if strings.EqualFold(s.TableName, table) {
+				return s.Name
+			}
+		}
+		return toStructName(table)
+	}
+
+	used := make(map[string]bool)
+	for _, field := range schema.Fields {
+		used[field.Name] = true
+	}
+
+	var relations []RelationInfo
+	add := func(rel RelationInfo) {
+		if used[rel.Name] {
+			return
+		}
+		used[rel.Name] = true
+		relations = append(relations, rel)
+	}
+
+	// belongs-to: 外键在本表
+	for _, fk := range schema.ForeignKeys {
+		if len(fk.Columns) != 1 || len(fk.RefColumns) != 1 {
+			continue
+		}
+		target := structName(fk.RefTable)
+		name := target
+		if lower := strings.ToLower(fk.Columns[0]); strings.HasSuffix(lower, "_id") && len(lower) > 3 {
+			name = toPascalCase(fk.Columns[0][:len(fk.Columns[0])-3])
+		}
+		add(RelationInfo{
+			Name:       name,
+			Kind:       RelationBelongsTo,
+			Target:     target,
+			ForeignKey: toPascalCase(fk.Columns[0]),
+			References: toPascalCase(fk.RefColumns[0]),
+		})
+	}
+
+	// has-many: 其他表的外键引用本表
+	for _, other := range all {
+		for _, fk := range other.ForeignKeys {
+			if len(fk.Columns) != 1 || len(fk.RefColumns) != 1 || !strings.EqualFold(fk.RefTable, schema.TableName) {
+				continue
+			}
+			add(RelationInfo{
+				Name:       toPascalCase(other.TableName),
+				Kind:       RelationHasMany,
+				Target:     other.Name,
+				ForeignKey: toPascalCase(fk.Columns[0]),
+				References: toPascalCase(fk.RefColumns[0]),
+			})
+		}
+	}
+
+	return relations
+}
+
+// writeEnum 写入枚举类型及其常量
+func (c *CodeGenerator) writeEnum(sb *strings.Builder, enum Enum) {
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("// %s 枚举类型\n", enum.Name))
+	sb.WriteString(fmt.Sprintf("type %s string\n\n", enum.Name))
+	sb.WriteString("const (\n")
+	for _, value := range enum.Values {
+		sb.WriteString(fmt.Sprintf("\t%s%s %s = %q\n", enum.Name, enumConstSuffix(value), enum.Name, value))
+	}
+	sb.WriteString(")\n")
+}
+
+// assignEnumTypes 为枚举列生成具名类型,并将字段类型替换为该类型
+// 类型名为结构体名加字段名 (如 Order + Status = OrderStatus),
+// 字段名已以结构体名开头时直接使用字段名;
+// 在 typeMappings 中显式映射过的列保持原类型
+func assignEnumTypes(schema *Schema, typeMappings map[string]string) {
+	schema.Enums = nil
+	for i := range schema.Fields {
+		field := &schema.Fields[i]
+		if len(field.Column.EnumValues) == 0 {
+			continue
+		}
+		if _, ok := typeMappings[field.Column.Type]; ok {
+			continue
+		}
+
+		name := field.Name
+		if !strings.HasPrefix(name, schema.Name) {
+			name = schema.Name + name
+		}
+		field.Type = name
+		schema.Enums = append(schema.Enums, Enum{Name: name, Values: field.Column.EnumValues})
+	}
+}
+
+// enumConstSuffix 将枚举值转换为常量名后缀
+// 非字母数字字符视为分隔符,如 "in-progress" 转换为 "InProgress",空值转换为 "Empty"
+func enumConstSuffix(value string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range value {
+		switch {
+		case r < 128 && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			sb.WriteRune(r)
+		default:
+			upper = true
+		}
+	}
+	if sb.Len() == 0 {
+		return "Empty"
+	}
+	return sb.String()
+}
+
+// buildIndexTags 根据表的索引生成每列的 GORM 索引 tag
+// 返回以小写列名为键的 tag 列表;命名索引带上原始索引名,
+// 同名索引出现在多个字段上即为 GORM 的复合索引,各列带上在索引中的 priority
+// 未命名的索引不会生成名称,复合索引的名称由 Parser 按数据库的默认规则补齐
+func buildIndexTags(schema *Schema) map[string][]string {
+	tags := make(map[string][]string)
+	for _, idx := range schema.Indexes {
+		tag := GormTagIndex
+		if idx.Unique {
+			tag = GormTagUniqueIndex
+		}
+		if idx.Name != "" {
+			tag += ":" + idx.Name
+		}
+
+		// 复合索引按索引中的列顺序标注 priority,与结构体字段顺序无关
+		composite := len(idx.Columns) > 1 && idx.Name != ""
+		for i, col := range idx.Columns {
+			colTag := tag
+			if composite {
+				colTag += fmt.Sprintf(",priority:%d", i+1)
+			}
+			key := strings.ToLower(col)
+			tags[key] = append(tags[key], colTag)
+		}
+	}
+	return tags
+}
+
 // ============================================================================
 // DAO 代码生成
 // ============================================================================
@@ -183,7 +405,9 @@ func (c *CodeGenerator) GenerateDAO(schema *Schema, methods []string) string {
 
 	// 导入
 	sb.WriteString("import (\n")
-	sb.WriteString("\t\"gorm.io/gorm\"\n")
+	for _, imp := range c.daoImports(schema, methods) {
+		sb.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
+	}
 	sb.WriteString(")\n\n")
 
 	// DAO 结构体
@@ -198,92 +422,220 @@ func (c *CodeGenerator) GenerateDAO(schema *Schema, methods []string) string {
 	sb.WriteString(fmt.Sprintf("\treturn &%s{db: db}\n", daoName))
 	sb.WriteString("}\n\n")
 
+	// 接口,供服务层依赖和 mock 替换
+	if c.options.Mock {
+		c.writeDAOInterface(&sb, schema, methods)
+	}
+
 	// 生成方法
 	for _, method := range methods {
-		switch method {
-		case "Create":
-			c.writeCreateMethod(&sb, schema, daoName)
-		case "Update":
-			c.writeUpdateMethod(&sb, schema, daoName)
-		case "Delete":
-			c.writeDeleteMethod(&sb, schema, daoName)
-		case "FindByID":
-			c.writeFindByIDMethod(&sb, schema, daoName)
-		case "FindAll":
-			c.writeFindAllMethod(&sb, schema, daoName)
-		}
+		c.writeDAOMethod(&sb, schema, daoName, method)
 	}
 
 	return sb.String()
 }
 
-func (c *CodeGenerator) writeCreateMethod(sb *strings.Builder, schema *Schema, daoName string) {
-	sb.WriteString(fmt.Sprintf("// Create 创建记录\n"))
-	sb.WriteString(fmt.Sprintf("func (d *%s) Create(entity *%s) error {\n", daoName, schema.Name))
-	sb.WriteString("\treturn d.db.Create(entity).Error\n")
-	sb.WriteString("}\n\n")
+// GenerateDAOMethods 分别生成每个 DAO 方法的代码,供自定义 dao 模板的 Methods 使用
+func (c *CodeGenerator) GenerateDAOMethods(schema *Schema, methods []string) []string {
+	daoName := schema.Name + "DAO"
+	result := make([]string, 0, len(methods))
+	for _, method := range methods {
+		var sb strings.Builder
+		c.writeDAOMethod(&sb, schema, daoName, method)
+		if sb.Len() > 0 {
+			result = append(result, sb.String())
+		}
+	}
+	return result
 }
 
-func (c *CodeGenerator) writeUpdateMethod(sb *strings.Builder, schema *Schema, daoName string) {
-	sb.WriteString(fmt.Sprintf("// Update 更新记录\n"))
-	sb.WriteString(fmt.Sprintf("func (d *%s) Update(entity *%s) error {\n", daoName, schema.Name))
-	sb.WriteString("\treturn d.db.Save(entity).Error\n")
+// writeDAOMethod 按方法名生成 DAO 方法及其 WithTx 变体,未知方法名忽略
+// 方法本身使用 DAO 的 db 调用 WithTx 变体,查询逻辑只在 WithTx 变体中生成一份
+func (c *CodeGenerator) writeDAOMethod(sb *strings.Builder, schema *Schema, daoName, method string) {
+	sigs := daoMethodSigs(schema, []string{method}, "")
+	if len(sigs) == 0 {
+		return
+	}
+	sig := sigs[0]
+	txSig := withTxSig(sig)
+
+	var doc string
+	var body func(db string) string
+	switch method {
+	case "Create":
+		doc, body = "创建记录", func(db string) string {
+			return fmt.Sprintf("\treturn %s.Create(entity).Error\n", db)
+		}
+	case "Update":
+		doc, body = "更新记录", func(db string) string {
+			return fmt.Sprintf("\treturn %s.Save(entity).Error\n", db)
+		}
+	case "Delete":
+		doc, body = "删除记录", func(db string) string {
+			return fmt.Sprintf("\treturn %s.Delete(&%s{}, id).Error\n", db, schema.Name)
+		}
+	case "FindByID":
+		doc, body = "根据 ID 查找记录", func(db string) string {
+			return fmt.Sprintf("\tvar entity %s\n", schema.Name) +
+				fmt.Sprintf("\tif err := %s.First(&entity, id).Error; err != nil {\n", db) +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\treturn &entity, nil\n"
+		}
+	case "FindAll":
+		doc, body = "查找所有记录", func(db string) string {
+			return fmt.Sprintf("\tvar entities []*%s\n", schema.Name) +
+				fmt.Sprintf("\tif err := %s.Find(&entities).Error; err != nil {\n", db) +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\treturn entities, nil\n"
+		}
+	case "InsertBatch":
+		doc, body = c.insertBatchBody(schema)
+	case "Upsert":
+		doc, body = c.upsertBody(schema)
+	}
+
+	// 方法本身
+	sb.WriteString(fmt.Sprintf("// %s %s\n", sig.Name, doc))
+	sb.WriteString(fmt.Sprintf("func (d *%s) %s(%s) %s {\n", daoName, sig.Name, sig.Params, sig.Results))
+	sb.WriteString(fmt.Sprintf("\treturn d.%s(%s)\n", txSig.Name, withTxArgs(sig, "d.db")))
+	sb.WriteString("}\n\n")
+
+	// WithTx 变体
+	sb.WriteString(fmt.Sprintf("// %s 在指定的 *gorm.DB (通常为事务) 上执行 %s\n", txSig.Name, sig.Name))
+	sb.WriteString(fmt.Sprintf("func (d *%s) %s(%s) %s {\n", daoName, txSig.Name, txSig.Params, txSig.Results))
+	db := "tx"
+	if c.queryTimeout > 0 {
+		// 不接收 ctx 的方法以 tx 上已绑定的 context 为父 context
+		parent := "tx.Statement.Context"
+		if sigUsesContext(sig) {
+			parent = "ctx"
+		}
+		sb.WriteString(fmt.Sprintf("\tctx, cancel := context.WithTimeout(%s, %s)\n", parent, durationLiteral(c.queryTimeout)))
+		sb.WriteString("\tdefer cancel()\n")
+		db = "tx.WithContext(ctx)"
+	} else if sigUsesContext(sig) {
+		db = "tx.WithContext(ctx)"
+	}
+	sb.WriteString(body(db))
 	sb.WriteString("}\n\n")
 }
 
-func (c *CodeGenerator) writeDeleteMethod(sb *strings.Builder, schema *Schema, daoName string) {
-	// 查找主键字段
-	var pkField *Field
-	for i := range schema.Fields {
-		if schema.Fields[i].Column.PrimaryKey {
-			pkField = &schema.Fields[i]
-			break
+// daoImports 返回 DAO 代码需要导入的包
+// InsertBatch、Upsert 接收 context,Upsert 使用 gorm 的 OnConflict 子句;
+// 设置了查询超时时所有方法都通过 context.WithTimeout 限制执行时间
+func (c *CodeGenerator) daoImports(schema *Schema, methods []string) []string {
+	sigs := daoMethodSigs(schema, methods, "")
+	withContext := sigsUseContext(sigs) || (c.queryTimeout > 0 && len(sigs) > 0)
+
+	var withClause bool
+	for _, sig := range sigs {
+		if sig.Name == "Upsert" {
+			withClause = true
 		}
 	}
 
-	pkType := "uint64"
-	if pkField != nil {
-		pkType = pkField.Type
+	var imports []string
+	if withContext {
+		imports = append(imports, "context")
 	}
+	if c.queryTimeout > 0 && len(sigs) > 0 {
+		imports = append(imports, "time")
+	}
+	imports = append(imports, "gorm.io/gorm")
+	if withClause {
+		imports = append(imports, "gorm.io/gorm/clause")
+	}
+	return imports
+}
 
-	sb.WriteString(fmt.Sprintf("// Delete 删除记录\n"))
-	sb.WriteString(fmt.Sprintf("func (d *%s) Delete(id %s) error {\n", daoName, pkType))
-	sb.WriteString(fmt.Sprintf("\treturn d.db.Delete(&%s{}, id).Error\n", schema.Name))
-	sb.WriteString("}\n\n")
+// durationLiteral 返回时长的 Go 表达式,如 5*time.Second
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d*%s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
 }
 
-func (c *CodeGenerator) writeFindByIDMethod(sb *strings.Builder, schema *Schema, daoName string) {
-	// 查找主键字段
-	var pkField *Field
-	for i := range schema.Fields {
-		if schema.Fields[i].Column.PrimaryKey {
-			pkField = &schema.Fields[i]
-			break
+// primaryKeyColumns 返回主键列名
+func primaryKeyColumns(schema *Schema) []string {
+	var columns []string
+	for _, field := range schema.Fields {
+		if field.Column.PrimaryKey {
+			columns = append(columns, field.Column.Name)
 		}
 	}
+	return columns
+}
 
-	pkType := "uint64"
-	if pkField != nil {
-		pkType = pkField.Type
+// insertBatchSize 返回 InsertBatch 每条 INSERT 语句的行数
+// 未设置 ReverseOptions.BatchSize 时保证行数乘以列数不超过方言的绑定参数上限
+func (c *CodeGenerator) insertBatchSize(schema *Schema) int {
+	if c.options.BatchSize > 0 {
+		return c.options.BatchSize
 	}
 
-	sb.WriteString(fmt.Sprintf("// FindByID 根据 ID 查找记录\n"))
-	sb.WriteString(fmt.Sprintf("func (d *%s) FindByID(id %s) (*%s, error) {\n", daoName, pkType, schema.Name))
-	sb.WriteString(fmt.Sprintf("\tvar entity %s\n", schema.Name))
-	sb.WriteString("\tif err := d.db.First(&entity, id).Error; err != nil {\n")
-	sb.WriteString("\t\treturn nil, err\n")
-	sb.WriteString("\t}\n")
-	sb.WriteString("\treturn &entity, nil\n")
-	sb.WriteString("}\n\n")
+	size := DefaultMaxBatchSize
+	if len(schema.Fields) > 0 {
+		size = min(size, maxBindParams(c.dialect)/len(schema.Fields))
+	}
+	return max(size, 1)
 }
 
-func (c *CodeGenerator) writeFindAllMethod(sb *strings.Builder, schema *Schema, daoName string) {
-	sb.WriteString(fmt.Sprintf("// FindAll 查找所有记录\n"))
-	sb.WriteString(fmt.Sprintf("func (d *%s) FindAll() ([]*%s, error) {\n", daoName, schema.Name))
-	sb.WriteString(fmt.Sprintf("\tvar entities []*%s\n", schema.Name))
-	sb.WriteString("\tif err := d.db.Find(&entities).Error; err != nil {\n")
-	sb.WriteString("\t\treturn nil, err\n")
-	sb.WriteString("\t}\n")
-	sb.WriteString("\treturn entities, nil\n")
-	sb.WriteString("}\n\n")
+// insertBatchBody 返回 InsertBatch 的说明和方法体
+func (c *CodeGenerator) insertBatchBody(schema *Schema) (string, func(db string) string) {
+	batchSize := c.insertBatchSize(schema)
+
+	doc := fmt.Sprintf("批量插入记录,每条 INSERT 语句最多 %d 行", batchSize)
+	return doc, func(db string) string {
+		return "\tif len(entities) == 0 {\n" +
+			"\t\treturn nil\n" +
+			"\t}\n" +
+			fmt.Sprintf("\treturn %s.CreateInBatches(entities, %d).Error\n", db, batchSize)
+	}
+}
+
+// upsertBody 返回主键冲突时更新其余列的 Upsert 说明和方法体,调用方保证表有主键
+// 冲突子句由 GORM 按方言生成:PostgreSQL/SQLite 为 ON CONFLICT ... DO UPDATE,
+// MySQL 为 ON DUPLICATE KEY UPDATE;所有列都是主键时冲突后不做任何修改
+func (c *CodeGenerator) upsertBody(schema *Schema) (string, func(db string) string) {
+	pkColumns := primaryKeyColumns(schema)
+
+	var updateColumns []string
+	for _, field := range schema.Fields {
+		if !field.Column.PrimaryKey {
+			updateColumns = append(updateColumns, fmt.Sprintf("%q", field.Column.Name))
+		}
+	}
+
+	conflictColumns := make([]string, 0, len(pkColumns))
+	for _, col := range pkColumns {
+		conflictColumns = append(conflictColumns, fmt.Sprintf("{Name: %q}", col))
+	}
+
+	return "插入记录,主键冲突时更新其余列", func(db string) string {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("\treturn %s.Clauses(clause.OnConflict{\n", db))
+		sb.WriteString(fmt.Sprintf("\t\tColumns: []clause.Column{%s},\n", strings.Join(conflictColumns, ", ")))
+		if len(updateColumns) > 0 {
+			sb.WriteString(fmt.Sprintf("\t\tDoUpdates: clause.AssignmentColumns([]string{%s}),\n", strings.Join(updateColumns, ", ")))
+		} else {
+			sb.WriteString("\t\tDoNothing: true,\n")
+		}
+		sb.WriteString("\t}).Create(entity).Error\n")
+		return sb.String()
+	}
 }
@@ -37,8 +37,10 @@ const (
 	TagYaml
 	// TagValidate 生成 validate Tag
 	TagValidate
+	// TagBinding 生成 gin 校验使用的 binding Tag (required, max=N)
+	TagBinding
 	// TagAll 生成所有 Tag
-	TagAll = TagGorm | TagJson | TagXml | TagYaml | TagValidate
+	TagAll = TagGorm | TagJson | TagXml | TagYaml | TagValidate | TagBinding
 	// TagDefault 默认生成 gorm 和 json Tag
 	TagDefault = TagGorm | TagJson
 )
@@ -104,6 +106,34 @@ const (
 	DefaultCreatedAtColumn = "created_at"
 	// DefaultUpdatedAtColumn 默认更新时间列名
 	DefaultUpdatedAtColumn = "updated_at"
+	// DefaultPostgresSchema PostgreSQL 默认 schema
+	DefaultPostgresSchema = "public"
+	// DefaultDecimalType DECIMAL/NUMERIC 列默认映射的 Go 类型,使用 string 避免浮点精度丢失
+	DefaultDecimalType = "string"
+)
+
+// ============================================================================
+// 精确数值类型 (Decimal Types)
+// ============================================================================
+
+const (
+	// DecimalTypeShopspring shopspring/decimal 的类型名,用于 Config.DecimalType
+	DecimalTypeShopspring = "decimal.Decimal"
+	// DecimalImportShopspring shopspring/decimal 的导入路径
+	DecimalImportShopspring = "github.com/shopspring/decimal"
+)
+
+// ============================================================================
+// 模板名称 (Template Names)
+// ============================================================================
+
+const (
+	// TemplateModel 模型结构体模板名,注册同名模板可覆盖内置的结构体生成
+	TemplateModel = "model"
+	// TemplateDAO DAO 模板名,注册同名模板可覆盖内置的 DAO 生成
+	TemplateDAO = "dao"
+	// TemplateFileExt Config.TemplateDir 中被加载的模板文件扩展名
+	TemplateFileExt = ".tmpl"
 )
 
 // ============================================================================
@@ -132,3 +162,37 @@ const (
 	// GormTagComment 注释
 	GormTagComment = "comment"
 )
+
+// ============================================================================
+// Mock 生成 (Mock Generation)
+// ============================================================================
+
+const (
+	// MockPackage 生成的 mock 代码的包名
+	// mock 文件应放在 DAO 所在目录下的 mocks/ 子目录中
+	MockPackage = "mocks"
+)
+
+// ============================================================================
+// 仓储聚合 (Repository Set)
+// ============================================================================
+
+const (
+	// RepositoriesFileName GenerateToDir 生成的仓储聚合文件名
+	RepositoriesFileName = "repositories.go"
+	// DAOFileSuffix GenerateToDir 生成的 DAO 文件名后缀,如 users_dao.go
+	DAOFileSuffix = "_dao.go"
+)
+
+// ============================================================================
+// 生成标记 (Generated Markers)
+// ============================================================================
+
+const (
+	// GeneratedFileHeader 写入文件的生成标记,位于文件第一行
+	// 符合 Go 的生成代码约定,覆盖已有文件前检查该标记,没有标记的文件视为手工修改
+	GeneratedFileHeader = "// Code generated by sqlgen. DO NOT EDIT."
+	// KeepMarker 保留块标记
+	// 成对出现的两行标记之间的代码在重新生成时原样保留,追加到新文件末尾
+	KeepMarker = "// +sqlgen:keep"
+)
@@ -152,6 +152,11 @@ func (g *Generator) filterFields(fields []FieldInfo, skipAutoIncrement bool) []F
 			continue
 		}
 
+		// 跳过 Config.Columns 过滤的列
+		if !g.config.Columns.Allows(field.ColumnName) {
+			continue
+		}
+
 		fieldNameLower := strings.ToLower(field.Name)
 		columnNameLower := strings.ToLower(field.ColumnName)
 
@@ -0,0 +1,319 @@
+package sqlgen
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const daoBatchTestDDL = `
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	email TEXT
+);`
+
+// daoBatchTestMain 在 SQLite 内存库上调用生成的 InsertBatch 和 Upsert
+const daoBatchTestMain = `package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/rei0721/go-scaffold/pkg/sqlgen/%s/models"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	ctx := context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		fail("open: %%v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		fail("migrate: %%v", err)
+	}
+	dao := models.NewUserDAO(db)
+
+	users := make([]*models.User, 25)
+	for i := range users {
+		users[i] = &models.User{Name: fmt.Sprintf("user%%d", i)}
+	}
+	if err := dao.InsertBatch(ctx, users); err != nil {
+		fail("InsertBatch: %%v", err)
+	}
+	if err := dao.InsertBatch(ctx, nil); err != nil {
+		fail("InsertBatch(nil): %%v", err)
+	}
+
+	var count int64
+	db.Model(&models.User{}).Count(&count)
+	if count != 25 {
+		fail("count after InsertBatch = %%d, want 25", count)
+	}
+
+	if err := dao.Upsert(ctx, &models.User{Id: users[0].Id, Name: "renamed"}); err != nil {
+		fail("Upsert existing: %%v", err)
+	}
+	if err := dao.Upsert(ctx, &models.User{Name: "new"}); err != nil {
+		fail("Upsert new: %%v", err)
+	}
+
+	var renamed models.User
+	if err := db.First(&renamed, users[0].Id).Error; err != nil {
+		fail("find renamed: %%v", err)
+	}
+	if renamed.Name != "renamed" {
+		fail("name after Upsert = %%q, want renamed", renamed.Name)
+	}
+
+	db.Model(&models.User{}).Count(&count)
+	if count != 26 {
+		fail("count after Upsert = %%d, want 26", count)
+	}
+}
+`
+
+// newDAOBatchBuilder 创建生成 InsertBatch 和 Upsert 的构建器
+func newDAOBatchBuilder(dialect Dialect) *ReverseBuilder {
+	return New(&Config{Dialect: dialect}).
+		ParseSQL(daoBatchTestDDL).
+		Package("models").
+		Tags(TagGorm).
+		DAOMethods("InsertBatch", "Upsert")
+}
+
+// TestGenerateDAO_BatchMethods 测试批量插入和 Upsert 方法的代码
+func TestGenerateDAO_BatchMethods(t *testing.T) {
+	_, daoCode, err := newDAOBatchBuilder(SQLite).GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"\t\"context\"\n",
+		"\t\"gorm.io/gorm/clause\"\n",
+		"func (d *UserDAO) InsertBatch(ctx context.Context, entities []*User) error",
+		// SQLite 绑定参数上限 999,3 列每批 333 行
+		"CreateInBatches(entities, 333)",
+		"func (d *UserDAO) Upsert(ctx context.Context, entity *User) error",
+		"Columns: []clause.Column{{Name: \"id\"}},",
+		"DoUpdates: clause.AssignmentColumns([]string{\"name\", \"email\"}),",
+	} {
+		if !strings.Contains(daoCode, want) {
+			t.Errorf("DAO code missing %q, got:\n%s", want, daoCode)
+		}
+	}
+
+	_, daoCode, err = newDAOBatchBuilder(MySQL).BatchSize(50).GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	if !strings.Contains(daoCode, "CreateInBatches(entities, 50)") {
+		t.Errorf("BatchSize not applied, got:\n%s", daoCode)
+	}
+}
+
+// TestGenerateDAO_UpsertWithoutPrimaryKey 测试没有主键的表不生成 Upsert
+func TestGenerateDAO_UpsertWithoutPrimaryKey(t *testing.T) {
+	_, daoCode, err := New(&Config{Dialect: SQLite}).
+		ParseSQL("CREATE TABLE logs (message TEXT NOT NULL);").
+		DAOMethods("InsertBatch", "Upsert").
+		GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+
+	if strings.Contains(daoCode, "Upsert") || strings.Contains(daoCode, "gorm/clause") {
+		t.Errorf("Upsert should be skipped without primary key, got:\n%s", daoCode)
+	}
+	if !strings.Contains(daoCode, "InsertBatch") {
+		t.Errorf("InsertBatch should still be generated, got:\n%s", daoCode)
+	}
+}
+
+// TestGenerateDAO_BatchSQLite 在 SQLite 上运行生成的 InsertBatch 和 Upsert
+func TestGenerateDAO_BatchSQLite(t *testing.T) {
+	// 每批 10 行,25 行需要 3 条 INSERT
+	modelCode, daoCode, err := newDAOBatchBuilder(SQLite).BatchSize(10).GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+
+	runDAOOnSQLite(t, modelCode, daoCode, daoBatchTestMain)
+}
+
+// daoTxTestMain 在 SQLite 内存库上调用带查询超时的 DAO 方法及其 WithTx 变体
+// 注册的回调检查每条语句的 context 都带有不超过超时时间的 deadline
+const daoTxTestMain = `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/rei0721/go-scaffold/pkg/sqlgen/%s/models"
+)
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func main() {
+	ctx := context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		fail("open: %%v", err)
+	}
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		fail("migrate: %%v", err)
+	}
+
+	checkDeadline := func(tx *gorm.DB) {
+		deadline, ok := tx.Statement.Context.Deadline()
+		if !ok || time.Until(deadline) > 2*time.Second {
+			tx.AddError(errors.New("query context should have a 2s deadline"))
+		}
+	}
+	db.Callback().Create().Before("gorm:create").Register("test:deadline", checkDeadline)
+	db.Callback().Query().Before("gorm:query").Register("test:deadline", checkDeadline)
+
+	dao := models.NewUserDAO(db)
+
+	// 回滚的事务中写入的记录不可见,说明 WithTx 使用了传入的 tx
+	tx := db.Begin()
+	if err := dao.CreateWithTx(tx, &models.User{Name: "rolled back"}); err != nil {
+		fail("CreateWithTx: %%v", err)
+	}
+	if err := dao.InsertBatchWithTx(ctx, tx, []*models.User{{Name: "a"}, {Name: "b"}}); err != nil {
+		fail("InsertBatchWithTx: %%v", err)
+	}
+	if all, err := dao.FindAllWithTx(tx); err != nil || len(all) != 3 {
+		fail("FindAllWithTx inside tx = %%d, %%v, want 3", len(all), err)
+	}
+	tx.Rollback()
+
+	if all, err := dao.FindAll(); err != nil || len(all) != 0 {
+		fail("FindAll after rollback = %%d, %%v, want 0", len(all), err)
+	}
+
+	if err := dao.Create(&models.User{Name: "committed"}); err != nil {
+		fail("Create: %%v", err)
+	}
+	if all, err := dao.FindAll(); err != nil || len(all) != 1 {
+		fail("FindAll after Create = %%d, %%v, want 1", len(all), err)
+	}
+
+	// 已取消的 ctx 传递到查询
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := dao.InsertBatch(canceled, []*models.User{{Name: "c"}}); err == nil {
+		fail("InsertBatch with canceled context should fail")
+	}
+}
+`
+
+// TestGenerateDAO_QueryTimeoutAndTx 测试生成的方法应用查询超时,WithTx 变体使用传入的事务
+func TestGenerateDAO_QueryTimeoutAndTx(t *testing.T) {
+	builder := func(timeout time.Duration) *ReverseBuilder {
+		return New(&Config{Dialect: SQLite, QueryTimeout: timeout}).
+			ParseSQL(daoBatchTestDDL).
+			Package("models").
+			Tags(TagGorm).
+			DAOMethods("Create", "FindAll", "InsertBatch")
+	}
+
+	modelCode, daoCode, err := builder(2 * time.Second).GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	for _, want := range []string{
+		"\t\"time\"\n",
+		"func (d *UserDAO) CreateWithTx(tx *gorm.DB, entity *User) error",
+		"func (d *UserDAO) InsertBatchWithTx(ctx context.Context, tx *gorm.DB, entities []*User) error",
+		"return d.InsertBatchWithTx(ctx, d.db, entities)",
+		"context.WithTimeout(tx.Statement.Context, 2*time.Second)",
+		"context.WithTimeout(ctx, 2*time.Second)",
+	} {
+		if !strings.Contains(daoCode, want) {
+			t.Errorf("DAO code missing %q, got:\n%s", want, daoCode)
+		}
+	}
+
+	// 未设置超时时不包装 context
+	_, plain, err := builder(0).GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	if strings.Contains(plain, "WithTimeout") || strings.Contains(plain, "\"time\"") {
+		t.Errorf("timeout should not be applied by default, got:\n%s", plain)
+	}
+	if !strings.Contains(plain, "return tx.Create(entity).Error") {
+		t.Errorf("CreateWithTx should use tx directly, got:\n%s", plain)
+	}
+
+	runDAOOnSQLite(t, modelCode, daoCode, daoTxTestMain)
+}
+
+// runDAOOnSQLite 生成的模型和 DAO 写入临时包,运行 mainTmpl 生成的程序
+// mainTmpl 中的 %s 替换为临时包路径;生成的包放在本模块的 testdata 下,以便使用模块已有的 gorm 和 sqlite 依赖
+func runDAOOnSQLite(t *testing.T, modelCode, daoCode, mainTmpl string) {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		t.Fatalf("failed to create testdata: %v", err)
+	}
+	dir, err := os.MkdirTemp("testdata", "daobatch")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+		os.Remove("testdata")
+	})
+
+	files := map[string]string{
+		"models/users.go":     modelCode,
+		"models/users_dao.go": daoCode,
+		"main.go":             fmt.Sprintf(mainTmpl, filepath.ToSlash(dir)),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "run", "./"+filepath.ToSlash(dir))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated DAO failed on SQLite: %v\n%s\n--- dao ---\n%s", err, out, daoCode)
+	}
+}
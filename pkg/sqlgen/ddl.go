@@ -2,6 +2,9 @@ package sqlgen
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 )
 
@@ -20,7 +23,7 @@ func (g *Generator) Table(model interface{}) (string, error) {
 		return "", ErrInvalidModel
 	}
 
-	return g.buildCreateTable(g.ctx.TableName, fields), nil
+	return g.buildCreateTable(g.ctx.TableName, fields, nil, false), nil
 }
 
 // Drop 生成 DROP TABLE 语句
@@ -48,19 +51,64 @@ func (g *Generator) Migrate(model interface{}) *MigrateBuilder {
 // CREATE TABLE 构建
 // ============================================================================
 
-func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) string {
+// buildCreateTable 构建 CREATE TABLE 语句
+// MySQL 的索引写在表定义内,其他方言在表之后生成独立的 CREATE INDEX 语句
+// 参数:
+//
+//	tableName: 表名
+//	fields: 字段列表,关联字段会被跳过
+//	foreignKeys: 外键约束,可以为 nil
+//	ifNotExists: 是否生成幂等语句 (IF NOT EXISTS)
+func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo, foreignKeys []ForeignKey, ifNotExists bool) string {
 	var sb strings.Builder
 	quotedTable := g.dialect.Quote(tableName)
-
-	sb.WriteString("CREATE TABLE ")
+	dialect := g.dialect.Name()
+
+	switch {
+	case ifNotExists && dialect == SQLServer:
+		sb.WriteString(fmt.Sprintf("IF OBJECT_ID(N'%s', N'U') IS NULL\n", escapeString(tableName)))
+		sb.WriteString("CREATE TABLE ")
+	case ifNotExists:
+		sb.WriteString("CREATE TABLE IF NOT EXISTS ")
+	default:
+		sb.WriteString("CREATE TABLE ")
+	}
 	sb.WriteString(quotedTable)
 	sb.WriteString(" (\n")
 
 	var columnDefs []string
 	var primaryKeys []string
 	var indexes []string
+	var indexStmts []string
+
+	// addIndex 按方言添加索引定义
+	addIndex := func(unique bool, indexName, column string) {
+		if dialect == MySQL {
+			keyword := "INDEX"
+			if unique {
+				keyword = "UNIQUE INDEX"
+			}
+			indexes = append(indexes, fmt.Sprintf("%s %s (%s)",
+				keyword, g.dialect.Quote(indexName), g.dialect.Quote(column)))
+			return
+		}
+
+		stmt := "CREATE INDEX "
+		if unique {
+			stmt = "CREATE UNIQUE INDEX "
+		}
+		if ifNotExists && dialect != SQLServer {
+			stmt += "IF NOT EXISTS "
+		}
+		indexStmts = append(indexStmts, fmt.Sprintf("%s%s ON %s (%s);",
+			stmt, g.dialect.Quote(indexName), quotedTable, g.dialect.Quote(column)))
+	}
 
 	for _, field := range fields {
+		if field.RelationType != nil {
+			continue
+		}
+
 		colDef := g.buildColumnDef(field)
 		columnDefs = append(columnDefs, "  "+colDef)
 
@@ -73,8 +121,7 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 			if indexName == "" || indexName == "true" {
 				indexName = "idx_" + tableName + "_" + field.ColumnName
 			}
-			indexes = append(indexes, fmt.Sprintf("INDEX %s (%s)",
-				g.dialect.Quote(indexName), g.dialect.Quote(field.ColumnName)))
+			addIndex(false, indexName, field.ColumnName)
 		}
 
 		if field.Tag.UniqueIndex != "" {
@@ -82,8 +129,7 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 			if indexName == "" || indexName == "true" {
 				indexName = "uk_" + tableName + "_" + field.ColumnName
 			}
-			indexes = append(indexes, fmt.Sprintf("UNIQUE INDEX %s (%s)",
-				g.dialect.Quote(indexName), g.dialect.Quote(field.ColumnName)))
+			addIndex(true, indexName, field.ColumnName)
 		}
 	}
 
@@ -102,6 +148,12 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 		sb.WriteString(idx)
 	}
 
+	// 添加外键约束
+	for _, fk := range foreignKeys {
+		sb.WriteString(",\n  ")
+		sb.WriteString(g.buildForeignKey(fk))
+	}
+
 	sb.WriteString("\n)")
 
 	// 添加引擎子句 (MySQL)
@@ -112,9 +164,28 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 
 	sb.WriteString(";")
 
+	for _, stmt := range indexStmts {
+		sb.WriteString("\n")
+		sb.WriteString(stmt)
+	}
+
 	return sb.String()
 }
 
+// buildForeignKey 构建外键约束子句
+func (g *Generator) buildForeignKey(fk ForeignKey) string {
+	quote := func(columns []string) string {
+		quoted := make([]string, len(columns))
+		for i, col := range columns {
+			quoted[i] = g.dialect.Quote(col)
+		}
+		return strings.Join(quoted, ", ")
+	}
+
+	return fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		g.dialect.Quote(fk.Name), quote(fk.Columns), g.dialect.Quote(fk.RefTable), quote(fk.RefColumns))
+}
+
 // buildColumnDef 构建列定义
 func (g *Generator) buildColumnDef(field FieldInfo) string {
 	var parts []string
@@ -159,6 +230,274 @@ func (g *Generator) buildDropTable(tableName string) string {
 	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", g.dialect.Quote(tableName))
 }
 
+// ============================================================================
+// 多表 DDL 构建
+// ============================================================================
+
+// ddlTable 多表 DDL 生成中的单个表
+type ddlTable struct {
+	model       reflect.Type
+	name        string
+	fields      []FieldInfo
+	foreignKeys []ForeignKey
+	// deps 依赖的表 (被本表外键引用的表)
+	deps []string
+}
+
+// DDL 为多个模型生成建表语句,按外键依赖排序
+// 外键从 GORM 关联字段推导: belongs-to (外键在本模型) 和 has-one/has-many (外键在目标模型),
+// 支持 foreignKey/references tag,默认外键为 字段名+ID 或 本模型名+ID,默认引用主键
+// 参数:
+//
+//	opts: 生成选项,可以为 nil
+//	models: 模型列表,如 &User{}, &Post{}
+//
+// 返回:
+//
+//	*DDLResult: 建表语句和回滚语句
+//	error: 模型无效或外键存在循环依赖时返回错误
+//
+// 使用示例:
+//
+//	result, err := gen.DDL(&sqlgen.DDLOptions{IfNotExists: true, GenerateDown: true}, &User{}, &Post{})
+//	// result.Up:   CREATE TABLE IF NOT EXISTS `users` ...; CREATE TABLE IF NOT EXISTS `posts` ...
+//	// result.Down: DROP TABLE IF EXISTS `posts`; DROP TABLE IF EXISTS `users`;
+func (g *Generator) DDL(opts *DDLOptions, models ...interface{}) (*DDLResult, error) {
+	if opts == nil {
+		opts = &DDLOptions{}
+	}
+	tables, err := g.describeModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := sortDDLTables(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DDLResult{}
+	var up, down []string
+	for _, t := range ordered {
+		result.Tables = append(result.Tables, t.name)
+		up = append(up, g.buildCreateTable(t.name, t.fields, t.foreignKeys, opts.IfNotExists))
+	}
+	if opts.GenerateDown {
+		for i := len(ordered) - 1; i >= 0; i-- {
+			down = append(down, g.buildDropTable(ordered[i].name))
+		}
+	}
+
+	result.Up = strings.Join(up, "\n\n")
+	result.Down = strings.Join(down, "\n")
+	return result, nil
+}
+
+// GenerateDDLToFile 生成多表 DDL 并写入文件
+// 回滚语句 (启用 GenerateDown 时) 写入同目录下的 .down.sql 文件:
+// 001_init.up.sql 对应 001_init.down.sql,schema.sql 对应 schema.down.sql
+func (g *Generator) GenerateDDLToFile(path string, opts *DDLOptions, models ...interface{}) error {
+	result, err := g.DDL(opts, models...)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return WrapError(ErrCodeFileIO, "failed to create directory", err)
+	}
+	if err := os.WriteFile(path, []byte(result.Up+"\n"), 0644); err != nil {
+		return WrapError(ErrCodeFileIO, "failed to write file", err)
+	}
+
+	if result.Down == "" {
+		return nil
+	}
+	if err := os.WriteFile(downMigrationPath(path), []byte(result.Down+"\n"), 0644); err != nil {
+		return WrapError(ErrCodeFileIO, "failed to write file", err)
+	}
+	return nil
+}
+
+// downMigrationPath 返回回滚语句的文件路径
+func downMigrationPath(path string) string {
+	if strings.HasSuffix(path, ".up.sql") {
+		return strings.TrimSuffix(path, ".up.sql") + ".down.sql"
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".down" + ext
+}
+
+// describeModels 解析模型的表名和字段,并根据关联字段推导外键
+// DDL 和 SchemaFromModels 共用
+func (g *Generator) describeModels(models []interface{}) ([]*ddlTable, error) {
+	if len(models) == 0 {
+		return nil, ErrInvalidModel
+	}
+
+	tables := make([]*ddlTable, 0, len(models))
+	byType := make(map[reflect.Type]*ddlTable, len(models))
+	for _, model := range models {
+		ng := g.clone()
+		if err := ng.parseModel(model); err != nil {
+			return nil, err
+		}
+		fields := parseStructFields(ng.ctx.ModelType, ng.ctx.ModelValue, ng.dialect)
+		if len(fields) == 0 {
+			return nil, ErrInvalidModel
+		}
+
+		t := &ddlTable{model: ng.ctx.ModelType, name: ng.ctx.TableName, fields: fields}
+		tables = append(tables, t)
+		byType[t.model] = t
+	}
+
+	for _, t := range tables {
+		g.resolveForeignKeys(t, byType)
+	}
+	return tables, nil
+}
+
+// resolveForeignKeys 根据关联字段推导外键,添加到持有外键列的表上
+func (g *Generator) resolveForeignKeys(owner *ddlTable, byType map[reflect.Type]*ddlTable) {
+	for _, field := range owner.fields {
+		if field.RelationType == nil {
+			continue
+		}
+
+		target, inScope := byType[field.RelationType]
+		if !inScope {
+			// 目标模型不在本次生成范围内,仅用于解析列名
+			target = g.describeModel(field.RelationType)
+		}
+
+		// belongs-to: 外键列在本模型
+		if !field.RelationMany {
+			fkField := field.Tag.ForeignKey
+			if fkField == "" {
+				fkField = field.Name + "ID"
+			}
+			if fkCol, ok := columnOf(owner.fields, fkField); ok {
+				if refCol, ok := referencedColumn(target.fields, field.Tag.References); ok {
+					addForeignKey(owner, target.name, fkCol, refCol)
+					continue
+				}
+			}
+		}
+
+		// has-one / has-many: 外键列在目标模型,目标需要在本次生成范围内
+		if !inScope || target == owner {
+			continue
+		}
+		fkField := field.Tag.ForeignKey
+		if fkField == "" {
+			fkField = owner.model.Name() + "ID"
+		}
+		fkCol, found := columnOf(target.fields, fkField)
+		if !found {
+			continue
+		}
+		if refCol, found := referencedColumn(owner.fields, field.Tag.References); found {
+			addForeignKey(target, owner.name, fkCol, refCol)
+		}
+	}
+}
+
+// describeModel 解析不在生成范围内的模型的表名和字段
+func (g *Generator) describeModel(t reflect.Type) *ddlTable {
+	model := reflect.New(t).Interface()
+	return &ddlTable{
+		model:  t,
+		name:   g.getTableName(model, t),
+		fields: parseStructFields(t, reflect.Value{}, g.dialect),
+	}
+}
+
+// addForeignKey 为表添加外键,相同列的外键只添加一次
+func addForeignKey(t *ddlTable, refTable, column, refColumn string) {
+	for _, fk := range t.foreignKeys {
+		if len(fk.Columns) == 1 && fk.Columns[0] == column {
+			return
+		}
+	}
+
+	t.foreignKeys = append(t.foreignKeys, ForeignKey{
+		Name:       "fk_" + t.name + "_" + column,
+		Columns:    []string{column},
+		RefTable:   refTable,
+		RefColumns: []string{refColumn},
+	})
+	if refTable != t.name {
+		t.deps = append(t.deps, refTable)
+	}
+}
+
+// columnOf 返回 Go 字段对应的列名
+func columnOf(fields []FieldInfo, goName string) (string, bool) {
+	for _, f := range fields {
+		if f.RelationType == nil && f.Name == goName {
+			return f.ColumnName, true
+		}
+	}
+	return "", false
+}
+
+// referencedColumn 返回被引用的列名,goName 为空时使用主键
+func referencedColumn(fields []FieldInfo, goName string) (string, bool) {
+	if goName != "" {
+		return columnOf(fields, goName)
+	}
+	for _, f := range fields {
+		if f.RelationType == nil && f.Tag.PrimaryKey {
+			return f.ColumnName, true
+		}
+	}
+	return columnOf(fields, "ID")
+}
+
+// sortDDLTables 按外键依赖排序,被引用的表在前,无依赖关系的表保持原顺序
+func sortDDLTables(tables []*ddlTable) ([]*ddlTable, error) {
+	index := make(map[string]*ddlTable, len(tables))
+	for _, t := range tables {
+		index[t.name] = t
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(tables))
+	ordered := make([]*ddlTable, 0, len(tables))
+
+	var visit func(t *ddlTable) error
+	visit = func(t *ddlTable) error {
+		switch state[t.name] {
+		case visiting:
+			return NewError(ErrCodeGenerateFailed, "circular foreign key dependency on table "+t.name)
+		case visited:
+			return nil
+		}
+		state[t.name] = visiting
+		for _, dep := range t.deps {
+			if d, ok := index[dep]; ok {
+				if err := visit(d); err != nil {
+					return err
+				}
+			}
+		}
+		state[t.name] = visited
+		ordered = append(ordered, t)
+		return nil
+	}
+
+	for _, t := range tables {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
 // ============================================================================
 // MigrateBuilder 迁移构建器
 // ============================================================================
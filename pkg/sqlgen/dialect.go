@@ -472,6 +472,19 @@ func RegisterDialect(d Dialect, handler DialectHandler) {
 	dialects[d] = handler
 }
 
+// maxBindParams 返回方言单条语句允许的绑定参数上限
+// 未知方言按 SQLite 旧版本的 999 保守处理
+func maxBindParams(d Dialect) int {
+	switch d {
+	case MySQL, PostgreSQL:
+		return 65535
+	case SQLServer:
+		return 2100
+	default:
+		return 999
+	}
+}
+
 // ============================================================================
 // SQL 插值辅助函数
 // ============================================================================
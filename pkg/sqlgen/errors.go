@@ -54,6 +54,8 @@ const (
 	ErrCodeMissingCondition
 	// ErrCodeEmptyData 空数据
 	ErrCodeEmptyData
+	// ErrCodeHandEdited 目标文件没有生成标记,视为手工修改
+	ErrCodeHandEdited
 )
 
 // ============================================================================
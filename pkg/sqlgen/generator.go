@@ -17,6 +17,11 @@ type Generator struct {
 	dialect DialectHandler
 	ctx     *QueryContext
 	mu      sync.RWMutex
+
+	// templates 自定义模板注册表,clone 出的生成器共享同一份
+	templates *templateRegistry
+	// templateErr 加载 Config.TemplateDir 时的错误,在逆向生成时返回
+	templateErr error
 }
 
 // New 创建新的 SQL 生成器
@@ -26,13 +31,19 @@ func New(cfg *Config) *Generator {
 	}
 
 	g := &Generator{
-		config: cfg,
-		ctx:    &QueryContext{},
+		config:    cfg,
+		ctx:       &QueryContext{},
+		templates: newTemplateRegistry(),
 	}
 
 	// 设置方言处理器
 	g.dialect = getDialect(cfg.Dialect)
 
+	// 加载自定义模板目录
+	if cfg.TemplateDir != "" {
+		g.templateErr = g.templates.loadDir(cfg.TemplateDir)
+	}
+
 	return g
 }
 
@@ -43,9 +54,11 @@ func (g *Generator) clone() *Generator {
 
 	newCtx := *g.ctx
 	return &Generator{
-		config:  g.config,
-		dialect: g.dialect,
-		ctx:     &newCtx,
+		config:      g.config,
+		dialect:     g.dialect,
+		ctx:         &newCtx,
+		templates:   g.templates,
+		templateErr: g.templateErr,
 	}
 }
 
@@ -209,11 +222,19 @@ type Tabler interface {
 // ============================================================================
 
 // toSnakeCase 将字符串转换为蛇形命名
+// 连续大写的缩写词视为一个单词,与 GORM 的默认命名一致: UserID -> user_id, HTTPServer -> http_server
 func toSnakeCase(s string) string {
+	runes := []rune(s)
+	isUpper := func(r rune) bool { return r >= 'A' && r <= 'Z' }
+
 	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteByte('_')
+	for i, r := range runes {
+		if i > 0 && isUpper(r) {
+			prevUpper := isUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			if runes[i-1] != '_' && (!prevUpper || nextLower) {
+				result.WriteByte('_')
+			}
 		}
 		result.WriteRune(r)
 	}
@@ -242,6 +263,31 @@ func toPascalCase(s string) string {
 	return strings.Join(parts, "")
 }
 
+// toStructName 将表名转换为结构体名
+// 最后一个单词取单数形式,如 sys_users 转换为 SysUser
+func toStructName(table string) string {
+	parts := strings.Split(table, "_")
+	last := len(parts) - 1
+	parts[last] = singularize(parts[last])
+	return toPascalCase(strings.Join(parts, "_"))
+}
+
+// singularize 将英文复数单词转换为单数 (仅处理常见规则)
+func singularize(word string) string {
+	lower := strings.ToLower(word)
+	switch {
+	case len(lower) > 3 && strings.HasSuffix(lower, "ies"):
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return word[:len(word)-2]
+	case len(lower) > 1 && strings.HasSuffix(lower, "s") &&
+		!strings.HasSuffix(lower, "ss") && !strings.HasSuffix(lower, "us") && !strings.HasSuffix(lower, "is"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
 // toKebabCase 将字符串转换为短横线命名
 func toKebabCase(s string) string {
 	return strings.ReplaceAll(toSnakeCase(s), "_", "-")
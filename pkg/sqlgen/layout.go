@@ -0,0 +1,104 @@
+package sqlgen
+
+import (
+	"path"
+	"strings"
+)
+
+// ============================================================================
+// 分包目录布局
+// ============================================================================
+
+// tablePackageName 返回表单独成包时的包名
+// 取单数化的结构体名转小写,如 users -> user,order_items -> orderitem
+func tablePackageName(table string) string {
+	return strings.ToLower(toStructName(table))
+}
+
+// splitPackages 是否按 Config.PackagePerTable 或 Config.TableGroups 拆分包
+func (r *ReverseBuilder) splitPackages() bool {
+	if r.generator == nil {
+		return false
+	}
+	cfg := r.generator.config
+	return cfg.PackagePerTable || len(cfg.TableGroups) > 0
+}
+
+// tablePackage 返回表所在的包名和相对输出目录的子目录
+// 未拆分包的表使用 ReverseOptions.Package,子目录为空;
+// 拆分后子目录名与包名相同,TableGroups 优先于 PackagePerTable
+func (r *ReverseBuilder) tablePackage(schema *Schema) (pkg, subdir string) {
+	if r.generator != nil {
+		cfg := r.generator.config
+		for group, tables := range cfg.TableGroups {
+			for _, table := range tables {
+				if strings.EqualFold(table, schema.TableName) {
+					return group, group
+				}
+			}
+		}
+		if cfg.PackagePerTable {
+			name := tablePackageName(schema.TableName)
+			return name, name
+		}
+	}
+	return r.options.Package, ""
+}
+
+// packageImportPath 返回子目录的导入路径,未设置 Config.ImportPath 时为空
+func (r *ReverseBuilder) packageImportPath(subdir string) string {
+	if r.generator == nil || r.generator.config.ImportPath == "" {
+		return ""
+	}
+	return path.Join(r.generator.config.ImportPath, subdir)
+}
+
+// qualifyRelations 处理跨包的关联字段
+// belongs-to 目标在其他包时使用 pkg.Type 形式并导入目标包;
+// has-many 只保留同包的关联,反向引用会与 belongs-to 形成循环导入;
+// 未设置 Config.ImportPath 时跨包关联无法导入,一并移除
+func (r *ReverseBuilder) qualifyRelations(schema *Schema) {
+	if !r.splitPackages() || len(schema.Relations) == 0 {
+		return
+	}
+
+	ownPkg, _ := r.tablePackage(schema)
+	targets := make(map[string]*Schema, len(r.schemas))
+	for _, s := range r.schemas {
+		targets[s.Name] = s
+	}
+
+	relations := schema.Relations[:0]
+	for _, rel := range schema.Relations {
+		target, ok := targets[rel.Target]
+		if !ok {
+			relations = append(relations, rel)
+			continue
+		}
+
+		pkg, subdir := r.tablePackage(target)
+		if pkg == ownPkg {
+			relations = append(relations, rel)
+			continue
+		}
+
+		importPath := r.packageImportPath(subdir)
+		if rel.Kind == RelationHasMany || importPath == "" {
+			continue
+		}
+		rel.Target = pkg + "." + rel.Target
+		schema.Imports = appendUnique(schema.Imports, importPath)
+		relations = append(relations, rel)
+	}
+	schema.Relations = relations
+}
+
+// appendUnique 追加不重复的元素
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
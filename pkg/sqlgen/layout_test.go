@@ -0,0 +1,157 @@
+package sqlgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const layoutTestDDL = `
+CREATE TABLE users (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	name varchar(64) NOT NULL
+);
+
+CREATE TABLE orders (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	user_id bigint unsigned NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE order_items (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	order_id bigint unsigned NOT NULL,
+	amount int NOT NULL,
+	FOREIGN KEY (order_id) REFERENCES orders(id)
+);`
+
+// assertPackageFile 断言文件存在且生成标记之后的包声明正确
+func assertPackageFile(t *testing.T, path, pkg string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected %s to be generated: %v", path, err)
+	}
+	if !strings.HasPrefix(string(content), GeneratedFileHeader+"\n\npackage "+pkg+"\n") {
+		t.Errorf("%s should declare package %s, got:\n%s", path, pkg, content)
+	}
+	return string(content)
+}
+
+// TestGenerateToDir_PackagePerTable 测试每个表生成到以单数表名命名的子包
+func TestGenerateToDir_PackagePerTable(t *testing.T) {
+	dir := t.TempDir()
+	err := New(&Config{Dialect: MySQL, PackagePerTable: true}).
+		ParseSQL(layoutTestDDL).
+		Tags(TagGorm).
+		GenerateToDir(dir)
+	if err != nil {
+		t.Fatalf("GenerateToDir() failed: %v", err)
+	}
+
+	assertPackageFile(t, filepath.Join(dir, "user", "users.go"), "user")
+	assertPackageFile(t, filepath.Join(dir, "order", "orders.go"), "order")
+	assertPackageFile(t, filepath.Join(dir, "orderitem", "order_items.go"), "orderitem")
+
+	if _, err := os.Stat(filepath.Join(dir, "users.go")); err == nil {
+		t.Error("users.go should not be generated in the root directory")
+	}
+}
+
+// newLayoutGroupBuilder 创建按分组拆分包并生成关联字段和仓储聚合的构建器
+func newLayoutGroupBuilder() *ReverseBuilder {
+	cfg := &Config{
+		Dialect:           MySQL,
+		GenerateRelations: true,
+		TableGroups: map[string][]string{
+			"account": {"users"},
+			"sales":   {"orders", "order_items"},
+		},
+		ImportPath: "example.com/gen/models",
+		Target:     GenerateTarget{RepositorySet: true},
+	}
+	return New(cfg).
+		ParseSQL(layoutTestDDL).
+		Package("models").
+		Tags(TagGorm).
+		WithComments(false).
+		DAOMethods("Create", "FindByID")
+}
+
+// TestGenerateToDir_TableGroups 测试分组的表生成到同一个子包,跨包引用使用新的导入路径
+func TestGenerateToDir_TableGroups(t *testing.T) {
+	dir := t.TempDir()
+	if err := newLayoutGroupBuilder().GenerateToDir(filepath.Join(dir, "models")); err != nil {
+		t.Fatalf("GenerateToDir() failed: %v", err)
+	}
+	models := filepath.Join(dir, "models")
+
+	assertPackageFile(t, filepath.Join(models, "account", "users.go"), "account")
+	assertPackageFile(t, filepath.Join(models, "account", "users_dao.go"), "account")
+	assertPackageFile(t, filepath.Join(models, "sales", "order_items.go"), "sales")
+	orders := assertPackageFile(t, filepath.Join(models, "sales", "orders.go"), "sales")
+	repos := assertPackageFile(t, filepath.Join(models, RepositoriesFileName), "models")
+
+	// 跨包的 belongs-to 导入目标包,同包关联不加限定
+	for _, want := range []string{"\"example.com/gen/models/account\"", "User *account.User", "OrderItems []*OrderItem"} {
+		if !strings.Contains(orders, want) {
+			t.Errorf("orders.go missing %q, got:\n%s", want, orders)
+		}
+	}
+
+	for _, want := range []string{
+		"\"example.com/gen/models/account\"",
+		"\"example.com/gen/models/sales\"",
+		"\tUser *account.UserDAO\n",
+		"\t\tOrderItem: sales.NewOrderItemDAO(db),\n",
+	} {
+		if !strings.Contains(repos, want) {
+			t.Errorf("repositories.go missing %q, got:\n%s", want, repos)
+		}
+	}
+
+	// 跨包的 has-many 会形成循环导入,不生成
+	users, _ := os.ReadFile(filepath.Join(models, "account", "users.go"))
+	if strings.Contains(string(users), "sales") {
+		t.Errorf("users.go should not reference package sales, got:\n%s", users)
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	files := map[string]string{
+		"go.mod":           "module example.com/gen\n\ngo 1.21\n\nrequire gorm.io/gorm v0.0.0\n\nreplace gorm.io/gorm => ./gormstub\n",
+		"gormstub/go.mod":  "module gorm.io/gorm\n\ngo 1.21\n",
+		"gormstub/gorm.go": gormStub,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated packages do not compile: %v\n%s", err, out)
+	}
+}
+
+// TestGenerateRepositories_SplitRequiresImportPath 测试拆分包后未设置 ImportPath 时无法生成仓储聚合
+func TestGenerateRepositories_SplitRequiresImportPath(t *testing.T) {
+	_, err := New(&Config{Dialect: MySQL, PackagePerTable: true}).
+		ParseSQL(layoutTestDDL).
+		GenerateRepositories()
+	if err == nil {
+		t.Fatal("expected error without Config.ImportPath")
+	}
+}
@@ -0,0 +1,117 @@
+package sqlgen
+
+import (
+	"os"
+	"strings"
+)
+
+// ============================================================================
+// 生成标记与保留块
+// ============================================================================
+
+// force 是否覆盖没有生成标记的已有文件,见 Config.Force
+func (r *ReverseBuilder) force() bool {
+	return r.generator != nil && r.generator.config.Force
+}
+
+// writeGeneratedFile 写入生成的代码,返回是否实际写入
+// 代码前添加 GeneratedFileHeader;目标文件已存在时:
+//   - 没有生成标记且未开启 force 时拒绝覆盖,返回 ErrCodeHandEdited 错误
+//   - 已有文件中的保留块追加到新代码末尾
+func writeGeneratedFile(path, code string, force bool) (bool, error) {
+	code = withGeneratedHeader(code)
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if !force && !hasGeneratedHeader(string(existing)) {
+			return false, NewError(ErrCodeHandEdited,
+				"refusing to overwrite hand-edited file "+path+" (missing generated header, set Config.Force to overwrite)")
+		}
+		code = appendKeepBlocks(code, extractKeepBlocks(string(existing)))
+	case !os.IsNotExist(err):
+		return false, WrapError(ErrCodeFileIO, "failed to read file", err)
+	}
+
+	written, err := writeFileIfChanged(path, []byte(code))
+	if err != nil {
+		return false, WrapError(ErrCodeFileIO, "failed to write file", err)
+	}
+	return written, nil
+}
+
+// withGeneratedHeader 在代码前添加生成标记,已带标记的代码 (如 mock) 原样返回
+func withGeneratedHeader(code string) string {
+	if hasGeneratedHeader(code) {
+		return code
+	}
+	return GeneratedFileHeader + "\n\n" + code
+}
+
+// hasGeneratedHeader 判断 package 子句之前是否有生成标记
+// 只检查文件头部,代码中出现的同名字符串不算
+func hasGeneratedHeader(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == GeneratedFileHeader {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// extractKeepBlocks 提取所有保留块,每个块包含首尾两行标记
+// 缺少结束标记的块延续到文件末尾,并补上结束标记
+func extractKeepBlocks(content string) []string {
+	var (
+		blocks  []string
+		current []string
+		inBlock bool
+	)
+	for _, line := range strings.Split(content, "\n") {
+		isMarker := strings.TrimSpace(line) == KeepMarker
+		switch {
+		case isMarker && !inBlock:
+			inBlock = true
+			current = []string{KeepMarker}
+		case isMarker && inBlock:
+			inBlock = false
+			blocks = append(blocks, strings.Join(append(current, KeepMarker), "\n"))
+		case inBlock:
+			current = append(current, line)
+		}
+	}
+	if inBlock {
+		current = trimTrailingBlankLines(current)
+		blocks = append(blocks, strings.Join(append(current, KeepMarker), "\n"))
+	}
+	return blocks
+}
+
+// appendKeepBlocks 将保留块追加到代码末尾,块之间空一行
+func appendKeepBlocks(code string, blocks []string) string {
+	if len(blocks) == 0 {
+		return code
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(code, "\n"))
+	sb.WriteString("\n")
+	for _, block := range blocks {
+		sb.WriteString("\n")
+		sb.WriteString(block)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// trimTrailingBlankLines 去掉末尾的空行
+func trimTrailingBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
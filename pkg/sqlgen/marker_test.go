@@ -0,0 +1,103 @@
+package sqlgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const markerTestDDL = `
+CREATE TABLE users (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	name varchar(64) NOT NULL
+);`
+
+// newMarkerBuilder 创建覆盖已有文件的构建器
+func newMarkerBuilder(ddl string, force bool) *ReverseBuilder {
+	return New(&Config{Dialect: MySQL, Force: force}).
+		ParseSQL(ddl).
+		Package("models").
+		Tags(TagGorm).
+		Overwrite(true)
+}
+
+// TestGenerateToDir_RefuseHandEdited 测试没有生成标记的已有文件不会被覆盖,开启 Force 后覆盖
+func TestGenerateToDir_RefuseHandEdited(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.go")
+	handEdited := "package models\n\n// User 手工维护的模型\ntype User struct{}\n"
+	if err := os.WriteFile(path, []byte(handEdited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := newMarkerBuilder(markerTestDDL, false).GenerateToDir(dir)
+	if !IsError(err, ErrCodeHandEdited) {
+		t.Fatalf("expected ErrCodeHandEdited, got %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != handEdited {
+		t.Errorf("hand-edited file should be kept, got:\n%s", content)
+	}
+
+	if err := newMarkerBuilder(markerTestDDL, true).GenerateToDir(dir); err != nil {
+		t.Fatalf("GenerateToDir() with Force failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(content), GeneratedFileHeader+"\n") || !strings.Contains(string(content), "Name string") {
+		t.Errorf("file should be regenerated with header, got:\n%s", content)
+	}
+}
+
+// TestGenerateToDir_PreservesKeepBlocks 测试重新生成时保留块被原样保留
+func TestGenerateToDir_PreservesKeepBlocks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.go")
+
+	if err := newMarkerBuilder(markerTestDDL, false).GenerateToDir(dir); err != nil {
+		t.Fatalf("GenerateToDir() failed: %v", err)
+	}
+
+	keep := KeepMarker + "\n\n// DisplayName 显示名称\nfunc (u *User) DisplayName() string {\n\treturn \"@\" + u.Name\n}\n" + KeepMarker
+	content, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(string(content)+"\n"+keep+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 表结构变化后重新生成,生成部分更新,保留块不变
+	ddl := strings.Replace(markerTestDDL, "name varchar(64) NOT NULL", "name varchar(64) NOT NULL,\n\temail varchar(128)", 1)
+	for i := 0; i < 2; i++ {
+		if err := newMarkerBuilder(ddl, false).GenerateToDir(dir); err != nil {
+			t.Fatalf("regenerate #%d failed: %v", i+1, err)
+		}
+
+		content, _ := os.ReadFile(path)
+		if !strings.Contains(string(content), "Email") {
+			t.Errorf("regenerated file should contain new column, got:\n%s", content)
+		}
+		if n := strings.Count(string(content), keep); n != 1 {
+			t.Errorf("regenerate #%d: keep block appears %d times, want 1:\n%s", i+1, n, content)
+		}
+	}
+}
+
+// TestExtractKeepBlocks 测试保留块的提取,缺少结束标记时延续到文件末尾
+func TestExtractKeepBlocks(t *testing.T) {
+	content := "package models\n\n" +
+		KeepMarker + "\nvar a = 1\n" + KeepMarker + "\n\n" +
+		"func generated() {}\n\n" +
+		"\t" + KeepMarker + "\nvar b = 2\n\n"
+
+	blocks := extractKeepBlocks(content)
+	want := []string{
+		KeepMarker + "\nvar a = 1\n" + KeepMarker,
+		KeepMarker + "\nvar b = 2\n" + KeepMarker,
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("extractKeepBlocks() = %q, want %q", blocks, want)
+	}
+	for i := range want {
+		if blocks[i] != want[i] {
+			t.Errorf("block[%d] = %q, want %q", i, blocks[i], want[i])
+		}
+	}
+}
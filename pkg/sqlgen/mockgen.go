@@ -0,0 +1,235 @@
+package sqlgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// DAO 接口与 Mock 生成
+// ============================================================================
+
+// daoMethodSig DAO 方法签名
+// 同一份签名用于生成接口和 mock,保证二者一致
+type daoMethodSig struct {
+	Name    string // 方法名
+	Params  string // 参数列表,如 "entity *User"
+	Args    string // 调用实参,如 "entity"
+	Results string // 返回值列表,如 "error" 或 "(*User, error)"
+	Zero    string // 未设置实现时返回的零值,如 "nil" 或 "nil, nil"
+}
+
+// daoMethodSigs 返回 DAO 方法签名列表
+// 参数:
+//
+//	schema: 表结构
+//	methods: 要生成的方法名,未知方法会被忽略(与 GenerateDAO 一致)
+//	qualifier: 实体类型的包限定符,如 "models.",同包时为空
+func daoMethodSigs(schema *Schema, methods []string, qualifier string) []daoMethodSig {
+	entity := qualifier + schema.Name
+	pkType := primaryKeyType(schema)
+
+	var sigs []daoMethodSig
+	for _, method := range methods {
+		switch method {
+		case "Create", "Update":
+			sigs = append(sigs, daoMethodSig{method, "entity *" + entity, "entity", "error", "nil"})
+		case "Delete":
+			sigs = append(sigs, daoMethodSig{method, "id " + pkType, "id", "error", "nil"})
+		case "FindByID":
+			sigs = append(sigs, daoMethodSig{method, "id " + pkType, "id", fmt.Sprintf("(*%s, error)", entity), "nil, nil"})
+		case "FindAll":
+			sigs = append(sigs, daoMethodSig{method, "", "", fmt.Sprintf("([]*%s, error)", entity), "nil, nil"})
+		case "InsertBatch":
+			sigs = append(sigs, daoMethodSig{method, "ctx context.Context, entities []*" + entity, "ctx, entities", "error", "nil"})
+		case "Upsert":
+			if len(primaryKeyColumns(schema)) > 0 {
+				sigs = append(sigs, daoMethodSig{method, "ctx context.Context, entity *" + entity, "ctx, entity", "error", "nil"})
+			}
+		}
+	}
+	return sigs
+}
+
+// daoInterfaceSigs 返回接口和 mock 的方法签名,每个方法后紧跟其 WithTx 变体
+func daoInterfaceSigs(schema *Schema, methods []string, qualifier string) []daoMethodSig {
+	base := daoMethodSigs(schema, methods, qualifier)
+	sigs := make([]daoMethodSig, 0, len(base)*2)
+	for _, sig := range base {
+		sigs = append(sigs, sig, withTxSig(sig))
+	}
+	return sigs
+}
+
+// withTxSig 返回方法的 WithTx 变体签名
+// tx 参数位于 ctx 之后、其他参数之前,如 InsertBatchWithTx(ctx context.Context, tx *gorm.DB, entities []*User)
+func withTxSig(sig daoMethodSig) daoMethodSig {
+	params := "tx *gorm.DB"
+	if rest, ok := strings.CutPrefix(sig.Params, "ctx context.Context"); ok {
+		params = "ctx context.Context, " + params + rest
+	} else if sig.Params != "" {
+		params += ", " + sig.Params
+	}
+
+	return daoMethodSig{
+		Name:    sig.Name + "WithTx",
+		Params:  params,
+		Args:    withTxArgs(sig, "tx"),
+		Results: sig.Results,
+		Zero:    sig.Zero,
+	}
+}
+
+// withTxArgs 返回调用 WithTx 变体的实参,tx 为事务实参表达式
+func withTxArgs(sig daoMethodSig, tx string) string {
+	if rest, ok := strings.CutPrefix(sig.Args, "ctx"); ok && sigUsesContext(sig) {
+		return "ctx, " + tx + rest
+	}
+	if sig.Args == "" {
+		return tx
+	}
+	return tx + ", " + sig.Args
+}
+
+// sigUsesContext 判断方法是否接收 context.Context
+func sigUsesContext(sig daoMethodSig) bool {
+	return strings.Contains(sig.Params, "context.Context")
+}
+
+// sigsUseContext 判断是否有方法接收 context.Context
+func sigsUseContext(sigs []daoMethodSig) bool {
+	for _, sig := range sigs {
+		if sigUsesContext(sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKeyType 返回主键字段的 Go 类型,没有主键时为 uint64
+func primaryKeyType(schema *Schema) string {
+	for i := range schema.Fields {
+		if schema.Fields[i].Column.PrimaryKey {
+			return schema.Fields[i].Type
+		}
+	}
+	return "uint64"
+}
+
+// daoInterfaceName 返回 DAO 接口名
+func daoInterfaceName(schema *Schema) string {
+	return schema.Name + "DAOInterface"
+}
+
+// writeDAOInterface 写入 DAO 接口定义及编译期实现检查
+func (c *CodeGenerator) writeDAOInterface(sb *strings.Builder, schema *Schema, methods []string) {
+	daoName := schema.Name + "DAO"
+	ifaceName := daoInterfaceName(schema)
+
+	sb.WriteString(fmt.Sprintf("// %s %s 的接口\n", ifaceName, daoName))
+	sb.WriteString("// 服务层依赖此接口,测试时可替换为 mock 实现\n")
+	sb.WriteString(fmt.Sprintf("type %s interface {\n", ifaceName))
+	for _, sig := range daoInterfaceSigs(schema, methods, "") {
+		sb.WriteString(fmt.Sprintf("\t%s(%s) %s\n", sig.Name, sig.Params, sig.Results))
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(fmt.Sprintf("var _ %s = (*%s)(nil)\n\n", ifaceName, daoName))
+}
+
+// GenerateDAOMock 生成 DAO 的 mock 实现代码
+// mock 为每个方法提供一个 XxxFunc 字段,测试中按需设置,未设置时返回零值
+// 参数:
+//
+//	schema: 表结构
+//	methods: DAO 方法列表,应与 GenerateDAO 使用的一致
+//	importPath: 实体和 DAO 接口所在包的导入路径
+//
+// 返回:
+//
+//	string: package mocks 的 Go 代码
+func (c *CodeGenerator) GenerateDAOMock(schema *Schema, methods []string, importPath string) string {
+	var sb strings.Builder
+
+	pkg := schema.Package
+	mockName := "Mock" + schema.Name + "DAO"
+	ifaceName := daoInterfaceName(schema)
+	sigs := daoInterfaceSigs(schema, methods, pkg+".")
+
+	sb.WriteString(GeneratedFileHeader + "\n\n")
+	sb.WriteString(fmt.Sprintf("package %s\n\n", MockPackage))
+
+	sb.WriteString("import (\n")
+	if sigsUseContext(sigs) {
+		sb.WriteString("\t\"context\"\n\n")
+	}
+	if len(sigs) > 0 {
+		sb.WriteString("\t\"gorm.io/gorm\"\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("\t%s \"%s\"\n", pkg, importPath))
+	sb.WriteString(")\n\n")
+
+	// mock 结构体
+	sb.WriteString(fmt.Sprintf("// %s %s.%s 的 mock 实现\n", mockName, pkg, ifaceName))
+	sb.WriteString("// 为需要的方法设置对应的 Func 字段,未设置时返回零值\n")
+	sb.WriteString(fmt.Sprintf("type %s struct {\n", mockName))
+	for _, sig := range sigs {
+		sb.WriteString(fmt.Sprintf("\t%sFunc func(%s) %s\n", sig.Name, sig.Params, sig.Results))
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(fmt.Sprintf("var _ %s.%s = (*%s)(nil)\n", pkg, ifaceName, mockName))
+
+	// 方法
+	for _, sig := range sigs {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("// %s 调用 %sFunc\n", sig.Name, sig.Name))
+		sb.WriteString(fmt.Sprintf("func (m *%s) %s(%s) %s {\n", mockName, sig.Name, sig.Params, sig.Results))
+		sb.WriteString(fmt.Sprintf("\tif m.%sFunc != nil {\n", sig.Name))
+		sb.WriteString(fmt.Sprintf("\t\treturn m.%sFunc(%s)\n", sig.Name, sig.Args))
+		sb.WriteString("\t}\n")
+		sb.WriteString(fmt.Sprintf("\treturn %s\n", sig.Zero))
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// ============================================================================
+// ReverseBuilder Mock 支持
+// ============================================================================
+
+// WithMock 启用 DAO 接口和 mock 生成
+// 参数:
+//
+//	importPath: 生成的结构体和 DAO 所在包的导入路径,如 "example.com/app/models"
+func (r *ReverseBuilder) WithMock(importPath string) *ReverseBuilder {
+	r.options.Mock = true
+	r.options.MockImportPath = importPath
+	return r
+}
+
+// GenerateDAOMock 生成第一个表的 DAO mock 代码
+// 需要先调用 WithMock 设置导入路径,方法列表与 DAOMethods 一致
+func (r *ReverseBuilder) GenerateDAOMock() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	if len(r.schemas) == 0 {
+		return "", ErrParseFailed
+	}
+
+	if !r.options.Mock || r.options.MockImportPath == "" {
+		return "", NewError(ErrCodeGenerateFailed, "mock import path is required, call WithMock first")
+	}
+
+	schema := r.schemas[0]
+	if r.options.StructName != "" {
+		schema.Name = r.options.StructName
+	}
+	schema.Package = r.options.Package
+
+	codegen := NewCodeGenerator(r.options)
+	return codegen.GenerateDAOMock(schema, r.daoMethods, r.options.MockImportPath), nil
+}
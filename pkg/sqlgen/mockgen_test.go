@@ -0,0 +1,137 @@
+package sqlgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const mockTestDDL = `
+CREATE TABLE accounts (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	name varchar(64) NOT NULL
+);`
+
+// gormStub 最小化的 gorm 替身,让生成的 DAO 无需下载依赖即可编译
+const gormStub = `package gorm
+
+type DB struct{ Error error }
+
+func (db *DB) Create(value interface{}) *DB                      { return db }
+func (db *DB) Save(value interface{}) *DB                        { return db }
+func (db *DB) Delete(value interface{}, conds ...interface{}) *DB { return db }
+func (db *DB) First(dest interface{}, conds ...interface{}) *DB  { return db }
+func (db *DB) Find(dest interface{}, conds ...interface{}) *DB   { return db }
+`
+
+// mockConsumer 使用 mock 的代码,验证 mock 可以作为接口注入
+const mockConsumer = `package consumer
+
+import (
+	"example.com/gen/models"
+	"example.com/gen/models/mocks"
+)
+
+func Use() models.AccountDAOInterface {
+	return &mocks.MockAccountDAO{
+		FindByIDFunc: func(id uint64) (*models.Account, error) {
+			return &models.Account{Id: id}, nil
+		},
+	}
+}
+`
+
+// newMockBuilder 创建启用 DAO 和 mock 的构建器
+func newMockBuilder() *ReverseBuilder {
+	return New(&Config{Dialect: MySQL}).
+		ParseSQL(mockTestDDL).
+		Name("Account").
+		Package("models").
+		Tags(TagGorm|TagJson).
+		WithComments(false).
+		DAOMethods("Create", "Update", "Delete", "FindByID", "FindAll").
+		WithMock("example.com/gen/models")
+}
+
+// TestGenerateDAOMock_Content 测试接口列出所有 DAO 方法,mock 实现同样的方法
+func TestGenerateDAOMock_Content(t *testing.T) {
+	_, daoCode, err := newMockBuilder().GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	mockCode, err := newMockBuilder().GenerateDAOMock()
+	if err != nil {
+		t.Fatalf("GenerateDAOMock() failed: %v", err)
+	}
+
+	if !strings.Contains(daoCode, "type AccountDAOInterface interface") {
+		t.Errorf("DAO code should contain interface, got:\n%s", daoCode)
+	}
+	if !strings.Contains(mockCode, "package mocks") || !strings.Contains(mockCode, "type MockAccountDAO struct") {
+		t.Errorf("mock code should contain mock struct, got:\n%s", mockCode)
+	}
+
+	for _, method := range []string{"Create", "Update", "Delete", "FindByID", "FindAll"} {
+		if !strings.Contains(daoCode, "\t"+method+"(") {
+			t.Errorf("interface should list %s", method)
+		}
+		if !strings.Contains(mockCode, ") "+method+"(") {
+			t.Errorf("mock should implement %s", method)
+		}
+	}
+}
+
+// TestGenerateDAOMock_RequiresImportPath 测试未设置导入路径时报错
+func TestGenerateDAOMock_RequiresImportPath(t *testing.T) {
+	_, err := New(&Config{Dialect: MySQL}).ParseSQL(mockTestDDL).DAOMethods("Create").GenerateDAOMock()
+	if err == nil {
+		t.Fatal("expected error without WithMock")
+	}
+}
+
+// TestGenerateDAOMock_Compiles 测试生成的接口和 mock 可以编译,且 mock 满足接口
+// 生成代码中的 var _ 断言保证了实现关系,编译通过即验证成功
+func TestGenerateDAOMock_Compiles(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	structCode, daoCode, err := newMockBuilder().GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	mockCode, err := newMockBuilder().GenerateDAOMock()
+	if err != nil {
+		t.Fatalf("GenerateDAOMock() failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                      "module example.com/gen\n\ngo 1.21\n\nrequire gorm.io/gorm v0.0.0\n\nreplace gorm.io/gorm => ./gormstub\n",
+		"gormstub/go.mod":             "module gorm.io/gorm\n\ngo 1.21\n",
+		"gormstub/gorm.go":            gormStub,
+		"models/account.go":           structCode,
+		"models/account_dao.go":       daoCode,
+		"models/mocks/account_dao.go": mockCode,
+		"consumer/consumer.go":        mockConsumer,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s\n--- dao ---\n%s\n--- mock ---\n%s", err, out, daoCode, mockCode)
+	}
+}
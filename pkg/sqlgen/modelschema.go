@@ -0,0 +1,130 @@
+package sqlgen
+
+import (
+	"reflect"
+)
+
+// ============================================================================
+// GORM 模型 -> Schema
+// ============================================================================
+
+// SchemaFromModel 将单个 GORM 模型解析为表结构
+// 等价于 SchemaFromModels(model) 的第一个结果
+func (g *Generator) SchemaFromModel(model interface{}) (*Schema, error) {
+	schemas, err := g.SchemaFromModels(model)
+	if err != nil {
+		return nil, err
+	}
+	return schemas[0], nil
+}
+
+// SchemaFromModels 通过反射将 GORM 模型解析为逆向生成使用的表结构
+// 模型和数据库 (或 DDL) 得到的 Schema 走同一套生成路径:
+// FromSchema 生成模型/DAO 代码,DumpSchema 写出快照
+// 解析规则与 DDL 一致:
+//   - 列名、SQL 类型、主键、自增、非空、默认值、注释和长度来自 gorm tag,SQL 类型按当前方言映射
+//   - 未标记 primaryKey 时 ID 字段视为主键 (与 GORM 一致)
+//   - index/uniqueIndex tag 转为索引,同名索引合并为复合索引
+//   - 外键由关联字段推导,has-one/has-many 的外键需要目标模型在同一次调用中
+//
+// 参数:
+//
+//	models: 模型列表,如 &User{}, &Post{}
+//
+// 返回:
+//
+//	[]*Schema: 与 models 顺序一致的表结构
+//	error: 模型无效时返回 ErrInvalidModel
+//
+// 使用示例:
+//
+//	schemas, err := gen.SchemaFromModels(&models.DBUser{})
+//	err = gen.FromSchema(schemas...).Package("dao").Tags(sqlgen.TagGorm).GenerateToDir("./dao")
+func (g *Generator) SchemaFromModels(models ...interface{}) ([]*Schema, error) {
+	tables, err := g.describeModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]*Schema, 0, len(tables))
+	for _, t := range tables {
+		schemas = append(schemas, tableSchema(t))
+	}
+	return schemas, nil
+}
+
+// tableSchema 将解析后的模型转换为 Schema
+func tableSchema(t *ddlTable) *Schema {
+	schema := &Schema{
+		Name:        t.model.Name(),
+		TableName:   t.name,
+		ForeignKeys: t.foreignKeys,
+	}
+
+	var pkName string
+	if pk := getPrimaryKeyField(t.fields); pk != nil {
+		pkName = pk.Name
+	}
+
+	indexes := make(map[string]int)
+	addIndex := func(name, column string, unique bool) {
+		// 未命名的索引各自独立
+		if name == "true" {
+			schema.Indexes = append(schema.Indexes, Index{Columns: []string{column}, Unique: unique})
+			return
+		}
+		if i, ok := indexes[name]; ok {
+			schema.Indexes[i].Columns = append(schema.Indexes[i].Columns, column)
+			return
+		}
+		indexes[name] = len(schema.Indexes)
+		schema.Indexes = append(schema.Indexes, Index{Name: name, Columns: []string{column}, Unique: unique})
+	}
+
+	for _, f := range t.fields {
+		if f.RelationType != nil {
+			continue
+		}
+
+		goType, importPath := reflectGoType(f.ReflectType)
+		primaryKey := f.Name == pkName
+		schema.Fields = append(schema.Fields, Field{
+			Name:   f.Name,
+			Type:   goType,
+			Import: importPath,
+			Column: Column{
+				Name:          f.ColumnName,
+				Type:          f.SQLType,
+				GoType:        goType,
+				PrimaryKey:    primaryKey,
+				AutoIncrement: f.Tag.AutoIncrement,
+				NotNull:       f.Tag.NotNull || primaryKey,
+				Default:       f.Tag.Default,
+				Comment:       f.Tag.Comment,
+				Size:          f.Tag.Size,
+			},
+			Comment: f.Tag.Comment,
+		})
+
+		if f.Tag.Index != "" {
+			addIndex(f.Tag.Index, f.ColumnName, false)
+		}
+		if f.Tag.UniqueIndex != "" {
+			addIndex(f.Tag.UniqueIndex, f.ColumnName, true)
+		}
+	}
+
+	analyzeImports(schema)
+	return schema
+}
+
+// reflectGoType 返回字段在生成代码中的类型和所需的导入路径
+// 类型使用包名限定 (gorm.DeletedAt),导入路径取自具名类型所在的包 (gorm.io/gorm)
+func reflectGoType(t reflect.Type) (string, string) {
+	base := t
+	for base.Name() == "" && (base.Kind() == reflect.Ptr || base.Kind() == reflect.Slice ||
+		base.Kind() == reflect.Array || base.Kind() == reflect.Map) {
+		base = base.Elem()
+	}
+	return t.String(), base.PkgPath()
+}
@@ -0,0 +1,132 @@
+package sqlgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rei0721/go-scaffold/internal/models"
+)
+
+// TestSchemaFromModel 测试将 models.DBUser 解析为表结构
+func TestSchemaFromModel(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+
+	schema, err := gen.SchemaFromModel(&models.DBUser{})
+	if err != nil {
+		t.Fatalf("SchemaFromModel() failed: %v", err)
+	}
+
+	if schema.Name != "DBUser" || schema.TableName != "users" {
+		t.Errorf("Name/TableName = %s/%s, want DBUser/users", schema.Name, schema.TableName)
+	}
+
+	tests := []struct {
+		name    string
+		column  string
+		sqlType string
+		goType  string
+		pk      bool
+		notNull bool
+	}{
+		{"ID", "id", "BIGINT", "int64", true, true},
+		{"CreatedAt", "created_at", "DATETIME", "time.Time", false, false},
+		{"UpdatedAt", "updated_at", "DATETIME", "time.Time", false, false},
+		{"DeletedAt", "deleted_at", "TEXT", "gorm.DeletedAt", false, false},
+		{"Username", "username", "VARCHAR(50)", "string", false, true},
+		{"Email", "email", "VARCHAR(100)", "string", false, true},
+		{"Password", "password", "VARCHAR(255)", "string", false, true},
+		{"Status", "status", "INT", "int", false, false},
+	}
+	if len(schema.Fields) != len(tests) {
+		t.Fatalf("expected %d fields, got %d: %+v", len(tests), len(schema.Fields), schema.Fields)
+	}
+	for i, tt := range tests {
+		f := schema.Fields[i]
+		if f.Name != tt.name || f.Type != tt.goType || f.Column.Name != tt.column || f.Column.Type != tt.sqlType {
+			t.Errorf("field %d = %s %s (%s %s), want %s %s (%s %s)",
+				i, f.Name, f.Type, f.Column.Name, f.Column.Type, tt.name, tt.goType, tt.column, tt.sqlType)
+		}
+		if f.Column.PrimaryKey != tt.pk || f.Column.NotNull != tt.notNull {
+			t.Errorf("field %s primary_key/not_null = %v/%v, want %v/%v",
+				f.Name, f.Column.PrimaryKey, f.Column.NotNull, tt.pk, tt.notNull)
+		}
+	}
+	if got := schema.Fields[7].Column.Default; got != "1" {
+		t.Errorf("status default = %q, want 1", got)
+	}
+
+	unique := 0
+	for _, idx := range schema.Indexes {
+		if idx.Unique {
+			unique++
+		}
+	}
+	if len(schema.Indexes) != 3 || unique != 2 {
+		t.Errorf("expected deleted_at index and 2 unique indexes, got %+v", schema.Indexes)
+	}
+
+	imports := strings.Join(schema.Imports, ",")
+	if !strings.Contains(imports, "time") || !strings.Contains(imports, "gorm.io/gorm") {
+		t.Errorf("imports should contain time and gorm.io/gorm, got %v", schema.Imports)
+	}
+
+	code, err := gen.FromSchema(schema).Package("models").Tags(TagGorm).Generate()
+	if err != nil {
+		t.Fatalf("FromSchema().Generate() failed: %v", err)
+	}
+	for _, want := range []string{"type DBUser struct", "gorm.DeletedAt", `"gorm.io/gorm"`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code should contain %q, got:\n%s", want, code)
+		}
+	}
+	if line := fieldLine(code, "Username"); !strings.Contains(line, "uniqueIndex") {
+		t.Errorf("Username should carry uniqueIndex, got %q", line)
+	}
+}
+
+// TestSchemaFromModelsForeignKeys 测试外键从关联字段推导,关联字段不生成列
+func TestSchemaFromModelsForeignKeys(t *testing.T) {
+	schemas, err := New(&Config{Dialect: MySQL}).SchemaFromModels(&ddlUser{}, &ddlPost{})
+	if err != nil {
+		t.Fatalf("SchemaFromModels() failed: %v", err)
+	}
+	users, posts := schemas[0], schemas[1]
+
+	if len(users.Fields) != 2 || len(posts.Fields) != 3 {
+		t.Errorf("association fields should not become columns, got users=%d posts=%d", len(users.Fields), len(posts.Fields))
+	}
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].RefTable != "users" || posts.ForeignKeys[0].Columns[0] != "user_id" {
+		t.Errorf("posts should reference users, got %+v", posts.ForeignKeys)
+	}
+	if len(posts.Indexes) != 1 || posts.Indexes[0].Name != "idx_posts_user_id" {
+		t.Errorf("posts should keep named index, got %+v", posts.Indexes)
+	}
+}
+
+// TestSchemaFromModelsInvalid 测试非结构体模型
+func TestSchemaFromModelsInvalid(t *testing.T) {
+	gen := New(nil)
+	if _, err := gen.SchemaFromModels(); err != ErrInvalidModel {
+		t.Errorf("expected ErrInvalidModel for no models, got %v", err)
+	}
+	if _, err := gen.SchemaFromModel(42); err != ErrInvalidModel {
+		t.Errorf("expected ErrInvalidModel for non-struct, got %v", err)
+	}
+}
+
+// TestToSnakeCase 测试缩写词按 GORM 规则转换
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"CreatedAt":  "created_at",
+		"HTTPServer": "http_server",
+		"DBUser":     "db_user",
+		"user_name":  "user_name",
+	}
+	for in, want := range tests {
+		if got := toSnakeCase(in); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
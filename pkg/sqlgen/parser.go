@@ -16,6 +16,16 @@ type Parser struct {
 	dialect Dialect
 	input   string
 	pos     int
+
+	// enumTypes PostgreSQL CREATE TYPE ... AS ENUM 定义的类型 (小写类型名 -> 枚举值)
+	enumTypes map[string][]string
+
+	// schemas PostgreSQL 只解析这些 schema 中的表,为空时为 public
+	schemas []string
+
+	// decimalType/decimalImport DECIMAL/NUMERIC 列的 Go 类型及其导入路径
+	decimalType   string
+	decimalImport string
 }
 
 // NewParser 创建新的解析器
@@ -25,10 +35,48 @@ func NewParser(dialect Dialect) *Parser {
 	}
 }
 
+// SetSchemas 设置 PostgreSQL 要解析的 schema 列表
+// 其他 schema 中的表会被跳过,为空时只解析 public;对其他方言无效
+func (p *Parser) SetSchemas(schemas ...string) *Parser {
+	p.schemas = schemas
+	return p
+}
+
+// SetDecimalType 设置 DECIMAL/NUMERIC 列的 Go 类型
+// goType 为空时使用 DefaultDecimalType;importPath 为 goType 所在包,
+// goType 为 DecimalTypeShopspring 且 importPath 为空时使用 DecimalImportShopspring
+func (p *Parser) SetDecimalType(goType, importPath string) *Parser {
+	if goType == DecimalTypeShopspring && importPath == "" {
+		importPath = DecimalImportShopspring
+	}
+	p.decimalType, p.decimalImport = goType, importPath
+	return p
+}
+
+// schemaAllowed 判断表所在的 schema 是否在解析范围内
+// PostgreSQL 未加引号的标识符不区分大小写,因此忽略大小写比较
+func (p *Parser) schemaAllowed(schemaName string) bool {
+	if p.dialect != PostgreSQL {
+		return true
+	}
+
+	schemas := p.schemas
+	if len(schemas) == 0 {
+		schemas = []string{DefaultPostgresSchema}
+	}
+	for _, s := range schemas {
+		if strings.EqualFold(strings.TrimSpace(s), schemaName) {
+			return true
+		}
+	}
+	return false
+}
+
 // Parse 解析 SQL DDL 脚本
 func (p *Parser) Parse(sql string) ([]*Schema, error) {
 	p.input = sql
 	p.pos = 0
+	p.enumTypes = p.findEnumTypes()
 
 	var schemas []*Schema
 
@@ -40,9 +88,15 @@ func (p *Parser) Parse(sql string) ([]*Schema, error) {
 		if err != nil {
 			continue // 跳过解析失败的表
 		}
+		if !p.schemaAllowed(schema.SchemaName) {
+			continue // 跳过未选择的 schema 中的表
+		}
 		schemas = append(schemas, schema)
 	}
 
+	// 独立的 CREATE INDEX 语句归入对应的表
+	p.attachCreateIndexStatements(schemas)
+
 	return schemas, nil
 }
 
@@ -57,8 +111,27 @@ func (p *Parser) ParseSingle(sql string) (*Schema, error) {
 
 // 正则表达式
 var (
-	// 匹配 CREATE TABLE 语句
-	createTableRegex = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([\s\S]+?)\)`)
+	// 匹配 CREATE TABLE 语句头部,列定义部分按括号配对截取
+	// 捕获组 1 为可选的 schema (如 sales.orders 中的 sales),捕获组 2 为表名
+	createTableRegex = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\.)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(`)
+
+	// 匹配 CREATE INDEX 语句头部,列列表部分按括号配对截取
+	createIndexRegex = regexp.MustCompile(`(?i)CREATE\s+(UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+ON\s+(?:[` + "`" + `"'\[]?\w+[` + "`" + `"'\]]?\.)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*(?:USING\s+(\w+)\s*)?\(`)
+
+	// 匹配列级 UNIQUE 约束
+	uniqueRegex = regexp.MustCompile(`(?i)\bUNIQUE\b`)
+
+	// 匹配 PostgreSQL CREATE TYPE ... AS ENUM 语句头部
+	createEnumRegex = regexp.MustCompile(`(?i)CREATE\s+TYPE\s+(?:\w+\.)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+AS\s+ENUM\s*\(`)
+
+	// 匹配表级外键约束
+	foreignKeyRegex = regexp.MustCompile(`(?i)^(?:CONSTRAINT\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+)?FOREIGN\s+KEY\s*(?:[` + "`" + `"'\[]?\w+[` + "`" + `"'\]]?\s*)?\(([^)]+)\)\s*REFERENCES\s+(?:[` + "`" + `"'\[]?\w+[` + "`" + `"'\]]?\.)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([^)]+)\)`)
+
+	// 匹配列级 REFERENCES 约束
+	referencesRegex = regexp.MustCompile(`(?i)\bREFERENCES\s+(?:[` + "`" + `"'\[]?\w+[` + "`" + `"'\]]?\.)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([^)]+)\)`)
+
+	// 匹配单引号字符串,'' 为转义的单引号
+	quotedValueRegex = regexp.MustCompile(`'((?:[^']|'')*)'`)
 
 	// 匹配列定义
 	columnDefRegex = regexp.MustCompile(`(?i)^[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+(\w+(?:\([^)]+\))?(?:\s+\w+)*)\s*(.*)$`)
@@ -78,24 +151,56 @@ var (
 
 func (p *Parser) findCreateTableStatements() []string {
 	var results []string
-	matches := createTableRegex.FindAllString(p.input, -1)
-	results = append(results, matches...)
+	for _, loc := range createTableRegex.FindAllStringIndex(p.input, -1) {
+		end := matchParen(p.input, loc[1]-1)
+		if end < 0 {
+			continue
+		}
+		results = append(results, p.input[loc[0]:end+1])
+	}
 	return results
 }
 
+// matchParen 返回与 open 位置的左括号配对的右括号位置,未找到返回 -1
+func matchParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
-	matches := createTableRegex.FindStringSubmatch(sql)
-	if len(matches) < 3 {
+	loc := createTableRegex.FindStringSubmatchIndex(sql)
+	if loc == nil {
+		return nil, ErrParseFailed
+	}
+	end := matchParen(sql, loc[1]-1)
+	if end < 0 {
 		return nil, ErrParseFailed
 	}
 
-	tableName := matches[1]
-	columnsBody := matches[2]
+	tableName := sql[loc[4]:loc[5]]
+	columnsBody := sql[loc[1]:end]
 
 	schema := &Schema{
-		Name:      toPascalCase(tableName),
+		Name:      toStructName(tableName),
 		TableName: tableName,
 	}
+	if loc[2] >= 0 {
+		schema.SchemaName = sql[loc[2]:loc[3]]
+	} else if p.dialect == PostgreSQL {
+		// 未限定 schema 的表位于默认 search_path
+		schema.SchemaName = DefaultPostgresSchema
+	}
 
 	// 解析列定义
 	columns := p.splitColumns(columnsBody)
@@ -108,24 +213,43 @@ func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
 			continue
 		}
 
-		// 检查是否是约束定义
-		if strings.HasPrefix(strings.ToUpper(colDef), "PRIMARY KEY") ||
-			strings.HasPrefix(strings.ToUpper(colDef), "CONSTRAINT") {
+		// 检查是否是主键约束
+		if pkMatch := pkConstraintRegex.FindStringSubmatch(colDef); len(pkMatch) > 1 &&
+			(strings.HasPrefix(strings.ToUpper(colDef), "PRIMARY KEY") ||
+				strings.HasPrefix(strings.ToUpper(colDef), "CONSTRAINT")) {
 			// 提取主键列
-			if pkMatch := pkConstraintRegex.FindStringSubmatch(colDef); len(pkMatch) > 1 {
-				pkCols := strings.Split(pkMatch[1], ",")
-				for _, pk := range pkCols {
-					primaryKeys = append(primaryKeys, strings.Trim(strings.TrimSpace(pk), "`\"'[]"))
-				}
+			pkCols := strings.Split(pkMatch[1], ",")
+			for _, pk := range pkCols {
+				primaryKeys = append(primaryKeys, strings.Trim(strings.TrimSpace(pk), "`\"'[]"))
 			}
 			continue
 		}
 
+		// 外键定义
+		if fkMatch := foreignKeyRegex.FindStringSubmatch(colDef); fkMatch != nil {
+			schema.ForeignKeys = append(schema.ForeignKeys, ForeignKey{
+				Name:       fkMatch[1],
+				Columns:    splitIndexColumns(fkMatch[2]),
+				RefTable:   fkMatch[3],
+				RefColumns: splitIndexColumns(fkMatch[4]),
+			})
+			continue
+		}
+
+		// 索引定义
+		if idx, ok := parseIndexDef(colDef); ok {
+			schema.Indexes = append(schema.Indexes, *idx)
+			continue
+		}
+
 		// 跳过其他约束
 		upper := strings.ToUpper(colDef)
-		if strings.HasPrefix(upper, "INDEX") ||
+		if strings.HasPrefix(upper, "CONSTRAINT") ||
+			strings.HasPrefix(upper, "INDEX") ||
 			strings.HasPrefix(upper, "KEY") ||
 			strings.HasPrefix(upper, "UNIQUE") ||
+			strings.HasPrefix(upper, "FULLTEXT") ||
+			strings.HasPrefix(upper, "SPATIAL") ||
 			strings.HasPrefix(upper, "FOREIGN") ||
 			strings.HasPrefix(upper, "CHECK") {
 			continue
@@ -138,6 +262,24 @@ func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
 		}
 
 		schema.Fields = append(schema.Fields, *col)
+
+		// 列级 UNIQUE 视为单列唯一索引
+		withoutComment := commentRegex.ReplaceAllString(colDef, "")
+		if uniqueRegex.MatchString(withoutComment) {
+			schema.Indexes = append(schema.Indexes, Index{
+				Columns: []string{col.Column.Name},
+				Unique:  true,
+			})
+		}
+
+		// 列级 REFERENCES 视为单列外键
+		if refMatch := referencesRegex.FindStringSubmatch(withoutComment); refMatch != nil {
+			schema.ForeignKeys = append(schema.ForeignKeys, ForeignKey{
+				Columns:    []string{col.Column.Name},
+				RefTable:   refMatch[1],
+				RefColumns: splitIndexColumns(refMatch[2]),
+			})
+		}
 	}
 
 	// 标记主键
@@ -149,12 +291,53 @@ func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
 		}
 	}
 
+	p.nameCompositeIndexes(schema)
+
 	// 检查需要导入的包
-	p.analyzeImports(schema)
+	analyzeImports(schema)
 
 	return schema, nil
 }
 
+// nameCompositeIndexes 为未命名的复合索引补上数据库实际使用的名称
+// GORM 按名称把多列归为同一个复合索引,因此名称必须与数据库一致,否则迁移时会另建索引:
+//   - MySQL: 以第一列命名,重名时追加 _2、_3
+//   - PostgreSQL: UNIQUE 约束命名为 表名_列名..._key
+//
+// 其他方言的默认名称无法从 DDL 推出,保持为空
+func (p *Parser) nameCompositeIndexes(schema *Schema) {
+	used := make(map[string]bool, len(schema.Indexes))
+	for _, idx := range schema.Indexes {
+		if idx.Name != "" {
+			used[strings.ToLower(idx.Name)] = true
+		}
+	}
+
+	for i := range schema.Indexes {
+		idx := &schema.Indexes[i]
+		if idx.Name != "" || len(idx.Columns) < 2 {
+			continue
+		}
+
+		var name string
+		switch p.dialect {
+		case MySQL:
+			name = idx.Columns[0]
+			for n := 2; used[strings.ToLower(name)]; n++ {
+				name = fmt.Sprintf("%s_%d", idx.Columns[0], n)
+			}
+		case PostgreSQL:
+			if idx.Unique {
+				name = schema.TableName + "_" + strings.Join(idx.Columns, "_") + "_key"
+			}
+		}
+		if name != "" {
+			idx.Name = name
+			used[strings.ToLower(name)] = true
+		}
+	}
+}
+
 // splitColumns 分割列定义 (处理嵌套括号)
 func (p *Parser) splitColumns(body string) []string {
 	var result []string
@@ -200,6 +383,18 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 
 	columnName := strings.Trim(parts[0], "`\"'[]")
 	restDef := strings.Join(parts[1:], " ")
+	sqlType := parts[1]
+
+	// MySQL enum('a', 'b') 的值列表中可能有空格,按括号截取完整类型
+	var enumValues []string
+	if strings.HasPrefix(strings.ToUpper(restDef), "ENUM") {
+		if open := strings.Index(restDef, "("); open > 0 {
+			if end := matchParen(restDef, open); end > 0 {
+				sqlType = restDef[:end+1]
+				enumValues = parseQuotedValues(restDef[open+1 : end])
+			}
+		}
+	}
 
 	// 解析数据类型
 	typeMatch := dataTypeRegex.FindStringSubmatch(restDef)
@@ -207,8 +402,10 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 		return nil, fmt.Errorf("cannot parse data type: %s", def)
 	}
 
-	sqlType := parts[1]
 	baseType := strings.ToUpper(typeMatch[1])
+	if values, ok := p.enumTypes[strings.ToLower(baseType)]; ok {
+		enumValues = values
+	}
 
 	// 解析类型参数
 	var size, precision, scale int
@@ -267,6 +464,15 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 		goType = "bool"
 	}
 
+	// 精确数值不映射为浮点数
+	var goImport string
+	if baseType == "DECIMAL" || baseType == "NUMERIC" {
+		goType, goImport = DefaultDecimalType, ""
+		if p.decimalType != "" {
+			goType, goImport = p.decimalType, p.decimalImport
+		}
+	}
+
 	col := Column{
 		Name:          columnName,
 		Type:          sqlType,
@@ -279,11 +485,13 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 		Size:          size,
 		Precision:     precision,
 		Scale:         scale,
+		EnumValues:    enumValues,
 	}
 
 	field := &Field{
 		Name:    toPascalCase(columnName),
 		Type:    goType,
+		Import:  goImport,
 		Column:  col,
 		Comment: comment,
 	}
@@ -292,10 +500,14 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 }
 
 // analyzeImports 分析需要导入的包
-func (p *Parser) analyzeImports(schema *Schema) {
+func analyzeImports(schema *Schema) {
 	imports := make(map[string]bool)
 
 	for _, field := range schema.Fields {
+		if field.Import != "" {
+			imports[field.Import] = true
+			continue
+		}
 		switch {
 		case strings.Contains(field.Type, "time.Time"):
 			imports["time"] = true
@@ -308,3 +520,157 @@ func (p *Parser) analyzeImports(schema *Schema) {
 		schema.Imports = append(schema.Imports, pkg)
 	}
 }
+
+// ============================================================================
+// 枚举解析
+// ============================================================================
+
+// findEnumTypes 解析 PostgreSQL 的 CREATE TYPE ... AS ENUM 语句
+func (p *Parser) findEnumTypes() map[string][]string {
+	types := make(map[string][]string)
+	for _, loc := range createEnumRegex.FindAllStringSubmatchIndex(p.input, -1) {
+		end := matchParen(p.input, loc[1]-1)
+		if end < 0 {
+			continue
+		}
+		name := strings.ToLower(p.input[loc[2]:loc[3]])
+		types[name] = parseQuotedValues(p.input[loc[1]:end])
+	}
+	return types
+}
+
+// parseQuotedValues 提取逗号分隔的单引号字符串值
+// 如 "'a', 'b'" 返回 ["a", "b"],值中连续两个单引号还原为一个
+func parseQuotedValues(body string) []string {
+	var values []string
+	for _, m := range quotedValueRegex.FindAllStringSubmatch(body, -1) {
+		values = append(values, strings.ReplaceAll(m[1], "''", "'"))
+	}
+	return values
+}
+
+// ============================================================================
+// 索引解析
+// ============================================================================
+
+// parseIndexDef 解析表内的索引定义
+// 支持 [CONSTRAINT name] UNIQUE [KEY|INDEX] [name] (cols) 和 KEY|INDEX [name] (cols),
+// 均可带 USING type;不是索引定义时返回 false
+func parseIndexDef(def string) (*Index, bool) {
+	open := strings.Index(def, "(")
+	if open < 0 {
+		return nil, false
+	}
+	end := matchParen(def, open)
+	if end < 0 {
+		return nil, false
+	}
+
+	idx := &Index{Columns: splitIndexColumns(def[open+1 : end])}
+	head := strings.Fields(def[:open])
+	i := 0
+	if i+1 < len(head) && strings.EqualFold(head[i], "CONSTRAINT") {
+		idx.Name = trimIdentifier(head[i+1])
+		i += 2
+	}
+	isIndex := false
+	if i < len(head) && strings.EqualFold(head[i], "UNIQUE") {
+		idx.Unique = true
+		isIndex = true
+		i++
+	}
+	if i < len(head) && (strings.EqualFold(head[i], "KEY") || strings.EqualFold(head[i], "INDEX")) {
+		isIndex = true
+		i++
+	}
+	if !isIndex {
+		return nil, false
+	}
+	for ; i < len(head); i++ {
+		if strings.EqualFold(head[i], "USING") && i+1 < len(head) {
+			idx.Type = strings.ToUpper(head[i+1])
+			i++
+			continue
+		}
+		idx.Name = trimIdentifier(head[i])
+	}
+
+	// 列列表之后的 USING type
+	tail := strings.Fields(def[end+1:])
+	if len(tail) >= 2 && strings.EqualFold(tail[0], "USING") {
+		idx.Type = strings.ToUpper(tail[1])
+	}
+
+	if len(idx.Columns) == 0 {
+		return nil, false
+	}
+	return idx, true
+}
+
+// attachCreateIndexStatements 解析 CREATE INDEX 语句并加入对应表的索引列表
+func (p *Parser) attachCreateIndexStatements(schemas []*Schema) {
+	for _, loc := range createIndexRegex.FindAllStringSubmatchIndex(p.input, -1) {
+		end := matchParen(p.input, loc[1]-1)
+		if end < 0 {
+			continue
+		}
+
+		idx := Index{
+			Name:    p.input[loc[4]:loc[5]],
+			Unique:  loc[2] >= 0,
+			Columns: splitIndexColumns(p.input[loc[1]:end]),
+		}
+		if loc[8] >= 0 {
+			idx.Type = strings.ToUpper(p.input[loc[8]:loc[9]])
+		}
+
+		tableName := p.input[loc[6]:loc[7]]
+		for _, schema := range schemas {
+			if strings.EqualFold(schema.TableName, tableName) {
+				schema.Indexes = append(schema.Indexes, idx)
+				break
+			}
+		}
+	}
+}
+
+// splitIndexColumns 拆分索引列列表,去掉前缀长度和排序方向
+// 如 "`name`(10) DESC, age" 返回 ["name", "age"]
+func splitIndexColumns(body string) []string {
+	var columns []string
+	depth := 0
+	start := 0
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) {
+			switch body[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+
+		part := body[start:i]
+		start = i + 1
+		if p := strings.Index(part, "("); p >= 0 {
+			part = part[:p]
+		}
+		if fields := strings.Fields(part); len(fields) > 0 {
+			columns = append(columns, trimIdentifier(fields[0]))
+		}
+	}
+	return columns
+}
+
+// trimIdentifier 去掉标识符两侧的引号
+func trimIdentifier(name string) string {
+	return strings.Trim(name, "`\"'[]")
+}
@@ -22,7 +22,9 @@ type ParsedTag struct {
 	Index         string
 	UniqueIndex   string
 	Comment       string
-	Ignore        bool // gorm:"-"
+	ForeignKey    string // 关联字段的外键 (Go 字段名)
+	References    string // 关联字段引用的字段 (Go 字段名)
+	Ignore        bool   // gorm:"-"
 }
 
 // parseGormTag 解析 gorm struct tag
@@ -63,6 +65,10 @@ func parseGormTag(tag string) *ParsedTag {
 				result.UniqueIndex = value
 			case "comment":
 				result.Comment = value
+			case "foreignkey":
+				result.ForeignKey = value
+			case "references":
+				result.References = value
 			}
 		} else {
 			// 处理单独的标志
@@ -73,6 +79,10 @@ func parseGormTag(tag string) *ParsedTag {
 				result.AutoIncrement = true
 			case "not null", "notnull":
 				result.NotNull = true
+			case "index":
+				result.Index = "true"
+			case "uniqueindex":
+				result.UniqueIndex = "true"
 			case "-":
 				result.Ignore = true
 			}
@@ -97,6 +107,9 @@ type FieldInfo struct {
 	// Type Go 类型
 	Type string
 
+	// ReflectType 字段的反射类型
+	ReflectType reflect.Type
+
 	// SQLType SQL 类型
 	SQLType string
 
@@ -111,6 +124,13 @@ type FieldInfo struct {
 
 	// Index 字段在结构体中的索引
 	Index int
+
+	// RelationType 关联字段指向的模型类型,非关联字段为 nil
+	// 关联字段不对应数据库列,仅用于推导外键
+	RelationType reflect.Type
+
+	// RelationMany 是否为一对多关联 ([]Model)
+	RelationMany bool
 }
 
 // parseStructFields 解析结构体字段
@@ -183,21 +203,65 @@ func parseStructFields(t reflect.Type, v reflect.Value, dialect DialectHandler)
 			isZero = true
 		}
 
+		relationType, relationMany := associationType(field.Type, parsedTag)
+
 		fields = append(fields, FieldInfo{
-			Name:       field.Name,
-			ColumnName: columnName,
-			Type:       goType,
-			SQLType:    sqlType,
-			Tag:        parsedTag,
-			Value:      fieldValue,
-			IsZero:     isZero,
-			Index:      i,
+			Name:         field.Name,
+			ColumnName:   columnName,
+			Type:         goType,
+			ReflectType:  field.Type,
+			SQLType:      sqlType,
+			Tag:          parsedTag,
+			Value:        fieldValue,
+			IsZero:       isZero,
+			Index:        i,
+			RelationType: relationType,
+			RelationMany: relationMany,
 		})
 	}
 
 	return fields
 }
 
+// associationType 判断字段是否为 GORM 关联字段 (Model、*Model、[]Model、[]*Model)
+// 目标结构体带有 foreignKey tag,或者目标结构体有主键 (primaryKey tag 或 ID 字段) 时视为关联,
+// 以区分 time.Time、sql.NullString 等普通结构体类型
+// 返回目标模型类型和是否为一对多,非关联字段返回 nil
+func associationType(t reflect.Type, tag *ParsedTag) (reflect.Type, bool) {
+	many := false
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		many = true
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	if tag.ForeignKey != "" || hasPrimaryKey(t) {
+		return t, many
+	}
+	return nil, false
+}
+
+// hasPrimaryKey 判断结构体是否有主键字段
+func hasPrimaryKey(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasPrimaryKey(field.Type) {
+			return true
+		}
+		if field.Name == "ID" || parseGormTag(field.Tag.Get("gorm")).PrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
 // getGoTypeName 获取 Go 类型名称的字符串表示
 func getGoTypeName(t reflect.Type) string {
 	switch t.Kind() {
@@ -0,0 +1,176 @@
+package sqlgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// 仓储聚合生成
+// ============================================================================
+
+// GenerateRepositories 生成聚合所有表 DAO 的 Repositories 结构体
+// 字段名为表对应的结构体名,类型为 *<Name>DAO,结构体名重复的表只保留第一个
+// 参数:
+//
+//	schemas: 表结构列表,应与生成 DAO 时使用的一致
+//	pkg: 包名,应与 DAO 所在包一致
+//
+// 返回:
+//
+//	string: Repositories 结构体和 NewRepositories 构造函数的 Go 代码
+func (c *CodeGenerator) GenerateRepositories(schemas []*Schema, pkg string) string {
+	return c.generateRepositories(schemas, pkg, nil)
+}
+
+// generateRepositories 生成 Repositories 代码
+// importPaths 为其他包的包名到导入路径的映射,Package 在其中的表使用 pkg.XxxDAO 引用,
+// 其余表视为与 pkg 同包
+func (c *CodeGenerator) generateRepositories(schemas []*Schema, pkg string, importPaths map[string]string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("package %s\n\n", pkg))
+
+	names := repositoryNames(schemas)
+	qualifiers := make(map[string]string, len(names))
+	var imports []string
+	for _, schema := range schemas {
+		if importPath, ok := importPaths[schema.Package]; ok && qualifiers[schema.Name] == "" {
+			qualifiers[schema.Name] = schema.Package + "."
+			imports = appendUnique(imports, importPath)
+		}
+	}
+	sort.Strings(imports)
+
+	sb.WriteString("import (\n")
+	sb.WriteString("\t\"gorm.io/gorm\"\n")
+	if len(imports) > 0 {
+		sb.WriteString("\n")
+		for _, imp := range imports {
+			sb.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
+		}
+	}
+	sb.WriteString(")\n\n")
+
+	// 聚合结构体
+	sb.WriteString("// Repositories 聚合所有表的数据访问对象\n")
+	sb.WriteString("// 服务层注入此结构体即可访问全部 DAO\n")
+	sb.WriteString("type Repositories struct {\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("\t%s *%s%sDAO\n", name, qualifiers[name], name))
+	}
+	sb.WriteString("}\n\n")
+
+	// 构造函数
+	sb.WriteString("// NewRepositories 使用同一个数据库连接创建所有 DAO\n")
+	sb.WriteString("func NewRepositories(db *gorm.DB) *Repositories {\n")
+	sb.WriteString("\treturn &Repositories{\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("\t\t%s: %sNew%sDAO(db),\n", name, qualifiers[name], name))
+	}
+	sb.WriteString("\t}\n")
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// repositoryNames 返回去重后的结构体名,保持表的声明顺序
+func repositoryNames(schemas []*Schema) []string {
+	seen := make(map[string]bool, len(schemas))
+	names := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		if schema.Name == "" || seen[schema.Name] {
+			continue
+		}
+		seen[schema.Name] = true
+		names = append(names, schema.Name)
+	}
+	return names
+}
+
+// ============================================================================
+// ReverseBuilder 仓储聚合支持
+// ============================================================================
+
+// GenerateRepositories 生成聚合所有表 DAO 的 Repositories 代码
+// 各表的 DAO 需使用相同的 Package 和 DAOMethods 生成,GenerateToDir 在启用
+// Config.Target.RepositorySet 时会一并生成
+func (r *ReverseBuilder) GenerateRepositories() (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	if len(r.schemas) == 0 {
+		return "", ErrParseFailed
+	}
+
+	// 拆分包时 repositories.go 位于输出目录,通过 Config.ImportPath 导入各子包
+	importPaths := make(map[string]string)
+	for _, schema := range r.schemas {
+		var subdir string
+		schema.Package, subdir = r.tablePackage(schema)
+		if schema.Package == r.options.Package {
+			continue
+		}
+		importPath := r.packageImportPath(subdir)
+		if importPath == "" {
+			return "", NewError(ErrCodeGenerateFailed, "import path is required for repositories across packages, set Config.ImportPath")
+		}
+		importPaths[schema.Package] = importPath
+	}
+
+	codegen := NewCodeGenerator(r.options)
+	return codegen.generateRepositories(r.schemas, r.options.Package, importPaths), nil
+}
+
+// writeRepositorySet 为每个表写入 DAO 文件,并写入 repositories.go
+// 文件名为相对 dir 的路径,拆分包时 DAO 文件位于包对应的子目录
+func (r *ReverseBuilder) writeRepositorySet(dir string, report *GenerateReport) error {
+	files := make(map[string]string, len(r.schemas)+1)
+	for _, schema := range r.schemas {
+		// DAO 与模型同包,拆分包时写入同一个子目录
+		var subdir string
+		schema.Package, subdir = r.tablePackage(schema)
+		daoCode, err := r.generateDAOCode(schema)
+		if err != nil {
+			return err
+		}
+		files[filepath.Join(subdir, convertNaming(schema.TableName, r.options.FileNaming)+DAOFileSuffix)] = daoCode
+	}
+
+	code, err := r.GenerateRepositories()
+	if err != nil {
+		return err
+	}
+	files[RepositoriesFileName] = code
+
+	// 按文件名顺序写入,保证 GenerateReport 的顺序稳定
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+
+		// 检查文件是否存在
+		if !r.options.Overwrite {
+			if _, err := os.Stat(path); err == nil {
+				report.add(path, false)
+				continue
+			}
+		}
+
+		written, err := writeGeneratedFile(path, files[name], r.force())
+		if err != nil {
+			return err
+		}
+		report.add(path, written)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package sqlgen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const repositoryTestDDL = `
+CREATE TABLE users (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	name varchar(64) NOT NULL
+);
+
+CREATE TABLE order_items (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	user_id bigint unsigned NOT NULL,
+	amount int NOT NULL
+);`
+
+// newRepositoryBuilder 创建启用仓储聚合的构建器
+func newRepositoryBuilder() *ReverseBuilder {
+	cfg := &Config{Dialect: MySQL, Target: GenerateTarget{RepositorySet: true}}
+	return New(cfg).
+		ParseSQL(repositoryTestDDL).
+		Package("models").
+		Tags(TagGorm).
+		DAOMethods("Create", "FindByID", "FindAll")
+}
+
+// TestGenerateRepositories_Content 测试聚合结构体为每个表包含一个字段
+func TestGenerateRepositories_Content(t *testing.T) {
+	code, err := newRepositoryBuilder().GenerateRepositories()
+	if err != nil {
+		t.Fatalf("GenerateRepositories() failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"package models",
+		"type Repositories struct",
+		"\tUser *UserDAO\n",
+		"\tOrderItem *OrderItemDAO\n",
+		"func NewRepositories(db *gorm.DB) *Repositories",
+		"\t\tUser: NewUserDAO(db),\n",
+		"\t\tOrderItem: NewOrderItemDAO(db),\n",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("repositories code missing %q, got:\n%s", want, code)
+		}
+	}
+}
+
+// TestGenerateRepositories_Compiles 测试 GenerateToDir 生成的模型、DAO 和仓储聚合可以编译
+func TestGenerateRepositories_Compiles(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	dir := t.TempDir()
+	if err := newRepositoryBuilder().GenerateToDir(filepath.Join(dir, "models")); err != nil {
+		t.Fatalf("GenerateToDir() failed: %v", err)
+	}
+
+	for _, name := range []string{"users_dao.go", "order_items_dao.go", RepositoriesFileName} {
+		if _, err := os.Stat(filepath.Join(dir, "models", name)); err != nil {
+			t.Errorf("expected %s to be generated: %v", name, err)
+		}
+	}
+
+	files := map[string]string{
+		"go.mod":           "module example.com/gen\n\ngo 1.21\n\nrequire gorm.io/gorm v0.0.0\n\nreplace gorm.io/gorm => ./gormstub\n",
+		"gormstub/go.mod":  "module gorm.io/gorm\n\ngo 1.21\n",
+		"gormstub/gorm.go": gormStub,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		code, _ := os.ReadFile(filepath.Join(dir, "models", RepositoriesFileName))
+		t.Fatalf("generated code does not compile: %v\n%s\n--- repositories ---\n%s", err, out, code)
+	}
+}
+
+// TestGenerateToDirReport_SkipsUnchanged 测试重复生成时内容未变化的文件全部跳过
+func TestGenerateToDirReport_SkipsUnchanged(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := newRepositoryBuilder().Overwrite(true).GenerateToDirReport(dir)
+	if err != nil {
+		t.Fatalf("first GenerateToDirReport() failed: %v", err)
+	}
+	// 两个模型文件、两个 DAO 文件和 repositories.go
+	if len(first.Written) != 5 || len(first.Skipped) != 0 {
+		t.Fatalf("first run: written %v, skipped %v", first.Written, first.Skipped)
+	}
+
+	second, err := newRepositoryBuilder().Overwrite(true).GenerateToDirReport(dir)
+	if err != nil {
+		t.Fatalf("second GenerateToDirReport() failed: %v", err)
+	}
+	if len(second.Written) != 0 || len(second.Skipped) != len(first.Written) {
+		t.Fatalf("second run: written %v, skipped %v", second.Written, second.Skipped)
+	}
+
+	// 内容变化的文件重新写入
+	usersPath := filepath.Join(dir, "users.go")
+	if err := os.WriteFile(usersPath, []byte(GeneratedFileHeader+"\n\npackage models\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	third, err := newRepositoryBuilder().Overwrite(true).GenerateToDirReport(dir)
+	if err != nil {
+		t.Fatalf("third GenerateToDirReport() failed: %v", err)
+	}
+	if len(third.Written) != 1 || third.Written[0] != usersPath {
+		t.Errorf("third run: written %v, want [%s]", third.Written, usersPath)
+	}
+}
@@ -1,6 +1,7 @@
 package sqlgen
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,13 +13,16 @@ import (
 
 // ParseSQL 从 SQL DDL 字符串解析表结构
 func (g *Generator) ParseSQL(ddl string) *ReverseBuilder {
-	parser := NewParser(g.config.Dialect)
+	parser := NewParser(g.config.Dialect).
+		SetSchemas(g.config.Schemas...).
+		SetDecimalType(g.config.DecimalType, g.config.DecimalImport)
 	schemas, _ := parser.Parse(ddl)
 
 	return &ReverseBuilder{
 		generator: g,
 		schemas:   schemas,
 		options:   DefaultReverseOptions(),
+		err:       g.templateErr,
 	}
 }
 
@@ -154,6 +158,12 @@ func (r *ReverseBuilder) Overwrite(enabled bool) *ReverseBuilder {
 	return r
 }
 
+// BatchSize 设置 DAO InsertBatch 每条 INSERT 语句的行数,见 ReverseOptions.BatchSize
+func (r *ReverseBuilder) BatchSize(n int) *ReverseBuilder {
+	r.options.BatchSize = n
+	return r
+}
+
 // Dialect 设置方言
 func (r *ReverseBuilder) Dialect(d Dialect) *ReverseBuilder {
 	r.generator.config.Dialect = d
@@ -224,54 +234,103 @@ func (r *ReverseBuilder) GenerateToFile(path string) error {
 		return WrapError(ErrCodeFileIO, "failed to create directory", err)
 	}
 
-	return os.WriteFile(path, []byte(code), 0644)
+	_, err = writeGeneratedFile(path, code, r.force())
+	return err
 }
 
 // GenerateToDir 生成代码到目录 (每个表一个文件)
 func (r *ReverseBuilder) GenerateToDir(dir string) error {
+	_, err := r.GenerateToDirReport(dir)
+	return err
+}
+
+// GenerateToDirReport 生成代码到目录,并返回各文件的写入情况
+// 内容未变化的文件不会被重写,见 GenerateReport
+func (r *ReverseBuilder) GenerateToDirReport(dir string) (*GenerateReport, error) {
 	if r.err != nil {
-		return r.err
+		return nil, r.err
 	}
 
 	// 确保目录存在
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return WrapError(ErrCodeFileIO, "failed to create directory", err)
+		return nil, WrapError(ErrCodeFileIO, "failed to create directory", err)
 	}
 
+	report := &GenerateReport{}
+
 	for _, schema := range r.schemas {
 		code, err := r.generateCode(schema)
 		if err != nil {
 			continue
 		}
 
-		// 生成文件名
+		// 生成文件名,拆分包时写入包对应的子目录
+		_, subdir := r.tablePackage(schema)
+		pkgDir := filepath.Join(dir, subdir)
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			return report, WrapError(ErrCodeFileIO, "failed to create directory", err)
+		}
 		filename := convertNaming(schema.TableName, r.options.FileNaming) + ".go"
-		filepath := filepath.Join(dir, filename)
+		filepath := filepath.Join(pkgDir, filename)
 
 		// 检查文件是否存在
 		if !r.options.Overwrite {
 			if _, err := os.Stat(filepath); err == nil {
+				report.add(filepath, false)
 				continue
 			}
 		}
 
-		if err := os.WriteFile(filepath, []byte(code), 0644); err != nil {
-			return WrapError(ErrCodeFileIO, "failed to write file", err)
+		written, err := writeGeneratedFile(filepath, code, r.force())
+		if err != nil {
+			return report, err
+		}
+		report.add(filepath, written)
+	}
+
+	// 生成 DAO 和仓储聚合
+	if r.generator != nil && r.generator.config.Target.RepositorySet {
+		if err := r.writeRepositorySet(dir, report); err != nil {
+			return report, err
 		}
 	}
 
-	return nil
+	return report, nil
 }
 
 // ============================================================================
 // 内部方法
 // ============================================================================
 
+// writeFileIfChanged 内容与已有文件不同时才写入,返回是否实际写入
+func writeFileIfChanged(path string, content []byte) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, content) {
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
+	// 过滤列,所有表一起过滤以保证关联字段引用的列都存在
+	if r.generator != nil {
+		for _, s := range r.schemas {
+			filterSchemaColumns(s, r.generator.config.Columns)
+		}
+	}
+
 	// 应用类型映射
 	for i := range schema.Fields {
 		if mappedType, ok := r.options.TypeMappings[schema.Fields[i].Column.Type]; ok {
 			schema.Fields[i].Type = mappedType
+			schema.Fields[i].Import = ""
 		}
 	}
 
@@ -282,6 +341,17 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 		}
 	}
 
+	// 枚举列使用具名类型
+	if r.generator != nil && r.generator.config.GenerateEnums {
+		assignEnumTypes(schema, r.options.TypeMappings)
+	}
+
+	// 根据外键生成关联字段
+	if r.generator != nil && r.generator.config.GenerateRelations {
+		schema.Relations = buildRelations(schema, r.schemas)
+		r.qualifyRelations(schema)
+	}
+
 	// 调用 BeforeGenerate 钩子
 	if r.options.BeforeGenerate != nil {
 		r.options.BeforeGenerate(schema)
@@ -302,12 +372,21 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 	}
 	schema.Imports = imports
 
-	// 设置包名
-	schema.Package = r.options.Package
+	// 设置包名,拆分包时按表确定
+	schema.Package, _ = r.tablePackage(schema)
 
-	// 生成代码
-	codegen := NewCodeGenerator(r.options)
-	code := codegen.Generate(schema)
+	// 生成代码,已注册 model 模板时使用自定义模板
+	var code string
+	if tmpl, ok := r.customTemplate(TemplateModel); ok {
+		rendered, err := RenderTemplate(tmpl, NewTemplateData(schema, r.options, nil))
+		if err != nil {
+			return "", err
+		}
+		code = rendered
+	} else {
+		codegen := NewCodeGenerator(r.options)
+		code = codegen.Generate(schema)
+	}
 
 	// 调用 AfterGenerate 钩子
 	if r.options.AfterGenerate != nil {
@@ -317,6 +396,40 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 	return code, nil
 }
 
+// filterSchemaColumns 移除被过滤的列,以及引用这些列的外键
+func filterSchemaColumns(schema *Schema, filter ColumnFilter) {
+	if len(filter.Include) == 0 && len(filter.Exclude) == 0 {
+		return
+	}
+
+	fields := schema.Fields[:0]
+	for _, field := range schema.Fields {
+		if filter.Allows(field.Column.Name) {
+			fields = append(fields, field)
+		}
+	}
+	schema.Fields = fields
+
+	// 被过滤的列可能是某个包的唯一使用者
+	schema.Imports = nil
+	analyzeImports(schema)
+
+	foreignKeys := schema.ForeignKeys[:0]
+	for _, fk := range schema.ForeignKeys {
+		kept := true
+		for _, col := range fk.Columns {
+			if !filter.Allows(col) {
+				kept = false
+				break
+			}
+		}
+		if kept {
+			foreignKeys = append(foreignKeys, fk)
+		}
+	}
+	schema.ForeignKeys = foreignKeys
+}
+
 // ============================================================================
 // 数据库逆向 (可选功能)
 // ============================================================================
@@ -0,0 +1,150 @@
+package sqlgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// Schema 快照文件
+// ============================================================================
+
+// 快照文件格式,按扩展名区分
+const (
+	schemaFormatJSON = "json"
+	schemaFormatYAML = "yaml"
+)
+
+// DumpSchema 将表结构写入快照文件
+// 文件格式由扩展名决定: .json 为 JSON,.yaml/.yml 为 YAML
+// 快照包含列、主键、索引、外键和注释,提交到仓库后可通过 ParseFromSchemaFile 离线生成代码
+func DumpSchema(schema *Schema, path string) error {
+	if schema == nil {
+		return ErrEmptyData
+	}
+
+	format, err := schemaFileFormat(path)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch format {
+	case schemaFormatJSON:
+		data, err = json.MarshalIndent(schema, "", "  ")
+		data = append(data, '\n')
+	default:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err = enc.Encode(schema); err == nil {
+			err = enc.Close()
+		}
+		data = buf.Bytes()
+	}
+	if err != nil {
+		return WrapError(ErrCodeGenerateFailed, "failed to encode schema", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return WrapError(ErrCodeFileIO, "failed to create directory", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return WrapError(ErrCodeFileIO, "failed to write file", err)
+	}
+	return nil
+}
+
+// ParseFromSchemaFile 从 DumpSchema 写入的快照文件读取表结构,不需要数据库连接
+// 手写的快照可以省略可推导的字段: 结构体名、字段名、字段类型 (取 Column.GoType) 和导入
+func (g *Generator) ParseFromSchemaFile(path string) (*Schema, error) {
+	format, err := schemaFileFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, WrapError(ErrCodeFileIO, "failed to read file", err)
+	}
+
+	schema := &Schema{}
+	switch format {
+	case schemaFormatJSON:
+		err = json.Unmarshal(content, schema)
+	default:
+		err = yaml.Unmarshal(content, schema)
+	}
+	if err != nil {
+		return nil, WrapError(ErrCodeParseFailed, "failed to decode schema file "+path, err)
+	}
+
+	if err := completeSchema(schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// FromSchema 从已解析的表结构创建逆向生成构建器
+// 通常与 ParseFromSchemaFile 配合使用:
+//
+//	schema, err := gen.ParseFromSchemaFile("schema/users.yaml")
+//	err = gen.FromSchema(schema).Package("models").GenerateToDir("./models")
+func (g *Generator) FromSchema(schemas ...*Schema) *ReverseBuilder {
+	return &ReverseBuilder{
+		generator: g,
+		schemas:   schemas,
+		options:   DefaultReverseOptions(),
+		err:       g.templateErr,
+	}
+}
+
+// schemaFileFormat 根据扩展名确定快照文件格式
+func schemaFileFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return schemaFormatJSON, nil
+	case ".yaml", ".yml":
+		return schemaFormatYAML, nil
+	default:
+		return "", NewError(ErrCodeFileIO, "unsupported schema file extension: "+path+" (want .json, .yaml or .yml)")
+	}
+}
+
+// completeSchema 校验快照并补全可推导的字段
+func completeSchema(schema *Schema) error {
+	if schema.TableName == "" {
+		return NewError(ErrCodeParseFailed, "schema file: table_name is required")
+	}
+	if len(schema.Fields) == 0 {
+		return NewError(ErrCodeParseFailed, "schema file: table "+schema.TableName+" has no fields")
+	}
+
+	if schema.Name == "" {
+		schema.Name = toStructName(schema.TableName)
+	}
+	for i := range schema.Fields {
+		field := &schema.Fields[i]
+		if field.Column.Name == "" {
+			return NewError(ErrCodeParseFailed, "schema file: table "+schema.TableName+" has a field without column name")
+		}
+		if field.Name == "" {
+			field.Name = toPascalCase(field.Column.Name)
+		}
+		if field.Type == "" {
+			field.Type = field.Column.GoType
+		}
+		if field.Type == "" {
+			return NewError(ErrCodeParseFailed, "schema file: column "+schema.TableName+"."+field.Column.Name+" has no Go type")
+		}
+	}
+	if len(schema.Imports) == 0 {
+		analyzeImports(schema)
+	}
+	return nil
+}
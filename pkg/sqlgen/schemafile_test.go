@@ -0,0 +1,109 @@
+package sqlgen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const schemaFileTestDDL = `
+CREATE TABLE posts (
+	id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+	user_id bigint NOT NULL COMMENT '作者',
+	title varchar(128) NOT NULL DEFAULT '' COMMENT '标题',
+	price decimal(10,2),
+	created_at datetime,
+	UNIQUE KEY uk_posts_title (title),
+	KEY idx_posts_user (user_id),
+	CONSTRAINT fk_posts_user FOREIGN KEY (user_id) REFERENCES users (id)
+);`
+
+// TestSchemaFile_RoundTrip 测试快照写入、读取后生成的代码与直接解析 DDL 一致
+func TestSchemaFile_RoundTrip(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+	generate := func(schema *Schema) string {
+		code, err := gen.FromSchema(schema).Package("models").Tags(TagGorm | TagJson).Generate()
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		return code
+	}
+
+	parsed, err := NewParser(MySQL).Parse(schemaFileTestDDL)
+	if err != nil || len(parsed) != 1 {
+		t.Fatalf("Parse() = %v, %v", parsed, err)
+	}
+	original := parsed[0]
+	original.Comment = "文章"
+
+	for _, name := range []string{"posts.json", "posts.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schema", name)
+			if err := DumpSchema(original, path); err != nil {
+				t.Fatalf("DumpSchema() failed: %v", err)
+			}
+
+			loaded, err := gen.ParseFromSchemaFile(path)
+			if err != nil {
+				t.Fatalf("ParseFromSchemaFile() failed: %v", err)
+			}
+			if !reflect.DeepEqual(loaded, original) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", loaded, original)
+			}
+
+			// 生成会修改 Schema (包名、导入),每次使用新读取的副本
+			want, _ := gen.ParseFromSchemaFile(path)
+			if got := generate(loaded); got != generate(want) || !strings.Contains(got, `gorm:"column:title`) {
+				t.Errorf("generated code mismatch:\n%s", got)
+			}
+		})
+	}
+
+	if len(original.Indexes) != 2 || len(original.ForeignKeys) != 1 {
+		t.Errorf("test DDL should produce indexes and foreign keys, got %+v", original)
+	}
+}
+
+// TestParseFromSchemaFile_Minimal 测试手写快照省略可推导字段
+func TestParseFromSchemaFile_Minimal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.yml")
+	content := `table_name: sys_users
+fields:
+  - column: {name: id, type: BIGINT, go_type: int64, primary_key: true}
+  - column: {name: created_at, type: DATETIME, go_type: time.Time}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := New(nil).ParseFromSchemaFile(path)
+	if err != nil {
+		t.Fatalf("ParseFromSchemaFile() failed: %v", err)
+	}
+	if schema.Name != "SysUser" || schema.Fields[1].Name != "CreatedAt" || schema.Fields[1].Type != "time.Time" {
+		t.Errorf("derived fields not filled: %+v", schema)
+	}
+	if !reflect.DeepEqual(schema.Imports, []string{"time"}) {
+		t.Errorf("Imports = %v, want [time]", schema.Imports)
+	}
+}
+
+// TestParseFromSchemaFile_Invalid 测试不支持的扩展名和缺少必填字段
+func TestParseFromSchemaFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	gen := New(nil)
+
+	if _, err := gen.ParseFromSchemaFile(filepath.Join(dir, "schema.sql")); !IsError(err, ErrCodeFileIO) {
+		t.Errorf("unsupported extension: expected ErrCodeFileIO, got %v", err)
+	}
+
+	path := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(path, []byte(`{"fields": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.ParseFromSchemaFile(path); !IsError(err, ErrCodeParseFailed) {
+		t.Errorf("missing table_name: expected ErrCodeParseFailed, got %v", err)
+	}
+}
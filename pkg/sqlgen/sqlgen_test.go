@@ -1,6 +1,8 @@
 package sqlgen
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -74,6 +76,112 @@ func TestDrop(t *testing.T) {
 	}
 }
 
+type ddlUser struct {
+	ID    uint64    `gorm:"column:id;primaryKey;autoIncrement"`
+	Name  string    `gorm:"column:name;size:64"`
+	Posts []ddlPost `gorm:"foreignKey:UserID"`
+}
+
+func (ddlUser) TableName() string { return "users" }
+
+type ddlPost struct {
+	ID     uint64   `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID uint64   `gorm:"column:user_id;not null;index:idx_posts_user_id"`
+	Title  string   `gorm:"column:title;size:128"`
+	User   *ddlUser `gorm:"foreignKey:UserID"`
+}
+
+func (ddlPost) TableName() string { return "posts" }
+
+func TestDDLOrderAndDown(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+
+	// 故意先传入引用方,验证按依赖排序
+	result, err := gen.DDL(&DDLOptions{IfNotExists: true, GenerateDown: true}, &ddlPost{}, &ddlUser{})
+	if err != nil {
+		t.Fatalf("DDL() failed: %v", err)
+	}
+
+	users := strings.Index(result.Up, "CREATE TABLE IF NOT EXISTS `users`")
+	posts := strings.Index(result.Up, "CREATE TABLE IF NOT EXISTS `posts`")
+	if users < 0 || posts < 0 || users > posts {
+		t.Fatalf("users should be created before posts, got:\n%s", result.Up)
+	}
+	if !strings.Contains(result.Up, "CONSTRAINT `fk_posts_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)") {
+		t.Errorf("posts should reference users, got:\n%s", result.Up)
+	}
+	if strings.Count(result.Up, "FOREIGN KEY") != 1 {
+		t.Errorf("belongs-to and has-many should produce one foreign key, got:\n%s", result.Up)
+	}
+	if strings.Contains(result.Up, "  `posts` ") || strings.Contains(result.Up, "  `user` ") {
+		t.Errorf("association fields should not become columns, got:\n%s", result.Up)
+	}
+
+	expectedDown := "DROP TABLE IF EXISTS `posts`;\nDROP TABLE IF EXISTS `users`;"
+	if result.Down != expectedDown {
+		t.Errorf("Down = %q, want %q", result.Down, expectedDown)
+	}
+	if len(result.Tables) != 2 || result.Tables[0] != "users" || result.Tables[1] != "posts" {
+		t.Errorf("Tables = %v, want [users posts]", result.Tables)
+	}
+}
+
+func TestDDLDialects(t *testing.T) {
+	opts := &DDLOptions{IfNotExists: true, GenerateDown: true}
+
+	result, err := New(&Config{Dialect: PostgreSQL}).DDL(opts, &ddlUser{}, &ddlPost{})
+	if err != nil {
+		t.Fatalf("DDL() failed: %v", err)
+	}
+	for _, want := range []string{
+		`CREATE TABLE IF NOT EXISTS "users"`,
+		`CREATE INDEX IF NOT EXISTS "idx_posts_user_id" ON "posts" ("user_id");`,
+		`FOREIGN KEY ("user_id") REFERENCES "users" ("id")`,
+	} {
+		if !strings.Contains(result.Up, want) {
+			t.Errorf("PostgreSQL up should contain %q, got:\n%s", want, result.Up)
+		}
+	}
+	if strings.Contains(result.Up, "  INDEX") || strings.Contains(result.Up, "ENGINE=") {
+		t.Errorf("PostgreSQL up should not use MySQL syntax, got:\n%s", result.Up)
+	}
+	if result.Down != "DROP TABLE IF EXISTS \"posts\";\nDROP TABLE IF EXISTS \"users\";" {
+		t.Errorf("unexpected PostgreSQL down: %q", result.Down)
+	}
+
+	result, err = New(&Config{Dialect: SQLServer}).DDL(opts, &ddlUser{}, &ddlPost{})
+	if err != nil {
+		t.Fatalf("DDL() failed: %v", err)
+	}
+	if !strings.Contains(result.Up, "IF OBJECT_ID(N'users', N'U') IS NULL\nCREATE TABLE [users]") {
+		t.Errorf("SQL Server up should guard with OBJECT_ID, got:\n%s", result.Up)
+	}
+
+	// 未启用选项时保持原有语句
+	result, _ = New(&Config{Dialect: MySQL}).DDL(nil, &ddlUser{})
+	if strings.Contains(result.Up, "IF NOT EXISTS") || result.Down != "" {
+		t.Errorf("options should be disabled by default, got up %q down %q", result.Up, result.Down)
+	}
+}
+
+func TestGenerateDDLToFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "001_init.up.sql")
+
+	err := New(&Config{Dialect: MySQL}).GenerateDDLToFile(path, &DDLOptions{GenerateDown: true}, &ddlUser{}, &ddlPost{})
+	if err != nil {
+		t.Fatalf("GenerateDDLToFile() failed: %v", err)
+	}
+
+	down, err := os.ReadFile(filepath.Join(dir, "001_init.down.sql"))
+	if err != nil {
+		t.Fatalf("down migration should be written: %v", err)
+	}
+	if !strings.HasPrefix(string(down), "DROP TABLE IF EXISTS `posts`;") {
+		t.Errorf("unexpected down migration: %q", down)
+	}
+}
+
 // ============================================================================
 // INSERT 测试
 // ============================================================================
@@ -293,6 +401,525 @@ func TestParseSQL(t *testing.T) {
 	}
 }
 
+func TestParseSQLTableNameAndIndexes(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+
+	ddl := `
+	CREATE TABLE user_account (
+		id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+		email varchar(128) NOT NULL,
+		phone varchar(32) UNIQUE,
+		tenant_id bigint NOT NULL,
+		status tinyint DEFAULT 1,
+		UNIQUE KEY uk_email (email),
+		KEY idx_tenant_status (tenant_id, status) USING BTREE
+	);
+	CREATE INDEX idx_status ON user_account (status);`
+
+	code, err := gen.ParseSQL(ddl).
+		Package("models").
+		Tags(TagGorm).
+		WithTableName(true).
+		Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+
+	if !strings.Contains(code, "TableName() string {\n\treturn \"user_account\"\n}") {
+		t.Errorf("TableName should return parsed table name, got:\n%s", code)
+	}
+
+	tests := []struct {
+		field string
+		tag   string
+	}{
+		{"Email", "uniqueIndex:uk_email"},
+		{"Phone", "uniqueIndex"},
+		{"TenantId", "index:idx_tenant_status"},
+		{"Status", "index:idx_tenant_status"},
+		{"Status", "index:idx_status"},
+	}
+	for _, tt := range tests {
+		line := fieldLine(code, tt.field)
+		if !strings.Contains(line, tt.tag) {
+			t.Errorf("field %s should carry %q, got %q", tt.field, tt.tag, line)
+		}
+	}
+
+	if line := fieldLine(code, "Id"); strings.Contains(line, "ndex") {
+		t.Errorf("primary key should not carry index tags, got %q", line)
+	}
+}
+
+// TestParseSQLCompositeIndexOrder 测试复合索引按索引列顺序生成 priority,
+// 未命名的复合唯一索引使用数据库的默认名称
+func TestParseSQLCompositeIndexOrder(t *testing.T) {
+	ddl := `
+	CREATE TABLE member (
+		id bigint PRIMARY KEY,
+		tenant_id bigint NOT NULL,
+		email varchar(128) NOT NULL,
+		status tinyint NOT NULL,
+		KEY idx_status_tenant (status, tenant_id),
+		UNIQUE KEY (email, tenant_id)
+	);`
+
+	tests := []struct {
+		dialect Dialect
+		field   string
+		tag     string
+	}{
+		{MySQL, "Status", "index:idx_status_tenant,priority:1"},
+		{MySQL, "TenantId", "index:idx_status_tenant,priority:2"},
+		{MySQL, "Email", "uniqueIndex:email,priority:1"},
+		{MySQL, "TenantId", "uniqueIndex:email,priority:2"},
+		{PostgreSQL, "Email", "uniqueIndex:member_email_tenant_id_key,priority:1"},
+		{PostgreSQL, "TenantId", "uniqueIndex:member_email_tenant_id_key,priority:2"},
+	}
+	for _, tt := range tests {
+		code, err := New(&Config{Dialect: tt.dialect}).ParseSQL(ddl).
+			Package("models").
+			Tags(TagGorm).
+			Generate()
+		if err != nil {
+			t.Fatalf("ParseSQL().Generate() failed: %v", err)
+		}
+		line := fieldLine(code, tt.field)
+		if !strings.Contains(line, tt.tag) {
+			t.Errorf("%s: field %s should carry %q, got %q", tt.dialect, tt.field, tt.tag, line)
+		}
+		if strings.Contains(line, "idx_member") {
+			t.Errorf("%s: field %s should not carry an invented index name, got %q", tt.dialect, tt.field, line)
+		}
+	}
+}
+
+func TestParseSQLNumericTypes(t *testing.T) {
+	ddl := `
+	CREATE TABLE orders (
+		id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+		quantity int unsigned NOT NULL,
+		amount decimal(10,2) NOT NULL,
+		rate float
+	);`
+
+	code, err := New(&Config{Dialect: MySQL}).ParseSQL(ddl).Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+
+	tests := []struct {
+		field  string
+		goType string
+	}{
+		{"Id", "uint64"},
+		{"Quantity", "uint32"},
+		{"Amount", "string"},
+		{"Rate", "float32"},
+	}
+	for _, tt := range tests {
+		if fields := strings.Fields(fieldLine(code, tt.field)); len(fields) < 2 || fields[1] != tt.goType {
+			t.Errorf("field %s should be %s, got %q", tt.field, tt.goType, fieldLine(code, tt.field))
+		}
+	}
+
+	code, err = New(&Config{Dialect: MySQL, DecimalType: DecimalTypeShopspring}).ParseSQL(ddl).Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+	if !strings.Contains(fieldLine(code, "Amount"), "decimal.Decimal") {
+		t.Errorf("Amount should be decimal.Decimal, got %q", fieldLine(code, "Amount"))
+	}
+	if !strings.Contains(code, `"github.com/shopspring/decimal"`) {
+		t.Errorf("code should import shopspring/decimal, got:\n%s", code)
+	}
+}
+
+func TestParseSQLBindingTags(t *testing.T) {
+	ddl := `
+	CREATE TABLE users (
+		id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+		name varchar(50) NOT NULL,
+		nickname varchar(32),
+		status tinyint NOT NULL DEFAULT 1,
+		age int NOT NULL
+	);`
+
+	code, err := New(&Config{Dialect: MySQL}).ParseSQL(ddl).
+		Tags(TagJson | TagBinding).
+		Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+
+	tests := []struct {
+		field string
+		tag   string
+	}{
+		{"Name", `binding:"required,max=50"`},
+		{"Nickname", `binding:"max=32"`},
+		{"Age", `binding:"required"`},
+	}
+	for _, tt := range tests {
+		if line := fieldLine(code, tt.field); !strings.Contains(line, tt.tag) {
+			t.Errorf("field %s should carry %s, got %q", tt.field, tt.tag, line)
+		}
+	}
+
+	for _, field := range []string{"Id", "Status"} {
+		if line := fieldLine(code, field); strings.Contains(line, "binding:") {
+			t.Errorf("field %s should not carry binding tag, got %q", field, line)
+		}
+	}
+}
+
+func TestParseSQLEnums(t *testing.T) {
+	ddl := `
+	CREATE TABLE orders (
+		id bigint PRIMARY KEY,
+		status enum('pending', 'in-progress') NOT NULL DEFAULT 'pending'
+	);`
+
+	code, err := New(&Config{Dialect: MySQL, GenerateEnums: true}).
+		ParseSQL(ddl).
+		Name("Order").
+		Tags(TagGorm).
+		Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"type OrderStatus string",
+		`OrderStatusPending OrderStatus = "pending"`,
+		`OrderStatusInProgress OrderStatus = "in-progress"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("code should contain %q, got:\n%s", want, code)
+		}
+	}
+	if line := fieldLine(code, "Status"); !strings.Contains(line, "Status OrderStatus") {
+		t.Errorf("Status field should use OrderStatus, got %q", line)
+	}
+
+	// 未启用时保持 string
+	code, _ = New(&Config{Dialect: MySQL}).ParseSQL(ddl).Name("Order").Generate()
+	if strings.Contains(code, "OrderStatus") || !strings.Contains(fieldLine(code, "Status"), "Status string") {
+		t.Errorf("enums should be disabled by default, got:\n%s", code)
+	}
+}
+
+func TestParseSQLPostgresEnums(t *testing.T) {
+	ddl := `
+	CREATE TYPE order_status AS ENUM ('pending', 'paid');
+	CREATE TABLE orders (
+		id bigint PRIMARY KEY,
+		status order_status NOT NULL
+	);`
+
+	code, err := New(&Config{Dialect: PostgreSQL, GenerateEnums: true}).
+		ParseSQL(ddl).
+		Name("Order").
+		Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+
+	if !strings.Contains(code, `OrderStatusPaid OrderStatus = "paid"`) {
+		t.Errorf("code should contain enum constants, got:\n%s", code)
+	}
+	if line := fieldLine(code, "Status"); !strings.Contains(line, "Status OrderStatus") {
+		t.Errorf("Status field should use OrderStatus, got %q", line)
+	}
+}
+
+const postgresSchemasDDL = `
+CREATE TABLE users (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(64) NOT NULL
+);
+
+CREATE TABLE sales.orders (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL REFERENCES public.users(id),
+  amount INTEGER NOT NULL
+);
+
+CREATE INDEX idx_orders_user ON sales.orders (user_id);
+
+CREATE TABLE "reporting"."daily_totals" (
+  day DATE PRIMARY KEY,
+  total BIGINT NOT NULL
+);`
+
+func TestParseSQLPostgresSchemas(t *testing.T) {
+	tables := func(cfg *Config) map[string]*Schema {
+		t.Helper()
+		schemas := New(cfg).ParseSQL(postgresSchemasDDL).schemas
+		result := make(map[string]*Schema, len(schemas))
+		for _, s := range schemas {
+			result[s.TableName] = s
+		}
+		return result
+	}
+
+	// 默认只解析 public
+	got := tables(&Config{Dialect: PostgreSQL})
+	if len(got) != 1 || got["users"] == nil || got["users"].SchemaName != "public" {
+		t.Fatalf("default schemas should parse only public tables, got %v", got)
+	}
+
+	got = tables(&Config{Dialect: PostgreSQL, Schemas: []string{"sales", "reporting"}})
+	if len(got) != 2 || got["users"] != nil {
+		t.Fatalf("expected sales and reporting tables only, got %v", got)
+	}
+	orders := got["orders"]
+	if orders == nil || orders.SchemaName != "sales" || orders.QualifiedTableName() != "sales.orders" {
+		t.Fatalf("orders should record schema sales, got %+v", orders)
+	}
+	if len(orders.Fields) != 3 || len(orders.Indexes) != 1 || len(orders.ForeignKeys) != 1 || orders.ForeignKeys[0].RefTable != "users" {
+		t.Errorf("orders columns/index/fk not parsed: %+v", orders)
+	}
+	if daily := got["daily_totals"]; daily == nil || daily.SchemaName != "reporting" {
+		t.Errorf("quoted schema should be recorded, got %+v", daily)
+	}
+
+	// 生成的 TableName 带 schema 限定,public 表保持原样
+	code, err := New(&Config{Dialect: PostgreSQL, Schemas: []string{"sales"}}).
+		ParseSQL(postgresSchemasDDL).WithTableName(true).Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if !strings.Contains(code, `return "sales.orders"`) {
+		t.Errorf("TableName should be schema-qualified, got:\n%s", code)
+	}
+
+	// 其他方言不过滤 schema
+	if got := tables(&Config{Dialect: MySQL}); len(got) != 3 {
+		t.Errorf("MySQL should parse all tables, got %d", len(got))
+	}
+}
+
+func TestParseSQLRelations(t *testing.T) {
+	ddl := `
+	CREATE TABLE users (
+		id bigint PRIMARY KEY,
+		name varchar(64)
+	);
+	CREATE TABLE posts (
+		id bigint PRIMARY KEY,
+		user_id bigint NOT NULL,
+		title varchar(128),
+		CONSTRAINT fk_posts_user FOREIGN KEY (user_id) REFERENCES users (id)
+	);`
+
+	codes, err := New(&Config{Dialect: MySQL, GenerateRelations: true}).
+		ParseSQL(ddl).
+		Tags(TagGorm).
+		GenerateAll()
+	if err != nil {
+		t.Fatalf("ParseSQL().GenerateAll() failed: %v", err)
+	}
+
+	post := codes["posts"]
+	if !strings.Contains(post, "type Post struct") {
+		t.Fatalf("posts should generate Post struct, got:\n%s", post)
+	}
+	if line := fieldLine(post, "User"); !strings.Contains(line, "User *User") || !strings.Contains(line, `gorm:"foreignKey:UserId;references:Id"`) {
+		t.Errorf("Post should have belongs-to User field, got %q", line)
+	}
+
+	user := codes["users"]
+	if line := fieldLine(user, "Posts"); !strings.Contains(line, "Posts []*Post") || !strings.Contains(line, "foreignKey:UserId") {
+		t.Errorf("User should have has-many Posts field, got %q", line)
+	}
+
+	// 未启用时不生成关联字段
+	codes, _ = New(&Config{Dialect: MySQL}).ParseSQL(ddl).GenerateAll()
+	if fieldLine(codes["posts"], "User") != "" {
+		t.Errorf("relations should be disabled by default, got:\n%s", codes["posts"])
+	}
+}
+
+func TestRenderTemplateRelations(t *testing.T) {
+	schema := &Schema{
+		Name:      "Post",
+		TableName: "posts",
+		Package:   "models",
+		Relations: []RelationInfo{{
+			Name:       "User",
+			Kind:       RelationBelongsTo,
+			Target:     "User",
+			ForeignKey: "UserId",
+			References: "Id",
+		}},
+	}
+
+	code, err := RenderTemplate(DefaultStructTemplate, NewTemplateData(schema, DefaultReverseOptions(), nil))
+	if err != nil {
+		t.Fatalf("RenderTemplate() failed: %v", err)
+	}
+	if !strings.Contains(code, "User *User `gorm:\"foreignKey:UserId;references:Id\"`") {
+		t.Errorf("template should render relation field, got:\n%s", code)
+	}
+}
+
+const customTemplateDDL = `CREATE TABLE user_accounts (
+  id BIGINT NOT NULL AUTO_INCREMENT,
+  user_name VARCHAR(64) NOT NULL,
+  PRIMARY KEY (id)
+);`
+
+func TestRegisterTemplateOverridesModel(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+	tmpl := `// custom {{.Name}} ({{ToKebabCase .Name}})
+package {{.Package}}
+{{range .Fields}}// {{.Name}} -> {{ToSnakeCase .Name}}
+{{end}}`
+	if err := gen.RegisterTemplate(TemplateModel, tmpl); err != nil {
+		t.Fatalf("RegisterTemplate() failed: %v", err)
+	}
+
+	code, err := gen.ParseSQL(customTemplateDDL).Package("models").Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	for _, want := range []string{"// custom UserAccount (user-account)", "package models", "// UserName -> user_name"} {
+		if !strings.Contains(code, want) {
+			t.Errorf("custom template output missing %q, got:\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "type UserAccount struct") {
+		t.Errorf("built-in model template should be overridden, got:\n%s", code)
+	}
+}
+
+func TestRegisterTemplateInvalid(t *testing.T) {
+	gen := New(nil)
+	if err := gen.RegisterTemplate(TemplateModel, "{{.Name"); !IsError(err, ErrCodeGenerateFailed) {
+		t.Errorf("RegisterTemplate() with invalid template = %v, want ErrCodeGenerateFailed", err)
+	}
+	if err := gen.RegisterTemplate("", "x"); err == nil {
+		t.Error("RegisterTemplate() with empty name should fail")
+	}
+}
+
+func TestTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "dao.tmpl"), []byte("package {{.Package}}\n// dao for {{.Name}}\n{{range .Methods}}{{.}}{{end}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "service.tmpl"), []byte("type {{.Name}}Service struct{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := New(&Config{Dialect: MySQL, TemplateDir: dir})
+
+	structCode, daoCode, err := gen.ParseSQL(customTemplateDDL).DAOMethods("Create").GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	if !strings.Contains(structCode, "type UserAccount struct") {
+		t.Errorf("model template is not overridden, built-in output expected, got:\n%s", structCode)
+	}
+	if !strings.Contains(daoCode, "// dao for UserAccount") || !strings.Contains(daoCode, "func (d *UserAccountDAO) Create(") {
+		t.Errorf("custom dao template output unexpected, got:\n%s", daoCode)
+	}
+
+	code, err := gen.ParseSQL(customTemplateDDL).GenerateTemplate("service")
+	if err != nil {
+		t.Fatalf("GenerateTemplate() failed: %v", err)
+	}
+	if code != "type UserAccountService struct{}" {
+		t.Errorf("GenerateTemplate() = %q", code)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.tmpl"), []byte("{{if}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(&Config{Dialect: MySQL, TemplateDir: dir}).ParseSQL(customTemplateDDL).Generate(); err == nil {
+		t.Error("Generate() should return the template dir load error")
+	}
+}
+
+type filteredUser struct {
+	ID        uint64 `gorm:"column:id;primaryKey;autoIncrement"`
+	Username  string `gorm:"column:username"`
+	Password  string `gorm:"column:password"`
+	AuditNote string `gorm:"column:audit_note"`
+}
+
+func (filteredUser) TableName() string { return "users" }
+
+func TestColumnFilter(t *testing.T) {
+	cfg := &Config{
+		Dialect: MySQL,
+		Columns: ColumnFilter{Exclude: []string{"password", "audit_*"}},
+	}
+
+	ddl := `
+	CREATE TABLE users (
+		id bigint PRIMARY KEY,
+		username varchar(64) NOT NULL,
+		password varchar(128) NOT NULL,
+		audit_by bigint,
+		audit_at datetime
+	);`
+
+	structCode, daoCode, err := New(cfg).ParseSQL(ddl).
+		DAOMethods("Create", "FindByID").
+		GenerateWithDAO()
+	if err != nil {
+		t.Fatalf("GenerateWithDAO() failed: %v", err)
+	}
+	for _, code := range []string{structCode, daoCode} {
+		for _, excluded := range []string{"password", "Password", "audit_", "Audit"} {
+			if strings.Contains(code, excluded) {
+				t.Errorf("generated code should not contain %q, got:\n%s", excluded, code)
+			}
+		}
+	}
+	if fieldLine(structCode, "Username") == "" {
+		t.Errorf("Username should be kept, got:\n%s", structCode)
+	}
+	// 过滤后不再需要 time 包
+	if strings.Contains(structCode, `"time"`) {
+		t.Errorf("unused time import should be dropped, got:\n%s", structCode)
+	}
+
+	user := &filteredUser{ID: 1, Username: "admin", Password: "secret", AuditNote: "x"}
+	insert, err := New(cfg).Create(user)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	update, err := New(cfg).Model(user).Where("id = ?", 1).Updates(user)
+	if err != nil {
+		t.Fatalf("Updates() failed: %v", err)
+	}
+	for _, sql := range []string{insert, update} {
+		if !strings.Contains(sql, "`username`") || strings.Contains(sql, "password") || strings.Contains(sql, "audit_note") {
+			t.Errorf("excluded columns should be removed, got %q", sql)
+		}
+	}
+
+	if !(ColumnFilter{Include: []string{"id", "user*"}}).Allows("USERNAME") {
+		t.Error("Include should match case-insensitively")
+	}
+	if (ColumnFilter{Include: []string{"id"}}).Allows("username") {
+		t.Error("columns outside Include should be filtered")
+	}
+}
+
+// fieldLine 返回生成代码中指定字段的定义行
+func fieldLine(code, field string) string {
+	for _, line := range strings.Split(code, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), field+" ") {
+			return line
+		}
+	}
+	return ""
+}
+
 // ============================================================================
 // 方言测试
 // ============================================================================
@@ -2,6 +2,10 @@ package sqlgen
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"text/template"
 )
 
@@ -20,11 +24,12 @@ import (
 {{end}}type {{.Name}} struct {
 {{range .Fields}}{{if and .Comment $.WithComments}}	// {{.Name}} {{.Comment}}
 {{end}}	{{.Name}} {{.Type}}{{if .Tags}} ` + "`{{.Tags}}`" + `{{end}}
+{{end}}{{range .Relations}}	{{.Name}} {{.GoType}} ` + "`gorm:\"{{.GormTag}}\"`" + `
 {{end}}}
 {{if $.WithTableName}}
 // TableName overrides the table name
 func ({{.Name}}) TableName() string {
-	return "{{.TableName}}"
+	return "{{.QualifiedTableName}}"
 }
 {{end}}`
 
@@ -53,21 +58,153 @@ func New{{.Name}}DAO(db *gorm.DB) *{{.Name}}DAO {
 // ============================================================================
 
 // TemplateData 传递给模板的数据结构
+// Schema 和 ReverseOptions 都有 Package、Imports 字段,
+// 模板中直接访问会产生歧义,因此单独提供,应使用 NewTemplateData 创建
 type TemplateData struct {
 	*Schema
 	*ReverseOptions
 	Methods []string
+
+	// Package 包名
+	Package string
+
+	// Imports 需要导入的包
+	Imports []string
+
+	// Relations 由外键推导的关联字段 (启用 Config.GenerateRelations 时生成)
+	Relations []RelationInfo
+}
+
+// NewTemplateData 创建模板数据
+func NewTemplateData(schema *Schema, opts *ReverseOptions, methods []string) *TemplateData {
+	return &TemplateData{
+		Schema:         schema,
+		ReverseOptions: opts,
+		Methods:        methods,
+		Package:        schema.Package,
+		Imports:        schema.Imports,
+		Relations:      schema.Relations,
+	}
+}
+
+// ============================================================================
+// 模板函数
+// ============================================================================
+
+// TemplateFuncs 返回自定义模板中可用的辅助函数
+//
+//	ToSnakeCase  UserName -> user_name
+//	ToCamelCase  user_name -> userName
+//	ToPascalCase user_name -> UserName
+//	ToKebabCase  UserName -> user-name
+//	Singularize  users -> user
+//	Lower/Upper  strings.ToLower / strings.ToUpper
+//	Join         strings.Join,如 {{Join .Imports ", "}}
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"ToSnakeCase":  toSnakeCase,
+		"ToCamelCase":  toCamelCase,
+		"ToPascalCase": toPascalCase,
+		"ToKebabCase":  toKebabCase,
+		"Singularize":  singularize,
+		"Lower":        strings.ToLower,
+		"Upper":        strings.ToUpper,
+		"Join":         strings.Join,
+	}
+}
+
+// parseTemplate 使用辅助函数解析模板
+func parseTemplate(name, tmplStr string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(TemplateFuncs()).Parse(tmplStr)
+	if err != nil {
+		return nil, WrapError(ErrCodeGenerateFailed, "failed to parse template "+name, err)
+	}
+	return tmpl, nil
+}
+
+// ============================================================================
+// 模板注册
+// ============================================================================
+
+// templateRegistry 自定义模板注册表
+type templateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]string
+}
+
+// newTemplateRegistry 创建模板注册表
+func newTemplateRegistry() *templateRegistry {
+	return &templateRegistry{templates: make(map[string]string)}
+}
+
+// register 校验并注册模板
+func (t *templateRegistry) register(name, tmplStr string) error {
+	if name == "" {
+		return NewError(ErrCodeGenerateFailed, "template name is empty")
+	}
+	if _, err := parseTemplate(name, tmplStr); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.templates[name] = tmplStr
+	t.mu.Unlock()
+	return nil
+}
+
+// lookup 查找已注册的模板
+func (t *templateRegistry) lookup(name string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	tmpl, ok := t.templates[name]
+	return tmpl, ok
+}
+
+// loadDir 加载目录下的所有 .tmpl 文件,模板名为去掉扩展名的文件名
+func (t *templateRegistry) loadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+TemplateFileExt))
+	if err != nil {
+		return WrapError(ErrCodeFileIO, "failed to list template dir", err)
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return WrapError(ErrCodeFileIO, "failed to read template file", err)
+		}
+		name := strings.TrimSuffix(filepath.Base(file), TemplateFileExt)
+		if err := t.register(name, string(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterTemplate 注册自定义模板
+// 模板在注册时解析校验,可使用 TemplateFuncs 中的辅助函数,数据为 *TemplateData;
+// 与内置模板 (TemplateModel、TemplateDAO) 同名时覆盖内置生成逻辑,
+// 重复注册同名模板会替换之前的内容
+func (g *Generator) RegisterTemplate(name, tmpl string) error {
+	return g.templates.register(name, tmpl)
+}
+
+// customTemplate 查找生成器上注册的自定义模板
+func (r *ReverseBuilder) customTemplate(name string) (string, bool) {
+	if r.generator == nil || r.generator.templates == nil {
+		return "", false
+	}
+	return r.generator.templates.lookup(name)
 }
 
 // ============================================================================
 // 模板渲染
 // ============================================================================
 
-// RenderTemplate 使用模板渲染代码
+// RenderTemplate 使用模板渲染代码,模板中可使用 TemplateFuncs 中的辅助函数
 func RenderTemplate(tmplStr string, data *TemplateData) (string, error) {
-	tmpl, err := template.New("sqlgen").Parse(tmplStr)
+	tmpl, err := parseTemplate("sqlgen", tmplStr)
 	if err != nil {
-		return "", WrapError(ErrCodeGenerateFailed, "failed to parse template", err)
+		return "", err
 	}
 
 	var buf bytes.Buffer
@@ -109,6 +246,11 @@ func (r *ReverseBuilder) GenerateWithDAO() (structCode string, daoCode string, e
 
 	schema := r.schemas[0]
 
+	// 应用自定义名称
+	if r.options.StructName != "" {
+		schema.Name = r.options.StructName
+	}
+
 	// 生成结构体
 	structCode, err = r.generateCode(schema)
 	if err != nil {
@@ -116,12 +258,53 @@ func (r *ReverseBuilder) GenerateWithDAO() (structCode string, daoCode string, e
 	}
 
 	// 生成 DAO
-	codegen := NewCodeGenerator(r.options)
-	daoCode = codegen.GenerateDAO(schema, r.daoMethods)
+	daoCode, err = r.generateDAOCode(schema)
+	if err != nil {
+		return "", "", err
+	}
 
 	return structCode, daoCode, nil
 }
 
+// generateDAOCode 生成单个表的 DAO 代码
+// 已注册 dao 模板时使用自定义模板,Methods 为各方法的代码
+func (r *ReverseBuilder) generateDAOCode(schema *Schema) (string, error) {
+	codegen := NewCodeGenerator(r.options)
+	if r.generator != nil {
+		codegen.dialect = r.generator.config.Dialect
+		codegen.queryTimeout = r.generator.config.QueryTimeout
+	}
+	if tmpl, ok := r.customTemplate(TemplateDAO); ok {
+		methods := codegen.GenerateDAOMethods(schema, r.daoMethods)
+		return RenderTemplate(tmpl, NewTemplateData(schema, r.options, methods))
+	}
+	return codegen.GenerateDAO(schema, r.daoMethods), nil
+}
+
+// GenerateTemplate 使用已注册的模板渲染第一个表
+// 用于 model、dao 之外的自定义模板 (如 service、handler),模板数据同 RenderTemplate
+func (r *ReverseBuilder) GenerateTemplate(name string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+
+	tmpl, ok := r.customTemplate(name)
+	if !ok {
+		return "", NewError(ErrCodeGenerateFailed, "template not registered: "+name)
+	}
+
+	if len(r.schemas) == 0 {
+		return "", ErrParseFailed
+	}
+
+	schema := r.schemas[0]
+	if r.options.StructName != "" {
+		schema.Name = r.options.StructName
+	}
+
+	return RenderTemplate(tmpl, NewTemplateData(schema, r.options, nil))
+}
+
 // ============================================================================
 // 增量更新支持
 // ============================================================================
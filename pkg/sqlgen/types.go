@@ -1,7 +1,9 @@
 package sqlgen
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,128 @@ type Config struct {
 
 	// AllowEmptyCondition 是否允许无条件的 UPDATE/DELETE
 	AllowEmptyCondition bool
+
+	// GenerateEnums 逆向生成时是否为枚举列生成具名类型和常量
+	// MySQL 识别 enum('a','b') 列类型,PostgreSQL 识别 CREATE TYPE ... AS ENUM 定义的类型
+	GenerateEnums bool
+
+	// GenerateRelations 逆向生成时是否根据外键生成关联字段
+	// 外键所在表生成 belongs-to 字段,被引用的表 (同一 DDL 中解析到时) 生成 has-many 字段,
+	// 生成的字段带 foreignKey/references tag,可直接用于 GORM Preload
+	GenerateRelations bool
+
+	// Columns 列过滤规则
+	// 逆向生成时被过滤的列不会出现在模型和 DAO 中,
+	// 正向生成 INSERT/UPDATE 时被过滤的列不会出现在列列表中
+	Columns ColumnFilter
+
+	// DecimalType 逆向生成时 DECIMAL/NUMERIC 列的 Go 类型,默认 string
+	// 设为 float64 恢复浮点映射 (会丢失精度),设为 DecimalTypeShopspring 时自动导入 shopspring/decimal,
+	// 其他带包名的类型需同时设置 DecimalImport
+	DecimalType string
+
+	// DecimalImport DecimalType 所在包的导入路径,如 "github.com/shopspring/decimal"
+	DecimalImport string
+
+	// Schemas PostgreSQL 逆向生成时解析的 schema 列表,默认 ["public"]
+	// 其他 schema 中的表 (如 CREATE TABLE reporting.daily) 会被跳过,对其他方言无效
+	Schemas []string
+
+	// TemplateDir 自定义模板目录
+	// 目录下的 *.tmpl 文件在 New 时加载,模板名为去掉扩展名的文件名,
+	// 与内置模板 (model、dao) 同名时覆盖内置生成逻辑
+	TemplateDir string
+
+	// PackagePerTable 逆向生成到目录时每个表生成到单独的包
+	// 包名和子目录名为单数化的表名,如 users -> user/users.go (package user),
+	// order_items -> orderitem/order_items.go (package orderitem)
+	PackagePerTable bool
+
+	// TableGroups 将表分组生成到子包,键为包名 (同时是子目录名),值为表名列表
+	// 优先于 PackagePerTable;未分组的表在启用 PackagePerTable 时单独成包,否则生成到输出目录
+	TableGroups map[string][]string
+
+	// ImportPath 逆向生成输出目录对应的导入路径,如 "example.com/app/models"
+	// 拆分包后跨包的 belongs-to 关联字段和 repositories.go 通过它导入子包,
+	// 未设置时跨包关联字段不生成
+	ImportPath string
+
+	// Force 逆向生成到文件时覆盖没有 GeneratedFileHeader 的已有文件
+	// 默认拒绝覆盖并返回 ErrCodeHandEdited 错误,避免手工修改被生成结果替换
+	Force bool
+
+	// QueryTimeout 生成的 DAO 方法的单次查询超时,为 0 时不限制
+	// 设置后每个方法执行查询前用 context.WithTimeout 包装 context:
+	// 接收 ctx 的方法包装传入的 ctx,其他方法包装 *gorm.DB 上已绑定的 context
+	QueryTimeout time.Duration
+
+	// Target 逆向生成的附加产物
+	Target GenerateTarget
+}
+
+// GenerateTarget 逆向生成的附加产物
+type GenerateTarget struct {
+	// RepositorySet 是否生成聚合所有表 DAO 的 Repositories 结构体及 NewRepositories 构造函数
+	// 启用后 GenerateToDir 会为每个表额外生成 DAO 文件和 repositories.go,
+	// 服务层只需注入一个 *Repositories
+	RepositorySet bool
+}
+
+// GenerateReport 生成到目录的结果
+// 内容与已有文件相同或因未开启 Overwrite 而保留的文件计入 Skipped,不会被重写,
+// 文件修改时间保持不变,重复执行 go:generate 不会产生多余的 VCS 变更
+type GenerateReport struct {
+	// Written 实际写入的文件路径
+	Written []string
+	// Skipped 跳过写入的文件路径
+	Skipped []string
+}
+
+// add 按是否写入记录文件路径
+func (r *GenerateReport) add(path string, written bool) {
+	if written {
+		r.Written = append(r.Written, path)
+	} else {
+		r.Skipped = append(r.Skipped, path)
+	}
+}
+
+// QualifiedTableName 返回带 schema 限定的表名
+// schema 为空或为 PostgreSQL 默认的 public 时返回表名本身,否则返回 schema.table
+func (s *Schema) QualifiedTableName() string {
+	if s.SchemaName == "" || s.SchemaName == DefaultPostgresSchema {
+		return s.TableName
+	}
+	return s.SchemaName + "." + s.TableName
+}
+
+// ColumnFilter 列过滤规则
+// 模式支持精确匹配、前缀通配 (audit_*) 和后缀通配 (*_internal),不区分大小写
+type ColumnFilter struct {
+	// Include 仅保留匹配的列,为空时保留所有列
+	Include []string
+
+	// Exclude 排除匹配的列,优先于 Include
+	Exclude []string
+}
+
+// Allows 判断列是否被保留
+func (f ColumnFilter) Allows(column string) bool {
+	column = strings.ToLower(column)
+	for _, pattern := range f.Exclude {
+		if matchPattern(column, strings.ToLower(pattern)) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, pattern := range f.Include {
+		if matchPattern(column, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
 }
 
 // DefaultConfig 返回默认配置
@@ -35,6 +159,7 @@ func DefaultConfig() *Config {
 		SkipZeroValue:       true,
 		SoftDelete:          true,
 		AllowEmptyCondition: false,
+		Schemas:             []string{DefaultPostgresSchema},
 	}
 }
 
@@ -45,94 +170,179 @@ func DefaultConfig() *Config {
 // Schema 表示解析后的表结构
 type Schema struct {
 	// Name 结构体名称 (PascalCase)
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// TableName 表名 (snake_case)
-	TableName string
+	TableName string `json:"table_name" yaml:"table_name"`
+
+	// SchemaName 表所在的 schema (命名空间),如 sales.orders 中的 sales
+	// PostgreSQL 未限定 schema 的表为 public,其他方言未限定时为空
+	SchemaName string `json:"schema_name,omitempty" yaml:"schema_name,omitempty"`
 
 	// Fields 字段列表
-	Fields []Field
+	Fields []Field `json:"fields,omitempty" yaml:"fields,omitempty"`
 
 	// Comment 表注释
-	Comment string
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
 
 	// Indexes 索引列表
-	Indexes []Index
+	Indexes []Index `json:"indexes,omitempty" yaml:"indexes,omitempty"`
+
+	// Enums 枚举类型列表 (启用 Config.GenerateEnums 时生成)
+	Enums []Enum `json:"enums,omitempty" yaml:"enums,omitempty"`
+
+	// ForeignKeys 外键列表
+	ForeignKeys []ForeignKey `json:"foreign_keys,omitempty" yaml:"foreign_keys,omitempty"`
+
+	// Relations 关联关系列表 (启用 Config.GenerateRelations 时由 ForeignKeys 生成)
+	Relations []RelationInfo `json:"-" yaml:"-"`
 
 	// Package 包名 (用于代码生成)
-	Package string
+	Package string `json:"-" yaml:"-"`
 
 	// Imports 需要导入的包
-	Imports []string
+	Imports []string `json:"imports,omitempty" yaml:"imports,omitempty"`
 }
 
 // Field 表示结构体字段
 type Field struct {
 	// Name Go 字段名 (PascalCase)
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Type Go 类型 (如 string, int64, *time.Time)
-	Type string
+	Type string `json:"type" yaml:"type"`
+
+	// Import Type 所需的导入路径,为空时根据类型推断 (如 time.Time -> time)
+	Import string `json:"import,omitempty" yaml:"import,omitempty"`
 
 	// Tags 完整的 struct tag 字符串
-	Tags string
+	Tags string `json:"-" yaml:"-"`
 
 	// Column 对应的数据库列信息
-	Column Column
+	Column Column `json:"column" yaml:"column"`
 
 	// Comment 字段注释
-	Comment string
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
 }
 
 // Column 表示数据库列定义
 type Column struct {
 	// Name 列名
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Type SQL 数据类型 (如 VARCHAR(64), BIGINT)
-	Type string
+	Type string `json:"type" yaml:"type"`
 
 	// GoType 对应的 Go 类型
-	GoType string
+	GoType string `json:"go_type,omitempty" yaml:"go_type,omitempty"`
 
 	// PrimaryKey 是否为主键
-	PrimaryKey bool
+	PrimaryKey bool `json:"primary_key,omitempty" yaml:"primary_key,omitempty"`
 
 	// AutoIncrement 是否自增
-	AutoIncrement bool
+	AutoIncrement bool `json:"auto_increment,omitempty" yaml:"auto_increment,omitempty"`
 
 	// NotNull 是否非空
-	NotNull bool
+	NotNull bool `json:"not_null,omitempty" yaml:"not_null,omitempty"`
 
 	// Default 默认值
-	Default string
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
 
 	// Comment 列注释
-	Comment string
+	Comment string `json:"comment,omitempty" yaml:"comment,omitempty"`
 
 	// Size 大小限制 (用于 VARCHAR 等)
-	Size int
+	Size int `json:"size,omitempty" yaml:"size,omitempty"`
 
 	// Precision 精度 (用于 DECIMAL 等)
-	Precision int
+	Precision int `json:"precision,omitempty" yaml:"precision,omitempty"`
 
 	// Scale 小数位数 (用于 DECIMAL 等)
-	Scale int
+	Scale int `json:"scale,omitempty" yaml:"scale,omitempty"`
+
+	// EnumValues 枚举列允许的值,非枚举列为空
+	EnumValues []string `json:"enum_values,omitempty" yaml:"enum_values,omitempty"`
 }
 
 // Index 表示数据库索引定义
 type Index struct {
 	// Name 索引名
-	Name string
+	Name string `json:"name" yaml:"name"`
 
 	// Columns 索引包含的列
-	Columns []string
+	Columns []string `json:"columns,omitempty" yaml:"columns,omitempty"`
 
 	// Unique 是否为唯一索引
-	Unique bool
+	Unique bool `json:"unique,omitempty" yaml:"unique,omitempty"`
 
 	// Type 索引类型 (BTREE, HASH 等)
-	Type string
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// ForeignKey 表示外键约束
+type ForeignKey struct {
+	// Name 约束名,未命名时为空
+	Name string `json:"name" yaml:"name"`
+
+	// Columns 本表的外键列
+	Columns []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+
+	// RefTable 被引用的表
+	RefTable string `json:"ref_table,omitempty" yaml:"ref_table,omitempty"`
+
+	// RefColumns 被引用的列
+	RefColumns []string `json:"ref_columns,omitempty" yaml:"ref_columns,omitempty"`
+}
+
+// RelationKind 关联类型
+type RelationKind string
+
+const (
+	// RelationBelongsTo 外键在本表,如 Post.User
+	RelationBelongsTo RelationKind = "belongs_to"
+	// RelationHasMany 外键在对方表,如 User.Posts
+	RelationHasMany RelationKind = "has_many"
+)
+
+// RelationInfo 描述由外键推导出的关联字段
+type RelationInfo struct {
+	// Name 关联字段名 (如 User, Posts)
+	Name string
+
+	// Kind 关联类型
+	Kind RelationKind
+
+	// Target 关联的结构体名 (如 User, Post)
+	Target string
+
+	// ForeignKey 外键字段的 Go 字段名 (如 UserId)
+	ForeignKey string
+
+	// References 被引用字段的 Go 字段名 (如 Id)
+	References string
+}
+
+// GoType 返回关联字段的 Go 类型
+// belongs-to 为 *Target,has-many 为 []*Target
+func (r RelationInfo) GoType() string {
+	if r.Kind == RelationHasMany {
+		return "[]*" + r.Target
+	}
+	return "*" + r.Target
+}
+
+// GormTag 返回关联字段的 GORM tag 值
+func (r RelationInfo) GormTag() string {
+	return fmt.Sprintf("foreignKey:%s;references:%s", r.ForeignKey, r.References)
+}
+
+// Enum 表示枚举列对应的 Go 类型
+type Enum struct {
+	// Name Go 类型名 (如 OrderStatus)
+	Name string `json:"name" yaml:"name"`
+
+	// Values 枚举值
+	Values []string `json:"values,omitempty" yaml:"values,omitempty"`
 }
 
 // ============================================================================
@@ -246,6 +456,19 @@ type ReverseOptions struct {
 
 	// Overwrite 是否覆盖已存在的文件
 	Overwrite bool
+
+	// Mock 是否为 DAO 生成接口和 mock 实现
+	// 启用后 DAO 代码中会包含 <Name>DAOInterface 接口,
+	// 并可通过 GenerateDAOMock 生成 mocks 包中的 Mock<Name>DAO
+	Mock bool
+
+	// MockImportPath 生成的结构体和 DAO 所在包的导入路径
+	// mock 代码通过此路径引用实体类型和 DAO 接口
+	MockImportPath string
+
+	// BatchSize DAO InsertBatch 每条 INSERT 语句的行数
+	// 为 0 时按方言的绑定参数上限除以列数计算,且不超过 DefaultMaxBatchSize
+	BatchSize int
 }
 
 // DefaultReverseOptions 返回默认逆向生成选项
@@ -264,6 +487,34 @@ func DefaultReverseOptions() *ReverseOptions {
 	}
 }
 
+// ============================================================================
+// DDL 生成配置 (DDL Options)
+// ============================================================================
+
+// DDLOptions 多表 DDL 生成选项
+type DDLOptions struct {
+	// IfNotExists 是否生成幂等的建表语句
+	// MySQL/PostgreSQL/SQLite 使用 CREATE TABLE IF NOT EXISTS,
+	// SQL Server 使用 IF OBJECT_ID(...) IS NULL 判断
+	IfNotExists bool
+
+	// GenerateDown 是否同时生成回滚语句 (DROP TABLE IF EXISTS)
+	// 删除顺序与创建顺序相反,保证先删除引用方
+	GenerateDown bool
+}
+
+// DDLResult 多表 DDL 生成结果
+type DDLResult struct {
+	// Up 建表语句,被引用的表排在前面
+	Up string
+
+	// Down 回滚语句,未启用 GenerateDown 时为空
+	Down string
+
+	// Tables 按创建顺序排列的表名
+	Tables []string
+}
+
 // ============================================================================
 // 辅助类型 (Helper Types)
 // ============================================================================
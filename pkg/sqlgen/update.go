@@ -150,6 +150,11 @@ func (g *Generator) filterUpdateFields(fields []FieldInfo) []FieldInfo {
 		fieldNameLower := strings.ToLower(field.Name)
 		columnNameLower := strings.ToLower(field.ColumnName)
 
+		// 跳过 Config.Columns 过滤的列
+		if !g.config.Columns.Allows(field.ColumnName) {
+			continue
+		}
+
 		// 如果在 Omit 列表中，跳过
 		if omitMap[fieldNameLower] || omitMap[columnNameLower] {
 			continue
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// BlobStore 基于 Storage 的内容寻址存储
+// 内容按 SHA256 存放在分片目录下 (<root>/ab/cd/abcd...),相同内容只保存一份,
+// 适合附件、导入文件等重复率高的场景
+//
+// 使用示例:
+//
+//	blobs := storage.NewBlobStore(fs, "")
+//	hash, err := blobs.PutBlob(data)
+//	data, err = blobs.GetBlob(hash)
+type BlobStore struct {
+	storage Storage
+	root    string
+}
+
+// NewBlobStore 创建内容寻址存储
+// 参数:
+//
+//	s: 底层存储
+//	root: 存放内容的根目录,为空时使用 DefaultBlobRoot
+func NewBlobStore(s Storage, root string) *BlobStore {
+	if root == "" {
+		root = DefaultBlobRoot
+	}
+	return &BlobStore{storage: s, root: root}
+}
+
+// PutBlob 保存内容并返回其 SHA256 哈希 (小写十六进制)
+// 内容已存在时不会重复写入;新内容先写入临时文件再重命名,
+// 读取方不会看到写了一半的文件
+func (b *BlobStore) PutBlob(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	target := b.BlobPath(hash)
+
+	exists, err := b.storage.Exists(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to check blob %s: %w", hash, err)
+	}
+	if exists {
+		return hash, nil
+	}
+
+	dir := filepath.Dir(target)
+	if err := b.storage.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create blob dir: %w", err)
+	}
+
+	fs := b.storage.FileSystem()
+	tmp, err := afero.TempFile(fs, dir, hash+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp blob: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		_ = fs.Remove(tmpName)
+		return "", fmt.Errorf("failed to write blob %s: %w", hash, err)
+	}
+
+	if err := fs.Rename(tmpName, target); err != nil {
+		_ = fs.Remove(tmpName)
+		return "", fmt.Errorf("failed to commit blob %s: %w", hash, err)
+	}
+
+	return hash, nil
+}
+
+// GetBlob 按哈希读取内容
+// 哈希格式错误时返回 ErrInvalidBlobHash,内容不存在时返回 ErrBlobNotFound
+func (b *BlobStore) GetBlob(hash string) ([]byte, error) {
+	if !validBlobHash(hash) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBlobHash, hash)
+	}
+
+	data, err := b.storage.ReadFile(b.BlobPath(hash))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, hash)
+		}
+		return nil, fmt.Errorf("failed to read blob %s: %w", hash, err)
+	}
+
+	return data, nil
+}
+
+// HasBlob 检查内容是否存在
+// 哈希格式错误时返回 ErrInvalidBlobHash
+func (b *BlobStore) HasBlob(hash string) (bool, error) {
+	if !validBlobHash(hash) {
+		return false, fmt.Errorf("%w: %q", ErrInvalidBlobHash, hash)
+	}
+
+	return b.storage.Exists(b.BlobPath(hash))
+}
+
+// BlobPath 返回哈希对应的存储路径,如 blobs/ab/cd/abcd...
+// 不校验哈希格式,调用方应传入 PutBlob 返回的哈希
+func (b *BlobStore) BlobPath(hash string) string {
+	parts := make([]string, 0, blobShardDepth+2)
+	parts = append(parts, b.root)
+	for i := 0; i < blobShardDepth && (i+1)*2 <= len(hash); i++ {
+		parts = append(parts, hash[i*2:(i+1)*2])
+	}
+	parts = append(parts, hash)
+	return filepath.Join(parts...)
+}
+
+// validBlobHash 判断是否为 64 位小写十六进制,同时防止哈希中带路径分隔符
+func validBlobHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newBlobStore 创建基于内存文件系统的内容寻址存储
+func newBlobStore(t *testing.T) (*BlobStore, Storage) {
+	t.Helper()
+
+	s, err := New(&Config{FSType: FSTypeMemory})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return NewBlobStore(s, ""), s
+}
+
+// TestBlobStoreDedup 测试相同内容得到相同哈希和路径,且只保存一个文件
+func TestBlobStoreDedup(t *testing.T) {
+	blobs, s := newBlobStore(t)
+	data := []byte("hello blob")
+
+	first, err := blobs.PutBlob(data)
+	if err != nil {
+		t.Fatalf("PutBlob() failed: %v", err)
+	}
+	second, err := blobs.PutBlob(append([]byte(nil), data...))
+	if err != nil {
+		t.Fatalf("PutBlob() failed: %v", err)
+	}
+	if first != second {
+		t.Fatalf("same content got different hashes: %s vs %s", first, second)
+	}
+
+	want := "blobs/" + first[:2] + "/" + first[2:4] + "/" + first
+	if got := blobs.BlobPath(first); got != want {
+		t.Errorf("BlobPath() = %s, want %s", got, want)
+	}
+
+	entries, err := s.ListDir(want[:strings.LastIndex(want, "/")])
+	if err != nil {
+		t.Fatalf("ListDir() failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != first {
+		t.Errorf("shard dir should contain exactly the blob, got %d entries", len(entries))
+	}
+
+	got, err := blobs.GetBlob(first)
+	if err != nil {
+		t.Fatalf("GetBlob() failed: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("GetBlob() = %q, want %q", got, data)
+	}
+
+	other, err := blobs.PutBlob([]byte("other blob"))
+	if err != nil {
+		t.Fatalf("PutBlob() failed: %v", err)
+	}
+	if other == first {
+		t.Error("different content should have different hashes")
+	}
+}
+
+// TestBlobStoreMissing 测试不存在的内容和非法哈希
+func TestBlobStoreMissing(t *testing.T) {
+	blobs, _ := newBlobStore(t)
+	missing := strings.Repeat("0", 64)
+
+	if ok, err := blobs.HasBlob(missing); err != nil || ok {
+		t.Errorf("HasBlob() = %v, %v, want false, nil", ok, err)
+	}
+	if _, err := blobs.GetBlob(missing); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("GetBlob() error = %v, want ErrBlobNotFound", err)
+	}
+
+	for _, hash := range []string{"", "abc", "../../etc/passwd", strings.Repeat("G", 64)} {
+		if _, err := blobs.GetBlob(hash); !errors.Is(err, ErrInvalidBlobHash) {
+			t.Errorf("GetBlob(%q) error = %v, want ErrInvalidBlobHash", hash, err)
+		}
+		if _, err := blobs.HasBlob(hash); !errors.Is(err, ErrInvalidBlobHash) {
+			t.Errorf("HasBlob(%q) error = %v, want ErrInvalidBlobHash", hash, err)
+		}
+	}
+}
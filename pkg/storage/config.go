@@ -19,6 +19,19 @@ type Config struct {
 
 	// WatchBufferSize 文件监听事件缓冲区大小
 	WatchBufferSize int `mapstructure:"watch_buffer_size"`
+
+	// MaxWatches 最多同时监听的路径数量
+	// 0 表示不限制 (仍受操作系统 inotify 上限约束)
+	MaxWatches int `mapstructure:"max_watches"`
+
+	// OnWatchError 文件监听器出错时的回调 (如事件队列溢出)
+	// 在监听器的事件分发 goroutine 中调用,不应长时间阻塞;为 nil 时忽略错误
+	OnWatchError func(error) `mapstructure:"-"`
+
+	// MaxTotalBytes BasePath 下所有文件的总大小上限 (字节)
+	// WriteFile/Copy/CopyDir/SaveExcel/SaveImage 写入后超过上限时返回 ErrQuotaExceeded,
+	// 0 表示不限制
+	MaxTotalBytes int64 `mapstructure:"max_total_bytes"`
 }
 
 // ValidateName 返回配置名称
@@ -46,6 +59,16 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("%w: watch_buffer_size must be non-negative", ErrInvalidConfig)
 	}
 
+	// 验证监听数量上限
+	if c.MaxWatches < 0 {
+		return fmt.Errorf("%w: max_watches must be non-negative", ErrInvalidConfig)
+	}
+
+	// 验证配额
+	if c.MaxTotalBytes < 0 {
+		return fmt.Errorf("%w: max_total_bytes must be non-negative", ErrInvalidConfig)
+	}
+
 	return nil
 }
 
@@ -82,4 +105,18 @@ func (c *Config) OverrideConfig() {
 			c.WatchBufferSize = val
 		}
 	}
+
+	// STORAGE_MAX_WATCHES
+	if maxWatches := os.Getenv("STORAGE_MAX_WATCHES"); maxWatches != "" {
+		if val, err := strconv.Atoi(maxWatches); err == nil {
+			c.MaxWatches = val
+		}
+	}
+
+	// STORAGE_MAX_TOTAL_BYTES
+	if maxTotalBytes := os.Getenv("STORAGE_MAX_TOTAL_BYTES"); maxTotalBytes != "" {
+		if val, err := strconv.ParseInt(maxTotalBytes, 10, 64); err == nil {
+			c.MaxTotalBytes = val
+		}
+	}
 }
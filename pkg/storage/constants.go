@@ -42,4 +42,37 @@ const (
 
 	// WatchEventChmod 文件权限变更事件
 	WatchEventChmod = "CHMOD"
+
+	// WatchEventError 监听器错误事件
+	// 监听器出错时发送给所有监听中的处理函数,错误本身通过 Config.OnWatchError 获取
+	WatchEventError = "ERROR"
+)
+
+// 内容寻址存储
+const (
+	// DefaultBlobRoot BlobStore 的默认根目录
+	DefaultBlobRoot = "blobs"
+
+	// blobShardDepth 分片目录层数,每层取哈希的 2 个字符 (ab/cd/abcd...)
+	blobShardDepth = 2
+)
+
+// Anchor 水印在图片中的锚点位置
+type Anchor int
+
+const (
+	// AnchorTopLeft 左上角
+	AnchorTopLeft Anchor = iota
+
+	// AnchorTopRight 右上角
+	AnchorTopRight
+
+	// AnchorCenter 居中
+	AnchorCenter
+
+	// AnchorBottomLeft 左下角
+	AnchorBottomLeft
+
+	// AnchorBottomRight 右下角
+	AnchorBottomRight
 )
@@ -51,7 +51,9 @@ func (i *impl) Copy(src, dst string, opts ...CopyOption) error {
 	}
 
 	// 写入目标文件
-	if err := afero.WriteFile(i.fs, dst, data, srcInfo.Mode()); err != nil {
+	if err := i.withQuota(dst, int64(len(data)), func() error {
+		return afero.WriteFile(i.fs, dst, data, srcInfo.Mode())
+	}); err != nil {
 		return fmt.Errorf("Storage: failed to write destination file: %w", err)
 	}
 
@@ -94,13 +96,24 @@ func (i *impl) CopyDir(src, dst string, opts ...CopyOption) error {
 		return fmt.Errorf("%w: %s is a file, use Copy instead", ErrNotDirectory, src)
 	}
 
-	// 对于 OS 文件系统,使用 otiai10/copy 库获得更好的性能
-	if i.config.FSType == FSTypeOS {
-		return i.copyDirWithLib(src, dst, options)
+	// 按源目录总大小检查配额,覆盖和跳过的文件使增量不精确,复制后重新统计
+	if i.config.MaxTotalBytes > 0 {
+		defer i.invalidateUsage()
+	}
+	size, err := dirSize(i.fs, src)
+	if err != nil {
+		return fmt.Errorf("Storage: failed to compute source directory size: %w", err)
 	}
 
-	// 对于其他文件系统,使用 afero 实现
-	return i.copyDirWithAfero(src, dst, options)
+	return i.withQuota("", size, func() error {
+		// 对于 OS 文件系统,使用 otiai10/copy 库获得更好的性能
+		if i.config.FSType == FSTypeOS {
+			return i.copyDirWithLib(src, dst, options)
+		}
+
+		// 对于其他文件系统,使用 afero 实现
+		return i.copyDirWithAfero(src, dst, options)
+	})
 }
 
 // copyDirWithLib 使用 otiai10/copy 库复制目录
@@ -23,4 +23,36 @@ var (
 
 	// ErrWatcherAlreadyExists 监听器已存在错误
 	ErrWatcherAlreadyExists = errors.New("Storage: watcher already exists for this path")
+
+	// ErrWatchLimitReached 监听数量达到上限错误
+	// 超过 Config.MaxWatches 或操作系统的 inotify 上限 (ENOSPC) 时返回
+	ErrWatchLimitReached = errors.New("Storage: watch limit reached")
+
+	// ErrInvalidSignature 访问令牌无效错误
+	ErrInvalidSignature = errors.New("Storage: invalid signed path token")
+
+	// ErrSignatureExpired 访问令牌过期错误
+	ErrSignatureExpired = errors.New("Storage: signed path token expired")
+
+	// ErrInvalidBlobHash 内容哈希格式错误
+	// 哈希必须是 64 位小写十六进制的 SHA256
+	ErrInvalidBlobHash = errors.New("Storage: invalid blob hash")
+
+	// ErrBlobNotFound 内容不存在错误
+	ErrBlobNotFound = errors.New("Storage: blob not found")
+
+	// ErrQuotaExceeded 写入后将超过 Config.MaxTotalBytes
+	ErrQuotaExceeded = errors.New("Storage: quota exceeded")
+
+	// ErrLockHeld 锁已被其他调用方持有
+	// TryLock 遇到竞争时返回
+	ErrLockHeld = errors.New("Storage: lock held by another owner")
+
+	// ErrInvalidWatermark 水印参数错误
+	// 水印图片为空、透明度不在 [0, 1] 或锚点未定义时返回
+	ErrInvalidWatermark = errors.New("Storage: invalid watermark")
+
+	// ErrInvalidImageSize 图片尺寸参数错误
+	// 宽高为负数或同时为 0 时返回
+	ErrInvalidImageSize = errors.New("Storage: invalid image size")
 )
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"image"
+	"io"
 	"os"
 	"time"
 
@@ -254,9 +255,98 @@ type Storage interface {
 	//   error: 处理失败时的错误
 	CropImage(src, dst string, rect image.Rectangle, format imaging.Format) error
 
+	// Watermark 为图片添加水印
+	// 参数:
+	//   src: 源图片路径
+	//   dst: 目标图片路径
+	//   mark: 水印图片,超出源图片的部分被裁掉
+	//   pos: 水印锚点,根据源图片尺寸计算位置,水印紧贴对应的边
+	//   opacity: 水印不透明度,0 为完全透明,1 为完全不透明
+	//   format: 输出格式
+	// 返回:
+	//   error: 参数无效时返回 ErrInvalidWatermark,处理失败时的错误
+	Watermark(src, dst string, mark image.Image, pos Anchor, opacity float64, format imaging.Format) error
+
+	// ConvertImage 转换图片格式
+	// 参数:
+	//   src: 源图片路径
+	//   dst: 目标图片路径
+	//   format: 输出格式
+	// 返回:
+	//   error: 处理失败时的错误
+	// 注意:
+	//   转为不支持透明度的 JPEG 时,透明区域以白色背景填充
+	ConvertImage(src, dst string, format imaging.Format) error
+
+	// ResizeDir 批量调整目录中所有图片的大小
+	// 参数:
+	//   srcDir: 源目录,递归处理子目录
+	//   dstDir: 目标目录,保持源目录结构,扩展名替换为输出格式对应的扩展名
+	//   width: 目标宽度 (0表示按比例)
+	//   height: 目标高度 (0表示按比例)
+	//   format: 输出格式
+	//   concurrency: 并发处理的文件数,<= 0 时使用 CPU 核数
+	// 返回:
+	//   ResizeReport: 每个文件的处理结果,单个文件失败不会中断其他文件
+	//   error: 参数无效 (ErrInvalidImageSize)、源目录不存在或不是目录时的错误
+	// 注意:
+	//   扩展名不是图片格式的文件计入 Skipped,图片解码或写入失败计入 Failed
+	ResizeDir(srcDir, dstDir string, width, height int, format imaging.Format, concurrency int) (ResizeReport, error)
+
+	// ===== 临时文件 =====
+
+	// TempFile 在当前文件系统中创建临时文件
+	// 参数:
+	//   dir: 所在目录,为空时使用 os.TempDir() (在当前文件系统内解析,目录不存在时自动创建)
+	//   pattern: 文件名模式,最后一个 "*" 替换为随机串,见 os.CreateTemp
+	// 返回:
+	//   string: 临时文件路径
+	//   io.WriteCloser: 用于写入的文件,调用方负责关闭
+	//   error: 创建失败时的错误
+	// 注意:
+	//   临时文件会被记录,由 CleanupTemp 或 Close 统一删除;写入不受 MaxTotalBytes 限制
+	TempFile(dir, pattern string) (string, io.WriteCloser, error)
+
+	// TempDir 在当前文件系统中创建临时目录
+	// 参数与 TempFile 相同,目录会被记录,由 CleanupTemp 或 Close 连同内容一起删除
+	TempDir(dir, pattern string) (string, error)
+
+	// CleanupTemp 删除 TempFile/TempDir 创建且尚未清理的临时文件和目录
+	// 返回:
+	//   error: 部分路径删除失败时的错误 (失败的路径保留,下次调用时重试)
+	CleanupTemp() error
+
+	// ===== 文件锁 =====
+
+	// Lock 获取路径上的排他建议锁,已被占用时阻塞等待
+	// 参数:
+	//   path: 加锁的路径,操作系统文件系统下不存在时创建为锁文件 (解锁后保留)
+	// 返回:
+	//   func(): 释放锁的函数,可重复调用
+	//   error: 加锁失败时的错误
+	// 注意:
+	//   操作系统文件系统使用 flock,可以协调多个进程;内存文件系统只在进程内协调。
+	//   建议锁只约束同样加锁的调用方,不阻止直接读写文件
+	Lock(path string) (unlock func(), err error)
+
+	// TryLock 尝试获取路径上的排他建议锁,不阻塞
+	// 参数和返回值与 Lock 相同,锁已被占用时立即返回 ErrLockHeld
+	TryLock(path string) (unlock func(), err error)
+
+	// ===== 磁盘配额 =====
+
+	// Usage 返回 BasePath 下所有文件的总字节数
+	// 返回:
+	//   int64: 已使用的字节数
+	//   error: 统计失败时的错误
+	// 注意:
+	//   设置 Config.MaxTotalBytes 后,写入超过上限时返回 ErrQuotaExceeded
+	Usage() (int64, error)
+
 	// ===== 生命周期管理 =====
 
 	// Close 关闭文件服务,释放资源
+	// 同时删除 TempFile/TempDir 创建的临时文件和目录
 	// 返回:
 	//   error: 关闭失败时的错误
 	Close() error
@@ -278,7 +368,7 @@ type WatchEvent struct {
 	// Path 发生变化的文件路径
 	Path string
 
-	// Op 操作类型 (CREATE, WRITE, REMOVE, RENAME, CHMOD)
+	// Op 操作类型 (CREATE, WRITE, REMOVE, RENAME, CHMOD, ERROR)
 	Op string
 
 	// Time 事件时间
@@ -288,6 +378,31 @@ type WatchEvent struct {
 	IsDir bool
 }
 
+// ResizeReport 批量调整图片大小的结果
+// 各列表按源文件路径排序
+type ResizeReport struct {
+	// Resized 处理成功的文件
+	Resized []ResizeResult
+
+	// Failed 处理失败的文件,Err 为失败原因
+	Failed []ResizeResult
+
+	// Skipped 扩展名不是图片格式而跳过的文件
+	Skipped []string
+}
+
+// ResizeResult 单个文件的处理结果
+type ResizeResult struct {
+	// Src 源文件路径
+	Src string
+
+	// Dst 目标文件路径
+	Dst string
+
+	// Err 失败原因,成功时为 nil
+	Err error
+}
+
 // CopyOption 文件复制选项接口
 type CopyOption interface {
 	apply(*copyOptions)
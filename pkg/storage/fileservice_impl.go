@@ -22,13 +22,15 @@ type impl struct {
 	watcher *fsnotify.Watcher
 	watches map[string]*watchEntry // 路径 -> 监听条目
 	closed  bool
+	quota   quotaState // 磁盘配额用量缓存
+	temps   tempState  // 已创建的临时文件和目录
+	locks   lockState  // 进程内的路径锁
 }
 
 // watchEntry 监听条目
 type watchEntry struct {
 	path    string
 	handler WatchHandler
-	cancel  context.CancelFunc
 }
 
 // New 创建新的 Storage 实例
@@ -86,6 +88,10 @@ func (i *impl) initWatcher() error {
 		return fmt.Errorf("Storage: failed to create watcher: %w", err)
 	}
 	i.watcher = watcher
+
+	// 单个 goroutine 分发事件和错误,监听器关闭后退出
+	go i.dispatchWatchEvents(watcher)
+
 	return nil
 }
 
@@ -109,7 +115,9 @@ func (i *impl) WriteFile(path string, data []byte, perm os.FileMode) error {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 
-	return afero.WriteFile(i.fs, path, data, perm)
+	return i.withQuota(path, int64(len(data)), func() error {
+		return afero.WriteFile(i.fs, path, data, perm)
+	})
 }
 
 // Remove 删除文件或空目录
@@ -117,6 +125,7 @@ func (i *impl) Remove(path string) error {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 
+	defer i.invalidateUsage()
 	return i.fs.Remove(path)
 }
 
@@ -125,6 +134,7 @@ func (i *impl) RemoveAll(path string) error {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
 
+	defer i.invalidateUsage()
 	return i.fs.RemoveAll(path)
 }
 
@@ -221,7 +231,9 @@ func (i *impl) SaveExcel(file *excelize.File, path string) error {
 	}
 
 	// 写入文件系统
-	if err := afero.WriteFile(i.fs, path, buf.Bytes(), 0644); err != nil {
+	if err := i.withQuota(path, int64(buf.Len()), func() error {
+		return afero.WriteFile(i.fs, path, buf.Bytes(), 0644)
+	}); err != nil {
 		return fmt.Errorf("Storage: failed to save excel file: %w", err)
 	}
 
@@ -276,7 +288,9 @@ func (i *impl) SaveImage(img image.Image, path string, format imaging.Format) er
 	}
 
 	// 写入文件系统
-	if err := afero.WriteFile(i.fs, path, buf.Bytes(), 0644); err != nil {
+	if err := i.withQuota(path, int64(buf.Len()), func() error {
+		return afero.WriteFile(i.fs, path, buf.Bytes(), 0644)
+	}); err != nil {
 		return fmt.Errorf("Storage: failed to save image file: %w", err)
 	}
 
@@ -322,16 +336,19 @@ func (i *impl) Close() error {
 		return nil
 	}
 
-	// 停止所有监听
+	// 停止所有监听,关闭后事件分发 goroutine 退出
 	if i.watcher != nil {
 		for path := range i.watches {
 			i.watcher.Remove(path)
 		}
 		i.watcher.Close()
+		i.watches = make(map[string]*watchEntry)
 	}
 
 	i.closed = true
-	return nil
+
+	// 清理临时文件和目录
+	return i.cleanupTemp(i.fs)
 }
 
 // Reload 重新加载配置
@@ -355,5 +372,11 @@ func (i *impl) Reload(ctx context.Context, config *Config) error {
 		return err
 	}
 
+	// 旧文件系统中的临时文件不再可达,切换前清理
+	_ = i.cleanupTemp(oldFS)
+
+	// 文件系统或基础路径可能已变化,重新统计用量
+	i.invalidateUsage()
+
 	return nil
 }
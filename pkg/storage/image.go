@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/spf13/afero"
+)
+
+// Watermark 为图片添加水印
+func (i *impl) Watermark(src, dst string, mark image.Image, pos Anchor, opacity float64, format imaging.Format) error {
+	if mark == nil {
+		return fmt.Errorf("%w: mark image is nil", ErrInvalidWatermark)
+	}
+	if opacity < 0 || opacity > 1 {
+		return fmt.Errorf("%w: opacity %v out of range [0, 1]", ErrInvalidWatermark, opacity)
+	}
+
+	img, err := i.OpenImage(src)
+	if err != nil {
+		return err
+	}
+
+	pt, err := anchorPoint(img.Bounds(), mark.Bounds(), pos)
+	if err != nil {
+		return err
+	}
+
+	// Overlay 按透明度混合,超出背景的部分自动裁掉
+	marked := imaging.Overlay(img, mark, img.Bounds().Min.Add(pt), opacity)
+
+	return i.SaveImage(flattenForFormat(marked, format), dst, format)
+}
+
+// ConvertImage 转换图片格式
+func (i *impl) ConvertImage(src, dst string, format imaging.Format) error {
+	img, err := i.OpenImage(src)
+	if err != nil {
+		return err
+	}
+
+	return i.SaveImage(flattenForFormat(img, format), dst, format)
+}
+
+// ResizeDir 批量调整目录中所有图片的大小
+// 先遍历源目录收集文件,再由 concurrency 个 worker 并发处理,单个文件失败只记录在报告中
+func (i *impl) ResizeDir(srcDir, dstDir string, width, height int, format imaging.Format, concurrency int) (ResizeReport, error) {
+	var report ResizeReport
+
+	if width < 0 || height < 0 || (width == 0 && height == 0) {
+		return report, fmt.Errorf("%w: %dx%d", ErrInvalidImageSize, width, height)
+	}
+	ext, ok := formatExtension(format)
+	if !ok {
+		return report, fmt.Errorf("Storage: %w: %v", imaging.ErrUnsupportedFormat, format)
+	}
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	jobs, skipped, err := i.collectImages(srcDir, dstDir, ext)
+	if err != nil {
+		return report, err
+	}
+	report.Skipped = skipped
+
+	results := make([]ResizeResult, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(jobs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range next {
+				results[n] = jobs[n]
+				results[n].Err = i.resizeTo(jobs[n].Src, jobs[n].Dst, width, height, format)
+			}
+		}()
+	}
+	for n := range jobs {
+		next <- n
+	}
+	close(next)
+	wg.Wait()
+
+	// jobs 已按源路径排序,结果保持相同顺序
+	for _, r := range results {
+		if r.Err != nil {
+			report.Failed = append(report.Failed, r)
+		} else {
+			report.Resized = append(report.Resized, r)
+		}
+	}
+	return report, nil
+}
+
+// collectImages 递归收集源目录中的图片,按源路径排序
+// 返回待处理的文件 (Src/Dst) 和因扩展名不是图片格式而跳过的文件
+func (i *impl) collectImages(srcDir, dstDir, ext string) ([]ResizeResult, []string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	info, err := i.fs.Stat(srcDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrPathNotFound, srcDir)
+		}
+		return nil, nil, fmt.Errorf("Storage: failed to stat source directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNotDirectory, srcDir)
+	}
+
+	var jobs []ResizeResult
+	var skipped []string
+	err = afero.Walk(i.fs, srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if _, err := imaging.FormatFromFilename(path); err != nil {
+			skipped = append(skipped, path)
+			return nil
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dstDir, strings.TrimSuffix(rel, filepath.Ext(rel))+ext)
+		jobs = append(jobs, ResizeResult{Src: path, Dst: dst})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Storage: failed to walk source directory: %w", err)
+	}
+
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].Src < jobs[b].Src })
+	sort.Strings(skipped)
+	return jobs, skipped, nil
+}
+
+// resizeTo 调整单个图片的大小并写入目标路径,目标目录不存在时自动创建
+func (i *impl) resizeTo(src, dst string, width, height int, format imaging.Format) error {
+	img, err := i.OpenImage(src)
+	if err != nil {
+		return err
+	}
+
+	if err := i.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("Storage: failed to create destination directory: %w", err)
+	}
+
+	resized := imaging.Resize(img, width, height, imaging.Lanczos)
+	return i.SaveImage(flattenForFormat(resized, format), dst, format)
+}
+
+// formatExtension 返回输出格式对应的文件扩展名
+func formatExtension(format imaging.Format) (string, bool) {
+	switch format {
+	case imaging.JPEG:
+		return ".jpg", true
+	case imaging.PNG:
+		return ".png", true
+	case imaging.GIF:
+		return ".gif", true
+	case imaging.TIFF:
+		return ".tif", true
+	case imaging.BMP:
+		return ".bmp", true
+	default:
+		return "", false
+	}
+}
+
+// anchorPoint 计算水印左上角相对背景原点的位置
+// 水印紧贴锚点对应的边,水印大于背景时可能为负数
+func anchorPoint(bg, mark image.Rectangle, pos Anchor) (image.Point, error) {
+	bw, bh := bg.Dx(), bg.Dy()
+	mw, mh := mark.Dx(), mark.Dy()
+
+	switch pos {
+	case AnchorTopLeft:
+		return image.Pt(0, 0), nil
+	case AnchorTopRight:
+		return image.Pt(bw-mw, 0), nil
+	case AnchorCenter:
+		return image.Pt((bw-mw)/2, (bh-mh)/2), nil
+	case AnchorBottomLeft:
+		return image.Pt(0, bh-mh), nil
+	case AnchorBottomRight:
+		return image.Pt(bw-mw, bh-mh), nil
+	default:
+		return image.Point{}, fmt.Errorf("%w: unknown anchor %d", ErrInvalidWatermark, pos)
+	}
+}
+
+// flattenForFormat 输出 JPEG 时将带透明度的图片合成到白色背景上
+// JPEG 编码会直接丢弃 alpha 通道,透明区域通常变成黑色
+func flattenForFormat(img image.Image, format imaging.Format) image.Image {
+	if format != imaging.JPEG {
+		return img
+	}
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img
+	}
+
+	b := img.Bounds()
+	bg := imaging.New(b.Dx(), b.Dy(), color.White)
+	return imaging.Overlay(bg, img, image.Pt(0, 0), 1)
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+)
+
+// newImageStorage 创建内存文件系统并写入 200x100 的蓝色 PNG
+func newImageStorage(t *testing.T) Storage {
+	t.Helper()
+	s, err := New(&Config{FSType: FSTypeMemory})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	src := imaging.New(200, 100, color.NRGBA{B: 255, A: 255})
+	if err := s.SaveImage(src, "/src.png", imaging.PNG); err != nil {
+		t.Fatalf("SaveImage() failed: %v", err)
+	}
+	return s
+}
+
+// TestWatermark 测试水印按锚点叠加,输出可以解码且尺寸与源图片一致
+func TestWatermark(t *testing.T) {
+	s := newImageStorage(t)
+	mark := imaging.New(20, 10, color.NRGBA{R: 255, A: 255})
+	red := color.NRGBA{R: 255, A: 255}
+
+	tests := []struct {
+		name   string
+		pos    Anchor
+		inside image.Point // 应被水印覆盖的点
+	}{
+		{"top-left", AnchorTopLeft, image.Pt(0, 0)},
+		{"center", AnchorCenter, image.Pt(100, 50)},
+		{"bottom-right", AnchorBottomRight, image.Pt(199, 99)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Watermark("/src.png", "/out.png", mark, tt.pos, 1, imaging.PNG); err != nil {
+				t.Fatalf("Watermark() failed: %v", err)
+			}
+
+			out, err := s.OpenImage("/out.png")
+			if err != nil {
+				t.Fatalf("output should decode: %v", err)
+			}
+			if got := out.Bounds().Size(); got != image.Pt(200, 100) {
+				t.Fatalf("output size = %v, want 200x100", got)
+			}
+			if got := color.NRGBAModel.Convert(out.At(tt.inside.X, tt.inside.Y)); got != red {
+				t.Errorf("pixel at %v = %v, want watermark color", tt.inside, got)
+			}
+		})
+	}
+
+	// 半透明水印与背景混合
+	if err := s.Watermark("/src.png", "/half.png", mark, AnchorTopLeft, 0.5, imaging.PNG); err != nil {
+		t.Fatalf("Watermark() failed: %v", err)
+	}
+	half, _ := s.OpenImage("/half.png")
+	if c := color.NRGBAModel.Convert(half.At(0, 0)).(color.NRGBA); c.R == 0 || c.B == 0 {
+		t.Errorf("half-opacity pixel = %v, want blend of mark and background", c)
+	}
+
+	if err := s.Watermark("/src.png", "/out.png", mark, AnchorCenter, 1.5, imaging.PNG); !errors.Is(err, ErrInvalidWatermark) {
+		t.Errorf("expected ErrInvalidWatermark for opacity 1.5, got %v", err)
+	}
+}
+
+// TestConvertImage 测试格式转换,透明区域转为 JPEG 时填充白色
+func TestConvertImage(t *testing.T) {
+	s := newImageStorage(t)
+
+	if err := s.ConvertImage("/src.png", "/src.jpg", imaging.JPEG); err != nil {
+		t.Fatalf("ConvertImage() failed: %v", err)
+	}
+	out, err := s.OpenImage("/src.jpg")
+	if err != nil {
+		t.Fatalf("output should decode: %v", err)
+	}
+	if got := out.Bounds().Size(); got != image.Pt(200, 100) {
+		t.Fatalf("output size = %v, want 200x100", got)
+	}
+
+	transparent := imaging.New(10, 10, color.NRGBA{})
+	if err := s.SaveImage(transparent, "/alpha.png", imaging.PNG); err != nil {
+		t.Fatalf("SaveImage() failed: %v", err)
+	}
+	if err := s.ConvertImage("/alpha.png", "/alpha.jpg", imaging.JPEG); err != nil {
+		t.Fatalf("ConvertImage() failed: %v", err)
+	}
+	flat, _ := s.OpenImage("/alpha.jpg")
+	if r, g, b, _ := flat.At(5, 5).RGBA(); r>>8 < 250 || g>>8 < 250 || b>>8 < 250 {
+		t.Errorf("transparent area should become white, got (%d, %d, %d)", r>>8, g>>8, b>>8)
+	}
+}
+
+// TestResizeDir 测试批量调整目录中的图片,跳过非图片文件,单个损坏的图片不影响其他文件
+func TestResizeDir(t *testing.T) {
+	s := newImageStorage(t)
+
+	src := imaging.New(200, 100, color.NRGBA{G: 255, A: 255})
+	for _, path := range []string{"/photos/a.png", "/photos/b.jpg", "/photos/sub/c.png"} {
+		if err := s.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll() failed: %v", err)
+		}
+		format, _ := imaging.FormatFromFilename(path)
+		if err := s.SaveImage(src, path, format); err != nil {
+			t.Fatalf("SaveImage() failed: %v", err)
+		}
+	}
+	if err := s.WriteFile("/photos/notes.txt", []byte("not an image"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if err := s.WriteFile("/photos/broken.png", []byte("not a png"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	report, err := s.ResizeDir("/photos", "/thumbs", 50, 0, imaging.JPEG, 2)
+	if err != nil {
+		t.Fatalf("ResizeDir() failed: %v", err)
+	}
+
+	wantResized := []string{"/thumbs/a.jpg", "/thumbs/b.jpg", "/thumbs/sub/c.jpg"}
+	if len(report.Resized) != len(wantResized) {
+		t.Fatalf("Resized = %+v, want %v", report.Resized, wantResized)
+	}
+	for n, want := range wantResized {
+		if got := report.Resized[n].Dst; got != want {
+			t.Errorf("Resized[%d].Dst = %s, want %s", n, got, want)
+		}
+		out, err := s.OpenImage(want)
+		if err != nil {
+			t.Fatalf("output %s should decode: %v", want, err)
+		}
+		if got := out.Bounds().Size(); got != image.Pt(50, 25) {
+			t.Errorf("output %s size = %v, want 50x25", want, got)
+		}
+	}
+
+	if len(report.Failed) != 1 || report.Failed[0].Src != "/photos/broken.png" || report.Failed[0].Err == nil {
+		t.Errorf("Failed = %+v, want /photos/broken.png with error", report.Failed)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0] != "/photos/notes.txt" {
+		t.Errorf("Skipped = %v, want [/photos/notes.txt]", report.Skipped)
+	}
+	if exists, _ := s.Exists("/thumbs/notes.jpg"); exists {
+		t.Error("skipped file should not be written")
+	}
+}
+
+// TestResizeDirInvalid 测试参数无效和源目录不存在
+func TestResizeDirInvalid(t *testing.T) {
+	s := newImageStorage(t)
+
+	if _, err := s.ResizeDir("/", "/out", 0, 0, imaging.PNG, 1); !errors.Is(err, ErrInvalidImageSize) {
+		t.Errorf("expected ErrInvalidImageSize for 0x0, got %v", err)
+	}
+	if _, err := s.ResizeDir("/missing", "/out", 10, 10, imaging.PNG, 1); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("expected ErrPathNotFound, got %v", err)
+	}
+	if _, err := s.ResizeDir("/src.png", "/out", 10, 10, imaging.PNG, 1); !errors.Is(err, ErrNotDirectory) {
+		t.Errorf("expected ErrNotDirectory, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// lockState 记录进程内的路径锁
+// 同一进程内的竞争由路径对应的互斥锁协调,跨进程的竞争由操作系统文件锁协调
+type lockState struct {
+	mu    sync.Mutex
+	locks map[string]*lockEntry
+}
+
+// lockEntry 单个路径的进程内锁,refs 为持有或等待该锁的调用方数量
+type lockEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock 获取路径上的排他建议锁,已被占用时阻塞等待
+func (i *impl) Lock(path string) (func(), error) {
+	return i.lock(path, true)
+}
+
+// TryLock 尝试获取路径上的排他建议锁,已被占用时立即返回 ErrLockHeld
+func (i *impl) TryLock(path string) (func(), error) {
+	return i.lock(path, false)
+}
+
+// lock 依次获取进程内锁和文件锁
+// 内存文件系统只使用进程内锁,不创建锁文件
+func (i *impl) lock(path string, wait bool) (func(), error) {
+	i.mu.RLock()
+	fsType, fs := i.config.FSType, i.fs
+	i.mu.RUnlock()
+
+	key, err := lockKey(fsType, fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := i.acquireLockEntry(key)
+	if wait {
+		entry.mu.Lock()
+	} else if !entry.mu.TryLock() {
+		i.releaseLockEntry(key, entry)
+		return nil, fmt.Errorf("%w: %s", ErrLockHeld, path)
+	}
+
+	var file *os.File
+	if fsType != FSTypeMemory {
+		file, err = openLockFile(fsType, key)
+		if err == nil {
+			err = flockFile(file, wait)
+			if err != nil {
+				file.Close()
+			}
+		}
+		if err != nil {
+			entry.mu.Unlock()
+			i.releaseLockEntry(key, entry)
+			if errLockContended(err) {
+				return nil, fmt.Errorf("%w: %s", ErrLockHeld, path)
+			}
+			return nil, fmt.Errorf("Storage: failed to lock %s: %w", path, err)
+		}
+	}
+
+	var once sync.Once
+	unlock := func() {
+		once.Do(func() {
+			if file != nil {
+				// 关闭文件描述符会释放文件锁
+				file.Close()
+			}
+			entry.mu.Unlock()
+			i.releaseLockEntry(key, entry)
+		})
+	}
+	return unlock, nil
+}
+
+// acquireLockEntry 返回路径对应的进程内锁并增加引用计数
+func (i *impl) acquireLockEntry(key string) *lockEntry {
+	i.locks.mu.Lock()
+	defer i.locks.mu.Unlock()
+
+	if i.locks.locks == nil {
+		i.locks.locks = make(map[string]*lockEntry)
+	}
+	entry, ok := i.locks.locks[key]
+	if !ok {
+		entry = &lockEntry{}
+		i.locks.locks[key] = entry
+	}
+	entry.refs++
+	return entry
+}
+
+// releaseLockEntry 减少引用计数,没有调用方时删除条目
+func (i *impl) releaseLockEntry(key string, entry *lockEntry) {
+	i.locks.mu.Lock()
+	defer i.locks.mu.Unlock()
+
+	entry.refs--
+	if entry.refs == 0 {
+		delete(i.locks.locks, key)
+	}
+}
+
+// lockKey 返回锁的标识
+// 操作系统文件系统使用真实的绝对路径,同一文件的不同写法对应同一把锁
+func lockKey(fsType FSType, fs afero.Fs, path string) (string, error) {
+	switch fsType {
+	case FSTypeMemory:
+		return filepath.Clean(path), nil
+	case FSTypeBasePathFS:
+		base, ok := fs.(*afero.BasePathFs)
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrInvalidFSType, fsType)
+		}
+		realPath, err := base.RealPath(path)
+		if err != nil {
+			return "", fmt.Errorf("Storage: failed to resolve lock path %s: %w", path, err)
+		}
+		return filepath.Abs(realPath)
+	default:
+		return filepath.Abs(path)
+	}
+}
+
+// openLockFile 打开用于加锁的文件
+// 文件不存在时创建,解锁后保留 (删除锁文件会让等待者锁住已被替换的文件);
+// 只读文件系统不能创建文件,只能锁定已存在的文件
+func openLockFile(fsType FSType, path string) (*os.File, error) {
+	if fsType == FSTypeReadOnly {
+		return os.Open(path)
+	}
+	return os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+}
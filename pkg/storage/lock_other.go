@@ -0,0 +1,15 @@
+//go:build !unix
+
+package storage
+
+import "os"
+
+// flockFile 非 unix 平台不支持 flock,只使用进程内锁协调
+func flockFile(f *os.File, wait bool) error {
+	return nil
+}
+
+// errLockContended 非 unix 平台不会出现文件锁竞争
+func errLockContended(err error) bool {
+	return false
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLock_OSExclusive 测试操作系统文件系统上的锁是排他的,释放后等待者才能获得锁
+func TestLock_OSExclusive(t *testing.T) {
+	s, err := New(&Config{FSType: FSTypeOS})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	path := filepath.Join(t.TempDir(), "job.lock")
+	unlock, err := s.Lock(path)
+	if err != nil {
+		t.Fatalf("Lock() failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("lock file should be created: %v", err)
+	}
+
+	// 另一个文件描述符上的 flock 与其他进程的行为相同
+	other, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open lock file: %v", err)
+	}
+	defer other.Close()
+	if err := flockFile(other, false); err == nil {
+		t.Error("flock on another descriptor should fail while locked")
+	} else if !errLockContended(err) {
+		t.Errorf("flock error = %v, want EWOULDBLOCK", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlock2, err := s.Lock(filepath.Join(filepath.Dir(path), ".", "job.lock"))
+		if err != nil {
+			t.Errorf("second Lock() failed: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- unlock2
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second Lock() should block while the lock is held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	unlock() // 重复释放无副作用
+
+	select {
+	case unlock2 := <-acquired:
+		if unlock2 != nil {
+			unlock2()
+		}
+	case <-time.After(time.Second):
+		t.Fatal("second Lock() should succeed after unlock")
+	}
+}
+
+// TestTryLock_Contention 测试锁被占用时 TryLock 立即返回 ErrLockHeld
+func TestTryLock_Contention(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		path string
+	}{
+		{"os", &Config{FSType: FSTypeOS}, filepath.Join(t.TempDir(), "job.lock")},
+		{"basepath", &Config{FSType: FSTypeBasePathFS, BasePath: t.TempDir()}, "/job.lock"},
+		{"memory", &Config{FSType: FSTypeMemory}, "/job.lock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.cfg)
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			t.Cleanup(func() { s.Close() })
+
+			unlock, err := s.TryLock(tt.path)
+			if err != nil {
+				t.Fatalf("TryLock() failed: %v", err)
+			}
+
+			if _, err := s.TryLock(tt.path); !errors.Is(err, ErrLockHeld) {
+				t.Errorf("TryLock() on held lock error = %v, want ErrLockHeld", err)
+			}
+
+			unlock()
+
+			unlock, err = s.TryLock(tt.path)
+			if err != nil {
+				t.Fatalf("TryLock() after unlock failed: %v", err)
+			}
+			unlock()
+		})
+	}
+}
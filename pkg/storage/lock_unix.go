@@ -0,0 +1,29 @@
+//go:build unix
+
+package storage
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// flockFile 对文件加排他的 flock 锁
+// wait 为 false 时使用 LOCK_NB,已被占用时返回 EWOULDBLOCK
+func flockFile(f *os.File, wait bool) error {
+	how := syscall.LOCK_EX
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+	for {
+		err := syscall.Flock(int(f.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// errLockContended 判断加锁失败是否因为锁已被其他进程持有
+func errLockContended(err error) bool {
+	return errors.Is(err, syscall.EWOULDBLOCK)
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// quotaState 磁盘配额的用量缓存
+// 写入时累加增量,删除、目录复制和重载后失效,下次写入时重新统计
+type quotaState struct {
+	mu    sync.Mutex
+	usage int64
+	valid bool
+}
+
+// Usage 返回 BasePath 下所有文件的总字节数
+// 每次调用都重新统计并刷新配额缓存;通过 FileSystem() 直接写入的文件
+// 不经过配额检查,但会计入下一次统计
+func (i *impl) Usage() (int64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	i.quota.mu.Lock()
+	defer i.quota.mu.Unlock()
+
+	usage, err := i.computeUsage()
+	if err != nil {
+		i.quota.valid = false
+		return 0, err
+	}
+	i.quota.usage, i.quota.valid = usage, true
+	return usage, nil
+}
+
+// withQuota 在配额内执行写入
+// 参数:
+//
+//	dst: 目标路径,已存在的文件大小会从增量中扣除 (覆盖写);为空时不扣除
+//	size: 写入的字节数
+//	write: 实际的写入操作
+//
+// 未设置 MaxTotalBytes 时直接写入;超出配额时返回 ErrQuotaExceeded 且不执行写入。
+// 调用方需持有 i.mu 读锁,检查和写入在 quota.mu 内完成,并发写入不会同时通过检查
+func (i *impl) withQuota(dst string, size int64, write func() error) error {
+	limit := i.config.MaxTotalBytes
+	if limit <= 0 {
+		return write()
+	}
+
+	i.quota.mu.Lock()
+	defer i.quota.mu.Unlock()
+
+	if !i.quota.valid {
+		usage, err := i.computeUsage()
+		if err != nil {
+			return err
+		}
+		i.quota.usage, i.quota.valid = usage, true
+	}
+
+	delta := size
+	if dst != "" {
+		if info, err := i.fs.Stat(dst); err == nil && info.Mode().IsRegular() {
+			delta -= info.Size()
+		}
+	}
+
+	if i.quota.usage+delta > limit {
+		return fmt.Errorf("%w: usage %d + %d bytes exceeds limit %d",
+			ErrQuotaExceeded, i.quota.usage, delta, limit)
+	}
+
+	if err := write(); err != nil {
+		// 写入失败时可能已写入部分内容,下次重新统计
+		i.quota.valid = false
+		return err
+	}
+
+	i.quota.usage += delta
+	return nil
+}
+
+// invalidateUsage 使配额缓存失效
+func (i *impl) invalidateUsage() {
+	i.quota.mu.Lock()
+	i.quota.valid = false
+	i.quota.mu.Unlock()
+}
+
+// computeUsage 统计 usageRoot 下所有普通文件的大小,根目录不存在时为 0
+func (i *impl) computeUsage() (int64, error) {
+	usage, err := dirSize(i.fs, i.usageRoot())
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Storage: failed to compute usage: %w", err)
+	}
+	return usage, nil
+}
+
+// usageRoot 返回统计用量的根目录
+// basepath 文件系统本身以 BasePath 为根,其他类型使用 BasePath (默认当前目录)
+func (i *impl) usageRoot() string {
+	if i.config.FSType == FSTypeBasePathFS {
+		return string(filepath.Separator)
+	}
+	if i.config.BasePath == "" {
+		return DefaultBasePath
+	}
+	return i.config.BasePath
+}
+
+// dirSize 统计目录下所有普通文件的大小
+func dirSize(fs afero.Fs, root string) (int64, error) {
+	var size int64
+	err := afero.Walk(fs, root, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
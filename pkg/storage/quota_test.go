@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+// newQuotaStorage 创建带配额的内存文件系统,用量统计在 /quota 下
+func newQuotaStorage(t *testing.T, limit int64) Storage {
+	t.Helper()
+
+	s, err := New(&Config{FSType: FSTypeMemory, BasePath: "/quota", MaxTotalBytes: limit})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// TestQuotaWriteFile 测试写满配额后拒绝写入,覆盖写按大小差值计算
+func TestQuotaWriteFile(t *testing.T) {
+	s := newQuotaStorage(t, 10)
+
+	if err := s.WriteFile("/quota/a.txt", []byte("123456"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if err := s.WriteFile("/quota/b.txt", []byte("1234"), 0644); err != nil {
+		t.Fatalf("WriteFile() up to the limit failed: %v", err)
+	}
+
+	err := s.WriteFile("/quota/c.txt", []byte("1"), 0644)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("WriteFile() over the limit error = %v, want ErrQuotaExceeded", err)
+	}
+	if ok, _ := s.Exists("/quota/c.txt"); ok {
+		t.Error("rejected write should not create the file")
+	}
+
+	// 覆盖写只计算增量: 6 -> 2 字节
+	if err := s.WriteFile("/quota/a.txt", []byte("12"), 0644); err != nil {
+		t.Fatalf("overwriting WriteFile() failed: %v", err)
+	}
+	usage, err := s.Usage()
+	if err != nil {
+		t.Fatalf("Usage() failed: %v", err)
+	}
+	if usage != 6 {
+		t.Errorf("Usage() = %d, want 6", usage)
+	}
+}
+
+// TestQuotaCopyAndRemove 测试复制受配额限制,删除后释放空间
+func TestQuotaCopyAndRemove(t *testing.T) {
+	s := newQuotaStorage(t, 10)
+
+	if err := s.WriteFile("/quota/a.txt", []byte("123456"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if err := s.Copy("/quota/a.txt", "/quota/b.txt"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Copy() over the limit error = %v, want ErrQuotaExceeded", err)
+	}
+
+	if err := s.Remove("/quota/a.txt"); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	if err := s.WriteFile("/quota/b.txt", []byte("1234567890"), 0644); err != nil {
+		t.Fatalf("WriteFile() after Remove() failed: %v", err)
+	}
+
+	usage, err := s.Usage()
+	if err != nil {
+		t.Fatalf("Usage() failed: %v", err)
+	}
+	if usage != 10 {
+		t.Errorf("Usage() = %d, want 10", usage)
+	}
+}
+
+// TestQuotaUnlimited 测试 MaxTotalBytes 为 0 时不限制写入
+func TestQuotaUnlimited(t *testing.T) {
+	s := newQuotaStorage(t, 0)
+
+	if err := s.WriteFile("/quota/a.txt", make([]byte, 1024), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	usage, err := s.Usage()
+	if err != nil {
+		t.Fatalf("Usage() failed: %v", err)
+	}
+	if usage != 1024 {
+		t.Errorf("Usage() = %d, want 1024", usage)
+	}
+}
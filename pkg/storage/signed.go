@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignedPath 为逻辑路径生成带过期时间的访问令牌
+// 令牌格式为 "<过期时间 unix 秒>.<HMAC-SHA256 签名>",签名覆盖路径和过期时间,
+// 与具体文件系统无关,可用于 HTTP 处理器在没有完整认证时限制下载
+func SignedPath(path string, ttl time.Duration, secret []byte) string {
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	return expires + "." + signPath(path, expires, secret)
+}
+
+// VerifySignedPath 校验路径的访问令牌
+// 令牌格式错误或签名不匹配时返回 ErrInvalidSignature,已过期时返回 ErrSignatureExpired
+func VerifySignedPath(path, token string, secret []byte) error {
+	expires, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return fmt.Errorf("%w: malformed token", ErrInvalidSignature)
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed expiry", ErrInvalidSignature)
+	}
+
+	// 先校验签名,避免未签名的过期时间泄露任何信息
+	expected := signPath(path, expires, secret)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// signPath 计算路径和过期时间的 HMAC-SHA256 签名 (URL 安全的 base64)
+func signPath(path, expires string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(path))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("test-secret")
+
+// TestSignedPath_Valid 测试有效令牌校验通过
+func TestSignedPath_Valid(t *testing.T) {
+	token := SignedPath("uploads/avatar.png", time.Minute, testSecret)
+
+	if err := VerifySignedPath("uploads/avatar.png", token, testSecret); err != nil {
+		t.Fatalf("VerifySignedPath() failed: %v", err)
+	}
+}
+
+// TestSignedPath_Expired 测试过期令牌返回 ErrSignatureExpired
+func TestSignedPath_Expired(t *testing.T) {
+	token := SignedPath("uploads/avatar.png", -time.Minute, testSecret)
+
+	err := VerifySignedPath("uploads/avatar.png", token, testSecret)
+	if !errors.Is(err, ErrSignatureExpired) {
+		t.Fatalf("VerifySignedPath() error = %v, want ErrSignatureExpired", err)
+	}
+}
+
+// TestSignedPath_Tampered 测试篡改路径、过期时间、签名或密钥时返回 ErrInvalidSignature
+func TestSignedPath_Tampered(t *testing.T) {
+	const path = "uploads/avatar.png"
+	token := SignedPath(path, time.Minute, testSecret)
+	expires, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		secret []byte
+	}{
+		{"other path", "uploads/other.png", token, testSecret},
+		{"extended expiry", path, "9999999999." + signature, testSecret},
+		{"modified signature", path, expires + "." + strings.Repeat("A", len(signature)), testSecret},
+		{"wrong secret", path, token, []byte("other-secret")},
+		{"malformed", path, "not-a-token", testSecret},
+		{"bad expiry", path, "abc." + signature, testSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignedPath(tt.path, tt.token, tt.secret)
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifySignedPath() error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
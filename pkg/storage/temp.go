@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// tempState 记录 TempFile/TempDir 创建的临时文件和目录
+type tempState struct {
+	mu    sync.Mutex
+	paths map[string]struct{}
+}
+
+// TempFile 在当前文件系统中创建临时文件
+func (i *impl) TempFile(dir, pattern string) (string, io.WriteCloser, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	dir, err := i.prepareTempDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := afero.TempFile(i.fs, dir, pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("Storage: failed to create temp file: %w", err)
+	}
+
+	i.trackTemp(f.Name())
+	return f.Name(), f, nil
+}
+
+// TempDir 在当前文件系统中创建临时目录
+func (i *impl) TempDir(dir, pattern string) (string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	dir, err := i.prepareTempDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := afero.TempDir(i.fs, dir, pattern)
+	if err != nil {
+		return "", fmt.Errorf("Storage: failed to create temp dir: %w", err)
+	}
+
+	i.trackTemp(path)
+	return path, nil
+}
+
+// CleanupTemp 删除 TempFile/TempDir 创建且尚未清理的临时文件和目录
+func (i *impl) CleanupTemp() error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.cleanupTemp(i.fs)
+}
+
+// prepareTempDir 确定临时文件所在目录并确保其存在
+// dir 为空时使用 os.TempDir(),与 afero.TempFile 的行为一致,
+// 但路径在当前文件系统内解析 (内存文件系统不会写入真实的系统临时目录)
+func (i *impl) prepareTempDir(dir string) (string, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	if err := i.fs.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("Storage: failed to create temp parent dir: %w", err)
+	}
+	return dir, nil
+}
+
+// trackTemp 记录临时文件或目录
+func (i *impl) trackTemp(path string) {
+	i.temps.mu.Lock()
+	defer i.temps.mu.Unlock()
+
+	if i.temps.paths == nil {
+		i.temps.paths = make(map[string]struct{})
+	}
+	i.temps.paths[path] = struct{}{}
+}
+
+// cleanupTemp 从 fs 中删除所有已记录的临时文件和目录
+// 已被调用方删除的路径视为清理成功,删除失败的路径保留,下次清理时重试
+func (i *impl) cleanupTemp(fs afero.Fs) error {
+	i.temps.mu.Lock()
+	defer i.temps.mu.Unlock()
+
+	if len(i.temps.paths) == 0 {
+		return nil
+	}
+	// 删除的文件可能计入了配额用量
+	defer i.invalidateUsage()
+
+	var errs []error
+	for path := range i.temps.paths {
+		if err := fs.RemoveAll(path); err != nil {
+			errs = append(errs, fmt.Errorf("Storage: failed to remove temp %s: %w", path, err))
+			continue
+		}
+		delete(i.temps.paths, path)
+	}
+	return errors.Join(errs...)
+}
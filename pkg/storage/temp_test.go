@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTempFileAndDir 测试临时文件和目录在内存文件系统中创建,并由 CleanupTemp 删除
+func TestTempFileAndDir(t *testing.T) {
+	s, err := New(&Config{FSType: FSTypeMemory})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	path, w, err := s.TempFile("/work", "export-*.xlsx")
+	if err != nil {
+		t.Fatalf("TempFile() failed: %v", err)
+	}
+	if !strings.HasPrefix(path, "/work/export-") || !strings.HasSuffix(path, ".xlsx") {
+		t.Errorf("TempFile() path = %s, want /work/export-*.xlsx", path)
+	}
+	if _, err := w.Write([]byte("data")); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if data, err := s.ReadFile(path); err != nil || string(data) != "data" {
+		t.Fatalf("ReadFile() = %q, %v", data, err)
+	}
+
+	dir, err := s.TempDir("", "resize-")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %v", err)
+	}
+	if err := s.WriteFile(dir+"/thumb.png", []byte("png"), 0644); err != nil {
+		t.Fatalf("WriteFile() in temp dir failed: %v", err)
+	}
+
+	if err := s.CleanupTemp(); err != nil {
+		t.Fatalf("CleanupTemp() failed: %v", err)
+	}
+	for _, p := range []string{path, dir} {
+		if ok, _ := s.Exists(p); ok {
+			t.Errorf("%s should be removed by CleanupTemp()", p)
+		}
+	}
+	if ok, _ := s.IsDir("/work"); !ok {
+		t.Error("parent dir /work should be kept")
+	}
+
+	if err := s.CleanupTemp(); err != nil {
+		t.Fatalf("second CleanupTemp() failed: %v", err)
+	}
+}
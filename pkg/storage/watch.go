@@ -1,8 +1,10 @@
 package storage
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -24,6 +26,11 @@ func (i *impl) Watch(path string, handler WatchHandler) error {
 		return ErrWatcherAlreadyExists
 	}
 
+	// 检查监听数量上限
+	if max := i.config.MaxWatches; max > 0 && len(i.watches) >= max {
+		return fmt.Errorf("%w: max_watches is %d", ErrWatchLimitReached, max)
+	}
+
 	// 检查路径是否存在
 	exists, err := afero.Exists(i.fs, path)
 	if err != nil {
@@ -35,67 +42,90 @@ func (i *impl) Watch(path string, handler WatchHandler) error {
 
 	// 添加到 watcher
 	if err := i.watcher.Add(path); err != nil {
+		if isWatchLimitError(err) {
+			return fmt.Errorf("%w: system inotify watch limit exceeded, increase fs.inotify.max_user_watches: %w",
+				ErrWatchLimitReached, err)
+		}
 		return fmt.Errorf("Storage: failed to add watcher: %w", err)
 	}
 
-	// 创建取消上下文
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// 创建监听条目
-	entry := &watchEntry{
+	i.watches[path] = &watchEntry{
 		path:    path,
 		handler: handler,
-		cancel:  cancel,
 	}
-	i.watches[path] = entry
-
-	// 启动事件处理 goroutine
-	go i.handleWatchEvents(ctx, entry)
 
 	return nil
 }
 
-// handleWatchEvents 处理文件监听事件
-func (i *impl) handleWatchEvents(ctx context.Context, entry *watchEntry) {
+// isWatchLimitError 判断是否为操作系统监听数量上限错误
+// Linux 上 inotify_add_watch 超过 fs.inotify.max_user_watches 时返回 ENOSPC
+func isWatchLimitError(err error) bool {
+	return errors.Is(err, syscall.ENOSPC)
+}
+
+// dispatchWatchEvents 分发监听器的事件和错误
+// 所有监听共享同一个 fsnotify 监听器,事件按路径分发给对应的处理函数,
+// 错误交给 Config.OnWatchError 并以 ERROR 事件通知所有处理函数
+// 监听器关闭后 Events/Errors 通道关闭,goroutine 随之退出
+func (i *impl) dispatchWatchEvents(watcher *fsnotify.Watcher) {
 	for {
 		select {
-		case <-ctx.Done():
-			// 监听被取消
-			return
-
-		case event, ok := <-i.watcher.Events:
+		case event, ok := <-watcher.Events:
 			if !ok {
 				return
 			}
 
-			// 检查事件路径是否匹配
-			if event.Name != entry.path {
+			entry := i.lookupWatch(event.Name)
+			if entry == nil {
 				continue
 			}
 
-			// 转换为 WatchEvent
-			watchEvent := i.convertFsnotifyEvent(event)
-
-			// 调用处理函数
-			entry.handler(watchEvent)
+			// 在锁外调用处理函数,允许处理函数中调用 StopWatch 等方法
+			entry.handler(i.convertFsnotifyEvent(event))
 
-		case err, ok := <-i.watcher.Errors:
+		case err, ok := <-watcher.Errors:
 			if !ok {
 				return
 			}
+			i.handleWatchError(err)
+		}
+	}
+}
 
-			// 发送错误事件
-			watchEvent := WatchEvent{
-				Path:  entry.path,
-				Op:    "ERROR",
-				Time:  time.Now(),
-				IsDir: false,
-			}
-			entry.handler(watchEvent)
+// lookupWatch 查找事件对应的监听条目
+// 优先匹配路径本身,其次匹配所在目录 (监听目录时事件路径为目录下的文件)
+func (i *impl) lookupWatch(name string) *watchEntry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 
-			// 记录错误(可选)
-			_ = err // 忽略错误,或者可以通过日志记录
-		}
+	if entry, ok := i.watches[name]; ok {
+		return entry
+	}
+	return i.watches[filepath.Dir(name)]
+}
+
+// handleWatchError 处理监听器错误
+func (i *impl) handleWatchError(err error) {
+	i.mu.RLock()
+	onError := i.config.OnWatchError
+	entries := make([]*watchEntry, 0, len(i.watches))
+	for _, entry := range i.watches {
+		entries = append(entries, entry)
+	}
+	i.mu.RUnlock()
+
+	if onError != nil {
+		onError(err)
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		entry.handler(WatchEvent{
+			Path:  entry.path,
+			Op:    WatchEventError,
+			Time:  now,
+			IsDir: false,
+		})
 	}
 }
 
@@ -136,14 +166,10 @@ func (i *impl) StopWatch(path string) error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	entry, exists := i.watches[path]
-	if !exists {
+	if _, exists := i.watches[path]; !exists {
 		return ErrWatcherNotFound
 	}
 
-	// 取消事件处理 goroutine
-	entry.cancel()
-
 	// 从 watcher 中移除
 	if err := i.watcher.Remove(path); err != nil {
 		return fmt.Errorf("Storage: failed to remove watcher: %w", err)
@@ -160,9 +186,8 @@ func (i *impl) StopAllWatch() {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	// 取消所有监听
-	for path, entry := range i.watches {
-		entry.cancel()
+	// 移除所有监听
+	for path := range i.watches {
 		i.watcher.Remove(path)
 	}
 
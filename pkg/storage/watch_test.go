@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newWatchStorage 创建启用监听的 OS 文件系统实例
+func newWatchStorage(t *testing.T, cfg *Config) *impl {
+	t.Helper()
+
+	cfg.FSType = FSTypeOS
+	cfg.EnableWatch = true
+	s, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s.(*impl)
+}
+
+// TestWatchErrorCallback 测试监听器错误会传给 OnWatchError 并以 ERROR 事件通知处理函数
+func TestWatchErrorCallback(t *testing.T) {
+	errCh := make(chan error, 1)
+	i := newWatchStorage(t, &Config{
+		OnWatchError: func(err error) { errCh <- err },
+	})
+
+	events := make(chan WatchEvent, 1)
+	dir := t.TempDir()
+	if err := i.Watch(dir, func(event WatchEvent) {
+		if event.Op == WatchEventError {
+			events <- event
+		}
+	}); err != nil {
+		t.Fatalf("Watch() failed: %v", err)
+	}
+
+	injected := errors.New("event queue overflow")
+	i.watcher.Errors <- injected
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, injected) {
+			t.Errorf("OnWatchError got %v, want %v", err, injected)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnWatchError was not called")
+	}
+
+	select {
+	case event := <-events:
+		if event.Path != dir {
+			t.Errorf("ERROR event path = %q, want %q", event.Path, dir)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler did not receive ERROR event")
+	}
+}
+
+// TestWatchMaxWatches 测试超过 MaxWatches 时返回 ErrWatchLimitReached
+func TestWatchMaxWatches(t *testing.T) {
+	i := newWatchStorage(t, &Config{MaxWatches: 1})
+	handler := func(WatchEvent) {}
+
+	if err := i.Watch(t.TempDir(), handler); err != nil {
+		t.Fatalf("first Watch() failed: %v", err)
+	}
+	if err := i.Watch(t.TempDir(), handler); !errors.Is(err, ErrWatchLimitReached) {
+		t.Fatalf("second Watch() error = %v, want ErrWatchLimitReached", err)
+	}
+}
@@ -20,6 +20,24 @@ const (
 	ConfigBlockFilenameSuffix = "_config.go"
 )
 
+// 标签键名风格,用于 Config.TagCase
+const (
+	// TagCaseOriginal 保留 YAML 原始键名（默认）
+	TagCaseOriginal = "original"
+
+	// TagCaseSnake snake_case，如 "max_conns"
+	TagCaseSnake = "snake"
+
+	// TagCaseCamel camelCase，如 "maxConns"
+	TagCaseCamel = "camel"
+
+	// TagCasePascal PascalCase，如 "MaxConns"
+	TagCasePascal = "pascal"
+
+	// TagCaseKebab kebab-case，如 "max-conns"
+	TagCaseKebab = "kebab"
+)
+
 var (
 	// DefaultTags 默认生成的标签列表
 	// - json: JSON 序列化
@@ -27,4 +45,15 @@ var (
 	// - mapstructure: Viper 配置读取
 	// - toml: TOML 序列化
 	DefaultTags = []string{"json", "yaml", "mapstructure", "toml"}
+
+	// DefaultInitialisms 默认的缩写词列表
+	// 与 Go 社区惯例 (golint) 一致,字段名中的这些单词整体大写
+	// 例如: "api_url" -> "APIURL", "user_id" -> "UserID"
+	DefaultInitialisms = []string{
+		"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID",
+		"HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "LHS", "QPS",
+		"RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP",
+		"TLS", "TTL", "UDP", "UI", "UID", "UUID", "URI", "URL",
+		"UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+	}
 )
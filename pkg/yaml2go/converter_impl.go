@@ -45,6 +45,11 @@ func normalizeConfig(config *Config) *Config {
 		config.Tags = copyStringSlice(DefaultTags)
 	}
 
+	// 设置默认缩写词
+	if config.Initialisms == nil {
+		config.Initialisms = copyStringSlice(DefaultInitialisms)
+	}
+
 	// 设置默认缩进风格
 	if config.IndentStyle == "" {
 		config.IndentStyle = DefaultIndentStyle
@@ -162,6 +167,11 @@ func (c *converter) SetConfig(config *Config) error {
 	if config.IndentStyle != "" && config.IndentStyle != IndentStyleTab && config.IndentStyle != IndentStyleSpace {
 		return fmt.Errorf("%w: invalid indent style: %s", ErrInvalidConfig, config.IndentStyle)
 	}
+	for tagName, tagCase := range config.TagCase {
+		if !isValidTagCase(tagCase) {
+			return fmt.Errorf("%w: invalid tag case for %s: %s", ErrInvalidConfig, tagName, tagCase)
+		}
+	}
 
 	c.mu.Lock()
 	c.config = normalizeConfig(config)
@@ -202,17 +212,12 @@ func (c *converter) buildFieldInfo(key string, value interface{}) (*FieldInfo, e
 	c.mu.RUnlock()
 
 	field := &FieldInfo{
-		Name:         sanitizeFieldName(key),
+		Name:         sanitizeFieldName(key, cfg),
 		OriginalName: key,
-		Tags:         make(map[string]string),
+		Tags:         buildTagValues(key, cfg),
 		IsPointer:    cfg.UsePointer,
 	}
 
-	// 生成标签
-	for _, tagName := range cfg.Tags {
-		field.Tags[tagName] = key
-	}
-
 	// 推断类型
 	fieldType, elementType, children, err := c.inferType(value)
 	if err != nil {
@@ -223,6 +228,12 @@ func (c *converter) buildFieldInfo(key string, value interface{}) (*FieldInfo, e
 	field.ElementType = elementType
 	field.Children = children
 
+	// 记录基础类型的示例值
+	switch value.(type) {
+	case string, int, int64, float64, bool:
+		field.DefaultValue = value
+	}
+
 	// 添加注释
 	if cfg.AddComments {
 		field.Comment = key + " 字段"
@@ -335,8 +346,12 @@ func (c *converter) generateStruct(f *jen.File, name string, fields []*FieldInfo
 		tagStr := buildTags(field.Tags, cfg.OmitEmpty)
 
 		fieldCode = fieldCode.Id(field.Name).Add(fieldType)
+		// 标签原样输出,jen.Tag 会按键名重新排序并转义,无法保持 buildTags 的顺序
 		if tagStr != "" {
-			fieldCode = fieldCode.Tag(map[string]string{"": strings.Trim(tagStr, "`")})
+			fieldCode = fieldCode.Id(tagStr)
+		}
+		if cfg.EmitDefaults && field.DefaultValue != nil {
+			fieldCode = fieldCode.Comment(defaultComment(field.DefaultValue))
 		}
 
 		structFields = append(structFields, fieldCode)
@@ -376,7 +391,10 @@ func (c *converter) buildFieldType(field *FieldInfo) jen.Code {
 
 			childCode := jen.Id(child.Name).Add(childType)
 			if tagStr != "" {
-				childCode = childCode.Tag(map[string]string{"": strings.Trim(tagStr, "`")})
+				childCode = childCode.Id(tagStr)
+			}
+			if cfg.EmitDefaults && child.DefaultValue != nil {
+				childCode = childCode.Comment(defaultComment(child.DefaultValue))
 			}
 			structFields = append(structFields, childCode)
 		}
@@ -409,18 +427,16 @@ func (c *converter) generateMainConfig(rootMap map[string]interface{}, cfg *Conf
 
 	// 构建主结构体字段
 	structFields := []jen.Code{}
+	configNames := make([]string, 0, len(rootMap))
 	for configName := range rootMap {
+		configNames = append(configNames, configName)
 		// 生成结构体名称 (如 "server" -> "ServerConfig")
-		structName := sanitizeFieldName(configName) + "Config"
+		structName := sanitizeFieldName(configName, cfg) + "Config"
 
 		// 创建字段
-		fieldCode := jen.Id(sanitizeFieldName(configName)).
+		fieldCode := jen.Id(sanitizeFieldName(configName, cfg)).
 			Op("*").Id(structName).
-			Tag(map[string]string{"": buildTags(map[string]string{
-				"mapstructure": configName,
-				"json":         configName,
-				"yaml":         configName,
-			}, false)[1:]})
+			Id(buildTags(buildTagValues(configName, cfg), false))
 
 		structFields = append(structFields, fieldCode)
 	}
@@ -428,6 +444,9 @@ func (c *converter) generateMainConfig(rootMap map[string]interface{}, cfg *Conf
 	// 生成主 Config 结构体
 	f.Type().Id("Config").Struct(structFields...)
 
+	// 生成子配置的 getter
+	c.generateMainGetters(f, configNames, cfg)
+
 	// 渲染代码
 	buf := &bytes.Buffer{}
 	if err := f.Render(buf); err != nil {
@@ -445,7 +464,7 @@ func (c *converter) generateMainConfig(rootMap map[string]interface{}, cfg *Conf
 // generateSubConfig 为单个顶级配置生成独立文件
 func (c *converter) generateSubConfig(configName string, configValue interface{}, cfg *Config) (*FileContent, error) {
 	// 1. 构建结构体信息
-	structName := sanitizeFieldName(configName) + "Config"
+	structName := sanitizeFieldName(configName, cfg) + "Config"
 
 	// 确保是 map 类型
 	configMap, ok := configValue.(map[string]interface{})
@@ -493,7 +512,7 @@ func (c *converter) generateSubConfig(configName string, configValue interface{}
 
 // generateSimpleConfig 为简单类型配置生成代码
 func (c *converter) generateSimpleConfig(configName string, configValue interface{}, cfg *Config) (*FileContent, error) {
-	structName := sanitizeFieldName(configName) + "Config"
+	structName := sanitizeFieldName(configName, cfg) + "Config"
 	f := jen.NewFile(cfg.PackageName)
 
 	// 推断类型
@@ -504,13 +523,7 @@ func (c *converter) generateSimpleConfig(configName string, configValue interfac
 
 	// 简单的值包装结构体
 	f.Type().Id(structName).Struct(
-		jen.Id("Value").Add(jen.Id(fieldType.String())).Tag(map[string]string{
-			"": buildTags(map[string]string{
-				"json":         "value",
-				"yaml":         "value",
-				"mapstructure": "value",
-			}, false)[1:],
-		}),
+		jen.Id("Value").Add(jen.Id(fieldType.String())).Id(buildTags(buildTagValues("value", cfg), false)),
 	)
 
 	buf := &bytes.Buffer{}
@@ -539,6 +552,12 @@ func (c *converter) generateSubConfigCode(structInfo *StructInfo, cfg *Config) (
 	// 生成结构体
 	c.generateStruct(f, structInfo.Name, structInfo.Fields, structInfo.Comment)
 
+	// 生成带示例值的构造函数
+	c.generateDefaultsConstructor(f, structInfo, cfg)
+
+	// 生成 nil 安全的 getter
+	c.generateGetters(f, structInfo, cfg)
+
 	// 渲染结构体代码
 	buf := &bytes.Buffer{}
 	if err := f.Render(buf); err != nil {
@@ -0,0 +1,201 @@
+package yaml2go
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const defaultsYAML = `
+server:
+  host: localhost
+  port: 8080
+  tls:
+    enabled: true
+`
+
+// TestConvert_EmitDefaults 测试默认值注释和构造函数引用 YAML 中的值
+func TestConvert_EmitDefaults(t *testing.T) {
+	result, err := New(&Config{PackageName: "config", EmitDefaults: true}).Convert(defaultsYAML)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+	if len(result.SubConfigs) != 1 {
+		t.Fatalf("expected 1 sub config, got %d", len(result.SubConfigs))
+	}
+	code := result.SubConfigs[0].Content
+
+	for _, want := range []string{
+		`// default: "localhost"`,
+		"// default: 8080",
+		"// default: true",
+		"func NewServerConfigWithDefaults() *ServerConfig",
+		`cfg.Host = "localhost"`,
+		"cfg.Port = 8080",
+		"cfg.TLS.Enabled = true",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code should contain %q, got:\n%s", want, code)
+		}
+	}
+}
+
+// TestConvert_WithoutDefaults 测试未开启时不输出默认值
+func TestConvert_WithoutDefaults(t *testing.T) {
+	result, err := New(&Config{PackageName: "config"}).Convert(defaultsYAML)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+	code := result.SubConfigs[0].Content
+
+	if strings.Contains(code, "default:") || strings.Contains(code, "WithDefaults") {
+		t.Errorf("generated code should not contain defaults, got:\n%s", code)
+	}
+}
+
+// TestConvert_TagCase 测试同一结构体中按标签使用不同的键名风格
+func TestConvert_TagCase(t *testing.T) {
+	result, err := New(&Config{
+		PackageName: "config",
+		TagCase:     map[string]string{"json": TagCaseCamel, "yaml": TagCaseSnake},
+	}).Convert(`
+database:
+  max_open_conns: 100
+  conn_max_lifetime: 30s
+`)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+	code := result.SubConfigs[0].Content
+
+	for _, want := range []string{
+		`json:"maxOpenConns" yaml:"max_open_conns" mapstructure:"max_open_conns" toml:"max_open_conns"`,
+		`json:"connMaxLifetime" yaml:"conn_max_lifetime"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code should contain %q, got:\n%s", want, code)
+		}
+	}
+
+	// 主配置同样生成 toml 标签
+	if !strings.Contains(result.MainConfig.Content, `toml:"database"`) {
+		t.Errorf("main config should contain toml tag, got:\n%s", result.MainConfig.Content)
+	}
+}
+
+// TestSetConfig_InvalidTagCase 测试不支持的键名风格
+func TestSetConfig_InvalidTagCase(t *testing.T) {
+	err := New(nil).SetConfig(&Config{TagCase: map[string]string{"json": "upper"}})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+// getterTestMain 对 nil 指针调用生成的 getter,返回值不是零值时退出码非 0
+const getterTestMain = `package main
+
+import (
+	"fmt"
+	"os"
+
+	"getterdemo/config"
+)
+
+func main() {
+	port := int64(8080)
+	cfg := &config.Config{Server: &config.ServerConfig{Port: &port}}
+
+	checks := []struct {
+		name string
+		ok   bool
+	}{
+		{"set pointer", cfg.GetServer().GetPort() == 8080},
+		{"nil pointer", cfg.GetServer().GetHost() == ""},
+		{"nil nested struct", !cfg.GetServer().GetTLSEnabled()},
+		{"nil sub config", (&config.Config{}).GetServer().GetPort() == 0},
+		{"nil root", (*config.Config)(nil).GetServer().GetTLSEnabled() == false},
+	}
+	for _, check := range checks {
+		if !check.ok {
+			fmt.Println("getter failed:", check.name)
+			os.Exit(1)
+		}
+	}
+}
+`
+
+// TestConvert_GenerateGetters 测试指针字段的 getter 在 nil 时返回零值
+func TestConvert_GenerateGetters(t *testing.T) {
+	result, err := New(&Config{
+		PackageName:     "config",
+		UsePointer:      true,
+		GenerateGetters: true,
+	}).Convert(defaultsYAML)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+	code := result.SubConfigs[0].Content
+
+	for _, want := range []string{
+		"func (c *ServerConfig) GetPort() int64",
+		"func (c *ServerConfig) GetTLSEnabled() bool",
+		"if c == nil || c.TLS == nil || c.TLS.Enabled == nil",
+		"return *c.TLS.Enabled",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code should contain %q, got:\n%s", want, code)
+		}
+	}
+	if !strings.Contains(result.MainConfig.Content, "func (c *Config) GetServer() *ServerConfig") {
+		t.Errorf("main config should contain GetServer, got:\n%s", result.MainConfig.Content)
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	// 只编译结构体和 getter: 追加的接口方法把 import 放在声明之后,
+	// 且 DefaultConfig/OverrideConfig 尚不支持指针字段
+	structCode := code
+	if i := strings.Index(code, "\nimport ("); i >= 0 {
+		structCode = code[:i]
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module getterdemo\n\ngo 1.24\n",
+		"main.go":          getterTestMain,
+		"config/config.go": result.MainConfig.Content,
+		"config/" + result.SubConfigs[0].FileName: structCode,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated getters failed: %v\n%s\n--- code ---\n%s", err, out, code)
+	}
+}
+
+// TestConvert_WithoutGetters 测试未开启时不生成 getter
+func TestConvert_WithoutGetters(t *testing.T) {
+	result, err := New(&Config{PackageName: "config", UsePointer: true}).Convert(defaultsYAML)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+	if strings.Contains(result.SubConfigs[0].Content, "GetPort") || strings.Contains(result.MainConfig.Content, "GetServer") {
+		t.Errorf("generated code should not contain getters, got:\n%s", result.SubConfigs[0].Content)
+	}
+}
@@ -0,0 +1,128 @@
+package yaml2go
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// generateGetters 为子配置结构体生成 nil 安全的 GetXxx 方法
+// 嵌套结构体是内联的匿名类型，无法定义方法，因此按路径展开为叶子字段的 getter
+// 例如: TLS.Enabled -> GetTLSEnabled()，路径上任一指针为 nil 时返回零值
+func (c *converter) generateGetters(f *jen.File, structInfo *StructInfo, cfg *Config) {
+	if !cfg.GenerateGetters {
+		return
+	}
+
+	for _, field := range structInfo.Fields {
+		c.generateFieldGetters(f, structInfo.Name, nil, field)
+	}
+}
+
+// generateFieldGetters 递归生成字段路径上的 getter
+// parents 为从接收者到当前字段的上级字段
+func (c *converter) generateFieldGetters(f *jen.File, structName string, parents []*FieldInfo, field *FieldInfo) {
+	path := append(append([]*FieldInfo{}, parents...), field)
+
+	if field.Type == TypeStruct {
+		for _, child := range field.Children {
+			c.generateFieldGetters(f, structName, path, child)
+		}
+		return
+	}
+
+	names := make([]string, len(path))
+	for i, p := range path {
+		names[i] = p.Name
+	}
+	name := "Get" + strings.Join(names, "")
+
+	// 接收者和路径上每一个指针字段都需要判空
+	target := jen.Id("c")
+	cond := jen.Id("c").Op("==").Nil()
+	for _, p := range path {
+		target = target.Clone().Dot(p.Name)
+		if isPointerField(p) {
+			cond = cond.Op("||").Add(target.Clone()).Op("==").Nil()
+		}
+	}
+
+	value := target
+	if isPointerField(field) {
+		value = jen.Op("*").Add(target)
+	}
+
+	f.Line()
+	f.Comment(name + " 返回 " + strings.Join(names, ".") + "，接收者或路径上的指针为 nil 时返回零值")
+	f.Func().Params(
+		jen.Id("c").Op("*").Id(structName),
+	).Id(name).Params().Add(c.getterValueType(field)).Block(
+		jen.If(cond).Block(
+			jen.Return(getterZeroValue(field)),
+		),
+		jen.Return(value),
+	)
+}
+
+// generateMainGetters 为主配置生成子配置的 getter
+// 接收者为 nil 时返回 nil，配合子配置的 getter 可以安全链式调用:
+// cfg.GetServer().GetPort()
+func (c *converter) generateMainGetters(f *jen.File, fields []string, cfg *Config) {
+	if !cfg.GenerateGetters {
+		return
+	}
+
+	for _, configName := range fields {
+		fieldName := sanitizeFieldName(configName, cfg)
+		name := "Get" + fieldName
+
+		f.Line()
+		f.Comment(name + " 返回 " + fieldName + "，接收者为 nil 时返回 nil")
+		f.Func().Params(
+			jen.Id("c").Op("*").Id("Config"),
+		).Id(name).Params().Op("*").Id(fieldName+"Config").Block(
+			jen.If(jen.Id("c").Op("==").Nil()).Block(
+				jen.Return(jen.Nil()),
+			),
+			jen.Return(jen.Id("c").Dot(fieldName)),
+		)
+	}
+}
+
+// isPointerField 字段是否生成为指针类型
+// interface{} 字段不使用指针，见 buildFieldType
+func isPointerField(field *FieldInfo) bool {
+	return field.IsPointer && field.Type != TypeInterface
+}
+
+// getterValueType 返回 getter 的返回类型（去掉字段外层的指针）
+func (c *converter) getterValueType(field *FieldInfo) jen.Code {
+	switch field.Type {
+	case TypeString:
+		return jen.String()
+	case TypeInt:
+		return jen.Int64()
+	case TypeFloat:
+		return jen.Float64()
+	case TypeBool:
+		return jen.Bool()
+	case TypeSlice:
+		return jen.Index().Add(c.buildFieldType(field.ElementType))
+	default:
+		return jen.Interface()
+	}
+}
+
+// getterZeroValue 返回 getter 返回类型的零值
+func getterZeroValue(field *FieldInfo) jen.Code {
+	switch field.Type {
+	case TypeString:
+		return jen.Lit("")
+	case TypeInt, TypeFloat:
+		return jen.Lit(0)
+	case TypeBool:
+		return jen.False()
+	default:
+		return jen.Nil()
+	}
+}
@@ -90,6 +90,49 @@ func (c *converter) buildDefaultConfigStruct(structInfo *StructInfo, cfg *Config
 	return jen.Op("&").Id(structInfo.Name).Values(fields...)
 }
 
+// generateDefaultsConstructor 生成 NewXxxWithDefaults 构造函数
+// 将字段初始化为 YAML 中的示例值，嵌套结构体递归赋值
+func (c *converter) generateDefaultsConstructor(f *jen.File, structInfo *StructInfo, cfg *Config) {
+	// 指针字段无法直接赋字面量，只输出注释
+	if !cfg.EmitDefaults || cfg.UsePointer {
+		return
+	}
+
+	body := []jen.Code{
+		jen.Id("cfg").Op(":=").Op("&").Id(structInfo.Name).Values(),
+	}
+	body = append(body, buildDefaultAssignments(nil, structInfo.Fields)...)
+	body = append(body, jen.Return(jen.Id("cfg")))
+
+	name := "New" + structInfo.Name + "WithDefaults"
+	f.Line()
+	f.Comment(name + " 返回以 YAML 示例值初始化的配置")
+	f.Func().Id(name).Params().Op("*").Id(structInfo.Name).Block(body...)
+}
+
+// buildDefaultAssignments 递归构建示例值赋值语句
+// 例如: cfg.Port = 8080, cfg.TLS.Enabled = true
+func buildDefaultAssignments(path []string, fields []*FieldInfo) []jen.Code {
+	var statements []jen.Code
+
+	for _, field := range fields {
+		fieldPath := append(copyStringSlice(path), field.Name)
+
+		switch {
+		case field.Type == TypeStruct:
+			statements = append(statements, buildDefaultAssignments(fieldPath, field.Children)...)
+		case field.DefaultValue != nil:
+			target := jen.Id("cfg")
+			for _, name := range fieldPath {
+				target = target.Dot(name)
+			}
+			statements = append(statements, target.Op("=").Lit(field.DefaultValue))
+		}
+	}
+
+	return statements
+}
+
 // generateOverrideConfigMethod 生成 OverrideConfig 方法
 func (c *converter) generateOverrideConfigMethod(f *jen.File, structInfo *StructInfo, cfg *Config) {
 	// 需要导入 strconv
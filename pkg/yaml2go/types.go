@@ -27,6 +27,10 @@ type FieldInfo struct {
 
 	// ElementType 数组元素类型（用于数组类型）
 	ElementType *FieldInfo
+
+	// DefaultValue YAML 中的示例值（仅基础类型，其他类型为 nil）
+	// 用于生成默认值注释和 NewXxxWithDefaults 构造函数
+	DefaultValue interface{}
 }
 
 // FieldType 字段类型枚举
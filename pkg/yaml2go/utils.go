@@ -1,6 +1,7 @@
 package yaml2go
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 
@@ -20,6 +21,41 @@ func toSnakeCase(s string) string {
 	return strcase.ToSnake(s)
 }
 
+// buildTagValues 按 cfg.Tags 和 cfg.TagCase 生成字段的标签值 {tagName: tagValue}
+// 例如: TagCase{"json": "camel"}, key "max_conns" -> {"json": "maxConns", "yaml": "max_conns", ...}
+func buildTagValues(key string, cfg *Config) map[string]string {
+	tags := make(map[string]string, len(cfg.Tags))
+	for _, tagName := range cfg.Tags {
+		tags[tagName] = convertTagCase(key, cfg.TagCase[tagName])
+	}
+	return tags
+}
+
+// convertTagCase 按风格转换标签键名,空值和未知风格保留原名
+func convertTagCase(key, tagCase string) string {
+	switch tagCase {
+	case TagCaseSnake:
+		return strcase.ToSnake(key)
+	case TagCaseCamel:
+		return strcase.ToLowerCamel(key)
+	case TagCasePascal:
+		return strcase.ToCamel(key)
+	case TagCaseKebab:
+		return strcase.ToKebab(key)
+	default:
+		return key
+	}
+}
+
+// isValidTagCase 检查标签键名风格是否受支持
+func isValidTagCase(tagCase string) bool {
+	switch tagCase {
+	case "", TagCaseOriginal, TagCaseSnake, TagCaseCamel, TagCasePascal, TagCaseKebab:
+		return true
+	}
+	return false
+}
+
 // buildTag 构建单个标签字符串
 // 例如: buildTag("json", "field_name", true) -> "json:\"field_name,omitempty\""
 func buildTag(tagName, tagValue string, omitEmpty bool) string {
@@ -83,15 +119,76 @@ func isGoKeyword(name string) bool {
 }
 
 // sanitizeFieldName 规范化字段名
-// 如果字段名是 Go 关键字，添加下划线前缀
-func sanitizeFieldName(name string) string {
-	goName := toGoFieldName(name)
+// 优先使用 cfg.FieldNameMap 中的显式映射;否则转换为驼峰命名并处理缩写词,
+// 如果字段名是 Go 关键字，添加 Field 前缀
+func sanitizeFieldName(name string, cfg *Config) string {
+	if goName, ok := cfg.FieldNameMap[name]; ok {
+		return goName
+	}
+
+	goName := applyInitialisms(toGoFieldName(name), cfg.Initialisms)
 	if isGoKeyword(strings.ToLower(goName)) {
 		return "Field" + goName
 	}
 	return goName
 }
 
+// applyInitialisms 将驼峰命名中的缩写词整体大写
+// 例如: "ApiUrl" -> "APIURL", "UserId" -> "UserID"
+func applyInitialisms(camel string, initialisms []string) string {
+	if len(initialisms) == 0 {
+		return camel
+	}
+
+	// 大写形式 -> 列表中的写法
+	lookup := make(map[string]string, len(initialisms))
+	for _, word := range initialisms {
+		lookup[strings.ToUpper(word)] = word
+	}
+
+	var sb strings.Builder
+	for _, word := range splitCamelWords(camel) {
+		if initialism, ok := lookup[strings.ToUpper(word)]; ok {
+			sb.WriteString(initialism)
+		} else {
+			sb.WriteString(word)
+		}
+	}
+	return sb.String()
+}
+
+// splitCamelWords 按大小写边界拆分驼峰命名,数字跟随前一个单词
+// 例如: "ApiUrl" -> ["Api", "Url"], "MyURLValue" -> ["My", "URL", "Value"], "Oauth2Token" -> ["Oauth2", "Token"]
+func splitCamelWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prev := runes[i-1]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// defaultComment 构建默认值注释
+// 例如: 8080 -> "default: 8080", "localhost" -> "default: \"localhost\""
+func defaultComment(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("default: %q", s)
+	}
+	return fmt.Sprintf("default: %v", value)
+}
+
 // isNumeric 检查字符串是否为数字
 func isNumeric(s string) bool {
 	if s == "" {
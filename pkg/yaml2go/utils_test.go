@@ -0,0 +1,49 @@
+package yaml2go
+
+import "testing"
+
+// TestSanitizeFieldName_Initialisms 测试缩写词整体大写
+func TestSanitizeFieldName_Initialisms(t *testing.T) {
+	cfg := normalizeConfig(&Config{})
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"api_url", "APIURL"},
+		{"user_id", "UserID"},
+		{"id", "ID"},
+		{"http_port", "HTTPPort"},
+		{"database_host", "DatabaseHost"},
+		{"type", "FieldType"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeFieldName(tt.name, cfg); got != tt.want {
+			t.Errorf("sanitizeFieldName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSanitizeFieldName_Custom 测试自定义缩写词和显式映射
+func TestSanitizeFieldName_Custom(t *testing.T) {
+	cfg := normalizeConfig(&Config{
+		Initialisms:  []string{"GRPC"},
+		FieldNameMap: map[string]string{"db": "Database"},
+	})
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"db", "Database"},
+		{"grpc_port", "GRPCPort"},
+		{"api_url", "ApiUrl"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeFieldName(tt.name, cfg); got != tt.want {
+			t.Errorf("sanitizeFieldName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -56,6 +56,13 @@ type Config struct {
 	// 注意: mapstructure 是 viper 使用的标签
 	Tags []string
 
+	// TagCase 按标签指定键名风格 {tagName: case}
+	// 可选值: "original", "snake", "camel", "pascal", "kebab"
+	// 未配置的标签保留 YAML 原始键名
+	// 示例: map[string]string{"json": "camel", "yaml": "snake"}
+	//   max_conns -> `json:"maxConns" yaml:"max_conns"`
+	TagCase map[string]string
+
 	// UsePointer 字段是否使用指针类型
 	// true: 字段类型为 *string, *int64 等
 	// false: 字段类型为 string, int64 等
@@ -68,6 +75,15 @@ type Config struct {
 	//   - 代码略显繁琐
 	UsePointer bool
 
+	// GenerateGetters 是否生成 nil 安全的 GetXxx 方法
+	// true: 为每个字段生成 getter，返回解引用后的值，接收者或指针为 nil 时返回零值
+	//       嵌套结构体按路径展开，如 TLS.Enabled -> GetTLSEnabled()
+	//       主配置生成 GetServer() 等方法，可安全链式调用 cfg.GetServer().GetPort()
+	// false: 不生成
+	// 默认: false
+	// 主要配合 UsePointer 使用，省去调用方逐层判空
+	GenerateGetters bool
+
 	// OmitEmpty 是否在标签中添加 omitempty 选项
 	// true: `json:"field,omitempty"`
 	// false: `json:"field"`
@@ -103,6 +119,25 @@ type Config struct {
 	// false: 生成单个文件（兼容模式）
 	// 默认: true
 	SplitFiles bool
+
+	// Initialisms 字段名中需要整体大写的缩写词
+	// 匹配时忽略大小写,生成时使用列表中的写法
+	// 默认: DefaultInitialisms
+	// 示例: append(yaml2go.DefaultInitialisms, "GRPC")
+	Initialisms []string
+
+	// EmitDefaults 是否输出 YAML 中的示例值
+	// true: 基础类型字段追加 `// default: 8080` 注释，
+	//       并为每个子配置生成 NewXxxWithDefaults 构造函数（递归初始化嵌套结构体）
+	// false: 不输出
+	// 默认: false
+	// 注意: UsePointer 为 true 时只输出注释，不生成构造函数
+	EmitDefaults bool
+
+	// FieldNameMap 字段名显式映射 (YAML 键名 -> Go 字段名)
+	// 命中时直接使用映射值,跳过驼峰转换、缩写词和关键字处理
+	// 示例: map[string]string{"db": "Database"}
+	FieldNameMap map[string]string
 }
 
 // New 创建一个新的 Converter 实例
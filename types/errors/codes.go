@@ -47,6 +47,11 @@ const (
 	// 前端应该显示密码强度提示
 	ErrInvalidPassword = 1003
 
+	// ErrRequestTooLarge 请求体过大
+	// 请求体超过服务端允许的大小,HTTP 状态码为 413 Request Entity Too Large
+	// 前端应该压缩或分片上传
+	ErrRequestTooLarge = 1004
+
 	// ==================== 业务错误 (2000-2999) ====================
 	// 这类错误表示业务规则不满足,不是技术问题
 
@@ -64,6 +69,11 @@ const (
 	// 前端可以提示用户使用其他邮箱或直接登录
 	ErrDuplicateEmail = 2002
 
+	// ErrTooManyRequests 请求过于频繁
+	// 触发限流时返回此错误,HTTP 状态码为 429 Too Many Requests
+	// 前端应该根据 Retry-After 响应头等待后重试
+	ErrTooManyRequests = 2003
+
 	// ==================== 认证/授权错误 (3000-3999) ====================
 	// 这类错误涉及用户身份验证和权限控制
 
@@ -114,4 +124,9 @@ const (
 	// 例如:Redis 连接失败、缓存写入失败等
 	// 一般缓存失败不应该影响主流程,可以降级到直接查数据库
 	ErrCacheError = 5002
+
+	// ErrServiceUnavailable 服务暂时不可用
+	// 例如:请求处理超时,HTTP 状态码为 503 Service Unavailable
+	// 前端可以稍后重试
+	ErrServiceUnavailable = 5003
 )
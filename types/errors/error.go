@@ -1,6 +1,10 @@
 package errors
 
-import "fmt"
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+)
 
 // BizError 表示一个业务错误,包含错误码、错误消息和可选的原因错误
 // 这是应用程序中所有业务错误的基础类型
@@ -96,3 +100,55 @@ func (e *BizError) WithCause(err error) *BizError {
 func (e *BizError) Unwrap() error {
 	return e.Cause
 }
+
+// AsBizError 从错误链中提取 BizError
+// 使用 errors.As 逐层解包,即使 BizError 被 fmt.Errorf("%w") 等多次包装也能取到
+// 参数:
+//   err: 任意错误,可以为 nil
+// 返回:
+//   *BizError: 错误链中第一个 BizError
+//   bool: 是否找到
+// 示例:
+//   if bizErr, ok := errors.AsBizError(err); ok {
+//       c.JSON(errors.HTTPStatus(bizErr.Code), result.ErrorWithTrace(bizErr.Code, bizErr.Message, traceID))
+//   }
+func AsBizError(err error) (*BizError, bool) {
+	var bizErr *BizError
+	if stderrors.As(err, &bizErr) {
+		return bizErr, true
+	}
+	return nil, false
+}
+
+// HTTPStatus 返回错误码对应的 HTTP 状态码
+// 按 codes.go 中的错误码范围映射:
+//   - 0: 200 OK
+//   - 1000-1999: 400 Bad Request,其中 ErrRequestTooLarge 为 413 Request Entity Too Large
+//   - 2000-2999: 422 Unprocessable Entity,其中 ErrTooManyRequests 为 429 Too Many Requests
+//   - 3000-3999: 401 Unauthorized,其中 ErrPermissionDenied 为 403 Forbidden
+//   - 4000-4999: 404 Not Found
+//   - 其他: 500 Internal Server Error,其中 ErrServiceUnavailable 为 503 Service Unavailable
+func HTTPStatus(code int) int {
+	switch {
+	case code == CodeSuccess:
+		return http.StatusOK
+	case code == ErrPermissionDenied:
+		return http.StatusForbidden
+	case code == ErrTooManyRequests:
+		return http.StatusTooManyRequests
+	case code == ErrRequestTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case code == ErrServiceUnavailable:
+		return http.StatusServiceUnavailable
+	case code >= ErrInvalidParams && code < ErrBusinessLogic:
+		return http.StatusBadRequest
+	case code >= ErrBusinessLogic && code < ErrUnauthorized:
+		return http.StatusUnprocessableEntity
+	case code >= ErrUnauthorized && code < ErrResourceNotFound:
+		return http.StatusUnauthorized
+	case code >= ErrResourceNotFound && code < ErrInternalServer:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
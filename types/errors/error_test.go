@@ -0,0 +1,60 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestAsBizError_Wrapped 测试多层包装后仍能提取 BizError
+func TestAsBizError_Wrapped(t *testing.T) {
+	cause := stderrors.New("duplicate key")
+	biz := NewBizError(ErrDuplicateUsername, "username already exists").WithCause(cause)
+	wrapped := fmt.Errorf("service: %w", fmt.Errorf("repository: %w", biz))
+
+	got, ok := AsBizError(wrapped)
+	if !ok {
+		t.Fatal("expected BizError to be found in wrapped chain")
+	}
+	if got.Code != ErrDuplicateUsername {
+		t.Errorf("code: got %d, want %d", got.Code, ErrDuplicateUsername)
+	}
+	if status := HTTPStatus(got.Code); status != http.StatusUnprocessableEntity {
+		t.Errorf("status: got %d, want %d", status, http.StatusUnprocessableEntity)
+	}
+
+	// 原因错误仍然可以通过错误链判断
+	if !stderrors.Is(wrapped, cause) {
+		t.Error("expected errors.Is to find the cause through BizError")
+	}
+}
+
+// TestAsBizError_NotFound 测试普通错误和 nil
+func TestAsBizError_NotFound(t *testing.T) {
+	if _, ok := AsBizError(fmt.Errorf("plain: %w", stderrors.New("boom"))); ok {
+		t.Error("plain error should not be a BizError")
+	}
+	if _, ok := AsBizError(nil); ok {
+		t.Error("nil should not be a BizError")
+	}
+}
+
+// TestHTTPStatus 测试错误码到 HTTP 状态码的映射
+func TestHTTPStatus(t *testing.T) {
+	tests := map[int]int{
+		CodeSuccess:         http.StatusOK,
+		ErrInvalidEmail:     http.StatusBadRequest,
+		ErrDuplicateEmail:   http.StatusUnprocessableEntity,
+		ErrTokenExpired:     http.StatusUnauthorized,
+		ErrPermissionDenied: http.StatusForbidden,
+		ErrUserNotFound:     http.StatusNotFound,
+		ErrDatabaseError:    http.StatusInternalServerError,
+		9999:                http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		if got := HTTPStatus(code); got != want {
+			t.Errorf("HTTPStatus(%d) = %d, want %d", code, got, want)
+		}
+	}
+}
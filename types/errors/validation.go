@@ -0,0 +1,98 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError 字段级校验错误
+// 由 ParseValidationErrors 从参数校验错误转换而来,供客户端按字段展示错误
+type FieldError struct {
+	// Field 字段名
+	// 注册了 json tag 名称函数时为 JSON 字段名,否则为结构体字段名
+	Field string `json:"field"`
+
+	// Tag 未通过的校验规则,如 required、min、email
+	Tag string `json:"tag"`
+
+	// Message 人类可读的错误描述
+	Message string `json:"message"`
+}
+
+// ParseValidationErrors 将参数校验错误转换为字段错误列表
+// 支持 validator.ValidationErrors(gin 的 ShouldBind 系列方法返回的类型),
+// 错误被 fmt.Errorf("%w") 包装时同样生效
+// 参数:
+//
+//	err: ShouldBindJSON 等方法返回的错误
+//
+// 返回:
+//
+//	[]FieldError: 字段错误列表;err 不是校验错误(如 JSON 语法错误)时返回 nil
+//
+// 示例:
+//
+//	if err := c.ShouldBindJSON(&req); err != nil {
+//	    if fields := errors.ParseValidationErrors(err); fields != nil {
+//	        result.ValidationFailed(c, traceID, fields)
+//	        return
+//	    }
+//	    result.BadRequest(c, "无效的请求参数")
+//	}
+func ParseValidationErrors(err error) []FieldError {
+	var ve validator.ValidationErrors
+	if !stderrors.As(err, &ve) || len(ve) == 0 {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, len(ve))
+	for _, fe := range ve {
+		fields = append(fields, newFieldError(fe))
+	}
+	return fields
+}
+
+// newFieldError 根据校验规则生成字段错误
+func newFieldError(fe validator.FieldError) FieldError {
+	return FieldError{
+		Field:   fe.Field(),
+		Tag:     fe.Tag(),
+		Message: validationMessage(fe),
+	}
+}
+
+// validationMessage 返回校验规则对应的错误描述
+// 未列出的规则返回通用描述
+func validationMessage(fe validator.FieldError) string {
+	field, param := fe.Field(), fe.Param()
+
+	// 字符串的 min/max/len 指长度,其他类型指数值或元素个数
+	unit := ""
+	if fe.Kind() == reflect.String {
+		unit = " characters"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, param, unit)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, param, unit)
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s%s", field, param, unit)
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, param)
+	default:
+		return fmt.Sprintf("%s is invalid", field)
+	}
+}
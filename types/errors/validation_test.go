@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// signupRequest 测试用的请求结构体
+type signupRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"min=8"`
+	Age      int    `json:"age" validate:"min=18"`
+}
+
+// newJSONValidator 创建使用 json tag 作为字段名的校验器,与 gin 的常见配置一致
+func newJSONValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	})
+	return v
+}
+
+// TestParseValidationErrors 测试缺少 username、password 过短和 age 过小
+func TestParseValidationErrors(t *testing.T) {
+	err := newJSONValidator().Struct(signupRequest{Password: "short", Age: 16})
+
+	want := []FieldError{
+		{Field: "username", Tag: "required", Message: "username is required"},
+		{Field: "password", Tag: "min", Message: "password must be at least 8 characters"},
+		{Field: "age", Tag: "min", Message: "age must be at least 18"},
+	}
+
+	if got := ParseValidationErrors(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// 被包装后仍能解析
+	if got := ParseValidationErrors(fmt.Errorf("bind: %w", err)); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapped: got %+v, want %+v", got, want)
+	}
+}
+
+// TestParseValidationErrors_NotValidation 测试非校验错误返回 nil
+func TestParseValidationErrors_NotValidation(t *testing.T) {
+	if got := ParseValidationErrors(fmt.Errorf("unexpected EOF")); got != nil {
+		t.Errorf("expected nil, got %+v", got)
+	}
+	if got := ParseValidationErrors(nil); got != nil {
+		t.Errorf("expected nil for nil error, got %+v", got)
+	}
+}
@@ -15,6 +15,24 @@ type RBACPolicy struct {
 	Action string `json:"action" binding:"required"`
 }
 
+// Permission 权限（资源+操作）
+type Permission struct {
+	// Resource 资源名称
+	Resource string `json:"resource" binding:"required"`
+
+	// Action 操作名称
+	Action string `json:"action" binding:"required"`
+}
+
+// AssignPermissionsRequest 批量为角色分配权限请求
+type AssignPermissionsRequest struct {
+	// Permissions 权限列表
+	Permissions []Permission `json:"permissions" binding:"required,min=1,dive"`
+
+	// Domain 域名（租户ID），可选
+	Domain string `json:"domain,omitempty"`
+}
+
 // AssignRoleRequest 分配角色请求
 type AssignRoleRequest struct {
 	// Role 角色名称
@@ -54,6 +72,37 @@ type CheckPermissionResponse struct {
 	Allowed bool `json:"allowed"`
 }
 
+// PermCheck 单项权限检查
+type PermCheck = Permission
+
+// CheckPermissionsRequest 批量权限检查请求
+type CheckPermissionsRequest struct {
+	// UserID 用户ID
+	UserID int64 `json:"user_id" binding:"required"`
+
+	// Domain 域名（租户ID），可选
+	Domain string `json:"domain,omitempty"`
+
+	// Checks 待检查的权限列表
+	Checks []PermCheck `json:"checks" binding:"required,min=1,dive"`
+}
+
+// CanUserBatchRequest 指定用户的批量权限检查请求
+// 用户ID取自路径参数
+type CanUserBatchRequest struct {
+	// Domain 域名（租户ID），可选
+	Domain string `json:"domain,omitempty"`
+
+	// Checks 待检查的权限列表
+	Checks []PermCheck `json:"checks" binding:"required,min=1,dive"`
+}
+
+// CheckPermissionsResponse 批量权限检查响应
+type CheckPermissionsResponse struct {
+	// Results 检查结果，键为 "resource:action"
+	Results map[string]bool `json:"results"`
+}
+
 // AddPolicyRequest 添加策略请求
 type AddPolicyRequest struct {
 	RBACPolicy
@@ -96,3 +145,38 @@ type PoliciesResponse struct {
 	// Total 总数
 	Total int `json:"total"`
 }
+
+// UserPermissionsResponse 用户权限响应
+type UserPermissionsResponse struct {
+	// UserID 用户ID
+	UserID int64 `json:"user_id"`
+
+	// Domain 域名（租户ID），未指定域时省略
+	Domain string `json:"domain,omitempty"`
+
+	// Permissions 权限列表
+	Permissions []Permission `json:"permissions"`
+}
+
+// RoleAssignment 角色分配关系
+// Subject 为用户ID时表示用户持有角色，为角色名时表示角色继承（Subject 继承 Role 的权限）
+type RoleAssignment struct {
+	// Subject 用户ID或子角色
+	Subject string `json:"subject"`
+
+	// Role 角色名称（或父角色）
+	Role string `json:"role"`
+
+	// Domain 域名（租户ID），可选
+	Domain string `json:"domain,omitempty"`
+}
+
+// PolicySnapshot RBAC配置快照
+// 用于导出备份、恢复或为新环境初始化权限数据
+type PolicySnapshot struct {
+	// Policies 角色权限策略
+	Policies []RBACPolicy `json:"policies"`
+
+	// Assignments 角色分配及角色继承关系
+	Assignments []RoleAssignment `json:"assignments"`
+}
@@ -33,6 +33,10 @@ type LoginResponse struct {
 	// 并在后续请求中放在 Authorization header 中
 	Token string `json:"token"`
 
+	// RefreshToken 刷新令牌
+	// 访问令牌过期后调用 /auth/refresh 换取新的令牌对,每个刷新令牌只能使用一次
+	RefreshToken string `json:"refreshToken,omitempty"`
+
 	// ExpiresIn 令牌有效期(秒)
 	// 前端可以用来计算令牌过期时间
 	ExpiresIn int `json:"expiresIn"`
@@ -47,7 +51,8 @@ type TokenResponse struct {
 	// AccessToken 新的访问令牌
 	AccessToken string `json:"access_token"`
 
-	// RefreshToken 新的刷新令牌（可选）
+	// RefreshToken 新的刷新令牌
+	// 轮换后原刷新令牌失效,客户端需要保存新的刷新令牌
 	RefreshToken string `json:"refresh_token,omitempty"`
 
 	// ExpiresIn 访问令牌有效期(秒)
@@ -2,6 +2,7 @@ package result
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rei0721/go-scaffold/types/errors"
@@ -58,6 +59,38 @@ func NotFound(c *gin.Context, message string) {
 	))
 }
 
+// ValidationFailedMessage 参数校验失败响应的消息
+const ValidationFailedMessage = "请求参数校验失败"
+
+// ValidationFailed 返回400参数校验失败响应,携带字段级错误
+// 用于 ShouldBindJSON 等绑定校验失败的场景,客户端可按字段展示错误
+// 参数:
+//
+//	c: Gin上下文
+//	traceID: 请求追踪 ID
+//	fields: 字段错误列表,通常由 errors.ParseValidationErrors 生成
+//
+// HTTP状态码: 400 Bad Request
+// 错误码: errors.ErrInvalidParams
+// 响应格式:
+//
+//	{
+//	  "code": 1000,
+//	  "message": "请求参数校验失败",
+//	  "data": [{"field": "username", "tag": "required", "message": "username is required"}],
+//	  "traceId": "123456789",
+//	  "serverTime": 1640000000
+//	}
+func ValidationFailed(c *gin.Context, traceID string, fields []errors.FieldError) {
+	c.JSON(http.StatusBadRequest, &Result[[]errors.FieldError]{
+		Code:       errors.ErrInvalidParams,
+		Message:    ValidationFailedMessage,
+		Data:       fields,
+		TraceID:    traceID,
+		ServerTime: time.Now().Unix(),
+	})
+}
+
 // InternalError 返回500内部服务器错误响应
 // 用于系统内部错误的场景
 // 参数:
@@ -0,0 +1,40 @@
+package result
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rei0721/go-scaffold/types/errors"
+)
+
+// TestValidationFailed 测试字段级校验错误响应
+func TestValidationFailed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	fields := []errors.FieldError{
+		{Field: "username", Tag: "required", Message: "username is required"},
+		{Field: "password", Tag: "min", Message: "password must be at least 8 characters"},
+	}
+	ValidationFailed(c, "trace-1", fields)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status: got %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	var resp Result[[]errors.FieldError]
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Code != errors.ErrInvalidParams || resp.TraceID != "trace-1" {
+		t.Errorf("unexpected code or traceId: %+v", resp)
+	}
+	if !reflect.DeepEqual(resp.Data, fields) {
+		t.Errorf("fields: got %+v, want %+v", resp.Data, fields)
+	}
+}
@@ -0,0 +1,102 @@
+package result
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rei0721/go-scaffold/pkg/i18n"
+	"github.com/rei0721/go-scaffold/types/errors"
+)
+
+const (
+	// ContextKeyLanguage 请求语言在 gin 上下文中的键,由 I18n 中间件设置
+	ContextKeyLanguage = "lang"
+
+	// ContextKeyI18n I18n 实例在 gin 上下文中的键,由 I18n 中间件设置
+	ContextKeyI18n = "i18n"
+
+	// ErrorMessageKeyPrefix 错误码消息在翻译文件中的键前缀
+	// 完整的键为前缀加错误码,如 types.errors.code_5000
+	ErrorMessageKeyPrefix = "types.errors.code_"
+)
+
+// ErrorLocalized 创建使用请求语言本地化错误消息的 Result
+// 消息按错误码从翻译文件中查找,语言和 I18n 实例来自 I18n 中间件
+// 参数:
+//
+//	c: Gin上下文
+//	code: 错误码,应使用 errors 包中定义的常量
+//	traceID: 请求追踪 ID
+//
+// 返回:
+//
+//	*Result[any]: 包含本地化消息和 TraceID 的错误响应
+//
+// 使用示例:
+//
+//	c.JSON(500, result.ErrorLocalized(c, errors.ErrInternalServer, traceID))
+//
+// 注意:
+//
+//	未挂载 I18n 中间件或翻译缺失时,使用按错误码范围确定的英文默认消息
+func ErrorLocalized(c *gin.Context, code int, traceID string) *Result[any] {
+	var (
+		i    i18n.I18n
+		lang string
+	)
+	if v, ok := c.Get(ContextKeyI18n); ok {
+		i, _ = v.(i18n.I18n)
+	}
+	if v, ok := c.Get(ContextKeyLanguage); ok {
+		lang, _ = v.(string)
+	}
+
+	return ErrorWithTrace(code, LocalizedMessage(i, lang, code), traceID)
+}
+
+// LocalizedMessage 查找错误码对应的本地化消息
+// 参数:
+//
+//	i: I18n 实例,为 nil 时直接返回默认消息
+//	lang: 目标语言,不支持时由 I18n 使用默认语言
+//	code: 错误码
+//
+// 返回:
+//
+//	string: 本地化消息;翻译缺失时返回默认消息
+func LocalizedMessage(i i18n.I18n, lang string, code int) string {
+	if i == nil {
+		return defaultErrorMessage(code)
+	}
+
+	key := ErrorMessageKey(code)
+	// T 在翻译缺失时返回消息 ID 本身
+	if msg := i.T(lang, key); msg != "" && msg != key {
+		return msg
+	}
+	return defaultErrorMessage(code)
+}
+
+// ErrorMessageKey 返回错误码在翻译文件中的键
+func ErrorMessageKey(code int) string {
+	return ErrorMessageKeyPrefix + strconv.Itoa(code)
+}
+
+// defaultErrorMessage 按错误码范围返回默认消息
+// 范围划分见 types/errors/codes.go
+func defaultErrorMessage(code int) string {
+	switch {
+	case code == errors.CodeSuccess:
+		return "success"
+	case code >= errors.ErrInvalidParams && code < errors.ErrBusinessLogic:
+		return "invalid parameters"
+	case code >= errors.ErrBusinessLogic && code < errors.ErrUnauthorized:
+		return "business error"
+	case code >= errors.ErrUnauthorized && code < errors.ErrResourceNotFound:
+		return "unauthorized"
+	case code >= errors.ErrResourceNotFound && code < errors.ErrInternalServer:
+		return "resource not found"
+	default:
+		return "internal server error"
+	}
+}
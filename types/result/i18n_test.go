@@ -0,0 +1,62 @@
+package result
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rei0721/go-scaffold/pkg/i18n"
+	"github.com/rei0721/go-scaffold/types/errors"
+)
+
+// newLocalizedContext 创建设置了语言和 I18n 实例的 gin 上下文
+func newLocalizedContext(t *testing.T, lang string) *gin.Context {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	i, err := i18n.New(&i18n.Config{
+		DefaultLanguage:    i18n.LanguageChinese,
+		SupportedLanguages: []string{i18n.LanguageChinese, i18n.LanguageEnglish},
+		MessagesDir:        "../../configs/locales",
+	})
+	if err != nil {
+		t.Fatalf("failed to create i18n: %v", err)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(ContextKeyI18n, i)
+	c.Set(ContextKeyLanguage, lang)
+	return c
+}
+
+// TestErrorLocalized 测试同一错误码按请求语言返回不同消息
+func TestErrorLocalized(t *testing.T) {
+	zh := ErrorLocalized(newLocalizedContext(t, i18n.LanguageChinese), errors.ErrInternalServer, "trace-1")
+	en := ErrorLocalized(newLocalizedContext(t, i18n.LanguageEnglish), errors.ErrInternalServer, "trace-1")
+
+	if zh.Message != "服务器内部错误" {
+		t.Errorf("zh-CN: got %q", zh.Message)
+	}
+	if en.Message != "internal server error" {
+		t.Errorf("en-US: got %q", en.Message)
+	}
+	if zh.Code != errors.ErrInternalServer || zh.TraceID != "trace-1" {
+		t.Errorf("unexpected result: %+v", zh)
+	}
+}
+
+// TestErrorLocalized_Fallback 测试翻译缺失或未挂载中间件时使用默认消息
+func TestErrorLocalized_Fallback(t *testing.T) {
+	// 翻译文件中没有的错误码
+	got := ErrorLocalized(newLocalizedContext(t, i18n.LanguageChinese), 4999, "")
+	if got.Message != "resource not found" {
+		t.Errorf("missing key: got %q", got.Message)
+	}
+
+	// 上下文中没有 I18n 实例
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	got = ErrorLocalized(c, errors.ErrUnauthorized, "")
+	if got.Message != "unauthorized" {
+		t.Errorf("no i18n: got %q", got.Message)
+	}
+}